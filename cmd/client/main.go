@@ -2,15 +2,20 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
+	"os"
 	"sync"
 	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/emptypb"
 
@@ -30,6 +35,12 @@ const (
 	routineCount = 10
 )
 
+var (
+	clientCertFile = flag.String("client-cert", "", "client certificate file presented to GRPC server")
+	clientKeyFile  = flag.String("client-key", "", "private key file of client certificate")
+	serverCAFile   = flag.String("server-ca", "", "CA bundle to verify GRPC server certificate, TLS is used if set")
+)
+
 func main() {
 	config, err := config.InitConfig()
 	if err != nil {
@@ -48,7 +59,12 @@ func main() {
 }
 
 func grpcTest(config config.Config) {
-	conn, err := grpc.Dial(config.GRPCNetAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds, err := grpcCredentials()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	conn, err := grpc.Dial(config.GRPCNetAddr, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -110,14 +126,14 @@ func deleteShortURLs(client pb.ShortenerClient, userID string, request *pb.Delet
 
 	ctx = grpc_context.SetUserIDContext(ctx, entity.UserID(userID))
 
-	_, err := client.DeleteURLs(ctx, request)
+	res, err := client.DeleteURLs(ctx, request)
 	if err != nil {
 		zap.L().Error("deleteShortURLs DeleteURLs", zap.Error(err))
 
 		return
 	}
 
-	fmt.Println("no errors while deleting")
+	fmt.Println("deletion job:", res.GetJobID())
 }
 
 func getBatchShortURL(client pb.ShortenerClient, userID string, req *pb.BatchRequest) {
@@ -168,6 +184,40 @@ func getShortURL(client pb.ShortenerClient, url, userID string) {
 	fmt.Println(original)
 }
 
+// grpcCredentials Returns TLS credentials with client certificate if server CA is set
+// and insecure credentials otherwise
+func grpcCredentials() (credentials.TransportCredentials, error) {
+	if *serverCAFile == "" {
+		return insecure.NewCredentials(), nil
+	}
+
+	caPEM, err := os.ReadFile(*serverCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading server CA bundle: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("server CA bundle %s doesn't contain certificates", *serverCAFile)
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if *clientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(*clientCertFile, *clientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error while loading client certificate: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
 func getOriginalGRPCURL(client pb.ShortenerClient, url, userID string) {
 	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
 	defer cancel()
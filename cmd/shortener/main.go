@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
@@ -10,17 +11,31 @@ import (
 	"runtime"
 	"runtime/pprof"
 	"syscall"
+	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/avGenie/url-shortener/internal/app/audit"
 	"github.com/avGenie/url-shortener/internal/app/config"
 	"github.com/avGenie/url-shortener/internal/app/grpc"
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/identity"
+	delete_handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
 	handlers "github.com/avGenie/url-shortener/internal/app/handlers/router"
 	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/metrics"
+	"github.com/avGenie/url-shortener/internal/app/quota"
+	"github.com/avGenie/url-shortener/internal/app/ratelimit"
 	storage "github.com/avGenie/url-shortener/internal/app/storage/api"
 	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	"github.com/avGenie/url-shortener/internal/app/tracing"
 	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+	"github.com/avGenie/url-shortener/internal/app/usecase/admin"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/events"
+	"github.com/avGenie/url-shortener/internal/app/usecase/https"
 	usecase_server "github.com/avGenie/url-shortener/internal/app/usecase/server"
+	"github.com/avGenie/url-shortener/internal/app/usecase/trash"
+	"github.com/avGenie/url-shortener/internal/app/usecase/webhook"
 )
 
 // Variables which contains build flag values
@@ -33,14 +48,36 @@ var (
 	BuildCommit = "N/A"
 )
 
-func main() {
-	printProgramInfo()
+const tracingShutdownTimeout = 5 * time.Second
+
+// configValidators Validators of component settings from config
+var configValidators = []config.Validator{
+	ratelimit.ValidateConfig,
+	tracing.ValidateConfig,
+	https.ValidateConfig,
+	identity.ValidateConfig,
+	grpc.ValidateConfig,
+	admin.ValidateConfig,
+	delete_handlers.ValidateConfig,
+	trash.ValidateConfig,
+	webhook.ValidateConfig,
+	events.ValidateConfig,
+	domain.ValidateConfig,
+	quota.ValidateConfig,
+}
 
-	config, err := config.InitConfig()
+func main() {
+	config, err := config.InitConfig(configValidators...)
+	if config.PrintConfig {
+		printConfig(config, err)
+	}
 	if err != nil {
-		zap.L().Fatal("Failed to initialize config", zap.Error(err))
+		fmt.Fprintf(os.Stderr, "Failed to initialize config: %s\n", err.Error())
+		os.Exit(1)
 	}
 
+	printProgramInfo()
+
 	err = logger.Initialize(config)
 	if err != nil {
 		zap.L().Fatal("Failed to initialize logger", zap.Error(err))
@@ -49,30 +86,107 @@ func main() {
 	sugar := *zap.S()
 	defer sugar.Sync()
 
-	storage, err := storage.InitStorage(config)
+	shutdownTracing, err := tracing.Init(config)
 	if err != nil {
 		sugar.Fatalw(
 			err.Error(),
-			"event", "init storage",
+			"event", "init tracing",
 		)
 	}
-	defer storage.Close()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
 
-	var cidrObj *cidr.CIDR
-	if config.TrustedSubnet != "" {
-		cidrObj, err = cidr.NewCIDR(config.TrustedSubnet)
+		err := shutdownTracing(ctx)
 		if err != nil {
-			sugar.Fatalw(
-				err.Error(),
-				"event", "cidr creation",
-			)
+			zap.L().Error("error while shutting down tracing", zap.Error(err))
 		}
+	}()
+
+	quotas, err := quota.NewPolicy(config)
+	if err != nil {
+		sugar.Fatalw(
+			err.Error(),
+			"event", "init quotas",
+		)
+	}
+
+	storage, err := storage.InitStorage(config, quotas)
+	if err != nil {
+		sugar.Fatalw(
+			err.Error(),
+			"event", "init storage",
+		)
+	}
+	defer storage.Close()
+
+	// mutating actions of users and admins are appended to audit trail of storage
+	audit.SetStore(storage)
+
+	err = metrics.RegisterStatistic(storage)
+	if err != nil {
+		sugar.Fatalw(
+			err.Error(),
+			"event", "register statistic metrics",
+		)
+	}
+
+	accessControl, err := cidr.NewAccessControl(config.TrustedSubnet, config.TrustedProxies)
+	if err != nil {
+		sugar.Fatalw(
+			err.Error(),
+			"event", "access control creation",
+		)
+	}
+
+	limiter, err := ratelimit.InitLimiter(config)
+	if err != nil {
+		sugar.Fatalw(
+			err.Error(),
+			"event", "init rate limiter",
+		)
+	}
+	defer limiter.Close()
+
+	tlsConfig, certs, err := https.NewTLSConfig(config)
+	if err != nil {
+		sugar.Fatalw(
+			err.Error(),
+			"event", "init tls",
+		)
+	}
+	defer certs.Close()
+
+	grpcTLSConfig, err := newGRPCTLSConfig(config, tlsConfig)
+	if err != nil {
+		sugar.Fatalw(
+			err.Error(),
+			"event", "init grpc tls",
+		)
+	}
+
+	mapper, err := identity.NewMapper(config)
+	if err != nil {
+		sugar.Fatalw(
+			err.Error(),
+			"event", "init grpc client identities",
+		)
 	}
 
-	startHTTPServer(config, storage, cidrObj)
+	startHTTPServer(config, storage, quotas, accessControl, limiter, tlsConfig, grpcTLSConfig, mapper, certs)
 }
 
-func startHTTPServer(config config.Config, storage model.Storage, cidr *cidr.CIDR) {
+func startHTTPServer(
+	config config.Config,
+	storage model.Storage,
+	quotas *quota.Policy,
+	accessControl *cidr.AccessControl,
+	limiter *ratelimit.Limiter,
+	tlsConfig *tls.Config,
+	grpcTLSConfig *tls.Config,
+	mapper *identity.Mapper,
+	certs *https.CertReloader,
+) {
 	ctx, cancel := signal.NotifyContext(
 		context.Background(),
 		syscall.SIGTERM,
@@ -82,19 +196,79 @@ func startHTTPServer(config config.Config, storage model.Storage, cidr *cidr.CID
 	)
 	defer cancel()
 
-	router := handlers.NewRouter(config, storage, cidr)
+	authenticator, err := admin.NewAuthenticator(config)
+	if err != nil {
+		zap.L().Fatal("Failed to create admin authenticator", zap.Error(err))
+	}
+
+	domains, err := domain.NewRegistry(config)
+	if err != nil {
+		zap.L().Fatal("Failed to create registry of short domains", zap.Error(err))
+	}
+
+	webhookService, err := webhook.NewService(storage, config, domains)
+	if err != nil {
+		zap.L().Fatal("Failed to create webhook service", zap.Error(err))
+	}
+	webhookService.Start()
+
+	bus, err := events.NewBus(storage, config)
+	if err != nil {
+		zap.L().Fatal("Failed to create event bus", zap.Error(err))
+	}
+	bus.Start()
+
+	// link events of all code paths changing storage are published to webhooks and live streams
+	storage = events.NewStorage(storage, webhookService, bus)
+
+	// delete handler is shared by servers to flush one queue on admin request
+	deleteHandler := delete_handlers.NewDeleteHandler(storage, config)
+
+	trashService, err := trash.NewService(storage, config, domains)
+	if err != nil {
+		zap.L().Fatal("Failed to create trash service", zap.Error(err))
+	}
+
+	purger := trash.NewPurger(trashService)
+	purger.Start()
+
+	usageService := quota.NewService(storage, quotas)
+
+	grpcServer, err := grpc.NewGRPCServer(config, storage, deleteHandler, trashService, webhookService, bus, domains, limiter, accessControl, authenticator, grpcTLSConfig, mapper)
+	if err != nil {
+		zap.L().Fatal("Failed to create GRPC server", zap.Error(err))
+	}
+
+	router := handlers.NewRouter(config, storage, deleteHandler, trashService, usageService, webhookService, bus, domains, accessControl, limiter, authenticator, handlers.RPCHandlers{
+		Gateway: grpcServer.Gateway(),
+		Web:     grpcServer.WebHandler(),
+	})
 
 	server := &http.Server{
 		Addr:    config.NetAddr,
 		Handler: router.Mux,
 	}
 
-	go usecase_server.Start(config.EnableHTTPS, server)
+	if config.EnableHTTPS {
+		server.TLSConfig = tlsConfig
+	}
+
+	go usecase_server.Start(server)
 
-	grpcServer := grpc.NewGRPCServer(config, storage)
+	var metricsServer *http.Server
+	if config.MetricsAddr != "" {
+		metricsServer = &http.Server{
+			Addr:    config.MetricsAddr,
+			Handler: handlers.NewMetricsRouter(config, accessControl),
+		}
+
+		go usecase_server.Start(metricsServer)
+	}
 
 	go grpcServer.Start()
 
+	go watchReload(ctx, config, accessControl, limiter, certs)
+
 	<-ctx.Done()
 
 	if len(config.ProfilerFile) != 0 {
@@ -109,14 +283,57 @@ func startHTTPServer(config config.Config, storage model.Storage, cidr *cidr.CID
 		}
 	}
 
+	// live event streams are finished, otherwise graceful shutdown of servers waits for them
+	bus.Close()
 	grpcServer.Stop()
 
 	zap.L().Info("Got interruption signal. Shutting down HTTP server gracefully...")
-	err := server.Shutdown(context.Background())
+	err = server.Shutdown(context.Background())
 	if err != nil {
 		zap.L().Error("error while shutting down server", zap.Error(err))
 	}
-	router.Stop()
+
+	if metricsServer != nil {
+		err = metricsServer.Shutdown(context.Background())
+		if err != nil {
+			zap.L().Error("error while shutting down metrics server", zap.Error(err))
+		}
+	}
+
+	deleteHandler.Stop()
+	purger.Stop()
+	webhookService.Stop()
+}
+
+// newGRPCTLSConfig Returns TLS config of GRPC listener
+//
+// Client certificates are required if client CA bundle is configured.
+// Returns nil config if TLS is disabled for GRPC listener
+func newGRPCTLSConfig(config config.Config, tlsConfig *tls.Config) (*tls.Config, error) {
+	if !config.GRPCEnableTLS {
+		return nil, nil
+	}
+
+	if config.GRPCClientCAFile == "" {
+		return tlsConfig, nil
+	}
+
+	return https.WithClientCA(tlsConfig, config.GRPCClientCAFile)
+}
+
+func printConfig(config config.Config, validationErr error) {
+	err := config.Print(os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to print config: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	if validationErr != nil {
+		fmt.Fprintln(os.Stderr, validationErr.Error())
+		os.Exit(1)
+	}
+
+	os.Exit(0)
 }
 
 func printProgramInfo() {
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/ratelimit"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+	"github.com/avGenie/url-shortener/internal/app/usecase/https"
+)
+
+// watchReload Reloads safe-to-change settings and TLS certificate on SIGHUP until context is done
+func watchReload(
+	ctx context.Context,
+	current config.Config,
+	accessControl *cidr.AccessControl,
+	limiter *ratelimit.Limiter,
+	certs *https.CertReloader,
+) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			current = reloadConfig(current, accessControl, limiter)
+			reloadCertificate(certs)
+		}
+	}
+}
+
+// reloadConfig Applies reloaded log level, trusted subnets and proxies, rate limits
+//
+// Returns current config if reloaded config is invalid
+func reloadConfig(current config.Config, accessControl *cidr.AccessControl, limiter *ratelimit.Limiter) config.Config {
+	zap.L().Info("reloading config")
+
+	next, ignored, err := config.Reload(current, configValidators...)
+	if err != nil {
+		zap.L().Error("config is not reloaded", zap.Error(err))
+		return current
+	}
+
+	if len(ignored) != 0 {
+		zap.L().Warn("changed settings require restart and are ignored", zap.Strings("settings", ignored))
+	}
+
+	policies, err := ratelimit.ParsePolicies(next.RateLimits)
+	if err != nil {
+		zap.L().Error("config is not reloaded", zap.Error(err))
+		return current
+	}
+
+	err = accessControl.Set(next.TrustedSubnet, next.TrustedProxies)
+	if err != nil {
+		zap.L().Error("config is not reloaded", zap.Error(err))
+		return current
+	}
+
+	err = logger.SetLevel(next.LogLevel)
+	if err != nil {
+		zap.L().Error("error while changing log level", zap.Error(err))
+	}
+
+	limiter.SetPolicies(policies)
+
+	zap.L().Info("config is reloaded")
+
+	return next
+}
+
+// reloadCertificate Reloads TLS certificate from files if TLS is enabled
+func reloadCertificate(certs *https.CertReloader) {
+	if certs == nil {
+		return
+	}
+
+	err := certs.Reload()
+	if err != nil {
+		zap.L().Error("TLS certificate is not reloaded", zap.Error(err))
+	}
+}
@@ -0,0 +1,185 @@
+// Package audit writes audit log of actions changing state of service
+//
+// Events are written to audit logger and are appended to audit trail of storage if it is set
+package audit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+)
+
+// Results of audited actions
+//
+// ResultSuccess - action was completed
+// ResultFailure - action failed
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Actions of users changing their links
+//
+// ActionLinkCreate - link is created
+// ActionLinkBatchCreate - batch of links is created
+// ActionLinkUpdate - metadata of link is replaced
+// ActionLinkDelete - links are deleted
+// ActionLinkRestore - deleted links are restored from trash
+const (
+	ActionLinkCreate      = "link.create"
+	ActionLinkBatchCreate = "link.batch_create"
+	ActionLinkUpdate      = "link.update"
+	ActionLinkDelete      = "link.delete"
+	ActionLinkRestore     = "link.restore"
+)
+
+// saveTimeout Timeout of appending event to audit trail of storage
+const saveTimeout = 3 * time.Second
+
+// Event Action of actor with its targets and error
+//
+// Target is empty if action is not related to a single object, targets contain objects of batch action
+type Event struct {
+	ActorType string
+	Actor     string
+	Action    string
+	Target    string
+	Targets   []string
+	Err       error
+}
+
+// Store Storage of audit trail
+type Store interface {
+	SaveAuditRecord(ctx context.Context, record entity.AuditRecord) error
+}
+
+var (
+	store      Store
+	storeMutex sync.RWMutex
+)
+
+// SetStore Sets storage where audited events are appended in addition to audit log
+//
+// Events are written to audit log only if storage is nil
+func SetStore(s Store) {
+	storeMutex.Lock()
+	defer storeMutex.Unlock()
+
+	store = s
+}
+
+// Log Writes event to audit log with request ID and client IP from context
+//
+// Event is appended to audit trail of storage if it is set. Error of storage is logged,
+// so audited action isn't failed by audit trail
+func Log(ctx context.Context, event Event) {
+	logger.Audit().Info("audit event", fields(ctx, event)...)
+
+	storeMutex.RLock()
+	s := store
+	storeMutex.RUnlock()
+
+	if s == nil {
+		return
+	}
+
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), saveTimeout)
+	defer cancel()
+
+	err := s.SaveAuditRecord(saveCtx, NewRecord(ctx, event, time.Now().UTC()))
+	if err != nil {
+		logger.FromContext(ctx).Error("error while saving audit record", zap.Error(err), zap.String("action", event.Action))
+	}
+}
+
+// LogUser Writes event of action of user changing its links to audit log
+//
+// Empty targets are skipped
+func LogUser(ctx context.Context, userID entity.UserID, action string, targets []string, err error) {
+	Log(ctx, Event{
+		ActorType: entity.AuditActorUser,
+		Actor:     userID.String(),
+		Action:    action,
+		Targets:   targets,
+		Err:       err,
+	})
+}
+
+// NewRecord Returns record of audit trail for event with request ID and client IP from context
+func NewRecord(ctx context.Context, event Event, createdAt time.Time) entity.AuditRecord {
+	record := entity.AuditRecord{
+		ID:        uuid.New().String(),
+		ActorType: event.ActorType,
+		Actor:     event.Actor,
+		Action:    event.Action,
+		Targets:   event.targets(),
+		ClientIP:  cidr.ClientIPFromContext(ctx),
+		RequestID: logger.RequestIDFromContext(ctx),
+		Result:    ResultSuccess,
+		CreatedAt: createdAt,
+	}
+
+	if event.Err != nil {
+		record.Result = ResultFailure
+		record.Error = event.Err.Error()
+	}
+
+	return record
+}
+
+// targets Returns target and targets of event without empty values
+func (e Event) targets() []string {
+	return nonEmpty(append([]string{e.Target}, e.Targets...))
+}
+
+func nonEmpty(values []string) []string {
+	var res []string
+	for _, value := range values {
+		if value != "" {
+			res = append(res, value)
+		}
+	}
+
+	return res
+}
+
+func fields(ctx context.Context, event Event) []zap.Field {
+	res := make([]zap.Field, 0, 8)
+	if event.ActorType != "" {
+		res = append(res, zap.String("actor_type", event.ActorType))
+	}
+
+	res = append(res,
+		zap.String("actor", event.Actor),
+		zap.String("action", event.Action),
+	)
+
+	if event.Target != "" {
+		res = append(res, zap.String("target", event.Target))
+	}
+
+	if targets := nonEmpty(event.Targets); len(targets) != 0 {
+		res = append(res, zap.Strings("targets", targets))
+	}
+
+	if requestID := logger.RequestIDFromContext(ctx); requestID != "" {
+		res = append(res, zap.String("request_id", requestID))
+	}
+
+	if ip := cidr.ClientIPFromContext(ctx); ip != "" {
+		res = append(res, zap.String("client_ip", ip))
+	}
+
+	if event.Err != nil {
+		return append(res, zap.String("result", ResultFailure), zap.Error(event.Err))
+	}
+
+	return append(res, zap.String("result", ResultSuccess))
+}
@@ -0,0 +1,223 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+func TestFields(t *testing.T) {
+	ctx := logger.WithRequestID(cidr.WithClientIP(context.Background(), "192.168.1.14"), "request-id")
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		event    Event
+		expected map[string]interface{}
+	}{
+		{
+			name: "successful action",
+			ctx:  ctx,
+			event: Event{
+				Actor:  "alice",
+				Action: "link.disable",
+				Target: "42b3e75f",
+			},
+			expected: map[string]interface{}{
+				"actor":      "alice",
+				"action":     "link.disable",
+				"target":     "42b3e75f",
+				"request_id": "request-id",
+				"client_ip":  "192.168.1.14",
+				"result":     ResultSuccess,
+			},
+		},
+		{
+			name: "batch action of user",
+			ctx:  ctx,
+			event: Event{
+				ActorType: entity.AuditActorUser,
+				Actor:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				Action:    ActionLinkDelete,
+				Targets:   []string{"42b3e75f", "", "aHR0cHM6"},
+			},
+			expected: map[string]interface{}{
+				"actor_type": entity.AuditActorUser,
+				"actor":      "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				"action":     ActionLinkDelete,
+				"targets":    []interface{}{"42b3e75f", "aHR0cHM6"},
+				"request_id": "request-id",
+				"client_ip":  "192.168.1.14",
+				"result":     ResultSuccess,
+			},
+		},
+		{
+			name: "failed action without target",
+			ctx:  context.Background(),
+			event: Event{
+				Actor:  "alice",
+				Action: "storage.compact",
+				Err:    errors.New("storage doesn't support compaction"),
+			},
+			expected: map[string]interface{}{
+				"actor":  "alice",
+				"action": "storage.compact",
+				"result": ResultFailure,
+				"error":  "storage doesn't support compaction",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+			zap.New(core).Info("audit event", fields(test.ctx, test.event)...)
+
+			entries := logs.All()
+			require.Len(t, entries, 1)
+			assert.Equal(t, test.expected, entries[0].ContextMap())
+		})
+	}
+}
+
+type testStore struct {
+	records []entity.AuditRecord
+	err     error
+}
+
+func (s *testStore) SaveAuditRecord(_ context.Context, record entity.AuditRecord) error {
+	s.records = append(s.records, record)
+
+	return s.err
+}
+
+func (s *testStore) ListAuditRecords(_ context.Context, filter entity.AuditFilter, limit int) ([]entity.AuditRecord, error) {
+	var res []entity.AuditRecord
+	for i := len(s.records) - 1; i >= 0 && len(res) < limit; i-- {
+		if filter.Match(s.records[i]) {
+			res = append(res, s.records[i])
+		}
+	}
+
+	return res, s.err
+}
+
+func TestLog(t *testing.T) {
+	store := &testStore{}
+	SetStore(store)
+	defer SetStore(nil)
+
+	ctx, cancel := context.WithCancel(logger.WithRequestID(cidr.WithClientIP(context.Background(), "192.168.1.14"), "request-id"))
+	cancel()
+
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	LogUser(ctx, userID, ActionLinkCreate, []string{"42b3e75f"}, nil)
+	Log(ctx, Event{
+		ActorType: entity.AuditActorAdmin,
+		Actor:     "alice",
+		Action:    "admin.storage.compact",
+		Err:       errors.New("storage doesn't support compaction"),
+	})
+
+	require.Len(t, store.records, 2)
+
+	created := store.records[0]
+	assert.NotEmpty(t, created.ID)
+	assert.False(t, created.CreatedAt.IsZero())
+	assert.Equal(t, entity.AuditRecord{
+		ID:        created.ID,
+		ActorType: entity.AuditActorUser,
+		Actor:     userID.String(),
+		Action:    ActionLinkCreate,
+		Targets:   []string{"42b3e75f"},
+		ClientIP:  "192.168.1.14",
+		RequestID: "request-id",
+		Result:    ResultSuccess,
+		CreatedAt: created.CreatedAt,
+	}, created)
+
+	compacted := store.records[1]
+	assert.Equal(t, "alice", compacted.Actor)
+	assert.Nil(t, compacted.Targets)
+	assert.Equal(t, ResultFailure, compacted.Result)
+	assert.Equal(t, "storage doesn't support compaction", compacted.Error)
+}
+
+func TestTrailList(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+
+	store := &testStore{}
+	for i, actor := range []string{userID.String(), "alice", userID.String(), userID.String()} {
+		actorType := entity.AuditActorUser
+		if actor == "alice" {
+			actorType = entity.AuditActorAdmin
+		}
+
+		store.records = append(store.records, entity.AuditRecord{
+			ID:        fmt.Sprintf("record-%d", i),
+			ActorType: actorType,
+			Actor:     actor,
+			Action:    ActionLinkCreate,
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	trail := NewTrail(store)
+
+	first, next, err := trail.Activity(ctx, userID, entity.AuditFilter{Actor: "alice"}, 2, "")
+	require.NoError(t, err)
+	assert.Equal(t, []entity.AuditRecord{store.records[3], store.records[2]}, first)
+	require.NotEmpty(t, next)
+
+	second, next, err := trail.Activity(ctx, userID, entity.AuditFilter{}, 2, next)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.AuditRecord{store.records[0]}, second)
+	assert.Empty(t, next)
+
+	all, next, err := trail.List(ctx, entity.AuditFilter{}, 0, "")
+	require.NoError(t, err)
+	assert.Len(t, all, 4)
+	assert.Empty(t, next)
+
+	tests := []struct {
+		name      string
+		filter    entity.AuditFilter
+		pageToken string
+		field     string
+	}{
+		{
+			name:      "invalid page token",
+			pageToken: "broken",
+			field:     "page_token",
+		},
+		{
+			name:   "invalid period",
+			filter: entity.AuditFilter{From: createdAt, To: createdAt},
+			field:  "from",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := trail.List(ctx, test.filter, 0, test.pageToken)
+
+			var fieldErr *link.FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, test.field, fieldErr.Field)
+		})
+	}
+}
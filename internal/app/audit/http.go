@@ -0,0 +1,94 @@
+package audit
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+// Query Conditions of page of audit trail parsed from query parameters of HTTP request
+type Query struct {
+	Filter    entity.AuditFilter
+	PageSize  int
+	PageToken string
+}
+
+// ParseQuery Parses conditions of page of audit trail from query parameters
+//
+// Parameters are actor_type, actor, action, target, from and to in RFC 3339 format, page_size and page_token.
+// Returns FieldError error if period or page size is invalid
+func ParseQuery(values url.Values) (Query, error) {
+	query := Query{
+		Filter: entity.AuditFilter{
+			ActorType: values.Get("actor_type"),
+			Actor:     values.Get("actor"),
+			Action:    values.Get("action"),
+			Target:    values.Get("target"),
+		},
+		PageToken: values.Get("page_token"),
+	}
+
+	var err error
+	query.Filter.From, err = parseTime(values, "from")
+	if err != nil {
+		return Query{}, err
+	}
+
+	query.Filter.To, err = parseTime(values, "to")
+	if err != nil {
+		return Query{}, err
+	}
+
+	if value := values.Get("page_size"); value != "" {
+		query.PageSize, err = strconv.Atoi(value)
+		if err != nil || query.PageSize < 0 {
+			return Query{}, &link.FieldError{Field: "page_size", Err: errors.New("must be non-negative integer")}
+		}
+	}
+
+	return query, nil
+}
+
+// PageToModel Converts page of audit records to output model
+func PageToModel(records []entity.AuditRecord, nextPageToken string) models.AuditPage {
+	page := models.AuditPage{
+		Records:       make([]models.AuditRecord, 0, len(records)),
+		NextPageToken: nextPageToken,
+	}
+
+	for _, record := range records {
+		page.Records = append(page.Records, models.AuditRecord{
+			ID:        record.ID,
+			ActorType: record.ActorType,
+			Actor:     record.Actor,
+			Action:    record.Action,
+			Targets:   record.Targets,
+			ClientIP:  record.ClientIP,
+			RequestID: record.RequestID,
+			Result:    record.Result,
+			Error:     record.Error,
+			CreatedAt: record.CreatedAt,
+		})
+	}
+
+	return page
+}
+
+func parseTime(values url.Values, name string) (time.Time, error) {
+	value := values.Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	res, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, &link.FieldError{Field: name, Err: errors.New("must be time in RFC 3339 format")}
+	}
+
+	return res.UTC(), nil
+}
@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+// Page sizes of audit trail
+//
+// DefaultPageSize - used if page size is not set
+// MaxPageSize - max page size, bigger page sizes are reduced to it
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// ErrInvalidPeriod Start of period of listed records is not before its end
+var ErrInvalidPeriod = errors.New("start of period must be before its end")
+
+// Lister Storage listing audit trail
+type Lister interface {
+	ListAuditRecords(ctx context.Context, filter entity.AuditFilter, limit int) ([]entity.AuditRecord, error)
+}
+
+// Trail Reads audit trail of storage by pages
+type Trail struct {
+	storage Lister
+}
+
+// NewTrail Creates reader of audit trail
+func NewTrail(storage Lister) *Trail {
+	return &Trail{
+		storage: storage,
+	}
+}
+
+// List Returns page of records satisfying filter, the newest first, and token of the next page
+//
+// Cursor of filter is replaced by position of page token. Token of the next page is empty if there are no more records.
+// Returns FieldError error if page token or period of filter is invalid
+func (t *Trail) List(ctx context.Context, filter entity.AuditFilter, pageSize int, pageToken string) ([]entity.AuditRecord, string, error) {
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return nil, "", &link.FieldError{Field: "from", Err: ErrInvalidPeriod}
+	}
+
+	cursor, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", &link.FieldError{Field: "page_token", Err: err}
+	}
+	filter.After = cursor
+
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	records, err := t.storage.ListAuditRecords(ctx, filter, pageSize+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("error while listing audit records: %w", err)
+	}
+
+	if len(records) <= pageSize {
+		return records, "", nil
+	}
+
+	records = records[:pageSize]
+
+	return records, encodePageToken(records[pageSize-1].Cursor()), nil
+}
+
+// Activity Returns page of actions of user changing its links, the newest first, and token of the next page
+//
+// Filter is restricted to actions of user, so actions of other actors are never returned
+func (t *Trail) Activity(
+	ctx context.Context,
+	userID entity.UserID,
+	filter entity.AuditFilter,
+	pageSize int,
+	pageToken string,
+) ([]entity.AuditRecord, string, error) {
+	filter.ActorType = entity.AuditActorUser
+	filter.Actor = userID.String()
+
+	return t.List(ctx, filter, pageSize, pageToken)
+}
+
+// pageToken Position of the next page of audit trail
+type pageToken struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"i"`
+}
+
+// encodePageToken Returns opaque token of the next page placed after cursor
+func encodePageToken(cursor entity.AuditCursor) string {
+	data, _ := json.Marshal(pageToken{
+		CreatedAt: cursor.CreatedAt,
+		ID:        cursor.ID,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken Returns cursor of page token
+//
+// Returns empty cursor if token is empty
+func decodePageToken(token string) (entity.AuditCursor, error) {
+	if token == "" {
+		return entity.AuditCursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return entity.AuditCursor{}, link.ErrInvalidPageToken
+	}
+
+	var res pageToken
+	err = json.Unmarshal(data, &res)
+	if err != nil || res.ID == "" {
+		return entity.AuditCursor{}, link.ErrInvalidPageToken
+	}
+
+	return entity.AuditCursor{
+		CreatedAt: res.CreatedAt,
+		ID:        res.ID,
+	}, nil
+}
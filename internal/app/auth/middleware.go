@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
 	"github.com/avGenie/url-shortener/internal/app/usecase/user"
 	"go.uber.org/zap"
 )
@@ -18,7 +19,7 @@ import (
 // Returns 401(StatusUnauthorized) if user ID obtained from cookies is invalid
 func AuthMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		zap.L().Info("start user authentication")
+		logger.FromContext(r.Context()).Info("start user authentication")
 
 		status := http.StatusOK
 		userIDCookie, err := r.Cookie(entity.UserIDKey)
@@ -26,9 +27,9 @@ func AuthMiddleware(next http.Handler) http.Handler {
 		// Cookies doesn't contain user id
 		if err != nil {
 			if errors.Is(err, http.ErrNoCookie) {
-				zap.L().Info("cookie with user id is not defined")
+				logger.FromContext(r.Context()).Info("cookie with user id is not defined")
 			} else {
-				zap.L().Info("error while getting cookie", zap.Error(err))
+				logger.FromContext(r.Context()).Info("error while getting cookie", zap.Error(err))
 			}
 
 			status = http.StatusUnauthorized
@@ -39,7 +40,7 @@ func AuthMiddleware(next http.Handler) http.Handler {
 		// User id invalid: may be empty
 		userID, err := entity.ValidateCookieUserID(userIDCookie)
 		if err != nil {
-			zap.L().Error("error while validating user id from cookie in user authentication", zap.Error(err))
+			logger.FromContext(r.Context()).Error("error while validating user id from cookie in user authentication", zap.Error(err))
 			status = http.StatusUnauthorized
 
 			processInvalidCookie(w)
@@ -63,6 +64,7 @@ func processInvalidCookie(w http.ResponseWriter) *http.Cookie {
 	cookie := &http.Cookie{
 		Name:  entity.UserIDKey,
 		Value: userID.String(),
+		Path:  "/",
 	}
 
 	http.SetCookie(w, cookie)
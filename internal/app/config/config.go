@@ -2,8 +2,12 @@
 package config
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
+	"os"
+	"sync"
 
 	"github.com/caarlos0/env/v10"
 )
@@ -14,44 +18,281 @@ const (
 	defaultBaseURIPrefix   = "http://localhost:8080"
 	defaultLogLevel        = "debug"
 	defaultFileStoragePath = "/tmp/short-url-db.json"
+	defaultRateLimitStore  = "memory"
+	defaultRateLimits      = ""
+	defaultTracingExporter = "none"
+	defaultTracingEndpoint = "localhost:4317"
+	defaultTracingRatio    = 1.0
+	defaultTLSMinVersion   = "1.2"
+
+	defaultGRPCKeepaliveTime    = "2h"
+	defaultGRPCKeepaliveTimeout = "20s"
+	defaultGRPCKeepaliveMinTime = "5m"
+	defaultGRPCMaxMsgSize       = 4 << 20
+
+	defaultDeleteQueueSize     = 1000
+	defaultDeleteQueueUserSize = 100
+	defaultDeleteWorkers       = 4
+
+	defaultTrashRetention      = "168h"
+	defaultTrashPurgeInterval  = "1h"
+	defaultTrashPurgeBatchSize = 500
+
+	defaultWebhookTimeout     = "5s"
+	defaultWebhookMaxAttempts = 8
+	defaultWebhookQueueSize   = 1000
+
+	defaultEventBufferSize   = 1000
+	defaultEventStreamBuffer = 100
+
+	defaultQuotaPlans       = ""
+	defaultQuotaDefaultPlan = "free"
+)
+
+const (
+	configFileEnv = "CONFIG"
+	maskedValue   = "******"
 )
 
 // Config struct
 type Config struct {
-	NetAddr           string `json:"server_address" env:"SERVER_ADDRESS"`
-	GRPCNetAddr       string `json:"grpc_server_address" env:"GRPC_SERVER_ADDRESS"`
-	BaseURIPrefix     string `json:"base_url" env:"BASE_URL"`
-	LogLevel          string `json:"-" env:"LOG_LEVEL"`
-	DBFileStoragePath string `json:"file_storage_path" env:"FILE_STORAGE_PATH"`
-	DBStorageConnect  string `json:"database_dsn" env:"DATABASE_DSN"`
-	ProfilerFile      string `json:"-" env:"PROFILER_FILE"`
-	ConfigFile        string `json:"-" env:"CONFIG"`
-	TrustedSubnet     string `json:"trusted_subnet" env:"TRUSTED_SUBNET"`
-	EnableHTTPS       bool   `json:"enable_https" env:"ENABLE_HTTPS"`
+	NetAddr              string  `json:"server_address" yaml:"server_address" toml:"server_address" env:"SERVER_ADDRESS"`
+	GRPCNetAddr          string  `json:"grpc_server_address" yaml:"grpc_server_address" toml:"grpc_server_address" env:"GRPC_SERVER_ADDRESS"`
+	BaseURIPrefix        string  `json:"base_url" yaml:"base_url" toml:"base_url" env:"BASE_URL"`
+	Domains              string  `json:"domains" yaml:"domains" toml:"domains" env:"DOMAINS"`
+	LogLevel             string  `json:"log_level" yaml:"log_level" toml:"log_level" env:"LOG_LEVEL"`
+	DBFileStoragePath    string  `json:"file_storage_path" yaml:"file_storage_path" toml:"file_storage_path" env:"FILE_STORAGE_PATH"`
+	DBStorageConnect     string  `json:"database_dsn" yaml:"database_dsn" toml:"database_dsn" env:"DATABASE_DSN"`
+	ProfilerFile         string  `json:"-" yaml:"-" toml:"-" env:"PROFILER_FILE"`
+	ConfigFile           string  `json:"-" yaml:"-" toml:"-" env:"CONFIG"`
+	TrustedSubnet        string  `json:"trusted_subnet" yaml:"trusted_subnet" toml:"trusted_subnet" env:"TRUSTED_SUBNET"`
+	TrustedProxies       string  `json:"trusted_proxies" yaml:"trusted_proxies" toml:"trusted_proxies" env:"TRUSTED_PROXIES"`
+	AdminTokens          string  `json:"admin_tokens" yaml:"admin_tokens" toml:"admin_tokens" env:"ADMIN_TOKENS"`
+	RateLimitStore       string  `json:"rate_limit_store" yaml:"rate_limit_store" toml:"rate_limit_store" env:"RATE_LIMIT_STORE"`
+	RateLimits           string  `json:"rate_limits" yaml:"rate_limits" toml:"rate_limits" env:"RATE_LIMITS"`
+	MetricsAddr          string  `json:"metrics_address" yaml:"metrics_address" toml:"metrics_address" env:"METRICS_ADDRESS"`
+	MetricsTrusted       bool    `json:"metrics_trusted_only" yaml:"metrics_trusted_only" toml:"metrics_trusted_only" env:"METRICS_TRUSTED_ONLY"`
+	TracingExporter      string  `json:"tracing_exporter" yaml:"tracing_exporter" toml:"tracing_exporter" env:"TRACING_EXPORTER"`
+	TracingEndpoint      string  `json:"tracing_endpoint" yaml:"tracing_endpoint" toml:"tracing_endpoint" env:"TRACING_ENDPOINT"`
+	TracingSampleRatio   float64 `json:"tracing_sample_ratio" yaml:"tracing_sample_ratio" toml:"tracing_sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+	EnableHTTPS          bool    `json:"enable_https" yaml:"enable_https" toml:"enable_https" env:"ENABLE_HTTPS"`
+	GRPCEnableTLS        bool    `json:"grpc_enable_tls" yaml:"grpc_enable_tls" toml:"grpc_enable_tls" env:"GRPC_ENABLE_TLS"`
+	GRPCClientCAFile     string  `json:"grpc_client_ca_file" yaml:"grpc_client_ca_file" toml:"grpc_client_ca_file" env:"GRPC_CLIENT_CA_FILE"`
+	GRPCClientIdentities string  `json:"grpc_client_identities" yaml:"grpc_client_identities" toml:"grpc_client_identities" env:"GRPC_CLIENT_IDENTITIES"`
+	TLSCertFile          string  `json:"tls_cert_file" yaml:"tls_cert_file" toml:"tls_cert_file" env:"TLS_CERT_FILE"`
+	TLSKeyFile           string  `json:"tls_key_file" yaml:"tls_key_file" toml:"tls_key_file" env:"TLS_KEY_FILE"`
+	TLSSelfSigned        bool    `json:"tls_self_signed" yaml:"tls_self_signed" toml:"tls_self_signed" env:"TLS_SELF_SIGNED"`
+	TLSMinVersion        string  `json:"tls_min_version" yaml:"tls_min_version" toml:"tls_min_version" env:"TLS_MIN_VERSION"`
+	TLSCipherSuites      string  `json:"tls_cipher_suites" yaml:"tls_cipher_suites" toml:"tls_cipher_suites" env:"TLS_CIPHER_SUITES"`
+	GRPCReflection       bool    `json:"grpc_reflection" yaml:"grpc_reflection" toml:"grpc_reflection" env:"GRPC_REFLECTION"`
+	GRPCKeepaliveTime    string  `json:"grpc_keepalive_time" yaml:"grpc_keepalive_time" toml:"grpc_keepalive_time" env:"GRPC_KEEPALIVE_TIME"`
+	GRPCKeepaliveTimeout string  `json:"grpc_keepalive_timeout" yaml:"grpc_keepalive_timeout" toml:"grpc_keepalive_timeout" env:"GRPC_KEEPALIVE_TIMEOUT"`
+	GRPCKeepaliveMinTime string  `json:"grpc_keepalive_min_time" yaml:"grpc_keepalive_min_time" toml:"grpc_keepalive_min_time" env:"GRPC_KEEPALIVE_MIN_TIME"`
+	GRPCMaxRecvMsgSize   int     `json:"grpc_max_recv_msg_size" yaml:"grpc_max_recv_msg_size" toml:"grpc_max_recv_msg_size" env:"GRPC_MAX_RECV_MSG_SIZE"`
+	GRPCMaxSendMsgSize   int     `json:"grpc_max_send_msg_size" yaml:"grpc_max_send_msg_size" toml:"grpc_max_send_msg_size" env:"GRPC_MAX_SEND_MSG_SIZE"`
+	GRPCCompression      string  `json:"grpc_compression" yaml:"grpc_compression" toml:"grpc_compression" env:"GRPC_COMPRESSION"`
+	GRPCWebOrigins       string  `json:"grpc_web_allowed_origins" yaml:"grpc_web_allowed_origins" toml:"grpc_web_allowed_origins" env:"GRPC_WEB_ALLOWED_ORIGINS"`
+	DeleteQueueSize      int     `json:"delete_queue_size" yaml:"delete_queue_size" toml:"delete_queue_size" env:"DELETE_QUEUE_SIZE"`
+	DeleteQueueUserSize  int     `json:"delete_queue_user_size" yaml:"delete_queue_user_size" toml:"delete_queue_user_size" env:"DELETE_QUEUE_USER_SIZE"`
+	DeleteWorkers        int     `json:"delete_workers" yaml:"delete_workers" toml:"delete_workers" env:"DELETE_WORKERS"`
+	TrashRetention       string  `json:"trash_retention" yaml:"trash_retention" toml:"trash_retention" env:"TRASH_RETENTION"`
+	TrashPurgeInterval   string  `json:"trash_purge_interval" yaml:"trash_purge_interval" toml:"trash_purge_interval" env:"TRASH_PURGE_INTERVAL"`
+	TrashPurgeBatchSize  int     `json:"trash_purge_batch_size" yaml:"trash_purge_batch_size" toml:"trash_purge_batch_size" env:"TRASH_PURGE_BATCH_SIZE"`
+	WebhookTimeout       string  `json:"webhook_timeout" yaml:"webhook_timeout" toml:"webhook_timeout" env:"WEBHOOK_TIMEOUT"`
+	WebhookMaxAttempts   int     `json:"webhook_max_attempts" yaml:"webhook_max_attempts" toml:"webhook_max_attempts" env:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookQueueSize     int     `json:"webhook_queue_size" yaml:"webhook_queue_size" toml:"webhook_queue_size" env:"WEBHOOK_QUEUE_SIZE"`
+	WebhookAllowPrivate  bool    `json:"webhook_allow_private" yaml:"webhook_allow_private" toml:"webhook_allow_private" env:"WEBHOOK_ALLOW_PRIVATE"`
+	EventBufferSize      int     `json:"event_buffer_size" yaml:"event_buffer_size" toml:"event_buffer_size" env:"EVENT_BUFFER_SIZE"`
+	EventStreamBuffer    int     `json:"event_stream_buffer" yaml:"event_stream_buffer" toml:"event_stream_buffer" env:"EVENT_STREAM_BUFFER"`
+	QuotaPlans           string  `json:"quota_plans" yaml:"quota_plans" toml:"quota_plans" env:"QUOTA_PLANS"`
+	QuotaDefaultPlan     string  `json:"quota_default_plan" yaml:"quota_default_plan" toml:"quota_default_plan" env:"QUOTA_DEFAULT_PLAN"`
+	QuotaUsers           string  `json:"quota_users" yaml:"quota_users" toml:"quota_users" env:"QUOTA_USERS"`
+	PrintConfig          bool    `json:"-" yaml:"-" toml:"-"`
 }
 
-// InitConfig Initialize config from flag and env variables
-func InitConfig() (Config, error) {
-	var config Config
-	flag.StringVar(&config.NetAddr, "a", defaultNetAddr, "net address host:port")
-	flag.StringVar(&config.GRPCNetAddr, "g", defaultGRPCNetAddr, "GRPC net address :port")
-	flag.StringVar(&config.BaseURIPrefix, "b", defaultBaseURIPrefix, "base output short URL")
-	flag.StringVar(&config.LogLevel, "l", defaultLogLevel, "log level")
-	flag.StringVar(&config.DBFileStoragePath, "f", defaultFileStoragePath, "database storage path")
-	flag.StringVar(&config.DBStorageConnect, "d", "", "database credentials in format: host=host port=port user=myuser password=xxxx dbname=mydb sslmode=disable")
-	flag.StringVar(&config.ProfilerFile, "p", "", "profiler file name")
-	flag.StringVar(&config.ConfigFile, "c", "", "configuration JSON file")
-	flag.StringVar(&config.TrustedSubnet, "t", "", "trusted subnet")
-	flag.BoolVar(&config.EnableHTTPS, "s", false, "enable HTTPS")
-	flag.Parse()
+var (
+	parseFlagsOnce sync.Once
+	commandLine    map[string]string
+)
 
-	if err := env.Parse(&config); err != nil {
+// InitConfig Initialize config from defaults, config file, flags and env variables
+//
+// Config is validated by Validate method and given validators.
+// Config is returned with validation error, so it can be printed
+func InitConfig(validators ...Validator) (Config, error) {
+	config, err := Load(commandLineFlags())
+	if err != nil {
 		return Config{}, err
 	}
 
-	if err := parseJSONConfig(&config); err != nil {
-		fmt.Printf("couldn't parse config file: %s\n", err.Error())
+	return config, config.Validate(validators...)
+}
+
+// Load Creates config from layers in order of increasing precedence:
+// defaults, config file, explicitly set flags and env variables
+//
+// Flags contain values of explicitly set flags by flag name.
+// Config file is obtained from CONFIG env variable or "-c" flag
+func Load(flags map[string]string) (Config, error) {
+	config := defaultConfig()
+
+	configFile := flags["c"]
+	if value, ok := os.LookupEnv(configFileEnv); ok {
+		configFile = value
+	}
+
+	if configFile != "" {
+		err := parseConfigFile(configFile, &config)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	registerFlags(fs, &config)
+	for name, value := range flags {
+		// flags of application which are not related to config
+		if fs.Lookup(name) == nil {
+			continue
+		}
+
+		err := fs.Set(name, value)
+		if err != nil {
+			return Config{}, fmt.Errorf("couldn't apply flag %q: %w", name, err)
+		}
+	}
+
+	if err := env.Parse(&config); err != nil {
+		return Config{}, fmt.Errorf("couldn't parse env variables: %w", err)
 	}
 
 	return config, nil
 }
+
+// Print Writes config in JSON format with masked database credentials and admin tokens
+func (c Config) Print(w io.Writer) error {
+	if c.DBStorageConnect != "" {
+		c.DBStorageConnect = maskedValue
+	}
+
+	if c.AdminTokens != "" {
+		c.AdminTokens = maskedValue
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(c)
+}
+
+func defaultConfig() Config {
+	return Config{
+		NetAddr:            defaultNetAddr,
+		GRPCNetAddr:        defaultGRPCNetAddr,
+		BaseURIPrefix:      defaultBaseURIPrefix,
+		LogLevel:           defaultLogLevel,
+		DBFileStoragePath:  defaultFileStoragePath,
+		RateLimitStore:     defaultRateLimitStore,
+		RateLimits:         defaultRateLimits,
+		TracingExporter:    defaultTracingExporter,
+		TracingEndpoint:    defaultTracingEndpoint,
+		TracingSampleRatio: defaultTracingRatio,
+		TLSMinVersion:      defaultTLSMinVersion,
+
+		GRPCKeepaliveTime:    defaultGRPCKeepaliveTime,
+		GRPCKeepaliveTimeout: defaultGRPCKeepaliveTimeout,
+		GRPCKeepaliveMinTime: defaultGRPCKeepaliveMinTime,
+		GRPCMaxRecvMsgSize:   defaultGRPCMaxMsgSize,
+		GRPCMaxSendMsgSize:   defaultGRPCMaxMsgSize,
+
+		DeleteQueueSize:     defaultDeleteQueueSize,
+		DeleteQueueUserSize: defaultDeleteQueueUserSize,
+		DeleteWorkers:       defaultDeleteWorkers,
+
+		TrashRetention:      defaultTrashRetention,
+		TrashPurgeInterval:  defaultTrashPurgeInterval,
+		TrashPurgeBatchSize: defaultTrashPurgeBatchSize,
+
+		WebhookTimeout:     defaultWebhookTimeout,
+		WebhookMaxAttempts: defaultWebhookMaxAttempts,
+		WebhookQueueSize:   defaultWebhookQueueSize,
+
+		EventBufferSize:   defaultEventBufferSize,
+		EventStreamBuffer: defaultEventStreamBuffer,
+
+		QuotaPlans:       defaultQuotaPlans,
+		QuotaDefaultPlan: defaultQuotaDefaultPlan,
+	}
+}
+
+// commandLineFlags Parses command line flags once and returns explicitly set flags
+func commandLineFlags() map[string]string {
+	parseFlagsOnce.Do(func() {
+		flagConfig := defaultConfig()
+		registerFlags(flag.CommandLine, &flagConfig)
+		flag.Parse()
+
+		commandLine = make(map[string]string)
+		flag.Visit(func(f *flag.Flag) {
+			commandLine[f.Name] = f.Value.String()
+		})
+	})
+
+	return commandLine
+}
+
+// registerFlags Defines config flags using current config values as defaults
+func registerFlags(fs *flag.FlagSet, config *Config) {
+	fs.StringVar(&config.NetAddr, "a", config.NetAddr, "net address host:port")
+	fs.StringVar(&config.GRPCNetAddr, "g", config.GRPCNetAddr, "GRPC net address :port")
+	fs.StringVar(&config.BaseURIPrefix, "b", config.BaseURIPrefix, "base output short URL")
+	fs.StringVar(&config.Domains, "domains", config.Domains, "short domains with own namespaces of short codes in format: base_url[;not_found=url][;users=id|id...],..., only base URL is used if empty")
+	fs.StringVar(&config.LogLevel, "l", config.LogLevel, "log level")
+	fs.StringVar(&config.DBFileStoragePath, "f", config.DBFileStoragePath, "database storage path")
+	fs.StringVar(&config.DBStorageConnect, "d", config.DBStorageConnect, "database credentials in format: host=host port=port user=myuser password=xxxx dbname=mydb sslmode=disable")
+	fs.StringVar(&config.ProfilerFile, "p", config.ProfilerFile, "profiler file name")
+	fs.StringVar(&config.ConfigFile, "c", config.ConfigFile, "configuration file in JSON, YAML or TOML format")
+	fs.StringVar(&config.TrustedSubnet, "t", config.TrustedSubnet, "trusted subnets in CIDR notation separated by comma")
+	fs.StringVar(&config.TrustedProxies, "trusted-proxies", config.TrustedProxies, "subnets of trusted proxies in CIDR notation separated by comma, forwarding headers are used only from them")
+	fs.StringVar(&config.AdminTokens, "admin-tokens", config.AdminTokens, "tokens of admin API in format: name:token,..., admin API is disabled if empty")
+	fs.StringVar(&config.RateLimitStore, "rate-limit-store", config.RateLimitStore, "rate limit store: memory, postgres or none")
+	fs.StringVar(&config.RateLimits, "rate-limits", config.RateLimits, "rate limit policies of create, redirect and api routes in format: route=rate:burst[:ip|user[:ip_rate:ip_burst]],..., user policy limits all users of ip by ip bucket if it is set, requests aren't limited if empty")
+	fs.StringVar(&config.MetricsAddr, "metrics-addr", config.MetricsAddr, "separate net address host:port of metrics listener, main listener is used if empty")
+	fs.BoolVar(&config.MetricsTrusted, "metrics-trusted-only", config.MetricsTrusted, "allow metrics scraping only from trusted subnet")
+	fs.StringVar(&config.TracingExporter, "tracing-exporter", config.TracingExporter, "tracing exporter: otlp, stdout or none")
+	fs.StringVar(&config.TracingEndpoint, "tracing-endpoint", config.TracingEndpoint, "OTLP collector GRPC endpoint host:port")
+	fs.Float64Var(&config.TracingSampleRatio, "tracing-sample-ratio", config.TracingSampleRatio, "ratio of sampled traces from 0 to 1")
+	fs.BoolVar(&config.EnableHTTPS, "s", config.EnableHTTPS, "enable HTTPS")
+	fs.BoolVar(&config.GRPCEnableTLS, "grpc-tls", config.GRPCEnableTLS, "enable TLS for GRPC")
+	fs.StringVar(&config.GRPCClientCAFile, "grpc-client-ca", config.GRPCClientCAFile, "CA bundle to verify GRPC client certificates, enables mutual TLS")
+	fs.StringVar(&config.GRPCClientIdentities, "grpc-client-identities", config.GRPCClientIdentities, "identities of GRPC client certificates in format: name=user:<user id>|service,...")
+	fs.StringVar(&config.TLSCertFile, "tls-cert", config.TLSCertFile, "TLS certificate file in PEM format")
+	fs.StringVar(&config.TLSKeyFile, "tls-key", config.TLSKeyFile, "TLS private key file in PEM format")
+	fs.BoolVar(&config.TLSSelfSigned, "tls-self-signed", config.TLSSelfSigned, "generate self-signed certificate if certificate files don't exist, for development only")
+	fs.StringVar(&config.TLSMinVersion, "tls-min-version", config.TLSMinVersion, "minimal TLS version: 1.2 or 1.3")
+	fs.StringVar(&config.TLSCipherSuites, "tls-cipher-suites", config.TLSCipherSuites, "TLS 1.2 cipher suites separated by comma, default suites are used if empty")
+	fs.BoolVar(&config.GRPCReflection, "grpc-reflection", config.GRPCReflection, "enable GRPC server reflection")
+	fs.StringVar(&config.GRPCKeepaliveTime, "grpc-keepalive-time", config.GRPCKeepaliveTime, "idle time after which GRPC server pings client")
+	fs.StringVar(&config.GRPCKeepaliveTimeout, "grpc-keepalive-timeout", config.GRPCKeepaliveTimeout, "time GRPC server waits for ping ack before closing connection")
+	fs.StringVar(&config.GRPCKeepaliveMinTime, "grpc-keepalive-min-time", config.GRPCKeepaliveMinTime, "minimal interval of client pings allowed by GRPC server")
+	fs.IntVar(&config.GRPCMaxRecvMsgSize, "grpc-max-recv-msg-size", config.GRPCMaxRecvMsgSize, "max size of GRPC message received by server in bytes")
+	fs.IntVar(&config.GRPCMaxSendMsgSize, "grpc-max-send-msg-size", config.GRPCMaxSendMsgSize, "max size of GRPC message sent by server in bytes")
+	fs.StringVar(&config.GRPCCompression, "grpc-compression", config.GRPCCompression, "compression of GRPC responses: gzip or none")
+	fs.StringVar(&config.GRPCWebOrigins, "grpc-web-allowed-origins", config.GRPCWebOrigins, "browser origins allowed to call gRPC-Web and Connect endpoints separated by comma, only same origin is allowed if empty")
+	fs.IntVar(&config.DeleteQueueSize, "delete-queue-size", config.DeleteQueueSize, "max count of deletion jobs waiting in memory, new deletions are rejected if queue is full")
+	fs.IntVar(&config.DeleteQueueUserSize, "delete-queue-user-size", config.DeleteQueueUserSize, "max count of deletion jobs of one user waiting in memory, 0 is no limit")
+	fs.IntVar(&config.DeleteWorkers, "delete-workers", config.DeleteWorkers, "count of workers flushing deleted URLs to storage concurrently")
+	fs.StringVar(&config.TrashRetention, "trash-retention", config.TrashRetention, "time deleted URLs are kept in trash and can be restored")
+	fs.StringVar(&config.TrashPurgeInterval, "trash-purge-interval", config.TrashPurgeInterval, "interval of removing URLs kept in trash longer than retention")
+	fs.IntVar(&config.TrashPurgeBatchSize, "trash-purge-batch-size", config.TrashPurgeBatchSize, "max count of URLs removed from storage in one batch")
+	fs.StringVar(&config.WebhookTimeout, "webhook-timeout", config.WebhookTimeout, "timeout of one delivery of event to webhook")
+	fs.IntVar(&config.WebhookMaxAttempts, "webhook-max-attempts", config.WebhookMaxAttempts, "count of failed attempts after which delivery of event to webhook is given up")
+	fs.IntVar(&config.WebhookQueueSize, "webhook-queue-size", config.WebhookQueueSize, "max count of link events waiting in memory for delivery to webhooks, new events are dropped if queue is full")
+	fs.BoolVar(&config.WebhookAllowPrivate, "webhook-allow-private", config.WebhookAllowPrivate, "allow delivery of events to webhooks on loopback, private and link-local addresses")
+	fs.IntVar(&config.EventBufferSize, "event-buffer-size", config.EventBufferSize, "count of the latest link events kept in memory for resumption of live event streams")
+	fs.IntVar(&config.EventStreamBuffer, "event-stream-buffer", config.EventStreamBuffer, "count of link events waiting for slow subscriber of live event stream before it is disconnected")
+	fs.StringVar(&config.QuotaPlans, "quota-plans", config.QuotaPlans, "quota plans in format: plan=active_links:links_per_day:batch_size:tracked_clicks,..., 0 is no limit, users have no quotas if empty")
+	fs.StringVar(&config.QuotaDefaultPlan, "quota-default-plan", config.QuotaDefaultPlan, "quota plan of users without assigned plan, it must be one of quota plans if they are set")
+	fs.StringVar(&config.QuotaUsers, "quota-users", config.QuotaUsers, "quota plans assigned to users in format: user_id=plan,...")
+	fs.BoolVar(&config.PrintConfig, "print-config", config.PrintConfig, "print effective config and exit")
+}
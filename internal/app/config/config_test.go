@@ -0,0 +1,264 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	type want struct {
+		netAddr       string
+		baseURIPrefix string
+		logLevel      string
+		trustedSubnet string
+	}
+	tests := []struct {
+		name     string
+		fileName string
+		file     string
+		flags    map[string]string
+		env      map[string]string
+		want     want
+	}{
+		{
+			name: "defaults",
+			want: want{
+				netAddr:       defaultNetAddr,
+				baseURIPrefix: defaultBaseURIPrefix,
+				logLevel:      defaultLogLevel,
+			},
+		},
+		{
+			name:     "json file",
+			fileName: "config.json",
+			file:     `{"server_address": "localhost:9090", "log_level": "info", "trusted_subnet": "10.0.0.0/8"}`,
+			want: want{
+				netAddr:       "localhost:9090",
+				baseURIPrefix: defaultBaseURIPrefix,
+				logLevel:      "info",
+				trustedSubnet: "10.0.0.0/8",
+			},
+		},
+		{
+			name:     "yaml file",
+			fileName: "config.yaml",
+			file:     "server_address: localhost:9090\nlog_level: warn\n",
+			want: want{
+				netAddr:       "localhost:9090",
+				baseURIPrefix: defaultBaseURIPrefix,
+				logLevel:      "warn",
+			},
+		},
+		{
+			name:     "toml file",
+			fileName: "config.toml",
+			file:     "server_address = \"localhost:9090\"\nbase_url = \"https://short.ru\"\n",
+			want: want{
+				netAddr:       "localhost:9090",
+				baseURIPrefix: "https://short.ru",
+				logLevel:      defaultLogLevel,
+			},
+		},
+		{
+			name:     "explicit default flag overrides file",
+			fileName: "config.json",
+			file:     `{"server_address": "localhost:9090", "log_level": "info"}`,
+			flags: map[string]string{
+				"a": defaultNetAddr,
+			},
+			want: want{
+				netAddr:       defaultNetAddr,
+				baseURIPrefix: defaultBaseURIPrefix,
+				logLevel:      "info",
+			},
+		},
+		{
+			name:     "env overrides flags and file",
+			fileName: "config.json",
+			file:     `{"log_level": "info"}`,
+			flags: map[string]string{
+				"l": "warn",
+				"b": "https://flag.ru",
+			},
+			env: map[string]string{
+				"LOG_LEVEL": "error",
+			},
+			want: want{
+				netAddr:       defaultNetAddr,
+				baseURIPrefix: "https://flag.ru",
+				logLevel:      "error",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags := map[string]string{}
+			for name, value := range test.flags {
+				flags[name] = value
+			}
+
+			if test.file != "" {
+				path := filepath.Join(t.TempDir(), test.fileName)
+				require.NoError(t, os.WriteFile(path, []byte(test.file), 0600))
+				flags["c"] = path
+			}
+
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+
+			config, err := Load(flags)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.want.netAddr, config.NetAddr)
+			assert.Equal(t, test.want.baseURIPrefix, config.BaseURIPrefix)
+			assert.Equal(t, test.want.logLevel, config.LogLevel)
+			assert.Equal(t, test.want.trustedSubnet, config.TrustedSubnet)
+		})
+	}
+}
+
+func TestDefaultsWithoutLimits(t *testing.T) {
+	config, err := Load(map[string]string{})
+	require.NoError(t, err)
+
+	// upgraded deployments keep serving requests without new limits until they are configured
+	assert.Empty(t, config.RateLimits)
+	assert.Empty(t, config.QuotaPlans)
+}
+
+func TestLoadInvalidFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		file     string
+	}{
+		{
+			name:     "unknown json key",
+			fileName: "config.json",
+			file:     `{"server_addr": "localhost:9090"}`,
+		},
+		{
+			name:     "unknown yaml key",
+			fileName: "config.yaml",
+			file:     "server_addr: localhost:9090\n",
+		},
+		{
+			name:     "unknown toml key",
+			fileName: "config.toml",
+			file:     "server_addr = \"localhost:9090\"\n",
+		},
+		{
+			name:     "unknown format",
+			fileName: "config.ini",
+			file:     "server_address=localhost:9090",
+		},
+		{
+			name:     "broken json",
+			fileName: "config.json",
+			file:     `{"server_address": `,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), test.fileName)
+			require.NoError(t, os.WriteFile(path, []byte(test.file), 0600))
+
+			_, err := Load(map[string]string{"c": path})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	errValidator := errors.New("validator error")
+
+	tests := []struct {
+		name       string
+		modify     func(config *Config)
+		validators []Validator
+		errCount   int
+	}{
+		{
+			name:     "valid config",
+			modify:   func(config *Config) {},
+			errCount: 0,
+		},
+		{
+			name: "several invalid settings",
+			modify: func(config *Config) {
+				config.NetAddr = "localhost"
+				config.BaseURIPrefix = "localhost:8080"
+				config.LogLevel = "loud"
+				config.TrustedSubnet = "10.0.0.0/8,192.168.1.1"
+			},
+			errCount: 4,
+		},
+		{
+			name: "invalid trusted proxies",
+			modify: func(config *Config) {
+				config.TrustedProxies = "10.0.0.1"
+			},
+			errCount: 1,
+		},
+		{
+			name: "metrics trusted only without subnet",
+			modify: func(config *Config) {
+				config.MetricsTrusted = true
+			},
+			errCount: 1,
+		},
+		{
+			name:   "validator error",
+			modify: func(config *Config) {},
+			validators: []Validator{
+				func(Config) error { return errValidator },
+			},
+			errCount: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := defaultConfig()
+			test.modify(&config)
+
+			err := config.Validate(test.validators...)
+			if test.errCount == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, ErrInvalidConfig)
+			assert.Equal(t, test.errCount, strings.Count(err.Error(), "\n"))
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	current := defaultConfig()
+
+	loaded := current
+	loaded.LogLevel = "error"
+	loaded.TrustedSubnet = "10.0.0.0/8"
+	loaded.TrustedProxies = "172.16.0.0/12"
+	loaded.RateLimits = "create=1:1"
+	loaded.NetAddr = "localhost:9090"
+
+	res, ignored := merge(current, loaded)
+
+	assert.Equal(t, "error", res.LogLevel)
+	assert.Equal(t, "10.0.0.0/8", res.TrustedSubnet)
+	assert.Equal(t, "172.16.0.0/12", res.TrustedProxies)
+	assert.Equal(t, "create=1:1", res.RateLimits)
+	assert.Equal(t, current.NetAddr, res.NetAddr)
+	assert.Equal(t, []string{"server_address"}, ignored)
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownConfigFormat Error that will be returned if config file extension is not supported
+var ErrUnknownConfigFormat = errors.New("unknown config file format")
+
+// parseConfigFile Fills config by values from JSON, YAML or TOML file
+//
+// Format is obtained from file extension. Unknown keys are treated as errors
+func parseConfigFile(path string, config *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("couldn't read config file: %w", err)
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = parseJSON(data, config)
+	case ".yaml", ".yml":
+		err = parseYAML(data, config)
+	case ".toml":
+		err = parseTOML(data, config)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownConfigFormat, ext)
+	}
+
+	if err != nil {
+		return fmt.Errorf("couldn't parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func parseJSON(data []byte, config *Config) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(config)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return err
+}
+
+func parseYAML(data []byte, config *Config) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	err := decoder.Decode(config)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	return err
+}
+
+func parseTOML(data []byte, config *Config) error {
+	meta, err := toml.NewDecoder(bytes.NewReader(data)).Decode(config)
+	if err != nil {
+		return err
+	}
+
+	if undecoded := meta.Undecoded(); len(undecoded) != 0 {
+		return fmt.Errorf("unknown keys: %v", undecoded)
+	}
+
+	return nil
+}
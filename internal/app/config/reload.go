@@ -0,0 +1,69 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// reloadableFields Fields of config which can be changed without restart
+var reloadableFields = map[string]bool{
+	"LogLevel":       true,
+	"TrustedSubnet":  true,
+	"TrustedProxies": true,
+	"RateLimits":     true,
+}
+
+// Reload Loads config again using the same flags and validates it
+//
+// Returns current config with changed reloadable settings: log level, trusted subnets and proxies, rate limits.
+// Returns names of changed settings which require restart and are ignored
+func Reload(current Config, validators ...Validator) (Config, []string, error) {
+	loaded, err := Load(commandLineFlags())
+	if err != nil {
+		return current, nil, err
+	}
+
+	err = loaded.Validate(validators...)
+	if err != nil {
+		return current, nil, err
+	}
+
+	res, ignored := merge(current, loaded)
+
+	return res, ignored, nil
+}
+
+// merge Copies reloadable fields of loaded config to current one
+func merge(current, loaded Config) (Config, []string) {
+	var ignored []string
+
+	res := current
+	resValue := reflect.ValueOf(&res).Elem()
+	loadedValue := reflect.ValueOf(loaded)
+	configType := resValue.Type()
+
+	for i := 0; i < configType.NumField(); i++ {
+		field := configType.Field(i)
+		if resValue.Field(i).Interface() == loadedValue.Field(i).Interface() {
+			continue
+		}
+
+		if reloadableFields[field.Name] {
+			resValue.Field(i).Set(loadedValue.Field(i))
+			continue
+		}
+
+		ignored = append(ignored, fieldName(field))
+	}
+
+	return res, ignored
+}
+
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+
+	return name
+}
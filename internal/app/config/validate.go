@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrInvalidConfig Error that will be returned if config contains invalid values
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Validator Validates config settings of application component
+type Validator func(config Config) error
+
+// Validate Validates config and settings of components by validators
+//
+// All found problems are joined in one error wrapping ErrInvalidConfig
+func (c Config) Validate(validators ...Validator) error {
+	errs := []error{
+		validateAddr("server_address", c.NetAddr, true),
+		validateAddr("grpc_server_address", c.GRPCNetAddr, true),
+		validateAddr("metrics_address", c.MetricsAddr, false),
+		validateBaseURL(c.BaseURIPrefix),
+		validateLogLevel(c.LogLevel),
+		validateSubnets("trusted_subnet", c.TrustedSubnet),
+		validateSubnets("trusted_proxies", c.TrustedProxies),
+	}
+
+	if c.MetricsTrusted && c.TrustedSubnet == "" {
+		errs = append(errs, fmt.Errorf("metrics_trusted_only requires trusted_subnet"))
+	}
+
+	for _, validator := range validators {
+		errs = append(errs, validator(c))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%w:\n%w", ErrInvalidConfig, err)
+	}
+
+	return nil
+}
+
+// ParseSubnets Parses IPv4 and IPv6 subnets in CIDR notation separated by comma
+func ParseSubnets(subnets string) ([]*net.IPNet, error) {
+	var res []*net.IPNet
+	for _, subnet := range strings.Split(subnets, ",") {
+		subnet = strings.TrimSpace(subnet)
+		if subnet == "" {
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(subnet)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, ipNet)
+	}
+
+	return res, nil
+}
+
+func validateAddr(name, addr string, required bool) error {
+	if addr == "" {
+		if required {
+			return fmt.Errorf("%s is required", name)
+		}
+
+		return nil
+	}
+
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", name, addr, err)
+	}
+
+	if port == "" {
+		return fmt.Errorf("invalid %s %q: missing port", name, addr)
+	}
+
+	return nil
+}
+
+func validateBaseURL(baseURL string) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid base_url %q: %w", baseURL, err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid base_url %q: absolute http or https URL is expected", baseURL)
+	}
+
+	return nil
+}
+
+func validateLogLevel(level string) error {
+	_, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log_level %q: %w", level, err)
+	}
+
+	return nil
+}
+
+func validateSubnets(name, subnets string) error {
+	_, err := ParseSubnets(subnets)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", name, subnets, err)
+	}
+
+	return nil
+}
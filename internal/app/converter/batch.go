@@ -1,14 +1,14 @@
 package converter
 
 import (
-	"fmt"
-
 	"github.com/avGenie/url-shortener/internal/app/models"
 	storage "github.com/avGenie/url-shortener/internal/app/storage/api/model"
 )
 
 // ConvertStorageBatchToOutBatch Converts internal storage batch object to external batch object
-func ConvertStorageBatchToOutBatch(batch storage.Batch, uriPrefix string) models.ResBatch {
+//
+// Full short URLs are built by short codes in storage
+func ConvertStorageBatchToOutBatch(batch storage.Batch, shortURL func(key string) string) models.ResBatch {
 	outBatch := make(models.ResBatch, 0, len(batch))
 	for _, obj := range batch {
 		if len(obj.ShortURL) == 0 {
@@ -17,7 +17,7 @@ func ConvertStorageBatchToOutBatch(batch storage.Batch, uriPrefix string) models
 
 		outObj := models.BatchObjectResponse{
 			ID:  obj.ID,
-			URL: fmt.Sprintf("%s/%s", uriPrefix, obj.ShortURL),
+			URL: shortURL(obj.ShortURL),
 		}
 
 		outBatch = append(outBatch, outObj)
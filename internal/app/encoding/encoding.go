@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/logger"
 )
 
 const (
@@ -38,7 +40,7 @@ func GzipMiddleware(h http.Handler) http.Handler {
 		acceptEncoding := req.Header.Get("Accept-Encoding")
 		supportGzip := strings.Contains(acceptEncoding, gzipEncodingFormat)
 		if supportGzip {
-			zap.L().Debug("sending gzip encoded message")
+			logger.FromContext(req.Context()).Debug("sending gzip encoded message")
 			cw := newCompressWriter(writer)
 			cw.writer.Header().Set("Content-Encoding", "gzip")
 			ow = cw
@@ -51,12 +53,12 @@ func GzipMiddleware(h http.Handler) http.Handler {
 		if sendGzip && isEncodingContentType(contentType) {
 			cr, err := newCompressReader(req.Body)
 			if err != nil {
-				zap.L().Error("invalid compress reader creation", zap.Error(err))
+				logger.FromContext(req.Context()).Error("invalid compress reader creation", zap.Error(err))
 				ow.WriteHeader(http.StatusInternalServerError)
 				return
 			}
 
-			zap.L().Debug("obtained gzip encoded message")
+			logger.FromContext(req.Context()).Debug("obtained gzip encoded message")
 			req.Body = cr
 			defer cr.Close()
 		}
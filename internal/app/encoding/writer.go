@@ -36,3 +36,14 @@ func (c *compressWriter) Write(p []byte) (int, error) {
 func (c *compressWriter) Close() error {
 	return c.gzWriter.Close()
 }
+
+// Flush Implements http.Flusher interface
+//
+// Compressed data is flushed to underlying writer, so streamed responses are not delayed by compression
+func (c *compressWriter) Flush() {
+	c.gzWriter.Flush()
+
+	if flusher, ok := c.writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// Types of actors of audited actions
+//
+// AuditActorUser - user changing its links, actor is ID of user
+// AuditActorAdmin - administrator authenticated by admin token, actor is name of token
+const (
+	AuditActorUser  = "user"
+	AuditActorAdmin = "admin"
+)
+
+// AuditRecord Contains action changing state of service kept in append-only audit trail
+//
+// Targets contain short IDs of links or other objects changed by action
+type AuditRecord struct {
+	ID        string    `json:"id"`
+	ActorType string    `json:"actor_type"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Targets   []string  `json:"targets,omitempty"`
+	ClientIP  string    `json:"client_ip,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditCursor Position of record in audit trail ordered from the newest records by creation time and ID
+//
+// Empty cursor points before the newest record
+type AuditCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// IsEmpty Returns true if cursor points before the newest record
+func (c AuditCursor) IsEmpty() bool {
+	return c.CreatedAt.IsZero() && c.ID == ""
+}
+
+// Cursor Returns cursor pointing to the record
+func (r AuditRecord) Cursor() AuditCursor {
+	return AuditCursor{
+		CreatedAt: r.CreatedAt,
+		ID:        r.ID,
+	}
+}
+
+// IsAfter Returns true if record is placed after cursor in audit trail ordered from the newest records
+func (r AuditRecord) IsAfter(cursor AuditCursor) bool {
+	if cursor.IsEmpty() {
+		return true
+	}
+
+	if r.CreatedAt.Equal(cursor.CreatedAt) {
+		return r.ID < cursor.ID
+	}
+
+	return r.CreatedAt.Before(cursor.CreatedAt)
+}
+
+// AuditFilter Conditions of records listed from audit trail
+//
+// Empty fields are not used for filtering. Records are selected from time From inclusive to time To exclusive
+type AuditFilter struct {
+	ActorType string
+	Actor     string
+	Action    string
+	Target    string
+	From      time.Time
+	To        time.Time
+	After     AuditCursor
+}
+
+// Match Returns true if record satisfies filter conditions and is placed after its cursor
+func (f AuditFilter) Match(record AuditRecord) bool {
+	switch {
+	case f.ActorType != "" && record.ActorType != f.ActorType:
+		return false
+	case f.Actor != "" && record.Actor != f.Actor:
+		return false
+	case f.Action != "" && record.Action != f.Action:
+		return false
+	case f.Target != "" && !slices.Contains(record.Targets, f.Target):
+		return false
+	case !f.From.IsZero() && record.CreatedAt.Before(f.From):
+		return false
+	case !f.To.IsZero() && !record.CreatedAt.Before(f.To):
+		return false
+	}
+
+	return record.IsAfter(f.After)
+}
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// DeleteJobStatus Status of background deletion job
+type DeleteJobStatus string
+
+// Statuses of deletion job
+//
+// DeleteJobPending - job is waiting for the first or the next attempt
+// DeleteJobDone - URLs of job are deleted
+// DeleteJobFailed - job is moved to dead letters after all attempts failed
+const (
+	DeleteJobPending DeleteJobStatus = "pending"
+	DeleteJobDone    DeleteJobStatus = "done"
+	DeleteJobFailed  DeleteJobStatus = "failed"
+)
+
+// DeleteJob Contains user URLs queued for deletion with state of deletion attempts
+//
+// Job is kept in outbox of storage, so it is retried after restart
+type DeleteJob struct {
+	ID            string          `json:"id"`
+	UserID        UserID          `json:"user_id"`
+	ShortURLs     []string        `json:"short_urls"`
+	Status        DeleteJobStatus `json:"status"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"last_error,omitempty"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// DeletedURLs Returns URLs of job to be deleted in storage
+func (j DeleteJob) DeletedURLs() DeletedURLBatch {
+	urls := make(DeletedURLBatch, 0, len(j.ShortURLs))
+	for _, shortURL := range j.ShortURLs {
+		urls = append(urls, DeletedURL{
+			UserID:   j.UserID.String(),
+			ShortURL: shortURL,
+		})
+	}
+
+	return urls
+}
+
+// IsDue Returns true if pending job should be attempted at given time
+func (j DeleteJob) IsDue(now time.Time) bool {
+	return j.Status == DeleteJobPending && !j.NextAttemptAt.After(now)
+}
@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+// Types of link lifecycle events
+//
+// EventLinkCreated - link is created
+// EventLinkDeleted - link is deleted and moved to trash
+// EventLinkExpired - deleted link is purged from trash after retention
+// EventLinkClicked - link is redirected
+const (
+	EventLinkCreated = "link.created"
+	EventLinkDeleted = "link.deleted"
+	EventLinkExpired = "link.expired"
+	EventLinkClicked = "link.clicked"
+)
+
+// LinkEvents Types of all link events
+var LinkEvents = []string{EventLinkCreated, EventLinkDeleted, EventLinkExpired, EventLinkClicked}
+
+// LinkEvent Contains event of user link published to webhooks and live event stream
+//
+// User and original URL may be empty if they are unknown to publisher
+type LinkEvent struct {
+	ID          string
+	Type        string
+	UserID      UserID
+	ShortURL    string
+	OriginalURL string
+	Time        time.Time
+}
@@ -0,0 +1,25 @@
+package entity
+
+// Kinds of client identities
+//
+// IdentityUser - client acts as user
+// IdentityService - internal service with elevated permissions
+const (
+	IdentityUser    = "user"
+	IdentityService = "service"
+)
+
+// ClientIdentity Identity of client obtained from client certificate
+type ClientIdentity struct {
+	// Name Subject common name or SAN of client certificate
+	Name string
+	// Kind Kind of identity: IdentityUser or IdentityService
+	Kind string
+	// UserID User ID of user identity
+	UserID UserID
+}
+
+// IsService Returns true if client is internal service
+func (i ClientIdentity) IsService() bool {
+	return i.Kind == IdentityService
+}
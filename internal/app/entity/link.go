@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Link Contains short link with its owner, creation time, deletion state, disabled state and metadata
+//
+// Deleted link is kept in trash of its owner since deletion time until it is restored or purged.
+// Disabled link is kept for its owner but is not redirected until it is enabled by admin.
+// Link created in workspace is managed by members of workspace besides its owner
+type Link struct {
+	ShortURL    string
+	OriginalURL string
+	UserID      UserID
+	WorkspaceID string
+	CreatedAt   time.Time
+	Deleted     bool
+	DeletedAt   time.Time
+	Disabled    bool
+	Metadata    map[string]string
+}
+
+// LinkCursor Position of link in list of user links ordered by creation time and short URL
+//
+// Empty cursor points to the beginning of the list
+type LinkCursor struct {
+	CreatedAt time.Time
+	ShortURL  string
+}
+
+// IsEmpty Returns true if cursor points to the beginning of the list
+func (c LinkCursor) IsEmpty() bool {
+	return c.CreatedAt.IsZero() && c.ShortURL == ""
+}
+
+// Cursor Returns cursor pointing to the link
+func (l Link) Cursor() LinkCursor {
+	return LinkCursor{
+		CreatedAt: l.CreatedAt,
+		ShortURL:  l.ShortURL,
+	}
+}
+
+// IsAfter Returns true if link is placed after cursor in list of user links
+func (l Link) IsAfter(cursor LinkCursor) bool {
+	if cursor.IsEmpty() {
+		return true
+	}
+
+	if l.CreatedAt.Equal(cursor.CreatedAt) {
+		return l.ShortURL > cursor.ShortURL
+	}
+
+	return l.CreatedAt.After(cursor.CreatedAt)
+}
+
+// Domain Returns lowercased host name of original URL or empty string if URL couldn't be parsed
+func (l Link) Domain() string {
+	u, err := url.Parse(l.OriginalURL)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(u.Hostname())
+}
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// Limits of user quota
+//
+// QuotaActiveLinks - count of links of user which are not deleted
+// QuotaLinksPerDay - count of links created by user during UTC day
+// QuotaBatchSize - count of links in one batch request
+// QuotaTrackedClicks - count of redirects tracked by links of user
+const (
+	QuotaActiveLinks   = "active_links"
+	QuotaLinksPerDay   = "links_per_day"
+	QuotaBatchSize     = "batch_size"
+	QuotaTrackedClicks = "tracked_clicks"
+)
+
+// Quota Contains limits of plan assigned to user
+//
+// Zero limit means that it isn't limited
+type Quota struct {
+	Plan             string
+	MaxActiveLinks   int
+	MaxLinksPerDay   int
+	MaxBatchSize     int
+	MaxTrackedClicks int64
+}
+
+// QuotaUsage Contains usage of user limits at some moment
+type QuotaUsage struct {
+	ActiveLinks   int
+	LinksToday    int
+	TrackedClicks int64
+}
+
+// QuotaDay Returns start of UTC day links created at are counted in
+func QuotaDay(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
@@ -1,8 +1,28 @@
 package entity
 
+import "time"
+
 // URLRecord is being used to form a string for the file database
+//
+// Record with deleted flag marks previously saved URL of user as deleted at deletion time.
+// Record with restored flag moves deleted URL of user from trash back to active URLs.
+// Record with purged flag removes previously saved URL.
+// Record without original URL and with disabled flag changes disabled state of previously saved URL.
+// Record with metadata updated flag replaces metadata of previously saved URL.
+// Record with erased flag marks erasure of all data of user which is completed by rewriting of storage files
 type URLRecord struct {
-	ShortURL    string `json:"short_url"`
-	OriginalURL string `json:"original_url"`
-	ID          uint   `json:"uuid"`
+	ShortURL    string            `json:"short_url"`
+	OriginalURL string            `json:"original_url"`
+	UserID      string            `json:"user_id,omitempty"`
+	ID          uint              `json:"uuid"`
+	CreatedAt   time.Time         `json:"created_at,omitempty"`
+	Deleted     bool              `json:"deleted,omitempty"`
+	DeletedAt   *time.Time        `json:"deleted_at,omitempty"`
+	Restored    bool              `json:"restored,omitempty"`
+	Purged      bool              `json:"purged,omitempty"`
+	Disabled    *bool             `json:"disabled,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	WorkspaceID string            `json:"workspace_id,omitempty"`
+	MetaUpdated bool              `json:"metadata_updated,omitempty"`
+	Erased      bool              `json:"erased,omitempty"`
 }
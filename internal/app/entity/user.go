@@ -8,6 +8,9 @@ import (
 // UserIDKey Cookie key to store user ID
 const UserIDKey = "user_id"
 
+// ErasedUserID Replaces ID of erased user in records which are kept for other users
+const ErasedUserID UserID = "00000000-0000-0000-0000-000000000000"
+
 // UserIDCtxKey Key to store user ID in go context
 type UserIDCtxKey struct{}
 
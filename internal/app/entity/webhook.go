@@ -0,0 +1,72 @@
+package entity
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// Webhook Contains subscription of user to link events
+//
+// Payloads of events are signed by secret of webhook.
+// Webhook without event types is subscribed to all events
+type Webhook struct {
+	ID        string    `json:"id"`
+	UserID    UserID    `json:"user_id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret"`
+	Events    []string  `json:"events,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsSubscribed Returns true if webhook is subscribed to event type
+func (w Webhook) IsSubscribed(event string) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, event)
+}
+
+// WebhookDeliveryStatus Status of delivery of event to webhook
+type WebhookDeliveryStatus string
+
+// Statuses of webhook delivery
+//
+// WebhookDeliveryPending - delivery is waiting for the first or the next attempt
+// WebhookDeliveryDelivered - event is accepted by receiver
+// WebhookDeliveryFailed - delivery is given up after all attempts failed
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery Contains signed payload of event sent to webhook with state of delivery attempts
+//
+// Delivery is kept in log of storage, so it is retried after restart and can be replayed
+type WebhookDelivery struct {
+	ID            string                `json:"id"`
+	WebhookID     string                `json:"webhook_id"`
+	UserID        UserID                `json:"user_id"`
+	Event         string                `json:"event"`
+	Payload       json.RawMessage       `json:"payload"`
+	Status        WebhookDeliveryStatus `json:"status"`
+	Attempts      int                   `json:"attempts"`
+	ResponseCode  int                   `json:"response_code,omitempty"`
+	LastError     string                `json:"last_error,omitempty"`
+	NextAttemptAt time.Time             `json:"next_attempt_at"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+// IsDue Returns true if pending delivery should be attempted at given time
+func (d WebhookDelivery) IsDue(now time.Time) bool {
+	return d.Status == WebhookDeliveryPending && !d.NextAttemptAt.After(now)
+}
+
+// WebhookRecord is being used to form a string of webhooks file of file database
+//
+// Record contains either webhook or state of delivery.
+// Record with deleted flag removes previously saved webhook with its deliveries
+type WebhookRecord struct {
+	Webhook  *Webhook         `json:"webhook,omitempty"`
+	Deleted  bool             `json:"deleted,omitempty"`
+	Delivery *WebhookDelivery `json:"delivery,omitempty"`
+}
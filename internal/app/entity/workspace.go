@@ -0,0 +1,79 @@
+package entity
+
+import "time"
+
+// WorkspaceRole Role of member in workspace
+type WorkspaceRole string
+
+// Roles of workspace members
+//
+// WorkspaceOwner - manages members and invites and edits links of workspace
+// WorkspaceEditor - creates, edits and deletes links of workspace
+// WorkspaceViewer - lists links and members of workspace
+const (
+	WorkspaceOwner  WorkspaceRole = "owner"
+	WorkspaceEditor WorkspaceRole = "editor"
+	WorkspaceViewer WorkspaceRole = "viewer"
+)
+
+// IsValid Returns true if role is known
+func (r WorkspaceRole) IsValid() bool {
+	return r == WorkspaceOwner || r == WorkspaceEditor || r == WorkspaceViewer
+}
+
+// CanEdit Returns true if role allows to create, edit and delete links of workspace
+func (r WorkspaceRole) CanEdit() bool {
+	return r == WorkspaceOwner || r == WorkspaceEditor
+}
+
+// CanManage Returns true if role allows to manage members and invites of workspace
+func (r WorkspaceRole) CanManage() bool {
+	return r == WorkspaceOwner
+}
+
+// Workspace Contains team of users sharing ownership of links
+//
+// Links created in workspace are kept with their creator, but they are managed by members of workspace
+// according to their roles, so links stay manageable when creator leaves workspace
+type Workspace struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedBy UserID    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkspaceMember Contains membership of user in workspace with role
+type WorkspaceMember struct {
+	WorkspaceID string        `json:"workspace_id"`
+	UserID      UserID        `json:"user_id"`
+	Role        WorkspaceRole `json:"role"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
+// WorkspaceInvite Contains single use invite to join workspace with role
+//
+// ID of invite is secret which is shared with invited user
+type WorkspaceInvite struct {
+	ID          string        `json:"id"`
+	WorkspaceID string        `json:"workspace_id"`
+	Role        WorkspaceRole `json:"role"`
+	CreatedBy   UserID        `json:"created_by"`
+	CreatedAt   time.Time     `json:"created_at"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+}
+
+// IsExpired Returns true if invite couldn't be accepted at given time
+func (i WorkspaceInvite) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt)
+}
+
+// WorkspaceRecord is being used to form a string of workspaces file of file database
+//
+// Record contains either workspace, membership or invite.
+// Record with deleted flag removes previously saved membership or invite
+type WorkspaceRecord struct {
+	Workspace *Workspace       `json:"workspace,omitempty"`
+	Member    *WorkspaceMember `json:"member,omitempty"`
+	Invite    *WorkspaceInvite `json:"invite,omitempty"`
+	Deleted   bool             `json:"deleted,omitempty"`
+}
@@ -0,0 +1,55 @@
+package admin
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/retry"
+	handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	usecase "github.com/avGenie/url-shortener/internal/app/usecase/admin"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+const errInternalMsg = "internal server error"
+
+// statusError Converts error of admin use cases to GRPC status
+//
+// Field errors are returned with BadRequest details,
+// deletions rejected by full queue are returned with RetryInfo details
+func statusError(ctx context.Context, err error) error {
+	var fieldErr *link.FieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		st, detailsErr := status.New(codes.InvalidArgument, "invalid request").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{
+					Field:       fieldErr.Field,
+					Description: fieldErr.Err.Error(),
+				},
+			},
+		})
+		if detailsErr != nil {
+			return status.Error(codes.InvalidArgument, fieldErr.Error())
+		}
+
+		return st.Err()
+	case errors.Is(err, link.ErrLinkNotFound):
+		return status.Error(codes.NotFound, "link is not found")
+	case errors.Is(err, usecase.ErrCompactionNotSupported):
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Is(err, handlers.ErrFlusherStopped):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, handlers.ErrQueueFull):
+		return retry.UnavailableError(ctx, handlers.ErrQueueFull.Error(), handlers.RetryAfter)
+	}
+
+	logger.FromContext(ctx).Error("error while processing admin request", zap.Error(err))
+
+	return status.Error(codes.Internal, errInternalMsg)
+}
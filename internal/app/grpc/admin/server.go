@@ -0,0 +1,204 @@
+// Package admin implements shortener.admin GRPC API
+package admin
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	usecase "github.com/avGenie/url-shortener/internal/app/usecase/admin"
+	pb "github.com/avGenie/url-shortener/proto/admin"
+)
+
+const requestTimeout = 3 * time.Second
+
+// Server GRPC server of shortener.admin API
+type Server struct {
+	pb.UnimplementedAdminServer
+
+	admin *usecase.Service
+}
+
+// NewServer Creates server of shortener.admin API
+func NewServer(admin *usecase.Service) *Server {
+	return &Server{
+		admin: admin,
+	}
+}
+
+// GetLink Returns link of any user with its owner
+//
+// Returns NotFound status if link is not found
+func (s *Server) GetLink(ctx context.Context, request *pb.GetLinkRequest) (*pb.Link, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	found, err := s.admin.GetLink(ctx, request.GetShortUrl())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return s.linkToProto(found), nil
+}
+
+// SetLinkDisabled Disables link of any user globally or enables it again
+//
+// Returns NotFound status if link is not found
+func (s *Server) SetLinkDisabled(ctx context.Context, request *pb.SetLinkDisabledRequest) (*pb.Link, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	changed, err := s.admin.SetLinkDisabled(ctx, request.GetShortUrl(), request.GetDisabled())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return s.linkToProto(changed), nil
+}
+
+// DeleteUserLinks Deletes all links of user in background
+func (s *Server) DeleteUserLinks(ctx context.Context, request *pb.DeleteUserLinksRequest) (*pb.DeleteUserLinksResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	deletion, err := s.admin.DeleteUserLinks(ctx, entity.UserID(request.GetUserId()))
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &pb.DeleteUserLinksResponse{
+		JobId:      deletion.JobID,
+		LinksCount: int64(deletion.Count),
+	}, nil
+}
+
+// FlushDeleteQueue Flushes queued deleted URLs to storage
+//
+// Returns Unavailable status if flusher is stopped
+func (s *Server) FlushDeleteQueue(ctx context.Context, _ *pb.FlushDeleteQueueRequest) (*pb.FlushDeleteQueueResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	flush, err := s.admin.FlushDeleteQueue(ctx)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &pb.FlushDeleteQueueResponse{UrlsCount: int64(flush.Count)}, nil
+}
+
+// GetDeleteQueueStats Returns state of queue of deletion jobs and its workers
+func (s *Server) GetDeleteQueueStats(ctx context.Context, _ *pb.GetDeleteQueueStatsRequest) (*pb.DeleteQueueStats, error) {
+	stats := s.admin.DeleteQueueStats(ctx)
+
+	return &pb.DeleteQueueStats{
+		QueuedJobs:   int64(stats.QueuedJobs),
+		QueuedUrls:   int64(stats.QueuedURLs),
+		Capacity:     int64(stats.Capacity),
+		UserCapacity: int64(stats.UserCapacity),
+		InFlightJobs: int64(stats.InFlightJobs),
+		Workers:      int64(stats.Workers),
+		BusyWorkers:  int64(stats.BusyWorkers),
+		Rejected:     stats.Rejected,
+	}, nil
+}
+
+// CompactStorage Rewrites storage data without history of changes
+//
+// Returns Unimplemented status if storage doesn't support compaction
+func (s *Server) CompactStorage(ctx context.Context, _ *pb.CompactStorageRequest) (*pb.CompactStorageResponse, error) {
+	res, err := s.admin.CompactStorage(ctx)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &pb.CompactStorageResponse{
+		SizeBeforeBytes: res.SizeBefore,
+		SizeAfterBytes:  res.SizeAfter,
+		LinksCount:      int64(res.Links),
+	}, nil
+}
+
+// GetLogLevel Returns current log level
+func (s *Server) GetLogLevel(ctx context.Context, _ *pb.GetLogLevelRequest) (*pb.LogLevel, error) {
+	return &pb.LogLevel{Level: s.admin.LogLevel(ctx).Level}, nil
+}
+
+// SetLogLevel Changes log level at runtime
+//
+// Returns InvalidArgument status if log level is unknown
+func (s *Server) SetLogLevel(ctx context.Context, request *pb.SetLogLevelRequest) (*pb.LogLevel, error) {
+	level, err := s.admin.SetLogLevel(ctx, request.GetLevel())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return &pb.LogLevel{Level: level.Level}, nil
+}
+
+// ListAuditRecords Returns page of audit trail filtered by request, the newest records first
+//
+// Returns InvalidArgument status with BadRequest details if period or page token is invalid
+func (s *Server) ListAuditRecords(ctx context.Context, request *pb.ListAuditRecordsRequest) (*pb.ListAuditRecordsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	filter := entity.AuditFilter{
+		ActorType: request.GetActorType(),
+		Actor:     request.GetActor(),
+		Action:    request.GetAction(),
+		Target:    request.GetTarget(),
+	}
+	if request.GetFrom() != nil {
+		filter.From = request.GetFrom().AsTime()
+	}
+	if request.GetTo() != nil {
+		filter.To = request.GetTo().AsTime()
+	}
+
+	records, next, err := s.admin.ListAuditRecords(ctx, filter, int(request.GetPageSize()), request.GetPageToken())
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	response := &pb.ListAuditRecordsResponse{
+		Records:       make([]*pb.AuditRecord, 0, len(records)),
+		NextPageToken: next,
+	}
+	for _, record := range records {
+		response.Records = append(response.Records, &pb.AuditRecord{
+			Id:         record.ID,
+			ActorType:  record.ActorType,
+			Actor:      record.Actor,
+			Action:     record.Action,
+			Targets:    record.Targets,
+			ClientIp:   record.ClientIP,
+			RequestId:  record.RequestID,
+			Result:     record.Result,
+			Error:      record.Error,
+			CreateTime: timestamppb.New(record.CreatedAt),
+		})
+	}
+
+	return response, nil
+}
+
+func (s *Server) linkToProto(link entity.Link) *pb.Link {
+	out := &pb.Link{
+		ShortUrl:    s.admin.ShortURL(link),
+		Alias:       link.ShortURL,
+		OriginalUrl: link.OriginalURL,
+		UserId:      link.UserID.String(),
+		Deleted:     link.Deleted,
+		Disabled:    link.Disabled,
+		Metadata:    link.Metadata,
+	}
+
+	if !link.CreatedAt.IsZero() {
+		out.CreateTime = timestamppb.New(link.CreatedAt)
+	}
+
+	return out
+}
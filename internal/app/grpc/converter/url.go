@@ -1,19 +1,24 @@
 package converter
 
 import (
+	"github.com/avGenie/url-shortener/internal/app/entity"
 	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
 
 	pb "github.com/avGenie/url-shortener/proto"
 )
 
-// AllURLsBatchToAllURLsResponse Converts model AllUrlsBatch struct to proto AllUrlsResponse
-func AllURLsBatchToAllURLsResponse(batch models.AllUrlsBatch) *pb.AllUrlsResponse {
-	urlsResponse := make([]*pb.UrlsResponse, 0, len(batch))
+// ShortURLFunc Returns full short URL of link
+type ShortURLFunc func(link entity.Link) string
 
-	for _, urls := range batch {
+// LinksToAllURLsResponse Converts links to proto AllUrlsResponse
+func LinksToAllURLsResponse(links []entity.Link, shortURL ShortURLFunc) *pb.AllUrlsResponse {
+	urlsResponse := make([]*pb.UrlsResponse, 0, len(links))
+
+	for _, val := range links {
 		response := &pb.UrlsResponse{
-			ShortURL:    urls.ShortURL,
-			OriginalURL: urls.OriginalURL,
+			ShortURL:    shortURL(val),
+			OriginalURL: val.OriginalURL,
 		}
 
 		urlsResponse = append(urlsResponse, response)
@@ -24,30 +29,30 @@ func AllURLsBatchToAllURLsResponse(batch models.AllUrlsBatch) *pb.AllUrlsRespons
 	}
 }
 
-// BatchRequestToReqBatch Converts proto BatchRequest to model ReqBatch struct
-func BatchRequestToReqBatch(request *pb.BatchRequest) models.ReqBatch {
-	outBatch := make(models.ReqBatch, 0, len(request.Urls))
+// BatchRequestToBatchItems Converts proto BatchRequest to batch items of link service
+func BatchRequestToBatchItems(request *pb.BatchRequest) []link.BatchItem {
+	items := make([]link.BatchItem, 0, len(request.GetUrls()))
 
 	for _, val := range request.GetUrls() {
-		batch := models.BatchObjectRequest{
-			ID:  val.GetCorrelationID(),
-			URL: val.GetOriginalURL(),
+		item := link.BatchItem{
+			CorrelationID: val.GetCorrelationID(),
+			OriginalURL:   val.GetOriginalURL(),
 		}
 
-		outBatch = append(outBatch, batch)
+		items = append(items, item)
 	}
 
-	return outBatch
+	return items
 }
 
-// ResBatchToBatchResponse Converts model ResBatch struct to proto BatchResponse
-func ResBatchToBatchResponse(resBatch models.ResBatch) *pb.BatchResponse {
-	outBatch := make([]*pb.BatchShortURLObject, 0, len(resBatch))
+// BatchResultsToBatchResponse Converts batch results of link service to proto BatchResponse
+func BatchResultsToBatchResponse(results []link.BatchResult, shortURL ShortURLFunc) *pb.BatchResponse {
+	outBatch := make([]*pb.BatchShortURLObject, 0, len(results))
 
-	for _, val := range resBatch {
+	for _, val := range results {
 		batch := &pb.BatchShortURLObject{
-			CorrelationID: val.ID,
-			ShortURL:      val.URL,
+			CorrelationID: val.CorrelationID,
+			ShortURL:      shortURL(val.Link),
 		}
 
 		outBatch = append(outBatch, batch)
@@ -58,9 +63,9 @@ func ResBatchToBatchResponse(resBatch models.ResBatch) *pb.BatchResponse {
 	}
 }
 
-// DeleteRequestToReqDeletedURLBatch Converts proto DeleteRequest to model ReqDeletedURLBatch struct
-func DeleteRequestToReqDeletedURLBatch(request *pb.DeleteRequest) models.ReqDeletedURLBatch {
-	output := make(models.ReqDeletedURLBatch, 0, len(request.Urls))
+// DeleteRequestToShortURLs Converts proto DeleteRequest to short URLs
+func DeleteRequestToShortURLs(request *pb.DeleteRequest) []string {
+	output := make([]string, 0, len(request.GetUrls()))
 
 	for _, val := range request.GetUrls() {
 		output = append(output, val.GetShortURL())
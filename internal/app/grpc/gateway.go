@@ -0,0 +1,146 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/grpc/interceptor"
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/ratelimit"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+	pbv2 "github.com/avGenie/url-shortener/proto/v2"
+)
+
+const (
+	gatewayBufferSize = 1 << 20
+	gatewayTarget     = "passthrough:///gateway"
+)
+
+// gateway REST gateway of shortener.v2 API
+//
+// Gateway transcodes HTTP requests to calls of internal GRPC server available
+// only through in-memory listener. Internal server uses the same interceptors
+// as public one except client certificate check, because REST clients are
+// identified by user cookie. Client IP is resolved by HTTP router and passed in metadata
+type gateway struct {
+	server   *grpc.Server
+	listener *bufconn.Listener
+	conn     *grpc.ClientConn
+	mux      *runtime.ServeMux
+}
+
+func newGateway(config config.Config, limiter *ratelimit.Limiter, control *cidr.AccessControl) (*gateway, error) {
+	transport, err := transportOptions(config)
+	if err != nil {
+		return nil, err
+	}
+
+	// admin service is not served by internal server, so admin tokens are not needed
+	unary, stream, err := interceptors(config, limiter, control, interceptor.InProcessClientIP, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+	opts = append(opts, transport...)
+
+	listener := bufconn.Listen(gatewayBufferSize)
+
+	conn, err := grpc.Dial(
+		gatewayTarget,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(config.GRPCMaxSendMsgSize)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(dropHeaders),
+		runtime.WithMetadata(gatewayMetadata),
+	)
+
+	err = pbv2.RegisterShortenerHandler(context.Background(), mux, conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return &gateway{
+		server:   grpc.NewServer(opts...),
+		listener: listener,
+		conn:     conn,
+		mux:      mux,
+	}, nil
+}
+
+// register Registers GRPC service in internal server of gateway
+func (g *gateway) register(desc *grpc.ServiceDesc, impl interface{}) {
+	g.server.RegisterService(desc, impl)
+}
+
+// serve Serves calls of gateway until it is stopped
+func (g *gateway) serve() error {
+	return g.server.Serve(g.listener)
+}
+
+// stop Stops internal server of gateway
+func (g *gateway) stop() {
+	g.conn.Close()
+	g.server.GracefulStop()
+}
+
+// dropHeaders Prevents forwarding of HTTP headers to GRPC metadata
+//
+// User id and client IP are passed only by gateway metadata,
+// so they can't be overridden by Grpc-Metadata headers
+func dropHeaders(string) (string, bool) {
+	return "", false
+}
+
+// gatewayMetadata Returns GRPC metadata of REST request
+//
+// Metadata contains request ID, user id from authentication cookie and client IP address
+func gatewayMetadata(_ context.Context, r *http.Request) metadata.MD {
+	md := metadata.MD{}
+
+	if userIDCtx, ok := r.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx); ok && userIDCtx.UserID.IsValid() {
+		md = metadata.Join(md, grpc_context.UserIDMetadata(userIDCtx.UserID))
+	}
+
+	if requestID := logger.RequestIDFromContext(r.Context()); requestID != "" {
+		md.Set(logger.RequestIDMetadataKey, requestID)
+	}
+
+	if ip := requestIP(r); ip != "" {
+		md.Set(strings.ToLower(cidr.RealIPHeader), ip)
+	}
+
+	return md
+}
+
+// requestIP Returns IP address of client resolved by HTTP router or IP address of remote peer
+func requestIP(r *http.Request) string {
+	if ip := cidr.ClientIPFromContext(r.Context()); ip != "" {
+		return ip
+	}
+
+	return cidr.AddrIP(r.RemoteAddr)
+}
@@ -0,0 +1,221 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	grpc_v2 "github.com/avGenie/url-shortener/internal/app/grpc/v2"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/storage/local"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+	"github.com/avGenie/url-shortener/internal/app/usecase/account"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/events"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+	"github.com/avGenie/url-shortener/internal/app/usecase/trash"
+	"github.com/avGenie/url-shortener/internal/app/usecase/webhook"
+	"github.com/avGenie/url-shortener/internal/app/usecase/workspace"
+	pbv2 "github.com/avGenie/url-shortener/proto/v2"
+)
+
+type testDeleter struct{}
+
+func (testDeleter) ProcessDeletedURLs(context.Context, entity.UserID, models.ReqDeletedURLBatch) (string, error) {
+	return "job", nil
+}
+
+func newTestGateway(t *testing.T) *gateway {
+	control, err := cidr.NewAccessControl("192.168.1.0/24", "")
+	require.NoError(t, err)
+
+	g, err := newGateway(config.Config{
+		GRPCKeepaliveTime:    "2h",
+		GRPCKeepaliveTimeout: "20s",
+		GRPCKeepaliveMinTime: "5m",
+		GRPCMaxRecvMsgSize:   4 << 20,
+		GRPCMaxSendMsgSize:   4 << 20,
+	}, nil, control)
+	require.NoError(t, err)
+
+	storage := local.NewTSLocalStorage(0)
+	domains, err := domain.NewRegistry(config.Config{BaseURIPrefix: "http://localhost:8080"})
+	require.NoError(t, err)
+	links := link.NewService(storage, testDeleter{}, domains)
+	trashService, err := trash.NewService(storage, config.Config{
+		TrashRetention:      "168h",
+		TrashPurgeInterval:  "1h",
+		TrashPurgeBatchSize: 500,
+	}, domains)
+	require.NoError(t, err)
+	webhookService, err := webhook.NewService(storage, config.Config{
+		WebhookTimeout:     "5s",
+		WebhookMaxAttempts: 8,
+		WebhookQueueSize:   1000,
+	}, domains)
+	require.NoError(t, err)
+	bus, err := events.NewBus(storage, config.Config{
+		EventBufferSize:   100,
+		EventStreamBuffer: 10,
+	})
+	require.NoError(t, err)
+	g.register(&pbv2.Shortener_ServiceDesc, grpc_v2.NewServer(links, trashService, webhookService, workspace.NewService(storage, links), account.NewService(storage, domains), bus))
+
+	go g.serve()
+	t.Cleanup(g.stop)
+
+	return g
+}
+
+func TestGateway(t *testing.T) {
+	const userID = entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+
+	g := newTestGateway(t)
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		body         string
+		userID       entity.UserID
+		header       map[string]string
+		clientIP     string
+		expectedCode int
+		expectedBody []string
+	}{
+		{
+			name:         "create link",
+			method:       http.MethodPost,
+			target:       "/api/v2/links",
+			body:         `{"originalUrl": "https://practicum.yandex.ru/", "metadata": {"campaign": "autumn"}}`,
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedBody: []string{`"shortUrl":"http://localhost:8080/42b3e75f"`, `"campaign":"autumn"`},
+		},
+		{
+			name:         "get link",
+			method:       http.MethodGet,
+			target:       "/api/v2/links/42b3e75f",
+			userID:       userID,
+			expectedCode: http.StatusOK,
+			expectedBody: []string{`"originalUrl":"https://practicum.yandex.ru/"`},
+		},
+		{
+			name:         "existing link",
+			method:       http.MethodPost,
+			target:       "/api/v2/links",
+			body:         `{"originalUrl": "https://practicum.yandex.ru/"}`,
+			userID:       userID,
+			expectedCode: http.StatusConflict,
+			expectedBody: []string{`"resourceType":"link"`, `"resourceName":"42b3e75f"`},
+		},
+		{
+			name:         "invalid request",
+			method:       http.MethodPost,
+			target:       "/api/v2/links",
+			body:         `{}`,
+			userID:       userID,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: []string{`"field":"original_url"`},
+		},
+		{
+			name:         "missing user id",
+			method:       http.MethodGet,
+			target:       "/api/v2/links",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "user id from metadata header is ignored",
+			method:       http.MethodGet,
+			target:       "/api/v2/links",
+			header:       map[string]string{"Grpc-Metadata-User_id": string(userID)},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "statistic from trusted subnet",
+			method:       http.MethodGet,
+			target:       "/api/v2/statistic",
+			clientIP:     "192.168.1.10",
+			expectedCode: http.StatusOK,
+			expectedBody: []string{`"linksCount":"1"`, `"usersCount":"1"`},
+		},
+		{
+			name:         "statistic from untrusted subnet",
+			method:       http.MethodGet,
+			target:       "/api/v2/statistic",
+			clientIP:     "10.0.0.1",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "statistic time series",
+			method:       http.MethodGet,
+			target:       "/api/v2/statistic?granularity=GRANULARITY_HOUR&from=2026-01-01T00:30:00Z&to=2026-01-01T02:00:00Z",
+			clientIP:     "192.168.1.10",
+			expectedCode: http.StatusOK,
+			expectedBody: []string{`"created":[{"time":"2026-01-01T00:00:00Z","count":"0"},{"time":"2026-01-01T01:00:00Z","count":"0"}]`},
+		},
+		{
+			name:         "statistic with invalid top",
+			method:       http.MethodGet,
+			target:       "/api/v2/statistic?top=1000",
+			clientIP:     "192.168.1.10",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: []string{`"field":"top"`},
+		},
+		{
+			name:         "statistic with X-Real-IP header from untrusted peer",
+			method:       http.MethodGet,
+			target:       "/api/v2/statistic",
+			header:       map[string]string{cidr.RealIPHeader: "192.168.1.10"},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+			for key, value := range test.header {
+				request.Header.Set(key, value)
+			}
+			if test.clientIP != "" {
+				request = request.WithContext(cidr.WithClientIP(request.Context(), test.clientIP))
+			}
+			if test.userID != "" {
+				request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, entity.UserIDCtx{
+					UserID:     test.userID,
+					StatusCode: http.StatusOK,
+				}))
+			}
+
+			writer := httptest.NewRecorder()
+			g.mux.ServeHTTP(writer, request)
+
+			res := writer.Result()
+			defer res.Body.Close()
+
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedCode, res.StatusCode, string(body))
+
+			// protojson randomly adds spaces to output, so body is compacted before matching
+			compacted := bytes.NewBuffer(nil)
+			if json.Compact(compacted, body) == nil {
+				body = compacted.Bytes()
+			}
+			for _, expected := range test.expectedBody {
+				assert.Contains(t, string(body), expected)
+			}
+		})
+	}
+}
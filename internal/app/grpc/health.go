@@ -0,0 +1,71 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	grpc_health "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/avGenie/url-shortener/internal/app/health"
+	pb "github.com/avGenie/url-shortener/proto"
+	pbv2 "github.com/avGenie/url-shortener/proto/v2"
+)
+
+const healthCheckInterval = 5 * time.Second
+
+// shortenerServices Names of shortener services of all API versions
+var shortenerServices = []string{
+	pb.Shortener_ServiceDesc.ServiceName,
+	pbv2.Shortener_ServiceDesc.ServiceName,
+}
+
+// updateHealth Sets serving status of whole server, Shortener services and each component
+//
+// Components are available as services with component names
+func (s *ShortenerServer) updateHealth(ctx context.Context) {
+	report := s.checker.Check(ctx)
+
+	status := servingStatus(report.Status)
+	s.healthServer.SetServingStatus("", status)
+	for _, service := range shortenerServices {
+		s.healthServer.SetServingStatus(service, status)
+	}
+
+	for name, component := range report.Components {
+		s.healthServer.SetServingStatus(name, servingStatus(component.Status))
+	}
+}
+
+// watchHealth Updates serving statuses periodically until server is stopped
+func (s *ShortenerServer) watchHealth() {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.updateHealth(context.Background())
+		}
+	}
+}
+
+func newHealthServer() *grpc_health.Server {
+	server := grpc_health.NewServer()
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	for _, service := range shortenerServices {
+		server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	return server
+}
+
+func servingStatus(status string) healthpb.HealthCheckResponse_ServingStatus {
+	if status == health.StatusUp {
+		return healthpb.HealthCheckResponse_SERVING
+	}
+
+	return healthpb.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,61 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/access"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/usecase/admin"
+)
+
+// AdminInterceptor Checks admin token of methods with admin annotation
+//
+// Name of admin is stored in context.
+// Returns PermissionDenied status if admin API is disabled.
+// Returns Unauthenticated status if admin token is missing or unknown
+func AdminInterceptor(authenticator *admin.Authenticator) grpc.UnaryServerInterceptor {
+	return unaryCheck(checkAdmin(authenticator))
+}
+
+// AdminStreamInterceptor Checks admin token of stream methods with admin annotation
+func AdminStreamInterceptor(authenticator *admin.Authenticator) grpc.StreamServerInterceptor {
+	return streamCheck(checkAdmin(authenticator))
+}
+
+func checkAdmin(authenticator *admin.Authenticator) checkFunc {
+	return func(ctx context.Context, method string) (context.Context, error) {
+		if !access.Method(method).GetAdmin() {
+			return ctx, nil
+		}
+
+		if !authenticator.Enabled() {
+			return nil, status.Error(codes.PermissionDenied, "admin API is disabled")
+		}
+
+		var authorization string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(strings.ToLower(admin.AuthorizationHeader)); len(values) > 0 {
+				authorization = values[0]
+			}
+		}
+
+		actor, ok := authenticator.Authenticate(authorization)
+		if !ok {
+			logger.FromContext(ctx).Info("unauthenticated admin request")
+
+			return nil, status.Error(codes.Unauthenticated, "invalid admin token")
+		}
+
+		ctx = admin.WithActor(ctx, actor)
+		ctx = logger.With(ctx, zap.String("admin", actor))
+
+		return ctx, nil
+	}
+}
@@ -3,24 +3,62 @@ package interceptor
 import (
 	"context"
 
-	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionpb_alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
+
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/access"
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
 )
 
+// publicMethods GRPC methods of standard services available without user id
+var publicMethods = map[string]bool{
+	healthpb.Health_Check_FullMethodName:                                    true,
+	healthpb.Health_Watch_FullMethodName:                                    true,
+	reflectionpb.ServerReflection_ServerReflectionInfo_FullMethodName:       true,
+	reflectionpb_alpha.ServerReflection_ServerReflectionInfo_FullMethodName: true,
+}
+
 // AuthInterceptor Checks user id from context
+//
+// Service identities of client certificates, public methods and methods
+// with skip_auth annotation are allowed without user id
 func AuthInterceptor(
 	ctx context.Context,
 	req interface{},
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (interface{}, error) {
+	return unaryCheck(checkAuth)(ctx, req, info, handler)
+}
+
+// AuthStreamInterceptor Checks user id from context of stream
+func AuthStreamInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return streamCheck(checkAuth)(srv, stream, info, handler)
+}
+
+func checkAuth(ctx context.Context, method string) (context.Context, error) {
+	if publicMethods[method] || access.Method(method).GetSkipAuth() {
+		return ctx, nil
+	}
+
+	if identity, ok := grpc_context.GetIdentityFromContext(ctx); ok && identity.IsService() {
+		return ctx, nil
+	}
+
 	userID := grpc_context.GetUserIDFromContext(ctx)
 
 	if len(userID) == 0 {
 		return nil, status.Error(codes.Unauthenticated, "missing user id")
 	}
 
-	return handler(ctx, req)
+	return ctx, nil
 }
@@ -0,0 +1,74 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+)
+
+func TestAuthInterceptor(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		ctx    context.Context
+		code   codes.Code
+	}{
+		{
+			name:   "user id from metadata",
+			method: "/shortener.Shortener/GetShortURL",
+			ctx:    metadata.NewIncomingContext(context.Background(), metadata.Pairs("user_id", "8c6c0dbc")),
+			code:   codes.OK,
+		},
+		{
+			name:   "missing user id",
+			method: "/shortener.Shortener/GetShortURL",
+			ctx:    context.Background(),
+			code:   codes.Unauthenticated,
+		},
+		{
+			name:   "method with skip_auth annotation",
+			method: "/shortener.Shortener/GetStatistic",
+			ctx:    context.Background(),
+			code:   codes.OK,
+		},
+		{
+			name:   "public method",
+			method: healthpb.Health_Check_FullMethodName,
+			ctx:    context.Background(),
+			code:   codes.OK,
+		},
+		{
+			name:   "service identity",
+			method: "/shortener.Shortener/GetShortURL",
+			ctx: grpc_context.SetIdentityContext(context.Background(), entity.ClientIdentity{
+				Name: "billing.internal",
+				Kind: entity.IdentityService,
+			}),
+			code: codes.OK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := AuthInterceptor(test.ctx, nil, &grpc.UnaryServerInfo{FullMethod: test.method}, func(context.Context, interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			assert.Equal(t, test.code, status.Code(err))
+
+			stream := &testStream{ctx: test.ctx}
+			err = AuthStreamInterceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: test.method}, func(interface{}, grpc.ServerStream) error {
+				return nil
+			})
+			assert.Equal(t, test.code, status.Code(err))
+		})
+	}
+}
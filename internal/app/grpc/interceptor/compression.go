@@ -0,0 +1,47 @@
+package interceptor
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	// registers gzip compressor for requests and responses
+	_ "google.golang.org/grpc/encoding/gzip"
+
+	"github.com/avGenie/url-shortener/internal/app/logger"
+)
+
+// CompressionInterceptor Compresses responses by given compressor
+//
+// Responses are sent uncompressed if client doesn't support the compressor
+func CompressionInterceptor(compressor string) grpc.UnaryServerInterceptor {
+	return unaryCheck(setCompressor(compressor))
+}
+
+// CompressionStreamInterceptor Compresses stream messages by given compressor
+func CompressionStreamInterceptor(compressor string) grpc.StreamServerInterceptor {
+	return streamCheck(setCompressor(compressor))
+}
+
+func setCompressor(compressor string) checkFunc {
+	return func(ctx context.Context, _ string) (context.Context, error) {
+		supported, err := grpc.ClientSupportedCompressors(ctx)
+		if err != nil {
+			return ctx, nil
+		}
+
+		for _, name := range supported {
+			if name != compressor {
+				continue
+			}
+
+			err = grpc.SetSendCompressor(ctx, compressor)
+			if err != nil {
+				logger.FromContext(ctx).Warn("couldn't set response compressor", zap.String("compressor", compressor), zap.Error(err))
+			}
+			break
+		}
+
+		return ctx, nil
+	}
+}
@@ -0,0 +1,83 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+)
+
+// LoggerInterceptor Accepts request ID from metadata or generates new one and logs GRPC call
+//
+// Request ID is sent back in response header and request-scoped logger is attached to context
+func LoggerInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	start := time.Now()
+
+	ctx = newRequestContext(ctx, info.FullMethod)
+
+	resp, err := handler(ctx, req)
+
+	logCall(ctx, "got incoming GRPC request", start, err)
+
+	return resp, err
+}
+
+// LoggerStreamInterceptor Accepts request ID from metadata or generates new one and logs GRPC stream
+func LoggerStreamInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+
+	ctx := newRequestContext(stream.Context(), info.FullMethod)
+
+	err := handler(srv, wrapStream(stream, ctx))
+
+	logCall(ctx, "got incoming GRPC stream", start, err)
+
+	return err
+}
+
+// newRequestContext Attaches request-scoped logger with request ID to context
+func newRequestContext(ctx context.Context, method string) context.Context {
+	var clientRequestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(logger.RequestIDMetadataKey); len(values) > 0 {
+			clientRequestID = values[0]
+		}
+	}
+	requestID := logger.RequestID(clientRequestID)
+
+	err := grpc.SetHeader(ctx, metadata.Pairs(logger.RequestIDMetadataKey, requestID))
+	if err != nil {
+		zap.L().Error("error while setting request id header", zap.Error(err))
+	}
+
+	fields := []zap.Field{zap.String("method", method)}
+	if userID := grpc_context.GetUserIDFromContext(ctx); userID.IsValid() {
+		fields = append(fields, zap.String("user_id", userID.String()))
+	}
+
+	return logger.NewRequestContext(ctx, requestID, fields...)
+}
+
+func logCall(ctx context.Context, msg string, start time.Time, err error) {
+	logger.FromContext(ctx).Info(
+		msg,
+		zap.Duration("duration", time.Since(start)),
+		zap.String("code", status.Code(err).String()),
+	)
+}
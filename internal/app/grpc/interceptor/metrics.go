@@ -0,0 +1,43 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/avGenie/url-shortener/internal/app/metrics"
+)
+
+// MetricsInterceptor Collects count and latency of GRPC calls by method and status code
+func MetricsInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	metrics.ObserveGRPCRequest(info.FullMethod, status.Code(err), time.Since(start))
+
+	return resp, err
+}
+
+// MetricsStreamInterceptor Collects count and duration of GRPC streams by method and status code
+func MetricsStreamInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	start := time.Now()
+
+	err := handler(srv, stream)
+
+	metrics.ObserveGRPCRequest(info.FullMethod, status.Code(err), time.Since(start))
+
+	return err
+}
@@ -0,0 +1,68 @@
+package interceptor
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/access"
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/identity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+)
+
+// MTLSInterceptor Identifies client by verified client certificate
+//
+// Returns Unauthenticated status if client certificate is missing or unknown.
+// Returns PermissionDenied status if method is available only for services
+func MTLSInterceptor(mapper *identity.Mapper) grpc.UnaryServerInterceptor {
+	return unaryCheck(checkClientCertificate(mapper))
+}
+
+// MTLSStreamInterceptor Identifies client of stream by verified client certificate
+func MTLSStreamInterceptor(mapper *identity.Mapper) grpc.StreamServerInterceptor {
+	return streamCheck(checkClientCertificate(mapper))
+}
+
+func checkClientCertificate(mapper *identity.Mapper) checkFunc {
+	return func(ctx context.Context, method string) (context.Context, error) {
+		if mapper == nil {
+			return ctx, nil
+		}
+
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "missing client certificate")
+		}
+
+		tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+		if !ok || len(tlsInfo.State.VerifiedChains) == 0 {
+			return nil, status.Error(codes.Unauthenticated, "missing client certificate")
+		}
+
+		cert := tlsInfo.State.VerifiedChains[0][0]
+		clientIdentity, ok := mapper.Identify(cert)
+		if !ok {
+			logger.FromContext(ctx).Info(
+				"unknown client certificate",
+				zap.String("subject", cert.Subject.String()),
+			)
+
+			return nil, status.Error(codes.Unauthenticated, "unknown client certificate")
+		}
+
+		if access.Method(method).GetServiceOnly() && !clientIdentity.IsService() {
+			return nil, status.Error(codes.PermissionDenied, "method is available only for services")
+		}
+
+		ctx = grpc_context.SetIdentityContext(ctx, clientIdentity)
+		ctx = logger.With(ctx, zap.String("client", clientIdentity.Name), zap.String("client_kind", clientIdentity.Kind))
+
+		return ctx, nil
+	}
+}
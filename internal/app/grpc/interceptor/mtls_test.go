@@ -0,0 +1,100 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/identity"
+)
+
+func TestMTLSInterceptor(t *testing.T) {
+	mapper, err := identity.NewMapper(config.Config{
+		GRPCClientCAFile:     "ca.pem",
+		GRPCClientIdentities: "billing.internal=service,alice.internal=user:alice",
+	})
+	require.NoError(t, err)
+
+	const (
+		statisticMethod = "/shortener.Shortener/GetStatistic"
+		urlMethod       = "/shortener.Shortener/GetOriginalURL"
+	)
+
+	tests := []struct {
+		name       string
+		commonName string
+		method     string
+		code       codes.Code
+		userID     entity.UserID
+	}{
+		{
+			name:       "service calls statistic",
+			commonName: "billing.internal",
+			method:     statisticMethod,
+			code:       codes.OK,
+			userID:     entity.UserID("metadata-user"),
+		},
+		{
+			name:       "user calls url method",
+			commonName: "alice.internal",
+			method:     urlMethod,
+			code:       codes.OK,
+			userID:     entity.UserID("alice"),
+		},
+		{
+			name:       "user calls statistic",
+			commonName: "alice.internal",
+			method:     statisticMethod,
+			code:       codes.PermissionDenied,
+		},
+		{
+			name:       "unknown certificate",
+			commonName: "stranger",
+			method:     urlMethod,
+			code:       codes.Unauthenticated,
+		},
+		{
+			name:   "missing certificate",
+			method: urlMethod,
+			code:   codes.Unauthenticated,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user_id", "metadata-user"))
+
+			var state tls.ConnectionState
+			if test.commonName != "" {
+				cert := &x509.Certificate{Subject: pkix.Name{CommonName: test.commonName}}
+				state.VerifiedChains = [][]*x509.Certificate{{cert}}
+			}
+			ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+
+			var userID entity.UserID
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				userID = grpc_context.GetUserIDFromContext(ctx)
+				return nil, nil
+			}
+
+			_, err := MTLSInterceptor(mapper)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: test.method}, handler)
+
+			assert.Equal(t, test.code, status.Code(err))
+			assert.Equal(t, test.userID, userID)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package interceptor
+
+import (
+	"context"
+	"strconv"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/ratelimit"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+)
+
+// methodRoutes Route groups of rate limit policies for GRPC methods
+var methodRoutes = map[string]string{
+	"/shortener.Shortener/GetShortURL":      ratelimit.RouteCreate,
+	"/shortener.Shortener/GetBatchShortURL": ratelimit.RouteCreate,
+	"/shortener.Shortener/GetOriginalURL":   ratelimit.RouteRedirect,
+	"/shortener.Shortener/GetAllUserURL":    ratelimit.RouteAPI,
+	"/shortener.Shortener/DeleteURLs":       ratelimit.RouteAPI,
+
+	"/shortener.v2.Shortener/CreateLink":            ratelimit.RouteCreate,
+	"/shortener.v2.Shortener/BatchCreateLinks":      ratelimit.RouteCreate,
+	"/shortener.v2.Shortener/GetLink":               ratelimit.RouteRedirect,
+	"/shortener.v2.Shortener/ListLinks":             ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/DeleteLinks":           ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/ListDeletedLinks":      ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/RestoreLinks":          ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/CreateWebhook":         ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/ListWebhooks":          ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/DeleteWebhook":         ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/ListWebhookDeliveries": ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/ReplayWebhookDelivery": ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/WatchEvents":           ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/CreateWorkspace":       ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/ListWorkspaces":        ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/ListWorkspaceMembers":  ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/UpdateWorkspaceMember": ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/RemoveWorkspaceMember": ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/CreateWorkspaceInvite": ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/AcceptWorkspaceInvite": ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/CreateWorkspaceLink":   ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/ListWorkspaceLinks":    ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/UpdateWorkspaceLink":   ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/DeleteWorkspaceLinks":  ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/ExportUserData":        ratelimit.RouteAPI,
+	"/shortener.v2.Shortener/EraseUser":             ratelimit.RouteAPI,
+}
+
+// unlimitedMethods GRPC methods of shortener services which aren't rate limited
+//
+// Statistic is available only from trusted subnets like its HTTP endpoint
+var unlimitedMethods = map[string]bool{
+	"/shortener.Shortener/GetStatistic":    true,
+	"/shortener.v2.Shortener/GetStatistic": true,
+}
+
+// RateLimitInterceptor Limits calls of GRPC methods by token bucket policies
+//
+// Returns ResourceExhausted status with retry-after trailer if client exceeded the policy
+func RateLimitInterceptor(limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return unaryCheck(checkRateLimit(limiter))
+}
+
+// RateLimitStreamInterceptor Limits opening of GRPC streams by token bucket policies
+func RateLimitStreamInterceptor(limiter *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return streamCheck(checkRateLimit(limiter))
+}
+
+func checkRateLimit(limiter *ratelimit.Limiter) checkFunc {
+	return func(ctx context.Context, method string) (context.Context, error) {
+		route, ok := methodRoutes[method]
+		if limiter == nil || !ok {
+			return ctx, nil
+		}
+
+		userID := grpc_context.GetUserIDFromContext(ctx)
+
+		res := limiter.Allow(ctx, route, userID, clientIP(ctx))
+		if !res.Allowed {
+			logger.FromContext(ctx).Info(
+				"too many requests",
+				zap.String("method", method),
+				zap.String("user_id", userID.String()),
+			)
+
+			grpc.SetTrailer(ctx, metadata.Pairs("retry-after", strconv.Itoa(res.RetryAfterSeconds())))
+
+			return nil, status.Error(codes.ResourceExhausted, "too many requests")
+		}
+
+		return ctx, nil
+	}
+}
+
+// clientIP Returns IP address of client resolved by ClientIPInterceptor or IP address of peer
+func clientIP(ctx context.Context) string {
+	if ip := cidr.ClientIPFromContext(ctx); ip != "" {
+		return ip
+	}
+
+	return cidr.AddrIP(peerAddr(ctx))
+}
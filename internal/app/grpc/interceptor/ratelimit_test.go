@@ -0,0 +1,113 @@
+package interceptor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/avGenie/url-shortener/internal/app/ratelimit"
+	pb "github.com/avGenie/url-shortener/proto"
+	pbv2 "github.com/avGenie/url-shortener/proto/v2"
+)
+
+func TestMethodRoutes(t *testing.T) {
+	tests := []struct {
+		name    string
+		methods []string
+		route   string
+	}{
+		{
+			name: "trash",
+			methods: []string{
+				"/shortener.v2.Shortener/ListDeletedLinks",
+				"/shortener.v2.Shortener/RestoreLinks",
+			},
+			route: ratelimit.RouteAPI,
+		},
+		{
+			name: "webhooks",
+			methods: []string{
+				"/shortener.v2.Shortener/CreateWebhook",
+				"/shortener.v2.Shortener/ListWebhooks",
+				"/shortener.v2.Shortener/DeleteWebhook",
+				"/shortener.v2.Shortener/ListWebhookDeliveries",
+				"/shortener.v2.Shortener/ReplayWebhookDelivery",
+			},
+			route: ratelimit.RouteAPI,
+		},
+		{
+			name: "event stream",
+			methods: []string{
+				"/shortener.v2.Shortener/WatchEvents",
+			},
+			route: ratelimit.RouteAPI,
+		},
+		{
+			name: "workspaces",
+			methods: []string{
+				"/shortener.v2.Shortener/CreateWorkspace",
+				"/shortener.v2.Shortener/ListWorkspaces",
+				"/shortener.v2.Shortener/ListWorkspaceMembers",
+				"/shortener.v2.Shortener/UpdateWorkspaceMember",
+				"/shortener.v2.Shortener/RemoveWorkspaceMember",
+				"/shortener.v2.Shortener/CreateWorkspaceInvite",
+				"/shortener.v2.Shortener/AcceptWorkspaceInvite",
+				"/shortener.v2.Shortener/CreateWorkspaceLink",
+				"/shortener.v2.Shortener/ListWorkspaceLinks",
+				"/shortener.v2.Shortener/UpdateWorkspaceLink",
+				"/shortener.v2.Shortener/DeleteWorkspaceLinks",
+			},
+			route: ratelimit.RouteAPI,
+		},
+		{
+			name: "account",
+			methods: []string{
+				"/shortener.v2.Shortener/ExportUserData",
+				"/shortener.v2.Shortener/EraseUser",
+			},
+			route: ratelimit.RouteAPI,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, method := range test.methods {
+				assert.Equal(t, test.route, methodRoutes[method], "method %s", method)
+			}
+		})
+	}
+}
+
+func TestAllMethodsRouted(t *testing.T) {
+	tests := []struct {
+		name string
+		desc grpc.ServiceDesc
+	}{
+		{
+			name: "v1",
+			desc: pb.Shortener_ServiceDesc,
+		},
+		{
+			name: "v2",
+			desc: pbv2.Shortener_ServiceDesc,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			methods := make([]string, 0, len(test.desc.Methods)+len(test.desc.Streams))
+			for _, method := range test.desc.Methods {
+				methods = append(methods, method.MethodName)
+			}
+			for _, stream := range test.desc.Streams {
+				methods = append(methods, stream.StreamName)
+			}
+
+			for _, method := range methods {
+				fullMethod := "/" + test.desc.ServiceName + "/" + method
+
+				_, routed := methodRoutes[fullMethod]
+				assert.True(t, routed != unlimitedMethods[fullMethod], "method %s must have rate limit route or be unlimited", fullMethod)
+			}
+		})
+	}
+}
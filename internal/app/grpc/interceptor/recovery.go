@@ -0,0 +1,60 @@
+package interceptor
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/avGenie/url-shortener/internal/app/logger"
+)
+
+const internalErrorMsg = "internal server error"
+
+// RecoveryInterceptor Recovers panic of handler
+//
+// Returns Internal status if handler panicked
+func RecoveryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recoverPanic(ctx, info.FullMethod, r)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// RecoveryStreamInterceptor Recovers panic of stream handler
+func RecoveryStreamInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = recoverPanic(stream.Context(), info.FullMethod, r)
+		}
+	}()
+
+	return handler(srv, stream)
+}
+
+func recoverPanic(ctx context.Context, method string, r interface{}) error {
+	logger.FromContext(ctx).Error(
+		"panic while processing GRPC request",
+		zap.String("method", method),
+		zap.String("panic", fmt.Sprint(r)),
+		zap.Stack("stack"),
+	)
+
+	return status.Error(codes.Internal, internalErrorMsg)
+}
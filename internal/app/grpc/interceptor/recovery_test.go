@@ -0,0 +1,51 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// testStream Server stream with given context and received messages
+type testStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	recv func(m interface{}) error
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testStream) RecvMsg(m interface{}) error {
+	return s.recv(m)
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/shortener.Shortener/GetShortURL"}
+
+	_, err := RecoveryInterceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		panic("handler panic")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	resp, err := RecoveryInterceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return "response", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "response", resp)
+}
+
+func TestRecoveryStreamInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/shortener.Shortener/Watch"}
+	stream := &testStream{ctx: context.Background()}
+
+	err := RecoveryStreamInterceptor(nil, stream, info, func(interface{}, grpc.ServerStream) error {
+		panic("stream handler panic")
+	})
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,69 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// checkFunc Checks call of GRPC method before handler
+//
+// Returns context passed to handler or error status if call is rejected
+type checkFunc func(ctx context.Context, method string) (context.Context, error)
+
+// serverStream Server stream with context replaced by interceptors
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context Returns context of stream
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// wrapStream Returns stream with given context
+func wrapStream(stream grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	if ctx == stream.Context() {
+		return stream
+	}
+
+	return &serverStream{
+		ServerStream: stream,
+		ctx:          ctx,
+	}
+}
+
+// unaryCheck Creates unary interceptor from check
+func unaryCheck(check checkFunc) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := check(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// streamCheck Creates stream interceptor from check
+func streamCheck(check checkFunc) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := check(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, wrapStream(stream, ctx))
+	}
+}
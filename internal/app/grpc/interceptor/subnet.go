@@ -0,0 +1,105 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/access"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+)
+
+// ClientIPFunc Resolves IP address of GRPC client
+type ClientIPFunc func(ctx context.Context) string
+
+// ClientIPInterceptor Resolves IP address of client and stores it in context
+//
+// IP address is used by trusted subnet check and rate limiting
+func ClientIPInterceptor(resolve ClientIPFunc) grpc.UnaryServerInterceptor {
+	return unaryCheck(checkClientIP(resolve))
+}
+
+// ClientIPStreamInterceptor Resolves IP address of client and stores it in stream context
+func ClientIPStreamInterceptor(resolve ClientIPFunc) grpc.StreamServerInterceptor {
+	return streamCheck(checkClientIP(resolve))
+}
+
+// PeerClientIP Returns resolver of client IP from peer address
+//
+// Forwarding metadata is used only if peer is a trusted proxy of access control
+func PeerClientIP(control *cidr.AccessControl) ClientIPFunc {
+	return func(ctx context.Context) string {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		return control.ClientIP(peerAddr(ctx), func(name string) []string {
+			return md.Get(name)
+		})
+	}
+}
+
+// InProcessClientIP Returns IP address of client from x-real-ip metadata
+//
+// Metadata is set by in-process HTTP transports which resolve client IP by themselves,
+// so it must be used only by server unavailable from network
+func InProcessClientIP(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(strings.ToLower(cidr.RealIPHeader)); len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
+
+// TrustedSubnetInterceptor Allows calls of methods with trusted_subnet annotation only from trusted subnets
+//
+// IP address is obtained from context filled by ClientIPInterceptor.
+// Returns PermissionDenied status if subnets are unknown or IP address is not in subnets
+func TrustedSubnetInterceptor(control *cidr.AccessControl) grpc.UnaryServerInterceptor {
+	return unaryCheck(checkTrustedSubnet(control))
+}
+
+// TrustedSubnetStreamInterceptor Allows opening of streams with trusted_subnet annotation only from trusted subnets
+func TrustedSubnetStreamInterceptor(control *cidr.AccessControl) grpc.StreamServerInterceptor {
+	return streamCheck(checkTrustedSubnet(control))
+}
+
+func checkClientIP(resolve ClientIPFunc) checkFunc {
+	return func(ctx context.Context, _ string) (context.Context, error) {
+		return cidr.WithClientIP(ctx, resolve(ctx)), nil
+	}
+}
+
+func checkTrustedSubnet(control *cidr.AccessControl) checkFunc {
+	return func(ctx context.Context, method string) (context.Context, error) {
+		if !access.Method(method).GetTrustedSubnet() {
+			return ctx, nil
+		}
+
+		ip := cidr.ClientIPFromContext(ctx)
+		if !control.Allowed(ip) {
+			logger.FromContext(ctx).Info("forbidden call from untrusted subnet", zap.String("ip", ip))
+
+			return nil, status.Error(codes.PermissionDenied, "method is available only from trusted subnet")
+		}
+
+		return ctx, nil
+	}
+}
+
+// peerAddr Returns network address of GRPC peer
+func peerAddr(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	return p.Addr.String()
+}
@@ -0,0 +1,125 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+)
+
+func TestTrustedSubnetInterceptor(t *testing.T) {
+	control, err := cidr.NewAccessControl("192.168.1.0/24, 2001:db8::/32", "172.16.0.0/12")
+	require.NoError(t, err)
+
+	const (
+		statisticMethod = "/shortener.Shortener/GetStatistic"
+		urlMethod       = "/shortener.Shortener/GetOriginalURL"
+	)
+
+	tests := []struct {
+		name     string
+		method   string
+		resolve  ClientIPFunc
+		md       metadata.MD
+		peerAddr string
+		code     codes.Code
+	}{
+		{
+			name:     "peer ip in subnet",
+			method:   statisticMethod,
+			resolve:  PeerClientIP(control),
+			peerAddr: "192.168.1.20:54321",
+			code:     codes.OK,
+		},
+		{
+			name:     "peer ipv6 in subnet",
+			method:   statisticMethod,
+			resolve:  PeerClientIP(control),
+			peerAddr: "[2001:db8::1]:54321",
+			code:     codes.OK,
+		},
+		{
+			name:     "ip not in subnet",
+			method:   statisticMethod,
+			resolve:  PeerClientIP(control),
+			peerAddr: "10.0.0.1:54321",
+			code:     codes.PermissionDenied,
+		},
+		{
+			name:     "x-real-ip from untrusted peer is ignored",
+			method:   statisticMethod,
+			resolve:  PeerClientIP(control),
+			md:       metadata.Pairs("x-real-ip", "192.168.1.10"),
+			peerAddr: "10.0.0.1:54321",
+			code:     codes.PermissionDenied,
+		},
+		{
+			name:     "x-forwarded-for from trusted proxy",
+			method:   statisticMethod,
+			resolve:  PeerClientIP(control),
+			md:       metadata.Pairs("x-forwarded-for", "192.168.1.10"),
+			peerAddr: "172.16.0.1:54321",
+			code:     codes.OK,
+		},
+		{
+			name:     "address appended by trusted proxy is used",
+			method:   statisticMethod,
+			resolve:  PeerClientIP(control),
+			md:       metadata.Pairs("x-forwarded-for", "192.168.1.10, 10.0.0.1"),
+			peerAddr: "172.16.0.1:54321",
+			code:     codes.PermissionDenied,
+		},
+		{
+			name:    "ip from in-process metadata in subnet",
+			method:  statisticMethod,
+			resolve: InProcessClientIP,
+			md:      metadata.Pairs("x-real-ip", "192.168.1.10"),
+			code:    codes.OK,
+		},
+		{
+			name:     "in-process server ignores peer",
+			method:   statisticMethod,
+			resolve:  InProcessClientIP,
+			peerAddr: "192.168.1.20:54321",
+			code:     codes.PermissionDenied,
+		},
+		{
+			name:     "method without annotation",
+			method:   urlMethod,
+			resolve:  PeerClientIP(control),
+			peerAddr: "10.0.0.1:54321",
+			code:     codes.OK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			if test.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, test.md)
+			}
+			if test.peerAddr != "" {
+				addr, err := net.ResolveTCPAddr("tcp", test.peerAddr)
+				require.NoError(t, err)
+				ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+			}
+
+			info := &grpc.UnaryServerInfo{FullMethod: test.method}
+			_, err := ClientIPInterceptor(test.resolve)(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return TrustedSubnetInterceptor(control)(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+					return nil, nil
+				})
+			})
+			assert.Equal(t, test.code, status.Code(err))
+		})
+	}
+}
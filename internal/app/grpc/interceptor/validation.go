@@ -0,0 +1,61 @@
+package interceptor
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/validation"
+)
+
+// validatingStream Server stream which validates received messages
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+// RecvMsg Receives message and validates it
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+
+	return validate(m)
+}
+
+// ValidationInterceptor Validates request by field rules from proto annotations
+//
+// Returns InvalidArgument status with BadRequest details if request is invalid
+func ValidationInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	err := validate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// ValidationStreamInterceptor Validates each received message of stream
+func ValidationStreamInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	return handler(srv, &validatingStream{ServerStream: stream})
+}
+
+func validate(req interface{}) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+
+	return validation.Validate(msg)
+}
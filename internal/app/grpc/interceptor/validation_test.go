@@ -0,0 +1,54 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/avGenie/url-shortener/proto"
+)
+
+func TestValidationInterceptor(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *pb.OriginalURL
+		code codes.Code
+	}{
+		{
+			name: "valid request",
+			req:  &pb.OriginalURL{Url: "https://practicum.yandex.ru/"},
+			code: codes.OK,
+		},
+		{
+			name: "invalid request",
+			req:  &pb.OriginalURL{},
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info := &grpc.UnaryServerInfo{FullMethod: "/shortener.Shortener/GetShortURL"}
+			_, err := ValidationInterceptor(context.Background(), test.req, info, func(context.Context, interface{}) (interface{}, error) {
+				return nil, nil
+			})
+			assert.Equal(t, test.code, status.Code(err))
+
+			stream := &testStream{
+				ctx: context.Background(),
+				recv: func(m interface{}) error {
+					m.(*pb.OriginalURL).Url = test.req.Url
+					return nil
+				},
+			}
+			err = ValidationStreamInterceptor(nil, stream, &grpc.StreamServerInfo{}, func(_ interface{}, stream grpc.ServerStream) error {
+				return stream.RecvMsg(&pb.OriginalURL{})
+			})
+			assert.Equal(t, test.code, status.Code(err))
+		})
+	}
+}
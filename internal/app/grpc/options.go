@@ -0,0 +1,162 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/grpc/interceptor"
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/identity"
+	"github.com/avGenie/url-shortener/internal/app/ratelimit"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+	"github.com/avGenie/url-shortener/internal/app/usecase/admin"
+)
+
+// Compression of GRPC responses
+//
+// CompressionNone - responses are not compressed
+// CompressionGzip - responses are compressed by gzip if client supports it
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+)
+
+// ValidateConfig Validates GRPC server settings from config
+func ValidateConfig(config config.Config) error {
+	_, err := transportOptions(config)
+
+	return err
+}
+
+// transportOptions Returns keepalive and message size options of GRPC server
+func transportOptions(config config.Config) ([]grpc.ServerOption, error) {
+	var errs []error
+
+	keepaliveTime, err := parseDuration("grpc_keepalive_time", config.GRPCKeepaliveTime)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	keepaliveTimeout, err := parseDuration("grpc_keepalive_timeout", config.GRPCKeepaliveTimeout)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	keepaliveMinTime, err := parseDuration("grpc_keepalive_min_time", config.GRPCKeepaliveMinTime)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if config.GRPCMaxRecvMsgSize <= 0 {
+		errs = append(errs, fmt.Errorf("grpc_max_recv_msg_size must be positive, got %d", config.GRPCMaxRecvMsgSize))
+	}
+
+	if config.GRPCMaxSendMsgSize <= 0 {
+		errs = append(errs, fmt.Errorf("grpc_max_send_msg_size must be positive, got %d", config.GRPCMaxSendMsgSize))
+	}
+
+	_, err = compressor(config)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    keepaliveTime,
+			Timeout: keepaliveTimeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             keepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+		grpc.MaxRecvMsgSize(config.GRPCMaxRecvMsgSize),
+		grpc.MaxSendMsgSize(config.GRPCMaxSendMsgSize),
+	}, nil
+}
+
+// compressor Returns name of compressor of responses
+//
+// Returns empty name if compression is disabled
+func compressor(config config.Config) (string, error) {
+	switch config.GRPCCompression {
+	case "", CompressionNone:
+		return "", nil
+	case CompressionGzip:
+		return CompressionGzip, nil
+	default:
+		return "", fmt.Errorf("unsupported grpc_compression %q", config.GRPCCompression)
+	}
+}
+
+// interceptors Returns chains of unary and stream interceptors
+//
+// Interceptors are called in order: metrics, access logging, panic recovery,
+// client IP resolution, client certificate identification, trusted subnet check,
+// admin token check, rate limiting, authentication, request validation and response compression
+func interceptors(
+	config config.Config,
+	limiter *ratelimit.Limiter,
+	control *cidr.AccessControl,
+	clientIP interceptor.ClientIPFunc,
+	mapper *identity.Mapper,
+	authenticator *admin.Authenticator,
+) ([]grpc.UnaryServerInterceptor, []grpc.StreamServerInterceptor, error) {
+	unary := []grpc.UnaryServerInterceptor{
+		interceptor.MetricsInterceptor,
+		interceptor.LoggerInterceptor,
+		interceptor.RecoveryInterceptor,
+		interceptor.ClientIPInterceptor(clientIP),
+		interceptor.MTLSInterceptor(mapper),
+		interceptor.TrustedSubnetInterceptor(control),
+		interceptor.AdminInterceptor(authenticator),
+		interceptor.RateLimitInterceptor(limiter),
+		interceptor.AuthInterceptor,
+		interceptor.ValidationInterceptor,
+	}
+
+	stream := []grpc.StreamServerInterceptor{
+		interceptor.MetricsStreamInterceptor,
+		interceptor.LoggerStreamInterceptor,
+		interceptor.RecoveryStreamInterceptor,
+		interceptor.ClientIPStreamInterceptor(clientIP),
+		interceptor.MTLSStreamInterceptor(mapper),
+		interceptor.TrustedSubnetStreamInterceptor(control),
+		interceptor.AdminStreamInterceptor(authenticator),
+		interceptor.RateLimitStreamInterceptor(limiter),
+		interceptor.AuthStreamInterceptor,
+		interceptor.ValidationStreamInterceptor,
+	}
+
+	name, err := compressor(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if name != "" {
+		unary = append(unary, interceptor.CompressionInterceptor(name))
+		stream = append(stream, interceptor.CompressionStreamInterceptor(name))
+	}
+
+	return unary, stream, nil
+}
+
+func parseDuration(name, value string) (time.Duration, error) {
+	res, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+
+	if res <= 0 {
+		return 0, fmt.Errorf("%s must be positive, got %q", name, value)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+)
+
+func TestValidateConfig(t *testing.T) {
+	valid := config.Config{
+		GRPCKeepaliveTime:    "2h",
+		GRPCKeepaliveTimeout: "20s",
+		GRPCKeepaliveMinTime: "5m",
+		GRPCMaxRecvMsgSize:   4 << 20,
+		GRPCMaxSendMsgSize:   4 << 20,
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(config *config.Config)
+		isError bool
+	}{
+		{
+			name:    "valid settings",
+			modify:  func(config *config.Config) {},
+			isError: false,
+		},
+		{
+			name: "gzip compression",
+			modify: func(config *config.Config) {
+				config.GRPCCompression = CompressionGzip
+			},
+			isError: false,
+		},
+		{
+			name: "unsupported compression",
+			modify: func(config *config.Config) {
+				config.GRPCCompression = "br"
+			},
+			isError: true,
+		},
+		{
+			name: "invalid keepalive time",
+			modify: func(config *config.Config) {
+				config.GRPCKeepaliveTime = "often"
+			},
+			isError: true,
+		},
+		{
+			name: "negative keepalive timeout",
+			modify: func(config *config.Config) {
+				config.GRPCKeepaliveTimeout = "-1s"
+			},
+			isError: true,
+		},
+		{
+			name: "zero message size",
+			modify: func(config *config.Config) {
+				config.GRPCMaxRecvMsgSize = 0
+			},
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := valid
+			test.modify(&config)
+
+			err := ValidateConfig(config)
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
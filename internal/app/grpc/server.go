@@ -1,16 +1,41 @@
 package grpc
 
 import (
+	"context"
+	"crypto/tls"
 	"log"
 	"net"
+	"net/http"
+	"sync"
 
 	"github.com/avGenie/url-shortener/internal/app/config"
+	grpc_admin "github.com/avGenie/url-shortener/internal/app/grpc/admin"
 	"github.com/avGenie/url-shortener/internal/app/grpc/interceptor"
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/identity"
+	grpc_v2 "github.com/avGenie/url-shortener/internal/app/grpc/v2"
 	handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
+	"github.com/avGenie/url-shortener/internal/app/health"
+	"github.com/avGenie/url-shortener/internal/app/ratelimit"
 	storage_api "github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+	"github.com/avGenie/url-shortener/internal/app/usecase/account"
+	"github.com/avGenie/url-shortener/internal/app/usecase/admin"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/events"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+	"github.com/avGenie/url-shortener/internal/app/usecase/trash"
+	"github.com/avGenie/url-shortener/internal/app/usecase/webhook"
+	"github.com/avGenie/url-shortener/internal/app/usecase/workspace"
 	pb "github.com/avGenie/url-shortener/proto"
+	adminpb "github.com/avGenie/url-shortener/proto/admin"
+	pbv2 "github.com/avGenie/url-shortener/proto/v2"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpc_health "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 // ShortenerServer GRPC server
@@ -18,31 +43,144 @@ type ShortenerServer struct {
 	pb.ShortenerServer
 
 	server        *grpc.Server
+	healthServer  *grpc_health.Server
+	checker       *health.Checker
 	deleteHandler *handlers.DeleteHandler
+	links         *link.Service
+	trash         *trash.Service
+	webhooks      *webhook.Service
+	events        *events.Bus
+	accounts      *account.Service
+	admin         *admin.Service
+	gateway       *gateway
+	web           http.Handler
 
 	storage storage_api.Storage
 	config  config.Config
+
+	done chan struct{}
+	once sync.Once
 }
 
 // NewGRPCServer Creates new GRPC server
-func NewGRPCServer(config config.Config, storage storage_api.Storage) *ShortenerServer {
-	return &ShortenerServer{
+//
+// Methods with trusted_subnet annotation are available only from trusted subnets of access control,
+// forwarding metadata is used to resolve client IP only if peer is a trusted proxy.
+// Methods with admin annotation are available only with tokens of admin authenticator.
+// Deleted URLs are queued to delete handler, which is not stopped with server.
+// Deleted URLs are listed and restored by trash service.
+// Webhooks of users are managed by webhook service.
+// Data of users is exported and erased by account service.
+// Link events are streamed live from event bus, which should be closed before server is stopped.
+// Server uses TLS if TLS config is not nil.
+// Clients are identified by certificates if identity mapper is not nil
+func NewGRPCServer(
+	config config.Config,
+	storage storage_api.Storage,
+	deleteHandler *handlers.DeleteHandler,
+	trashService *trash.Service,
+	webhookService *webhook.Service,
+	bus *events.Bus,
+	domains *domain.Registry,
+	limiter *ratelimit.Limiter,
+	control *cidr.AccessControl,
+	authenticator *admin.Authenticator,
+	tlsConfig *tls.Config,
+	mapper *identity.Mapper,
+) (*ShortenerServer, error) {
+	transport, err := transportOptions(config)
+	if err != nil {
+		return nil, err
+	}
+
+	unary, stream, err := interceptors(config, limiter, control, interceptor.PeerClientIP(control), mapper, authenticator)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+	opts = append(opts, transport...)
+
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	gateway, err := newGateway(config, limiter, control)
+	if err != nil {
+		return nil, err
+	}
+
+	checker := health.NewChecker()
+	checker.Add(health.ComponentStorage, storage.PingServer)
+	checker.Add(health.ComponentDeleteFlusher, deleteHandler.Check)
+
+	s := &ShortenerServer{
 		storage:       storage,
 		config:        config,
-		server:        grpc.NewServer(grpc.UnaryInterceptor(interceptor.AuthInterceptor)),
-		deleteHandler: handlers.NewDeleteHandler(storage),
+		server:        grpc.NewServer(opts...),
+		healthServer:  newHealthServer(),
+		checker:       checker,
+		deleteHandler: deleteHandler,
+		links:         link.NewService(storage, deleteHandler, domains),
+		trash:         trashService,
+		webhooks:      webhookService,
+		events:        bus,
+		accounts:      account.NewService(storage, domains, deleteHandler, bus),
+		admin:         admin.NewService(storage, deleteHandler, domains),
+		gateway:       gateway,
+		done:          make(chan struct{}),
 	}
+	s.registerServices()
+
+	s.web, err = newWebHandler(config, gateway.server)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// registerServices Registers services in GRPC server and in internal server of gateway
+//
+// Shortener services of v1 and v2 APIs are served together.
+// Admin service is served only by GRPC server.
+// Health service is registered with statuses of server and its components.
+// Reflection service is registered if it is enabled in config
+func (s *ShortenerServer) registerServices() {
+	serverV2 := grpc_v2.NewServer(s.links, s.trash, s.webhooks, workspace.NewService(s.storage, s.links), s.accounts, s.events)
+
+	pb.RegisterShortenerServer(s.server, s)
+	pbv2.RegisterShortenerServer(s.server, serverV2)
+	adminpb.RegisterAdminServer(s.server, grpc_admin.NewServer(s.admin))
+	healthpb.RegisterHealthServer(s.server, s.healthServer)
+
+	if s.config.GRPCReflection {
+		reflection.Register(s.server)
+	}
+
+	s.gateway.register(&pb.Shortener_ServiceDesc, s)
+	s.gateway.register(&pbv2.Shortener_ServiceDesc, serverV2)
 }
 
-// Start Starts GRPC server
+// Start Starts GRPC server and internal server of gateway
 func (s *ShortenerServer) Start() {
 	listen, err := net.Listen("tcp", s.config.GRPCNetAddr)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// регистрируем сервис
-	pb.RegisterShortenerServer(s.server, s)
+	go func() {
+		if err := s.gateway.serve(); err != nil {
+			zap.L().Error("error while serving REST gateway", zap.Error(err))
+		}
+	}()
+
+	s.updateHealth(context.Background())
+	go s.watchHealth()
 
 	zap.L().Info("Server gRPC starts", zap.String("address:", s.config.GRPCNetAddr))
 
@@ -51,7 +189,29 @@ func (s *ShortenerServer) Start() {
 	}
 }
 
+// Gateway Returns HTTP handler of REST gateway of shortener.v2 API
+//
+// Gateway serves requests after server is started
+func (s *ShortenerServer) Gateway() http.Handler {
+	return s.gateway.mux
+}
+
+// WebHandler Returns HTTP handler of shortener services for browser clients
+//
+// Services of v1 and v2 APIs are served over gRPC-Web and Connect protocols
+func (s *ShortenerServer) WebHandler() http.Handler {
+	return s.web
+}
+
 // Stop Stops GRPC server
+//
+// Server reports NOT_SERVING status to health clients before stopping
 func (s *ShortenerServer) Stop() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+
+	s.healthServer.Shutdown()
 	s.server.GracefulStop()
+	s.gateway.stop()
 }
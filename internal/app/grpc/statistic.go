@@ -4,7 +4,7 @@ import (
 	"context"
 
 	"github.com/avGenie/url-shortener/internal/app/grpc/converter"
-	get_handlers "github.com/avGenie/url-shortener/internal/app/handlers/get"
+	"github.com/avGenie/url-shortener/internal/app/logger"
 	pb "github.com/avGenie/url-shortener/proto"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -17,9 +17,9 @@ func (s *ShortenerServer) GetStatistic(ctx context.Context, _ *emptypb.Empty) (*
 	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	stat, err := get_handlers.ProcessServiceStatistic(ctx, s.storage)
+	stat, err := s.links.Statistic(ctx)
 	if err != nil {
-		zap.L().Error("could not process statistic", zap.String("error", err.Error()))
+		logger.FromContext(ctx).Error("could not process statistic", zap.String("error", err.Error()))
 
 		return nil, status.Errorf(codes.Internal, ErrInternalMsg)
 	}
@@ -2,17 +2,18 @@ package grpc
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"time"
 
-	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/audit"
 	"github.com/avGenie/url-shortener/internal/app/grpc/converter"
 	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
-	get_handlers "github.com/avGenie/url-shortener/internal/app/handlers/get"
-	post_handlers "github.com/avGenie/url-shortener/internal/app/handlers/post"
-	"github.com/avGenie/url-shortener/internal/app/models"
-	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/retry"
+	handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
 	pb "github.com/avGenie/url-shortener/proto"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -29,76 +30,85 @@ const (
 )
 
 // GetShortURL Returns short URL by original and user id
+//
+// Returns ResourceExhausted status with QuotaFailure details if user exceeded quota
 func (s *ShortenerServer) GetShortURL(ctx context.Context, original *pb.OriginalURL) (*pb.ShortURL, error) {
 	userID := grpc_context.GetUserIDFromContext(ctx)
 
 	if s.config.BaseURIPrefix == "" {
-		zap.L().Error(ErrEmptyBaseURIPrefixMsg)
+		logger.FromContext(ctx).Error(ErrEmptyBaseURIPrefixMsg)
 
 		return nil, status.Errorf(codes.Internal, ErrInternalMsg)
 	}
 
-	if ok := entity.IsValidURL(original.Url); !ok {
-		zap.L().Error(ErrWrongURLFormatMsg)
-
-		return nil, status.Errorf(codes.InvalidArgument, "couldn't parse %s url", original)
-	}
-
 	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	outputURL, err := post_handlers.PostURLProcessing(
-		s.storage,
-		ctx,
-		userID,
-		original.Url,
-		s.config.BaseURIPrefix,
-	)
+	created, err := s.links.Create(ctx, userID, "", original.GetUrl(), nil)
+	audit.LogUser(ctx, userID, audit.ActionLinkCreate, []string{created.ShortURL}, err)
 	if err != nil {
-		zap.L().Error("could not create a short URL", zap.String("error", err.Error()))
-		if errors.Is(err, storage_err.ErrURLAlreadyExists) {
+		logger.FromContext(ctx).Error("could not create a short URL", zap.String("error", err.Error()))
+		if errors.Is(err, link.ErrInvalidURL) {
+			return nil, status.Errorf(codes.InvalidArgument, "couldn't parse %s url", original)
+		}
+		if errors.Is(err, link.ErrLinkExists) {
 			return nil, status.Errorf(codes.AlreadyExists, "url already exists in storage for this user")
 		}
+		if errors.Is(err, domain.ErrDomainForbidden) {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		var quotaErr *api.QuotaError
+		if errors.As(err, &quotaErr) {
+			return nil, retry.QuotaExceededError(ctx, quotaErr)
+		}
 
 		return nil, status.Errorf(codes.Internal, ErrInternalMsg)
 	}
 
-	return &pb.ShortURL{Url: outputURL}, nil
+	return &pb.ShortURL{Url: s.links.ShortURL(created)}, nil
 }
 
 // GetOriginalURL Returns original URL by short and user id
+//
+// Call is counted in click statistic of short URL.
+// Returns PermissionDenied status if link is disabled by admin
 func (s *ShortenerServer) GetOriginalURL(ctx context.Context, original *pb.ShortURL) (*pb.OriginalURL, error) {
 	userID := grpc_context.GetUserIDFromContext(ctx)
 
-	shortURL, err := entity.ParseURL(original.Url)
-	if err != nil {
-		zap.L().Error("couldn't parse original URL", zap.Error(err), zap.String("user_id", userID.String()))
-
-		return nil, status.Errorf(codes.InvalidArgument, "couldn't parse %s url", original)
-	}
-
 	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	url, err := s.storage.GetURL(ctx, userID, *shortURL)
+	found, err := s.links.Get(ctx, userID, original.GetUrl())
+	if err == nil && found.Deleted {
+		err = link.ErrLinkNotFound
+	}
 	if err != nil {
-		if errors.Is(err, storage_err.ErrAllURLsDeleted) {
+		if errors.Is(err, link.ErrLinkNotFound) {
 			errMsg := "original url has been deleted for this user"
-			zap.L().Error(errMsg, zap.Error(err), zap.String("user_id", userID.String()))
+			logger.FromContext(ctx).Error(errMsg, zap.Error(err), zap.String("user_id", userID.String()))
 
 			return nil, status.Errorf(codes.NotFound, errMsg)
 		}
 
-		zap.L().Error(
+		logger.FromContext(ctx).Error(
 			"error while getting url",
 			zap.String("error", err.Error()),
-			zap.String("short_url", shortURL.String()),
+			zap.String("short_url", original.GetUrl()),
 		)
 
 		return nil, status.Errorf(codes.Internal, ErrInternalMsg)
 	}
 
-	return &pb.OriginalURL{Url: url.String()}, nil
+	if found.Disabled {
+		return nil, status.Errorf(codes.PermissionDenied, "original url is disabled")
+	}
+
+	err = s.links.RecordClick(ctx, found.ShortURL)
+	if err != nil {
+		logger.FromContext(ctx).Warn("error while recording click", zap.Error(err), zap.String("short_url", found.ShortURL))
+	}
+
+	return &pb.OriginalURL{Url: found.OriginalURL}, nil
 }
 
 // GetAllUserURL Returns all user URLs
@@ -106,7 +116,7 @@ func (s *ShortenerServer) GetAllUserURL(ctx context.Context, _ *emptypb.Empty) (
 	userID := grpc_context.GetUserIDFromContext(ctx)
 
 	if s.config.BaseURIPrefix == "" {
-		zap.L().Error(ErrEmptyBaseURIPrefixMsg)
+		logger.FromContext(ctx).Error(ErrEmptyBaseURIPrefixMsg)
 
 		return nil, status.Errorf(codes.Internal, ErrInternalMsg)
 	}
@@ -114,73 +124,84 @@ func (s *ShortenerServer) GetAllUserURL(ctx context.Context, _ *emptypb.Empty) (
 	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	data, err := get_handlers.ProcessAllUserURL(s.storage, ctx, userID, s.config.BaseURIPrefix)
+	links, err := s.links.All(ctx, userID)
 	if err != nil {
-		if errors.Is(err, get_handlers.ErrAllURLNotFound) {
-			errMsg := "all urls not found for given user"
-			zap.L().Error(errMsg, zap.String("user_id", userID.String()))
-
-			return nil, status.Errorf(codes.NotFound, errMsg)
-		}
-
-		zap.L().Error("error while processing all user urls", zap.Error(err))
+		logger.FromContext(ctx).Error("error while processing all user urls", zap.Error(err))
 
 		return nil, status.Errorf(codes.Internal, ErrInternalMsg)
 	}
 
-	var batch models.AllUrlsBatch
-	err = json.Unmarshal(data, &batch)
-	if err != nil {
-		zap.L().Error("error while unmarshalling all user data", zap.Error(err))
+	if len(links) == 0 {
+		errMsg := "all urls not found for given user"
+		logger.FromContext(ctx).Error(errMsg, zap.String("user_id", userID.String()))
 
-		return nil, status.Errorf(codes.Internal, ErrInternalMsg)
+		return nil, status.Errorf(codes.NotFound, errMsg)
 	}
 
-	urlsResponse := converter.AllURLsBatchToAllURLsResponse(batch)
-
-	return urlsResponse, nil
+	return converter.LinksToAllURLsResponse(links, s.links.ShortURL), nil
 }
 
 // GetBatchShortURL Returns short URL by original batch of URLs and user id
+//
+// Returns ResourceExhausted status with QuotaFailure details if user exceeded quota
 func (s *ShortenerServer) GetBatchShortURL(ctx context.Context, originalBatch *pb.BatchRequest) (*pb.BatchResponse, error) {
 	userID := grpc_context.GetUserIDFromContext(ctx)
 
 	if s.config.BaseURIPrefix == "" {
-		zap.L().Error(ErrEmptyBaseURIPrefixMsg)
+		logger.FromContext(ctx).Error(ErrEmptyBaseURIPrefixMsg)
 
 		return nil, status.Errorf(codes.Internal, ErrInternalMsg)
 	}
 
-	reqBatch := converter.BatchRequestToReqBatch(originalBatch)
-
 	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
 	defer cancel()
 
-	resBatch, err := post_handlers.BatchURLProcessing(s.storage, ctx, userID, reqBatch, s.config.BaseURIPrefix)
+	results, err := s.links.CreateBatch(ctx, userID, "", converter.BatchRequestToBatchItems(originalBatch))
+	audit.LogUser(ctx, userID, audit.ActionLinkBatchCreate, link.BatchKeys(results), err)
 	if err != nil {
-		zap.L().Error("error while batch url processing", zap.Error(err))
+		logger.FromContext(ctx).Error("error while batch url processing", zap.Error(err))
+		if errors.Is(err, link.ErrInvalidURL) {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+		if errors.Is(err, domain.ErrDomainForbidden) {
+			return nil, status.Errorf(codes.PermissionDenied, err.Error())
+		}
+		var quotaErr *api.QuotaError
+		if errors.As(err, &quotaErr) {
+			return nil, retry.QuotaExceededError(ctx, quotaErr)
+		}
 
 		return nil, status.Errorf(codes.Internal, ErrInternalMsg)
 	}
 
-	outBatch := converter.ResBatchToBatchResponse(resBatch)
-
-	return outBatch, nil
+	return converter.BatchResultsToBatchResponse(results, s.links.ShortURL), nil
 }
 
-// DeleteURLs Deleted URLs by aliases and user id
-func (s *ShortenerServer) DeleteURLs(ctx context.Context, request *pb.DeleteRequest) (*emptypb.Empty, error) {
+// DeleteURLs Deleted URLs by aliases and user id in background
+//
+// Returns ID of deletion job.
+// Returns Unavailable status with RetryInfo details if queue of deletion jobs is full
+func (s *ShortenerServer) DeleteURLs(ctx context.Context, request *pb.DeleteRequest) (*pb.DeleteResponse, error) {
 	userID := grpc_context.GetUserIDFromContext(ctx)
 
 	if s.config.BaseURIPrefix == "" {
-		zap.L().Error(ErrEmptyBaseURIPrefixMsg)
+		logger.FromContext(ctx).Error(ErrEmptyBaseURIPrefixMsg)
 
 		return nil, status.Errorf(codes.Internal, ErrInternalMsg)
 	}
 
-	urlBatch := converter.DeleteRequestToReqDeletedURLBatch(request)
+	shortURLs := converter.DeleteRequestToShortURLs(request)
+
+	jobID, err := s.links.Delete(ctx, userID, shortURLs)
+	audit.LogUser(ctx, userID, audit.ActionLinkDelete, s.links.Keys(shortURLs), err)
+	if errors.Is(err, handlers.ErrQueueFull) {
+		return nil, retry.UnavailableError(ctx, handlers.ErrQueueFull.Error(), handlers.RetryAfter)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("error while deleting urls", zap.Error(err))
 
-	s.deleteHandler.ProcessDeletedURLs(userID, urlBatch)
+		return nil, status.Errorf(codes.Internal, ErrInternalMsg)
+	}
 
-	return &emptypb.Empty{}, nil
+	return &pb.DeleteResponse{JobID: jobID}, nil
 }
@@ -0,0 +1,50 @@
+// Package access provides access rules of GRPC methods from proto annotations
+package access
+
+import (
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	pb "github.com/avGenie/url-shortener/proto"
+)
+
+var rules sync.Map
+
+// Method Returns access rules of GRPC method by full method name "/package.Service/Method"
+//
+// Returns empty rules if method is unknown or doesn't have access annotation
+func Method(fullMethod string) *pb.MethodAccess {
+	if res, ok := rules.Load(fullMethod); ok {
+		return res.(*pb.MethodAccess)
+	}
+
+	res := methodAccess(fullMethod)
+	rules.Store(fullMethod, res)
+
+	return res
+}
+
+func methodAccess(fullMethod string) *pb.MethodAccess {
+	name := strings.Replace(strings.TrimPrefix(fullMethod, "/"), "/", ".", 1)
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return &pb.MethodAccess{}
+	}
+
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return &pb.MethodAccess{}
+	}
+
+	res, ok := proto.GetExtension(method.Options(), pb.E_Access).(*pb.MethodAccess)
+	if !ok || res == nil {
+		return &pb.MethodAccess{}
+	}
+
+	return res
+}
@@ -0,0 +1,47 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMethod(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		skipAuth      bool
+		trustedSubnet bool
+		serviceOnly   bool
+	}{
+		{
+			name:          "annotated method",
+			method:        "/shortener.Shortener/GetStatistic",
+			skipAuth:      true,
+			trustedSubnet: true,
+			serviceOnly:   true,
+		},
+		{
+			name:   "method without annotation",
+			method: "/shortener.Shortener/GetShortURL",
+		},
+		{
+			name:   "unknown method",
+			method: "/shortener.Shortener/Unknown",
+		},
+		{
+			name:   "invalid method name",
+			method: "GetStatistic",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rules := Method(test.method)
+
+			assert.Equal(t, test.skipAuth, rules.GetSkipAuth())
+			assert.Equal(t, test.trustedSubnet, rules.GetTrustedSubnet())
+			assert.Equal(t, test.serviceOnly, rules.GetServiceOnly())
+		})
+	}
+}
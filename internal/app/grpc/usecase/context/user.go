@@ -7,15 +7,22 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
-const (
-	userIDKey = "user_id"
-)
+// UserIDMetadataKey Metadata key containing user id
+const UserIDMetadataKey = "user_id"
+
+type identityCtxKey struct{}
 
 // GetUserIDFromContext Gets user id from context
+//
+// User id of client certificate identity has priority over user id from metadata
 func GetUserIDFromContext(ctx context.Context) entity.UserID {
+	if identity, ok := GetIdentityFromContext(ctx); ok && identity.Kind == entity.IdentityUser {
+		return identity.UserID
+	}
+
 	var userID string
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
-		values := md.Get(userIDKey)
+		values := md.Get(UserIDMetadataKey)
 		if len(values) > 0 {
 			userID = values[0]
 		}
@@ -26,5 +33,22 @@ func GetUserIDFromContext(ctx context.Context) entity.UserID {
 
 // SetUserIDContext Sets user id to context
 func SetUserIDContext(ctx context.Context, userID entity.UserID) context.Context {
-	return metadata.AppendToOutgoingContext(ctx, userIDKey, userID.String())
+	return metadata.AppendToOutgoingContext(ctx, UserIDMetadataKey, userID.String())
+}
+
+// UserIDMetadata Returns metadata containing user id
+func UserIDMetadata(userID entity.UserID) metadata.MD {
+	return metadata.Pairs(UserIDMetadataKey, userID.String())
+}
+
+// GetIdentityFromContext Gets identity of client certificate from context
+func GetIdentityFromContext(ctx context.Context) (entity.ClientIdentity, bool) {
+	identity, ok := ctx.Value(identityCtxKey{}).(entity.ClientIdentity)
+
+	return identity, ok
+}
+
+// SetIdentityContext Sets identity of client certificate to context
+func SetIdentityContext(ctx context.Context, identity entity.ClientIdentity) context.Context {
+	return context.WithValue(ctx, identityCtxKey{}, identity)
 }
@@ -0,0 +1,124 @@
+// Package identity maps client certificates of mutual TLS to client identities
+package identity
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// ErrInvalidIdentity Error that will be returned if client identity couldn't be parsed
+var ErrInvalidIdentity = errors.New("invalid client identity")
+
+// Mapper Maps client certificates to identities by subject common name or SAN
+type Mapper struct {
+	identities map[string]entity.ClientIdentity
+}
+
+// NewMapper Creates mapper using identities from config
+//
+// Returns nil mapper if mutual TLS is disabled
+func NewMapper(config config.Config) (*Mapper, error) {
+	if config.GRPCClientCAFile == "" {
+		return nil, nil
+	}
+
+	identities, err := ParseIdentities(config.GRPCClientIdentities)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mapper{
+		identities: identities,
+	}, nil
+}
+
+// Identify Returns identity of client certificate
+//
+// SANs are checked before subject common name.
+// Returns false if certificate is unknown
+func (m *Mapper) Identify(cert *x509.Certificate) (entity.ClientIdentity, bool) {
+	for _, name := range certificateNames(cert) {
+		if identity, ok := m.identities[name]; ok {
+			return identity, true
+		}
+	}
+
+	return entity.ClientIdentity{}, false
+}
+
+// ValidateConfig Validates mutual TLS settings from config
+func ValidateConfig(config config.Config) error {
+	var errs []error
+
+	if config.GRPCClientCAFile != "" && !config.GRPCEnableTLS {
+		errs = append(errs, fmt.Errorf("grpc_client_ca_file requires grpc_enable_tls"))
+	}
+
+	_, err := ParseIdentities(config.GRPCClientIdentities)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid grpc_client_identities: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseIdentities Parses identities in format "name=user:<user id>|service,..."
+//
+// Name is subject common name or SAN (DNS name, email or URI) of client certificate
+func ParseIdentities(raw string) (map[string]entity.ClientIdentity, error) {
+	identities := make(map[string]entity.ClientIdentity)
+	if strings.TrimSpace(raw) == "" {
+		return identities, nil
+	}
+
+	for _, item := range strings.Split(raw, ",") {
+		name, spec, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidIdentity, item)
+		}
+
+		identity := entity.ClientIdentity{
+			Name: name,
+		}
+
+		kind, userID, _ := strings.Cut(spec, ":")
+		switch kind {
+		case entity.IdentityService:
+			if userID != "" {
+				return nil, fmt.Errorf("%w: service identity can't contain user id in %q", ErrInvalidIdentity, item)
+			}
+		case entity.IdentityUser:
+			if userID == "" {
+				return nil, fmt.Errorf("%w: user id is required in %q", ErrInvalidIdentity, item)
+			}
+			identity.UserID = entity.UserID(userID)
+		default:
+			return nil, fmt.Errorf("%w: unknown kind in %q", ErrInvalidIdentity, item)
+		}
+		identity.Kind = kind
+
+		identities[name] = identity
+	}
+
+	return identities, nil
+}
+
+func certificateNames(cert *x509.Certificate) []string {
+	names := make([]string, 0, len(cert.DNSNames)+len(cert.EmailAddresses)+len(cert.URIs)+1)
+	names = append(names, cert.DNSNames...)
+	names = append(names, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		names = append(names, uri.String())
+	}
+
+	if cert.Subject.CommonName != "" {
+		names = append(names, cert.Subject.CommonName)
+	}
+
+	return names
+}
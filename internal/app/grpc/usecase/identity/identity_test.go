@@ -0,0 +1,125 @@
+package identity
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+func TestParseIdentities(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected map[string]entity.ClientIdentity
+		isError  bool
+	}{
+		{
+			name:     "empty identities",
+			raw:      "",
+			expected: map[string]entity.ClientIdentity{},
+		},
+		{
+			name: "user and service identities",
+			raw:  "billing.internal=service, alice@example.com=user:8c6c0dbc",
+			expected: map[string]entity.ClientIdentity{
+				"billing.internal": {
+					Name: "billing.internal",
+					Kind: entity.IdentityService,
+				},
+				"alice@example.com": {
+					Name:   "alice@example.com",
+					Kind:   entity.IdentityUser,
+					UserID: entity.UserID("8c6c0dbc"),
+				},
+			},
+		},
+		{
+			name:    "user without id",
+			raw:     "alice=user",
+			isError: true,
+		},
+		{
+			name:    "service with user id",
+			raw:     "billing=service:8c6c0dbc",
+			isError: true,
+		},
+		{
+			name:    "unknown kind",
+			raw:     "billing=admin",
+			isError: true,
+		},
+		{
+			name:    "missing name",
+			raw:     "=service",
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identities, err := ParseIdentities(test.raw)
+			if test.isError {
+				assert.ErrorIs(t, err, ErrInvalidIdentity)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, identities)
+		})
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	identities, err := ParseIdentities("billing.internal=service,alice@example.com=user:alice,spiffe://internal/stats=service")
+	require.NoError(t, err)
+
+	mapper := &Mapper{identities: identities}
+
+	tests := []struct {
+		name     string
+		cert     *x509.Certificate
+		expected string
+		ok       bool
+	}{
+		{
+			name:     "common name",
+			cert:     &x509.Certificate{Subject: pkix.Name{CommonName: "billing.internal"}},
+			expected: "billing.internal",
+			ok:       true,
+		},
+		{
+			name: "email SAN has priority over common name",
+			cert: &x509.Certificate{
+				Subject:        pkix.Name{CommonName: "billing.internal"},
+				EmailAddresses: []string{"alice@example.com"},
+			},
+			expected: "alice@example.com",
+			ok:       true,
+		},
+		{
+			name:     "URI SAN",
+			cert:     &x509.Certificate{URIs: []*url.URL{{Scheme: "spiffe", Host: "internal", Path: "/stats"}}},
+			expected: "spiffe://internal/stats",
+			ok:       true,
+		},
+		{
+			name: "unknown certificate",
+			cert: &x509.Certificate{Subject: pkix.Name{CommonName: "stranger"}},
+			ok:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			identity, ok := mapper.Identify(test.cert)
+			require.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, identity.Name)
+		})
+	}
+}
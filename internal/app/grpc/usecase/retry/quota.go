@@ -0,0 +1,51 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// QuotaExceededError Returns ResourceExhausted status with QuotaFailure details of exceeded limit
+//
+// RetryInfo details and retry-after trailer are added if usage of exceeded limit is reset over time
+func QuotaExceededError(ctx context.Context, quotaErr *api.QuotaError) error {
+	st := status.New(codes.ResourceExhausted, api.ErrQuotaExceeded.Error())
+	details := []protoadapt.MessageV1{
+		&errdetails.QuotaFailure{
+			Violations: []*errdetails.QuotaFailure_Violation{
+				{
+					Subject:     quotaErr.Limit,
+					Description: fmt.Sprintf("limit of %s is %d", quotaErr.Limit, quotaErr.Max),
+				},
+			},
+		},
+	}
+
+	if !quotaErr.ResetAt.IsZero() {
+		delay := time.Until(quotaErr.ResetAt)
+		grpc.SetTrailer(ctx, metadata.Pairs(TrailerKey, strconv.Itoa(Seconds(delay))))
+
+		details = append(details, &errdetails.RetryInfo{
+			RetryDelay: durationpb.New(delay),
+		})
+	}
+
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -0,0 +1,41 @@
+// Package retry reports to GRPC clients when rejected calls could be retried
+package retry
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// TrailerKey Trailer containing count of seconds to wait before retry
+const TrailerKey = "retry-after"
+
+// UnavailableError Returns Unavailable status with RetryInfo details
+//
+// Delay is also set to retry-after trailer the same way as for rate limited calls
+func UnavailableError(ctx context.Context, msg string, delay time.Duration) error {
+	grpc.SetTrailer(ctx, metadata.Pairs(TrailerKey, strconv.Itoa(Seconds(delay))))
+
+	st := status.New(codes.Unavailable, msg)
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(delay),
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// Seconds Returns count of seconds to wait before retry rounded up
+func Seconds(delay time.Duration) int {
+	return int(math.Max(1, math.Ceil(delay.Seconds())))
+}
@@ -0,0 +1,76 @@
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+func TestUnavailableError(t *testing.T) {
+	st, ok := status.FromError(UnavailableError(context.Background(), "delete queue is full", 5*time.Second))
+	require.True(t, ok)
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, "delete queue is full", st.Message())
+
+	require.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Second, info.GetRetryDelay().AsDuration())
+}
+
+func TestSeconds(t *testing.T) {
+	assert.Equal(t, 1, Seconds(0))
+	assert.Equal(t, 1, Seconds(300*time.Millisecond))
+	assert.Equal(t, 5, Seconds(5*time.Second))
+	assert.Equal(t, 3, Seconds(2100*time.Millisecond))
+}
+
+func TestQuotaExceededError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       *api.QuotaError
+		wantRetry bool
+	}{
+		{
+			name: "active links",
+			err:  &api.QuotaError{Limit: "active_links", Max: 100},
+		},
+		{
+			name:      "links per day",
+			err:       &api.QuotaError{Limit: "links_per_day", Max: 10, ResetAt: time.Now().Add(time.Hour)},
+			wantRetry: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			st, ok := status.FromError(QuotaExceededError(context.Background(), test.err))
+			require.True(t, ok)
+			assert.Equal(t, codes.ResourceExhausted, st.Code())
+
+			var failure *errdetails.QuotaFailure
+			var info *errdetails.RetryInfo
+			for _, detail := range st.Details() {
+				switch d := detail.(type) {
+				case *errdetails.QuotaFailure:
+					failure = d
+				case *errdetails.RetryInfo:
+					info = d
+				}
+			}
+
+			require.NotNil(t, failure)
+			require.Len(t, failure.GetViolations(), 1)
+			assert.Equal(t, test.err.Limit, failure.GetViolations()[0].GetSubject())
+			assert.Equal(t, test.wantRetry, info != nil)
+		})
+	}
+}
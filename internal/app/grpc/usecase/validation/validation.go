@@ -0,0 +1,120 @@
+// Package validation validates GRPC requests by field rules from proto annotations
+package validation
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	pb "github.com/avGenie/url-shortener/proto"
+)
+
+const invalidRequestMsg = "invalid request"
+
+// Validate Checks message fields by validation rules
+//
+// Returns InvalidArgument status with BadRequest details containing all violations
+func Validate(msg proto.Message) error {
+	violations := validateMessage(msg.ProtoReflect(), "")
+	if len(violations) == 0 {
+		return nil
+	}
+
+	st, err := status.New(codes.InvalidArgument, invalidRequestMsg).WithDetails(&errdetails.BadRequest{
+		FieldViolations: violations,
+	})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, invalidRequestMsg)
+	}
+
+	return st.Err()
+}
+
+func validateMessage(msg protoreflect.Message, prefix string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		path := prefix + string(field.Name())
+
+		rules, _ := proto.GetExtension(field.Options(), pb.E_Rules).(*pb.FieldRules)
+		violations = append(violations, validateField(msg, field, path, rules)...)
+
+		if field.Kind() != protoreflect.MessageKind || !msg.Has(field) {
+			continue
+		}
+
+		if field.IsList() {
+			list := msg.Get(field).List()
+			// items of too long list are not validated to limit count of violations
+			if maxItems := int(rules.GetMaxItems()); maxItems > 0 && list.Len() > maxItems {
+				continue
+			}
+
+			for j := 0; j < list.Len(); j++ {
+				violations = append(violations, validateMessage(list.Get(j).Message(), fmt.Sprintf("%s[%d].", path, j))...)
+			}
+			continue
+		}
+
+		if !field.IsMap() {
+			violations = append(violations, validateMessage(msg.Get(field).Message(), path+".")...)
+		}
+	}
+
+	return violations
+}
+
+func validateField(
+	msg protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+	path string,
+	rules *pb.FieldRules,
+) []*errdetails.BadRequest_FieldViolation {
+	if rules == nil {
+		return nil
+	}
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	violation := func(format string, args ...interface{}) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       path,
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if rules.GetRequired() && !msg.Has(field) {
+		violation("field is required")
+		return violations
+	}
+
+	if field.IsList() || field.IsMap() {
+		if maxItems := int(rules.GetMaxItems()); maxItems > 0 && itemsCount(msg, field) > maxItems {
+			violation("must contain at most %d items", maxItems)
+		}
+
+		return violations
+	}
+
+	if field.Kind() == protoreflect.StringKind {
+		if maxLen := int(rules.GetMaxLen()); maxLen > 0 && utf8.RuneCountInString(msg.Get(field).String()) > maxLen {
+			violation("must be at most %d characters long", maxLen)
+		}
+	}
+
+	return violations
+}
+
+func itemsCount(msg protoreflect.Message, field protoreflect.FieldDescriptor) int {
+	if field.IsMap() {
+		return msg.Get(field).Map().Len()
+	}
+
+	return msg.Get(field).List().Len()
+}
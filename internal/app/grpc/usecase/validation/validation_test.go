@@ -0,0 +1,110 @@
+package validation
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	pb "github.com/avGenie/url-shortener/proto"
+	pbv2 "github.com/avGenie/url-shortener/proto/v2"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		msg    proto.Message
+		fields []string
+	}{
+		{
+			name: "valid request",
+			msg:  &pb.OriginalURL{Url: "https://practicum.yandex.ru/"},
+		},
+		{
+			name: "message without rules",
+			msg:  &emptypb.Empty{},
+		},
+		{
+			name:   "missing required field",
+			msg:    &pb.OriginalURL{},
+			fields: []string{"url"},
+		},
+		{
+			name:   "too long field",
+			msg:    &pb.ShortURL{Url: strings.Repeat("a", 2049)},
+			fields: []string{"url"},
+		},
+		{
+			name:   "empty repeated field",
+			msg:    &pb.BatchRequest{},
+			fields: []string{"urls"},
+		},
+		{
+			name: "invalid items of repeated field",
+			msg: &pb.BatchRequest{
+				Urls: []*pb.BatchOriginalURLObject{
+					{CorrelationID: "1", OriginalURL: "https://practicum.yandex.ru/"},
+					{CorrelationID: "2"},
+					{OriginalURL: "https://ya.ru/"},
+				},
+			},
+			fields: []string{"urls[1].originalURL", "urls[2].correlationID"},
+		},
+		{
+			name: "too many items",
+			msg: &pb.DeleteRequest{
+				Urls: make([]*pb.DeleteObject, 1001),
+			},
+			fields: []string{"urls"},
+		},
+		{
+			name: "too many map entries",
+			msg: &pbv2.CreateLinkRequest{
+				OriginalUrl: "https://practicum.yandex.ru/",
+				Metadata:    manyMetadata(33),
+			},
+			fields: []string{"metadata"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.msg)
+			if len(test.fields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+
+			var fields []string
+			for _, detail := range st.Details() {
+				badRequest, ok := detail.(*errdetails.BadRequest)
+				require.True(t, ok)
+
+				for _, violation := range badRequest.GetFieldViolations() {
+					fields = append(fields, violation.GetField())
+				}
+			}
+			assert.Equal(t, test.fields, fields)
+		})
+	}
+}
+
+func manyMetadata(count int) map[string]string {
+	metadata := make(map[string]string, count)
+	for i := 0; i < count; i++ {
+		metadata[strconv.Itoa(i)] = "value"
+	}
+
+	return metadata
+}
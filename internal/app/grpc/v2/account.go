@@ -0,0 +1,77 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	pb "github.com/avGenie/url-shortener/proto/v2"
+)
+
+// exportTimeout Timeout of export and erasure of user data, which read and change all records of user
+const exportTimeout = 30 * time.Second
+
+// ExportUserData Exports all data kept about user
+//
+// Data is exported as JSON document of the same format as HTTP export
+func (s *Server) ExportUserData(ctx context.Context, _ *pb.ExportUserDataRequest) (*pb.UserDataExport, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	export, err := s.accounts.Export(ctx, userID)
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		logger.FromContext(ctx).Error("error while converting user data export to output", zap.Error(err))
+		return nil, status.Error(codes.Internal, errInternalMsg)
+	}
+
+	return &pb.UserDataExport{
+		Data:       data,
+		ExportTime: timestamppb.New(export.ExportedAt),
+	}, nil
+}
+
+// EraseUser Erases all data of user from storage
+//
+// Returns counts of erased and anonymized records, repeated erasure returns zero counts
+func (s *Server) EraseUser(ctx context.Context, _ *pb.EraseUserRequest) (*pb.UserErasure, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	defer cancel()
+
+	erasure, err := s.accounts.Erase(ctx, userID)
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	return erasureToProto(erasure), nil
+}
+
+func erasureToProto(erasure models.UserErasure) *pb.UserErasure {
+	return &pb.UserErasure{
+		Links:             int64(erasure.Links),
+		TransferredLinks:  int64(erasure.TransferredLinks),
+		DeleteJobs:        int64(erasure.DeleteJobs),
+		Webhooks:          int64(erasure.Webhooks),
+		WebhookDeliveries: int64(erasure.WebhookDeliveries),
+		Memberships:       int64(erasure.Memberships),
+		Invites:           int64(erasure.Invites),
+		Workspaces:        int64(erasure.Workspaces),
+		AuditRecords:      int64(erasure.AuditRecords),
+	}
+}
@@ -0,0 +1,102 @@
+package v2
+
+import (
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	pb "github.com/avGenie/url-shortener/proto/v2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// granularities Granularities of statistic query by proto values
+var granularities = map[pb.GetStatisticRequest_Granularity]string{
+	pb.GetStatisticRequest_GRANULARITY_HOUR: models.GranularityHour,
+	pb.GetStatisticRequest_GRANULARITY_DAY:  models.GranularityDay,
+}
+
+func (s *Server) linkToProto(link entity.Link) *pb.Link {
+	out := &pb.Link{
+		ShortUrl:    s.links.ShortURL(link),
+		Alias:       link.ShortURL,
+		OriginalUrl: link.OriginalURL,
+		Deleted:     link.Deleted,
+		Disabled:    link.Disabled,
+		Metadata:    link.Metadata,
+		WorkspaceId: link.WorkspaceID,
+	}
+
+	if link.WorkspaceID != "" {
+		out.CreatorId = link.UserID.String()
+	}
+
+	if !link.CreatedAt.IsZero() {
+		out.CreateTime = timestamppb.New(link.CreatedAt)
+	}
+
+	if link.Deleted && !link.DeletedAt.IsZero() {
+		out.DeleteTime = timestamppb.New(link.DeletedAt)
+		out.ExpireTime = timestamppb.New(s.trash.ExpiresAt(link))
+	}
+
+	return out
+}
+
+func statisticQueryFromProto(request *pb.GetStatisticRequest) models.StatisticQuery {
+	query := models.StatisticQuery{
+		Granularity: granularities[request.GetGranularity()],
+		Top:         int(request.GetTop()),
+	}
+
+	if request.GetFrom() != nil {
+		query.From = request.GetFrom().AsTime()
+	}
+	if request.GetTo() != nil {
+		query.To = request.GetTo().AsTime()
+	}
+
+	return query
+}
+
+func statisticToProto(stat models.ServiceStatistic) *pb.Statistic {
+	out := &pb.Statistic{
+		LinksCount:        int64(stat.URLCount),
+		UsersCount:        int64(stat.UserCount),
+		DeletedLinksCount: int64(stat.DeletedCount),
+		StorageSizeBytes:  stat.StorageSize,
+		From:              timestampOrNil(stat.From),
+		To:                timestampOrNil(stat.To),
+	}
+
+	for _, bucket := range stat.Created {
+		out.Created = append(out.Created, &pb.Statistic_TimeCount{
+			Time:  timestamppb.New(bucket.Time),
+			Count: int64(bucket.Count),
+		})
+	}
+
+	for _, domain := range stat.TopDomains {
+		out.TopDomains = append(out.TopDomains, &pb.Statistic_DomainCount{
+			Domain:     domain.Domain,
+			LinksCount: int64(domain.Count),
+		})
+	}
+
+	for _, link := range stat.TopLinks {
+		out.TopLinks = append(out.TopLinks, &pb.Statistic_LinkClicks{
+			ShortUrl:    link.ShortURL,
+			OriginalUrl: link.OriginalURL,
+			Clicks:      link.Clicks,
+		})
+	}
+
+	return out
+}
+
+func timestampOrNil(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
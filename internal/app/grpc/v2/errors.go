@@ -0,0 +1,106 @@
+package v2
+
+import (
+	"context"
+	"errors"
+
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/retry"
+	handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/usecase/account"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+	"github.com/avGenie/url-shortener/internal/app/usecase/webhook"
+	"github.com/avGenie/url-shortener/internal/app/usecase/workspace"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+const (
+	errInternalMsg = "internal server error"
+
+	resourceTypeLink      = "link"
+	resourceTypeWebhook   = "webhook"
+	resourceTypeDelivery  = "webhook_delivery"
+	resourceTypeWorkspace = "workspace"
+	resourceTypeMember    = "workspace_member"
+	resourceTypeInvite    = "workspace_invite"
+)
+
+// statusError Converts error of link use cases to GRPC status with error details
+//
+// Field errors are returned with BadRequest details,
+// absent and existing links are returned with ResourceInfo details,
+// absent webhooks and their deliveries are returned with ResourceInfo details,
+// absent workspaces, their members and invites are returned with ResourceInfo details,
+// operations not allowed for role of workspace member are returned with PermissionDenied status,
+// operations conflicting with state of workspace are returned with FailedPrecondition status,
+// domains not allowed for user are returned with PermissionDenied status,
+// deletions rejected by full queue are returned with RetryInfo details,
+// operations exceeding user quota are returned with QuotaFailure details
+func statusError(ctx context.Context, err error, resourceName string) error {
+	var fieldErr *link.FieldError
+	var quotaErr *api.QuotaError
+	switch {
+	case errors.As(err, &fieldErr):
+		return withDetails(status.New(codes.InvalidArgument, "invalid request"), &errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{
+					Field:       fieldErr.Field,
+					Description: fieldErr.Err.Error(),
+				},
+			},
+		})
+	case errors.Is(err, link.ErrLinkNotFound):
+		return withDetails(status.New(codes.NotFound, "link is not found"), resourceInfo(ctx, resourceTypeLink, resourceName, err))
+	case errors.Is(err, link.ErrLinkExists):
+		return withDetails(status.New(codes.AlreadyExists, "link already exists"), resourceInfo(ctx, resourceTypeLink, resourceName, err))
+	case errors.Is(err, webhook.ErrWebhookNotFound):
+		return withDetails(status.New(codes.NotFound, "webhook is not found"), resourceInfo(ctx, resourceTypeWebhook, resourceName, err))
+	case errors.Is(err, webhook.ErrDeliveryNotFound):
+		return withDetails(status.New(codes.NotFound, "webhook delivery is not found"), resourceInfo(ctx, resourceTypeDelivery, resourceName, err))
+	case errors.Is(err, workspace.ErrWorkspaceNotFound):
+		return withDetails(status.New(codes.NotFound, "workspace is not found"), resourceInfo(ctx, resourceTypeWorkspace, resourceName, err))
+	case errors.Is(err, workspace.ErrMemberNotFound):
+		return withDetails(status.New(codes.NotFound, "workspace member is not found"), resourceInfo(ctx, resourceTypeMember, resourceName, err))
+	case errors.Is(err, workspace.ErrInviteNotFound):
+		return withDetails(status.New(codes.NotFound, "workspace invite is not found"), resourceInfo(ctx, resourceTypeInvite, resourceName, err))
+	case errors.Is(err, workspace.ErrAlreadyMember):
+		return withDetails(status.New(codes.AlreadyExists, "user is already member of workspace"), resourceInfo(ctx, resourceTypeMember, resourceName, err))
+	case errors.Is(err, workspace.ErrLastOwner), errors.Is(err, account.ErrSoleOwner):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrDomainForbidden), errors.Is(err, workspace.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, handlers.ErrQueueFull):
+		return retry.UnavailableError(ctx, handlers.ErrQueueFull.Error(), handlers.RetryAfter)
+	case errors.As(err, &quotaErr):
+		return retry.QuotaExceededError(ctx, quotaErr)
+	}
+
+	logger.FromContext(ctx).Error("error while processing link request", zap.Error(err))
+
+	return status.Error(codes.Internal, errInternalMsg)
+}
+
+func resourceInfo(ctx context.Context, resourceType, resourceName string, err error) *errdetails.ResourceInfo {
+	return &errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		Owner:        grpc_context.GetUserIDFromContext(ctx).String(),
+		Description:  err.Error(),
+	}
+}
+
+func withDetails(st *status.Status, details protoadapt.MessageV1) error {
+	detailed, err := st.WithDetails(details)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
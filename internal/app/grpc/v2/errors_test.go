@@ -0,0 +1,141 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+	"github.com/avGenie/url-shortener/internal/app/usecase/workspace"
+)
+
+func TestStatusError(t *testing.T) {
+	const userID = "ac2a4811-4f10-487f-bde3-e39a14af7cd8"
+
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+		wantInfo *errdetails.ResourceInfo
+		wantBad  *errdetails.BadRequest_FieldViolation
+	}{
+		{
+			name:     "field error",
+			err:      &link.FieldError{Field: "original_url", Err: link.ErrInvalidURL},
+			wantCode: codes.InvalidArgument,
+			wantBad: &errdetails.BadRequest_FieldViolation{
+				Field:       "original_url",
+				Description: link.ErrInvalidURL.Error(),
+			},
+		},
+		{
+			name:     "link not found",
+			err:      link.ErrLinkNotFound,
+			wantCode: codes.NotFound,
+			wantInfo: &errdetails.ResourceInfo{
+				ResourceType: resourceTypeLink,
+				ResourceName: "abcdefgh",
+				Owner:        userID,
+				Description:  link.ErrLinkNotFound.Error(),
+			},
+		},
+		{
+			name:     "link exists",
+			err:      link.ErrLinkExists,
+			wantCode: codes.AlreadyExists,
+			wantInfo: &errdetails.ResourceInfo{
+				ResourceType: resourceTypeLink,
+				ResourceName: "abcdefgh",
+				Owner:        userID,
+				Description:  link.ErrLinkExists.Error(),
+			},
+		},
+		{
+			name:     "workspace not found",
+			err:      workspace.ErrWorkspaceNotFound,
+			wantCode: codes.NotFound,
+			wantInfo: &errdetails.ResourceInfo{
+				ResourceType: resourceTypeWorkspace,
+				ResourceName: "abcdefgh",
+				Owner:        userID,
+				Description:  workspace.ErrWorkspaceNotFound.Error(),
+			},
+		},
+		{
+			name:     "workspace role forbids operation",
+			err:      fmt.Errorf("%w %q", workspace.ErrForbidden, "viewer"),
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "the last owner of workspace",
+			err:      workspace.ErrLastOwner,
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name:     "delete queue is full",
+			err:      fmt.Errorf("error while queueing links for deletion: %w", handlers.ErrQueueFull),
+			wantCode: codes.Unavailable,
+		},
+		{
+			name:     "quota exceeded",
+			err:      fmt.Errorf("error while saving link: %w", &api.QuotaError{Limit: entity.QuotaActiveLinks, Max: 100}),
+			wantCode: codes.ResourceExhausted,
+		},
+		{
+			name:     "internal error",
+			err:      fmt.Errorf("error while saving link: %w", errors.New("connection refused")),
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user_id", userID))
+
+			st, ok := status.FromError(statusError(ctx, test.err, "abcdefgh"))
+			require.True(t, ok)
+			assert.Equal(t, test.wantCode, st.Code())
+
+			var info *errdetails.ResourceInfo
+			var bad *errdetails.BadRequest
+			for _, detail := range st.Details() {
+				switch d := detail.(type) {
+				case *errdetails.ResourceInfo:
+					info = d
+				case *errdetails.BadRequest:
+					bad = d
+				}
+			}
+
+			if test.wantInfo != nil {
+				require.NotNil(t, info)
+				assert.Equal(t, test.wantInfo.GetResourceType(), info.GetResourceType())
+				assert.Equal(t, test.wantInfo.GetResourceName(), info.GetResourceName())
+				assert.Equal(t, test.wantInfo.GetOwner(), info.GetOwner())
+				assert.Equal(t, test.wantInfo.GetDescription(), info.GetDescription())
+			} else {
+				assert.Nil(t, info)
+			}
+
+			if test.wantBad != nil {
+				require.NotNil(t, bad)
+				require.Len(t, bad.GetFieldViolations(), 1)
+				assert.Equal(t, test.wantBad.GetField(), bad.GetFieldViolations()[0].GetField())
+				assert.Equal(t, test.wantBad.GetDescription(), bad.GetFieldViolations()[0].GetDescription())
+			} else {
+				assert.Nil(t, bad)
+			}
+		})
+	}
+}
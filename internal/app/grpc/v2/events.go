@@ -0,0 +1,69 @@
+package v2
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	"github.com/avGenie/url-shortener/internal/app/grpc/usecase/retry"
+	"github.com/avGenie/url-shortener/internal/app/usecase/events"
+	pb "github.com/avGenie/url-shortener/proto/v2"
+)
+
+// resubscribeDelay Delay before client should resume stream finished by server
+const resubscribeDelay = time.Second
+
+// WatchEvents Streams link events of user live
+//
+// Stream is resumed after last event ID of request if event is still kept in buffer of bus.
+// Returns Unavailable status with RetryInfo details if client is too slow or server is shutting down,
+// stream should be resumed with ID of the last received event. Stream is finished if user is erased
+func (s *Server) WatchEvents(request *pb.WatchEventsRequest, stream pb.Shortener_WatchEventsServer) error {
+	ctx := stream.Context()
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	sub, backlog, err := s.events.Subscribe(userID, request.GetLastEventId())
+	if err != nil {
+		return retry.UnavailableError(ctx, err.Error(), resubscribeDelay)
+	}
+	defer sub.Close()
+
+	for _, event := range backlog {
+		if err := stream.Send(s.eventToProto(event)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.Events():
+			if !ok {
+				if errors.Is(sub.Err(), events.ErrUserErased) {
+					return nil
+				}
+
+				return retry.UnavailableError(ctx, sub.Err().Error(), resubscribeDelay)
+			}
+
+			if err := stream.Send(s.eventToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *Server) eventToProto(event events.Event) *pb.Event {
+	return &pb.Event{
+		Id:          event.ID,
+		Type:        event.Type,
+		ShortUrl:    s.links.ShortURL(entity.Link{ShortURL: event.ShortURL}),
+		Alias:       event.ShortURL,
+		OriginalUrl: event.OriginalURL,
+		Time:        timestamppb.New(event.Time),
+	}
+}
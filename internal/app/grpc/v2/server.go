@@ -0,0 +1,225 @@
+// Package v2 implements shortener.v2 GRPC API
+package v2
+
+import (
+	"context"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/audit"
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	"github.com/avGenie/url-shortener/internal/app/usecase/account"
+	"github.com/avGenie/url-shortener/internal/app/usecase/events"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+	"github.com/avGenie/url-shortener/internal/app/usecase/trash"
+	"github.com/avGenie/url-shortener/internal/app/usecase/webhook"
+	"github.com/avGenie/url-shortener/internal/app/usecase/workspace"
+	pb "github.com/avGenie/url-shortener/proto/v2"
+)
+
+const requestTimeout = 3 * time.Second
+
+// Server GRPC server of shortener.v2 API
+type Server struct {
+	pb.UnimplementedShortenerServer
+
+	links      *link.Service
+	trash      *trash.Service
+	webhooks   *webhook.Service
+	workspaces *workspace.Service
+	accounts   *account.Service
+	events     *events.Bus
+}
+
+// NewServer Creates server of shortener.v2 API
+func NewServer(
+	links *link.Service,
+	trash *trash.Service,
+	webhooks *webhook.Service,
+	workspaces *workspace.Service,
+	accounts *account.Service,
+	bus *events.Bus,
+) *Server {
+	return &Server{
+		links:      links,
+		trash:      trash,
+		webhooks:   webhooks,
+		workspaces: workspaces,
+		accounts:   accounts,
+		events:     bus,
+	}
+}
+
+// CreateLink Creates link of user for original URL on domain from request
+//
+// Returns AlreadyExists status with ResourceInfo details if link already exists.
+// Returns PermissionDenied status if user is not allowed to create links on domain
+func (s *Server) CreateLink(ctx context.Context, request *pb.CreateLinkRequest) (*pb.Link, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	created, err := s.links.Create(ctx, userID, request.GetDomain(), request.GetOriginalUrl(), request.GetMetadata())
+	audit.LogUser(ctx, userID, audit.ActionLinkCreate, []string{created.ShortURL}, err)
+	if err != nil {
+		return nil, statusError(ctx, err, created.ShortURL)
+	}
+
+	return s.linkToProto(created), nil
+}
+
+// BatchCreateLinks Creates links of user for batch of original URLs on domain from request
+//
+// Returns PermissionDenied status if user is not allowed to create links on domain
+func (s *Server) BatchCreateLinks(ctx context.Context, request *pb.BatchCreateLinksRequest) (*pb.BatchCreateLinksResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	items := make([]link.BatchItem, 0, len(request.GetEntries()))
+	for _, entry := range request.GetEntries() {
+		items = append(items, link.BatchItem{
+			CorrelationID: entry.GetCorrelationId(),
+			OriginalURL:   entry.GetOriginalUrl(),
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	results, err := s.links.CreateBatch(ctx, userID, request.GetDomain(), items)
+	audit.LogUser(ctx, userID, audit.ActionLinkBatchCreate, link.BatchKeys(results), err)
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	response := &pb.BatchCreateLinksResponse{
+		Results: make([]*pb.BatchCreateLinksResponse_Result, 0, len(results)),
+	}
+	for _, result := range results {
+		response.Results = append(response.Results, &pb.BatchCreateLinksResponse_Result{
+			CorrelationId: result.CorrelationID,
+			Link:          s.linkToProto(result.Link),
+		})
+	}
+
+	return response, nil
+}
+
+// GetLink Returns user link by short ID or full short URL
+//
+// Deleted links are returned with deletion state.
+// Returns NotFound status with ResourceInfo details if link is not found
+func (s *Server) GetLink(ctx context.Context, request *pb.GetLinkRequest) (*pb.Link, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	found, err := s.links.Get(ctx, userID, request.GetShortUrl())
+	if err != nil {
+		return nil, statusError(ctx, err, request.GetShortUrl())
+	}
+
+	return s.linkToProto(found), nil
+}
+
+// ListLinks Returns page of user links ordered by creation time
+func (s *Server) ListLinks(ctx context.Context, request *pb.ListLinksRequest) (*pb.ListLinksResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	links, next, err := s.links.List(ctx, userID, int(request.GetPageSize()), request.GetPageToken())
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	response := &pb.ListLinksResponse{
+		Links:         make([]*pb.Link, 0, len(links)),
+		NextPageToken: next,
+	}
+	for _, val := range links {
+		response.Links = append(response.Links, s.linkToProto(val))
+	}
+
+	return response, nil
+}
+
+// DeleteLinks Deletes user links in background
+//
+// Returns ID of deletion job
+func (s *Server) DeleteLinks(ctx context.Context, request *pb.DeleteLinksRequest) (*pb.DeleteLinksResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	jobID, err := s.links.Delete(ctx, userID, request.GetShortUrls())
+	audit.LogUser(ctx, userID, audit.ActionLinkDelete, s.links.Keys(request.GetShortUrls()), err)
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	return &pb.DeleteLinksResponse{
+		JobId:         jobID,
+		AcceptedCount: int32(len(request.GetShortUrls())),
+	}, nil
+}
+
+// ListDeletedLinks Returns deleted user links which can be restored
+func (s *Server) ListDeletedLinks(ctx context.Context, _ *pb.ListDeletedLinksRequest) (*pb.ListDeletedLinksResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	links, err := s.trash.List(ctx, userID)
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	response := &pb.ListDeletedLinksResponse{
+		Links: make([]*pb.Link, 0, len(links)),
+	}
+	for _, link := range links {
+		response.Links = append(response.Links, s.linkToProto(link))
+	}
+
+	return response, nil
+}
+
+// RestoreLinks Restores deleted user links from trash
+//
+// Links which are not in trash of user or whose retention is expired are skipped.
+// Returns short IDs of restored links
+func (s *Server) RestoreLinks(ctx context.Context, request *pb.RestoreLinksRequest) (*pb.RestoreLinksResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	restored, err := s.trash.Restore(ctx, userID, request.GetShortUrls())
+	if err != nil {
+		audit.LogUser(ctx, userID, audit.ActionLinkRestore, s.links.Keys(request.GetShortUrls()), err)
+
+		return nil, statusError(ctx, err, "")
+	}
+
+	audit.LogUser(ctx, userID, audit.ActionLinkRestore, restored, nil)
+
+	return &pb.RestoreLinksResponse{
+		Restored: restored,
+	}, nil
+}
+
+// GetStatistic Returns counts of links and users, time series of created links, top domains and top clicked links
+//
+// Returns InvalidArgument status with bad request details if range, granularity or top count is invalid
+func (s *Server) GetStatistic(ctx context.Context, request *pb.GetStatisticRequest) (*pb.Statistic, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	stat, err := s.links.DetailedStatistic(ctx, statisticQueryFromProto(request))
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	return statisticToProto(stat), nil
+}
@@ -0,0 +1,142 @@
+package v2
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	pb "github.com/avGenie/url-shortener/proto/v2"
+)
+
+// CreateWebhook Creates webhook of user subscribed to link events
+//
+// Secret of webhook is returned only by this method
+func (s *Server) CreateWebhook(ctx context.Context, request *pb.CreateWebhookRequest) (*pb.Webhook, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	webhook, err := s.webhooks.Create(ctx, userID, request.GetUrl(), request.GetEvents(), request.GetSecret())
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	out := webhookToProto(webhook)
+	out.Secret = webhook.Secret
+
+	return out, nil
+}
+
+// ListWebhooks Returns webhooks of user without their secrets
+func (s *Server) ListWebhooks(ctx context.Context, _ *pb.ListWebhooksRequest) (*pb.ListWebhooksResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	webhooks, err := s.webhooks.List(ctx, userID)
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	response := &pb.ListWebhooksResponse{
+		Webhooks: make([]*pb.Webhook, 0, len(webhooks)),
+	}
+	for _, webhook := range webhooks {
+		response.Webhooks = append(response.Webhooks, webhookToProto(webhook))
+	}
+
+	return response, nil
+}
+
+// DeleteWebhook Deletes webhook of user with its deliveries
+//
+// Returns NotFound status if webhook is not found
+func (s *Server) DeleteWebhook(ctx context.Context, request *pb.DeleteWebhookRequest) (*pb.DeleteWebhookResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	err := s.webhooks.Delete(ctx, userID, request.GetId())
+	if err != nil {
+		return nil, statusError(ctx, err, request.GetId())
+	}
+
+	return &pb.DeleteWebhookResponse{}, nil
+}
+
+// ListWebhookDeliveries Returns the latest deliveries of webhook of user, the newest first
+//
+// Returns NotFound status if webhook is not found
+func (s *Server) ListWebhookDeliveries(ctx context.Context, request *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	deliveries, err := s.webhooks.Deliveries(ctx, userID, request.GetWebhookId())
+	if err != nil {
+		return nil, statusError(ctx, err, request.GetWebhookId())
+	}
+
+	response := &pb.ListWebhookDeliveriesResponse{
+		Deliveries: make([]*pb.WebhookDelivery, 0, len(deliveries)),
+	}
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, deliveryToProto(delivery))
+	}
+
+	return response, nil
+}
+
+// ReplayWebhookDelivery Sends payload of delivery of user to its webhook again
+//
+// Returns new pending delivery.
+// Returns NotFound status if delivery or its webhook is not found
+func (s *Server) ReplayWebhookDelivery(ctx context.Context, request *pb.ReplayWebhookDeliveryRequest) (*pb.WebhookDelivery, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	delivery, err := s.webhooks.Replay(ctx, userID, request.GetId())
+	if err != nil {
+		return nil, statusError(ctx, err, request.GetId())
+	}
+
+	return deliveryToProto(delivery), nil
+}
+
+func webhookToProto(webhook entity.Webhook) *pb.Webhook {
+	return &pb.Webhook{
+		Id:         webhook.ID,
+		Url:        webhook.URL,
+		Events:     webhook.Events,
+		CreateTime: timestampOrNil(webhook.CreatedAt),
+	}
+}
+
+func deliveryToProto(delivery entity.WebhookDelivery) *pb.WebhookDelivery {
+	out := &pb.WebhookDelivery{
+		Id:           delivery.ID,
+		WebhookId:    delivery.WebhookID,
+		Event:        delivery.Event,
+		Payload:      string(delivery.Payload),
+		Status:       string(delivery.Status),
+		Attempts:     int32(delivery.Attempts),
+		ResponseCode: int32(delivery.ResponseCode),
+		LastError:    delivery.LastError,
+		CreateTime:   timestampOrNil(delivery.CreatedAt),
+		UpdateTime:   timestampOrNil(delivery.UpdatedAt),
+	}
+
+	if delivery.Status == entity.WebhookDeliveryPending {
+		out.NextAttemptTime = timestamppb.New(delivery.NextAttemptAt)
+	}
+
+	return out
+}
@@ -0,0 +1,263 @@
+package v2
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/avGenie/url-shortener/internal/app/audit"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	"github.com/avGenie/url-shortener/internal/app/usecase/workspace"
+	pb "github.com/avGenie/url-shortener/proto/v2"
+)
+
+// CreateWorkspace Creates workspace with user as its owner
+func (s *Server) CreateWorkspace(ctx context.Context, request *pb.CreateWorkspaceRequest) (*pb.Workspace, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	membership, err := s.workspaces.Create(ctx, userID, request.GetName())
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	return workspaceToProto(membership), nil
+}
+
+// ListWorkspaces Returns workspaces user is member of with roles of user
+func (s *Server) ListWorkspaces(ctx context.Context, _ *pb.ListWorkspacesRequest) (*pb.ListWorkspacesResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	memberships, err := s.workspaces.List(ctx, userID)
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	response := &pb.ListWorkspacesResponse{
+		Workspaces: make([]*pb.Workspace, 0, len(memberships)),
+	}
+	for _, membership := range memberships {
+		response.Workspaces = append(response.Workspaces, workspaceToProto(membership))
+	}
+
+	return response, nil
+}
+
+// ListWorkspaceMembers Returns members of workspace ordered by time of joining
+//
+// Returns NotFound status if workspace is not found or user is not its member
+func (s *Server) ListWorkspaceMembers(ctx context.Context, request *pb.ListWorkspaceMembersRequest) (*pb.ListWorkspaceMembersResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	members, err := s.workspaces.Members(ctx, userID, request.GetWorkspaceId())
+	if err != nil {
+		return nil, statusError(ctx, err, request.GetWorkspaceId())
+	}
+
+	response := &pb.ListWorkspaceMembersResponse{
+		Members: make([]*pb.WorkspaceMember, 0, len(members)),
+	}
+	for _, member := range members {
+		response.Members = append(response.Members, memberToProto(member))
+	}
+
+	return response, nil
+}
+
+// UpdateWorkspaceMember Changes role of workspace member
+//
+// Returns PermissionDenied status if user is not owner of workspace.
+// Returns FailedPrecondition status if the last owner is demoted
+func (s *Server) UpdateWorkspaceMember(ctx context.Context, request *pb.UpdateWorkspaceMemberRequest) (*pb.WorkspaceMember, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	member, err := s.workspaces.SetRole(
+		ctx,
+		userID,
+		request.GetWorkspaceId(),
+		entity.UserID(request.GetUserId()),
+		entity.WorkspaceRole(request.GetRole()),
+	)
+	if err != nil {
+		return nil, statusError(ctx, err, request.GetUserId())
+	}
+
+	return memberToProto(member), nil
+}
+
+// RemoveWorkspaceMember Removes member from workspace
+//
+// Returns PermissionDenied status if user is not owner of workspace and removes other member.
+// Returns FailedPrecondition status if the last owner is removed
+func (s *Server) RemoveWorkspaceMember(ctx context.Context, request *pb.RemoveWorkspaceMemberRequest) (*pb.RemoveWorkspaceMemberResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	err := s.workspaces.RemoveMember(ctx, userID, request.GetWorkspaceId(), entity.UserID(request.GetUserId()))
+	if err != nil {
+		return nil, statusError(ctx, err, request.GetUserId())
+	}
+
+	return &pb.RemoveWorkspaceMemberResponse{}, nil
+}
+
+// CreateWorkspaceInvite Creates single use invite to workspace
+//
+// Returns PermissionDenied status if user is not owner of workspace
+func (s *Server) CreateWorkspaceInvite(ctx context.Context, request *pb.CreateWorkspaceInviteRequest) (*pb.WorkspaceInvite, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	invite, err := s.workspaces.Invite(ctx, userID, request.GetWorkspaceId(), entity.WorkspaceRole(request.GetRole()))
+	if err != nil {
+		return nil, statusError(ctx, err, request.GetWorkspaceId())
+	}
+
+	return &pb.WorkspaceInvite{
+		Id:          invite.ID,
+		WorkspaceId: invite.WorkspaceID,
+		Role:        string(invite.Role),
+		ExpireTime:  timestamppb.New(invite.ExpiresAt),
+	}, nil
+}
+
+// AcceptWorkspaceInvite Adds user to workspace of invite with its role
+//
+// Returns NotFound status if invite is not found, already accepted or expired.
+// Returns AlreadyExists status if user is already member of workspace
+func (s *Server) AcceptWorkspaceInvite(ctx context.Context, request *pb.AcceptWorkspaceInviteRequest) (*pb.WorkspaceMember, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	member, err := s.workspaces.Accept(ctx, userID, request.GetId())
+	if err != nil {
+		return nil, statusError(ctx, err, "")
+	}
+
+	return memberToProto(member), nil
+}
+
+// CreateWorkspaceLink Creates link in workspace for original URL on domain from request
+//
+// Returns AlreadyExists status with ResourceInfo details if link already exists.
+// Returns PermissionDenied status if user is viewer of workspace or is not allowed to create links on domain
+func (s *Server) CreateWorkspaceLink(ctx context.Context, request *pb.CreateWorkspaceLinkRequest) (*pb.Link, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	created, err := s.workspaces.CreateLink(
+		ctx,
+		userID,
+		request.GetWorkspaceId(),
+		request.GetDomain(),
+		request.GetOriginalUrl(),
+		request.GetMetadata(),
+	)
+	audit.LogUser(ctx, userID, audit.ActionLinkCreate, []string{created.ShortURL}, err)
+	if err != nil {
+		return nil, statusError(ctx, err, created.ShortURL)
+	}
+
+	return s.linkToProto(created), nil
+}
+
+// ListWorkspaceLinks Returns page of links of workspace ordered by creation time
+func (s *Server) ListWorkspaceLinks(ctx context.Context, request *pb.ListWorkspaceLinksRequest) (*pb.ListLinksResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	links, next, err := s.workspaces.Links(ctx, userID, request.GetWorkspaceId(), int(request.GetPageSize()), request.GetPageToken())
+	if err != nil {
+		return nil, statusError(ctx, err, request.GetWorkspaceId())
+	}
+
+	response := &pb.ListLinksResponse{
+		Links:         make([]*pb.Link, 0, len(links)),
+		NextPageToken: next,
+	}
+	for _, link := range links {
+		response.Links = append(response.Links, s.linkToProto(link))
+	}
+
+	return response, nil
+}
+
+// UpdateWorkspaceLink Replaces metadata of link of workspace
+//
+// Returns NotFound status if link is not found in workspace
+func (s *Server) UpdateWorkspaceLink(ctx context.Context, request *pb.UpdateWorkspaceLinkRequest) (*pb.Link, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	updated, err := s.workspaces.UpdateLink(ctx, userID, request.GetWorkspaceId(), request.GetShortUrl(), request.GetMetadata())
+	audit.LogUser(ctx, userID, audit.ActionLinkUpdate, []string{updated.ShortURL}, err)
+	if err != nil {
+		return nil, statusError(ctx, err, request.GetShortUrl())
+	}
+
+	return s.linkToProto(updated), nil
+}
+
+// DeleteWorkspaceLinks Deletes links of workspace moving them to trash of their creators
+//
+// Links are deleted in background by deletion jobs of their creators, links which are not found in workspace are skipped
+func (s *Server) DeleteWorkspaceLinks(ctx context.Context, request *pb.DeleteWorkspaceLinksRequest) (*pb.DeleteWorkspaceLinksResponse, error) {
+	userID := grpc_context.GetUserIDFromContext(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	deleted, err := s.workspaces.DeleteLinks(ctx, userID, request.GetWorkspaceId(), request.GetShortUrls())
+	if err != nil {
+		audit.LogUser(ctx, userID, audit.ActionLinkDelete, s.links.Keys(request.GetShortUrls()), err)
+
+		return nil, statusError(ctx, err, request.GetWorkspaceId())
+	}
+
+	audit.LogUser(ctx, userID, audit.ActionLinkDelete, deleted, nil)
+
+	return &pb.DeleteWorkspaceLinksResponse{Deleted: deleted}, nil
+}
+
+func workspaceToProto(membership workspace.Membership) *pb.Workspace {
+	return &pb.Workspace{
+		Id:         membership.Workspace.ID,
+		Name:       membership.Workspace.Name,
+		Role:       string(membership.Role),
+		CreatorId:  membership.Workspace.CreatedBy.String(),
+		CreateTime: timestamppb.New(membership.Workspace.CreatedAt),
+	}
+}
+
+func memberToProto(member entity.WorkspaceMember) *pb.WorkspaceMember {
+	return &pb.WorkspaceMember{
+		WorkspaceId: member.WorkspaceID,
+		UserId:      member.UserID.String(),
+		Role:        string(member.Role),
+		JoinTime:    timestamppb.New(member.CreatedAt),
+	}
+}
@@ -0,0 +1,82 @@
+package grpc
+
+import (
+	"net/http"
+	"strings"
+
+	connectcors "connectrpc.com/cors"
+	"connectrpc.com/vanguard/vanguardgrpc"
+	"github.com/rs/cors"
+	"google.golang.org/grpc"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	grpc_context "github.com/avGenie/url-shortener/internal/app/grpc/usecase/context"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+)
+
+const corsMaxAge = 7200
+
+// newWebHandler Creates HTTP handler serving services of GRPC server over gRPC-Web and Connect protocols
+//
+// Calls are transcoded to GRPC server in-process, so they pass the same interceptors as GRPC calls.
+// Cross-origin calls are allowed only from origins of config, only same origin is allowed if there are none
+func newWebHandler(config config.Config, server *grpc.Server) (http.Handler, error) {
+	transcoder, err := vanguardgrpc.NewTranscoder(server)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := webMetadataMiddleware(transcoder)
+
+	origins := splitOrigins(config.GRPCWebOrigins)
+	if len(origins) == 0 {
+		return handler, nil
+	}
+
+	return cors.New(cors.Options{
+		AllowedOrigins:   origins,
+		AllowedMethods:   connectcors.AllowedMethods(),
+		AllowedHeaders:   append(connectcors.AllowedHeaders(), logger.RequestIDHeader),
+		ExposedHeaders:   append(connectcors.ExposedHeaders(), logger.RequestIDHeader),
+		AllowCredentials: true,
+		MaxAge:           corsMaxAge,
+	}).Handler(handler), nil
+}
+
+// webMetadataMiddleware Passes user id from authentication cookie, request ID and client IP to GRPC metadata
+//
+// User id and IP address sent by client in headers are removed, so they can't override values of server
+func webMetadataMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for key := range r.Header {
+			if strings.EqualFold(key, grpc_context.UserIDMetadataKey) {
+				delete(r.Header, key)
+			}
+		}
+
+		r.Header.Set(cidr.RealIPHeader, requestIP(r))
+
+		if userIDCtx, ok := r.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx); ok && userIDCtx.UserID.IsValid() {
+			r.Header.Set(grpc_context.UserIDMetadataKey, userIDCtx.UserID.String())
+		}
+
+		if requestID := logger.RequestIDFromContext(r.Context()); requestID != "" {
+			r.Header.Set(logger.RequestIDHeader, requestID)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func splitOrigins(origins string) []string {
+	var res []string
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			res = append(res, origin)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,161 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+	pbv2 "github.com/avGenie/url-shortener/proto/v2"
+)
+
+const testOrigin = "http://dashboard.local"
+
+func newTestWebServer(t *testing.T, userID entity.UserID) *httptest.Server {
+	g := newTestGateway(t)
+
+	web, err := newWebHandler(config.Config{GRPCWebOrigins: testOrigin}, g.server)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if userID != "" {
+			r = r.WithContext(context.WithValue(r.Context(), entity.UserIDCtxKey{}, entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			}))
+		}
+
+		web.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestWebHandlerProtocols(t *testing.T) {
+	const userID = entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+
+	server := newTestWebServer(t, userID)
+
+	tests := []struct {
+		name string
+		opts []connect.ClientOption
+	}{
+		{
+			name: "grpc-web",
+			opts: []connect.ClientOption{connect.WithGRPCWeb()},
+		},
+		{
+			name: "connect",
+		},
+		{
+			name: "connect json",
+			opts: []connect.ClientOption{connect.WithProtoJSON()},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			createLink := connect.NewClient[pbv2.CreateLinkRequest, pbv2.Link](
+				server.Client(),
+				server.URL+"/shortener.v2.Shortener/CreateLink",
+				test.opts...,
+			)
+			getLink := connect.NewClient[pbv2.GetLinkRequest, pbv2.Link](
+				server.Client(),
+				server.URL+"/shortener.v2.Shortener/GetLink",
+				test.opts...,
+			)
+
+			originalURL := "https://practicum.yandex.ru/" + test.name
+
+			created, err := createLink.CallUnary(context.Background(), connect.NewRequest(&pbv2.CreateLinkRequest{
+				OriginalUrl: originalURL,
+			}))
+			require.NoError(t, err)
+
+			found, err := getLink.CallUnary(context.Background(), connect.NewRequest(&pbv2.GetLinkRequest{
+				ShortUrl: created.Msg.GetAlias(),
+			}))
+			require.NoError(t, err)
+			assert.Equal(t, originalURL, found.Msg.GetOriginalUrl())
+
+			_, err = createLink.CallUnary(context.Background(), connect.NewRequest(&pbv2.CreateLinkRequest{}))
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+		})
+	}
+}
+
+func TestWebHandlerIgnoresUserIDHeader(t *testing.T) {
+	server := newTestWebServer(t, "")
+
+	listLinks := connect.NewClient[pbv2.ListLinksRequest, pbv2.ListLinksResponse](
+		server.Client(),
+		server.URL+"/shortener.v2.Shortener/ListLinks",
+		connect.WithGRPCWeb(),
+	)
+
+	request := connect.NewRequest(&pbv2.ListLinksRequest{})
+	request.Header().Set("user_id", "ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+
+	_, err := listLinks.CallUnary(context.Background(), request)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+}
+
+func TestWebHandlerIgnoresRealIPHeader(t *testing.T) {
+	server := newTestWebServer(t, "")
+
+	getStatistic := connect.NewClient[pbv2.GetStatisticRequest, pbv2.Statistic](
+		server.Client(),
+		server.URL+"/shortener.v2.Shortener/GetStatistic",
+	)
+
+	request := connect.NewRequest(&pbv2.GetStatisticRequest{})
+	request.Header().Set(cidr.RealIPHeader, "192.168.1.10")
+
+	_, err := getStatistic.CallUnary(context.Background(), request)
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func TestWebHandlerCORS(t *testing.T) {
+	server := newTestWebServer(t, "")
+
+	tests := []struct {
+		name          string
+		origin        string
+		expectedAllow string
+	}{
+		{
+			name:          "allowed origin",
+			origin:        testOrigin,
+			expectedAllow: testOrigin,
+		},
+		{
+			name:   "unknown origin",
+			origin: "http://evil.local",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request, err := http.NewRequest(http.MethodOptions, server.URL+"/shortener.v2.Shortener/CreateLink", nil)
+			require.NoError(t, err)
+			request.Header.Set("Origin", test.origin)
+			request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			request.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+
+			res, err := server.Client().Do(request)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			assert.Equal(t, test.expectedAllow, res.Header.Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Package handlers implements HTTP API of data subject requests of user
+//
+// User can download all data kept about it and erase it from service
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/usecase/account"
+)
+
+// timeout Timeout of export and erasure, they read or rewrite all data of user
+const timeout = 30 * time.Second
+
+// AccountManager Use cases of data of user
+type AccountManager interface {
+	Export(ctx context.Context, userID entity.UserID) (models.UserExport, error)
+	Erase(ctx context.Context, userID entity.UserID) (models.UserErasure, error)
+}
+
+// ExportHandler Processes GET "/api/user/export" endpoint. Sends JSON bundle of all data kept about user as attachment
+//
+// Activity contains actions of user and actions of other actors targeting user,
+// actions of other actors on links of user aren't exported
+//
+// Returns 200(StatusOK) if processing was successful
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 500(StatusInternalServerError) when storage request errors
+func ExportHandler(manager AccountManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		export, err := manager.Export(ctx, userID)
+		if err != nil {
+			logger.FromContext(req.Context()).Error("error while exporting user data", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		attachment := fmt.Sprintf(`attachment; filename="user-data-%s.json"`, export.ExportedAt.Format("20060102T150405Z"))
+		writer.Header().Set("Content-Disposition", attachment)
+		writeJSON(req.Context(), writer, export)
+	}
+}
+
+// EraseHandler Processes DELETE "/api/user" endpoint. Erases all data of user and sends counts of erased records
+//
+// Cookie of user ID is expired, so client gets new user ID with the next request.
+// Links created by user in workspaces with other owners are transferred to the earliest joined owner.
+// Erasure is idempotent, repeated request returns zero counts.
+// Returns 200(StatusOK) if processing was successful
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 409(StatusConflict) if user is the only owner of workspace with other members
+// Returns 500(StatusInternalServerError) when storage request errors
+func EraseHandler(manager AccountManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		erasure, err := manager.Erase(ctx, userID)
+		if errors.Is(err, account.ErrSoleOwner) {
+			http.Error(writer, err.Error(), http.StatusConflict)
+			return
+		}
+		if err != nil {
+			logger.FromContext(req.Context()).Error("error while erasing user data", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		http.SetCookie(writer, &http.Cookie{
+			Name:   entity.UserIDKey,
+			Path:   "/",
+			MaxAge: -1,
+		})
+		writeJSON(req.Context(), writer, erasure)
+	}
+}
+
+func userIDFromRequest(writer http.ResponseWriter, req *http.Request) (entity.UserID, bool) {
+	userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
+	if !ok {
+		logger.FromContext(req.Context()).Error("user id couldn't obtain from context while user data processing")
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return "", false
+	}
+
+	if userIDCtx.StatusCode == http.StatusUnauthorized {
+		logger.FromContext(req.Context()).Error("user id couldn't obtain from context")
+		writer.WriteHeader(http.StatusUnauthorized)
+
+		return "", false
+	}
+
+	if len(userIDCtx.UserID.String()) == 0 {
+		logger.FromContext(req.Context()).Error("empty user id from context")
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return "", false
+	}
+
+	return userIDCtx.UserID, true
+}
+
+func writeJSON(ctx context.Context, writer http.ResponseWriter, value any) {
+	out, err := json.Marshal(value)
+	if err != nil {
+		logger.FromContext(ctx).Error("error while converting user data response to output", zap.Error(err))
+
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(http.StatusOK)
+	writer.Write(out)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/handlers/account/mock"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/usecase/account"
+)
+
+func TestHandlers(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	exportedAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	export := models.UserExport{
+		UserID:     userID.String(),
+		ExportedAt: exportedAt,
+		Links: []models.ExportedLink{
+			{
+				ShortURL:    "http://localhost:8080/abc",
+				Alias:       "abc",
+				OriginalURL: "https://example.com",
+				CreatedAt:   exportedAt,
+			},
+		},
+	}
+
+	type want struct {
+		statusCode  int
+		body        string
+		disposition string
+		expired     bool
+	}
+	tests := []struct {
+		name      string
+		method    string
+		userIDCtx entity.UserIDCtx
+		prepare   func(s *mock.MockAccountManager)
+		want      want
+	}{
+		{
+			name:   "export user data",
+			method: http.MethodGet,
+			prepare: func(s *mock.MockAccountManager) {
+				s.EXPECT().Export(gomock.Any(), userID).Return(export, nil)
+			},
+			want: want{
+				statusCode:  http.StatusOK,
+				body:        `"original_url":"https://example.com"`,
+				disposition: `attachment; filename="user-data-20261019T120000Z.json"`,
+			},
+		},
+		{
+			name:   "export with storage error",
+			method: http.MethodGet,
+			prepare: func(s *mock.MockAccountManager) {
+				s.EXPECT().Export(gomock.Any(), userID).Return(models.UserExport{}, errors.New("storage error"))
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name:      "export of unauthorized user",
+			method:    http.MethodGet,
+			userIDCtx: entity.UserIDCtx{StatusCode: http.StatusUnauthorized},
+			prepare:   func(s *mock.MockAccountManager) {},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+		{
+			name:   "erase user data",
+			method: http.MethodDelete,
+			prepare: func(s *mock.MockAccountManager) {
+				s.EXPECT().Erase(gomock.Any(), userID).Return(models.UserErasure{Links: 2, AuditRecords: 3}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `"links":2`,
+				expired:    true,
+			},
+		},
+		{
+			name:   "erase with storage error",
+			method: http.MethodDelete,
+			prepare: func(s *mock.MockAccountManager) {
+				s.EXPECT().Erase(gomock.Any(), userID).Return(models.UserErasure{}, errors.New("storage error"))
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name:   "erase of the only workspace owner",
+			method: http.MethodDelete,
+			prepare: func(s *mock.MockAccountManager) {
+				s.EXPECT().Erase(gomock.Any(), userID).Return(models.UserErasure{}, fmt.Errorf("error while erasing user: %w", account.ErrSoleOwner))
+			},
+			want: want{
+				statusCode: http.StatusConflict,
+				body:       account.ErrSoleOwner.Error(),
+			},
+		},
+		{
+			name:      "erase of unauthorized user",
+			method:    http.MethodDelete,
+			userIDCtx: entity.UserIDCtx{StatusCode: http.StatusUnauthorized},
+			prepare:   func(s *mock.MockAccountManager) {},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mock.NewMockAccountManager(ctrl)
+			test.prepare(s)
+
+			userIDCtx := test.userIDCtx
+			if userIDCtx.StatusCode == 0 {
+				userIDCtx = entity.UserIDCtx{UserID: userID, StatusCode: http.StatusOK}
+			}
+
+			handler := ExportHandler(s)
+			if test.method == http.MethodDelete {
+				handler = EraseHandler(s)
+			}
+
+			request := httptest.NewRequest(test.method, "/", nil)
+			request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, userIDCtx))
+			writer := httptest.NewRecorder()
+
+			handler(writer, request)
+
+			res := writer.Result()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			assert.Contains(t, string(body), test.want.body)
+			assert.Equal(t, test.want.disposition, res.Header.Get("Content-Disposition"))
+
+			expired := false
+			for _, cookie := range res.Cookies() {
+				if cookie.Name == entity.UserIDKey && cookie.MaxAge < 0 {
+					expired = true
+				}
+			}
+			assert.Equal(t, test.want.expired, expired)
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Package handlers implements HTTP admin API
+//
+// Requests are authenticated by admin tokens before handlers are called
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/audit"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	delete_handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	usecase "github.com/avGenie/url-shortener/internal/app/usecase/admin"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+const timeout = 3 * time.Second
+
+// Administrator Use cases of service administration
+type Administrator interface {
+	ShortURL(link entity.Link) string
+	GetLink(ctx context.Context, shortURL string) (entity.Link, error)
+	SetLinkDisabled(ctx context.Context, shortURL string, disabled bool) (entity.Link, error)
+	DeleteUserLinks(ctx context.Context, userID entity.UserID) (models.AdminDeletion, error)
+	FlushDeleteQueue(ctx context.Context) (models.AdminFlush, error)
+	DeleteQueueStats(ctx context.Context) models.DeleteQueueStats
+	CompactStorage(ctx context.Context) (models.CompactionResult, error)
+	LogLevel(ctx context.Context) models.LogLevel
+	SetLogLevel(ctx context.Context, level string) (models.LogLevel, error)
+	ListAuditRecords(ctx context.Context, filter entity.AuditFilter, pageSize int, pageToken string) ([]entity.AuditRecord, string, error)
+}
+
+// Routes Returns router of admin API
+//
+// GET /links/{url} - returns link of any user
+// POST /links/{url}/disable, POST /links/{url}/enable - changes disabled state of link
+// DELETE /users/{user_id}/links - deletes all links of user in background
+// POST /delete-queue/flush - flushes queued deleted URLs to storage
+// GET /delete-queue - returns state of queue of deletion jobs and its workers
+// POST /storage/compact - rewrites storage data without history of changes
+// GET /log-level, PUT /log-level - returns or changes log level
+// GET /audit - returns page of audit trail filtered by query parameters
+func Routes(admin Administrator) http.Handler {
+	r := chi.NewRouter()
+
+	r.Get("/links/{url}", GetLinkHandler(admin))
+	r.Post("/links/{url}/disable", SetLinkDisabledHandler(admin, true))
+	r.Post("/links/{url}/enable", SetLinkDisabledHandler(admin, false))
+	r.Delete("/users/{user_id}/links", DeleteUserLinksHandler(admin))
+	r.Post("/delete-queue/flush", FlushDeleteQueueHandler(admin))
+	r.Get("/delete-queue", GetDeleteQueueStatsHandler(admin))
+	r.Post("/storage/compact", CompactStorageHandler(admin))
+	r.Get("/log-level", GetLogLevelHandler(admin))
+	r.Put("/log-level", SetLogLevelHandler(admin))
+	r.Get("/audit", ListAuditRecordsHandler(admin))
+
+	return r
+}
+
+// GetLinkHandler Returns link of any user with its owner and states
+//
+// Returns 200(StatusOk) if processing was successful
+// Returns 404(StatusNotFound) if link is not found
+// Returns 500(StatusInternalServerError) when storage request errors
+func GetLinkHandler(admin Administrator) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		found, err := admin.GetLink(ctx, chi.URLParam(req, "url"))
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, linkToModel(admin, found))
+	}
+}
+
+// SetLinkDisabledHandler Disables link of any user globally or enables it again
+//
+// Returns 200(StatusOk) with changed link if processing was successful
+// Returns 404(StatusNotFound) if link is not found
+// Returns 500(StatusInternalServerError) when storage request errors
+func SetLinkDisabledHandler(admin Administrator, disabled bool) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		changed, err := admin.SetLinkDisabled(ctx, chi.URLParam(req, "url"), disabled)
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, linkToModel(admin, changed))
+	}
+}
+
+// DeleteUserLinksHandler Deletes all links of user in background
+//
+// Returns 202(StatusAccepted) with deletion job if processing was successful
+// Returns 400(StatusBadRequest) if user id is invalid
+// Returns 503(StatusServiceUnavailable) with Retry-After header if queue of deletion jobs is full
+// Returns 500(StatusInternalServerError) when storage request errors
+func DeleteUserLinksHandler(admin Administrator) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		deletion, err := admin.DeleteUserLinks(ctx, entity.UserID(chi.URLParam(req, "user_id")))
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusAccepted, deletion)
+	}
+}
+
+// FlushDeleteQueueHandler Flushes queued deleted URLs to storage
+//
+// Returns 200(StatusOk) with count of flushed URLs if processing was successful
+// Returns 503(StatusServiceUnavailable) if flusher is stopped
+// Returns 500(StatusInternalServerError) when storage request errors
+func FlushDeleteQueueHandler(admin Administrator) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		flush, err := admin.FlushDeleteQueue(ctx)
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, flush)
+	}
+}
+
+// GetDeleteQueueStatsHandler Returns state of queue of deletion jobs and its workers
+//
+// Returns 200(StatusOk)
+func GetDeleteQueueStatsHandler(admin Administrator) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		writeJSON(req.Context(), writer, http.StatusOK, admin.DeleteQueueStats(req.Context()))
+	}
+}
+
+// CompactStorageHandler Rewrites storage data without history of changes
+//
+// Returns 200(StatusOk) with sizes of storage if processing was successful
+// Returns 501(StatusNotImplemented) if storage doesn't support compaction
+// Returns 500(StatusInternalServerError) when storage errors
+func CompactStorageHandler(admin Administrator) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		res, err := admin.CompactStorage(req.Context())
+		if err != nil {
+			writeError(req.Context(), writer, err)
+
+			return
+		}
+
+		writeJSON(req.Context(), writer, http.StatusOK, res)
+	}
+}
+
+// GetLogLevelHandler Returns current log level
+//
+// Returns 200(StatusOk)
+func GetLogLevelHandler(admin Administrator) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		writeJSON(req.Context(), writer, http.StatusOK, admin.LogLevel(req.Context()))
+	}
+}
+
+// SetLogLevelHandler Changes log level at runtime from JSON body {"level": "..."}
+//
+// Returns 200(StatusOk) with new log level if processing was successful
+// Returns 400(StatusBadRequest) if body couldn't be parsed or log level is unknown
+func SetLogLevelHandler(admin Administrator) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		var level models.LogLevel
+		if err := json.NewDecoder(req.Body).Decode(&level); err != nil {
+			http.Error(writer, "wrong JSON format", http.StatusBadRequest)
+
+			return
+		}
+
+		changed, err := admin.SetLogLevel(req.Context(), level.Level)
+		if err != nil {
+			writeError(req.Context(), writer, err)
+
+			return
+		}
+
+		writeJSON(req.Context(), writer, http.StatusOK, changed)
+	}
+}
+
+// ListAuditRecordsHandler Returns page of audit trail, the newest records first
+//
+// Records are filtered by query parameters actor_type, actor, action, target, from and to in RFC 3339 format,
+// page is selected by query parameters page_size and page_token.
+// Returns 200(StatusOk) if processing was successful
+// Returns 400(StatusBadRequest) if period, page size or page token is invalid
+// Returns 500(StatusInternalServerError) when storage request errors
+func ListAuditRecordsHandler(admin Administrator) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		query, err := audit.ParseQuery(req.URL.Query())
+		if err != nil {
+			writeError(req.Context(), writer, err)
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		records, next, err := admin.ListAuditRecords(ctx, query.Filter, query.PageSize, query.PageToken)
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, audit.PageToModel(records, next))
+	}
+}
+
+func linkToModel(admin Administrator, link entity.Link) models.AdminLink {
+	return models.AdminLink{
+		ShortURL:    admin.ShortURL(link),
+		Alias:       link.ShortURL,
+		OriginalURL: link.OriginalURL,
+		UserID:      link.UserID.String(),
+		CreatedAt:   link.CreatedAt,
+		Deleted:     link.Deleted,
+		Disabled:    link.Disabled,
+		Metadata:    link.Metadata,
+	}
+}
+
+func writeJSON(ctx context.Context, writer http.ResponseWriter, statusCode int, value any) {
+	out, err := json.Marshal(value)
+	if err != nil {
+		logger.FromContext(ctx).Error("error while converting admin response to output", zap.Error(err))
+
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	writer.Write(out)
+}
+
+func writeError(ctx context.Context, writer http.ResponseWriter, err error) {
+	var fieldErr *link.FieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		http.Error(writer, fieldErr.Error(), http.StatusBadRequest)
+	case errors.Is(err, link.ErrLinkNotFound):
+		http.Error(writer, "link is not found", http.StatusNotFound)
+	case errors.Is(err, usecase.ErrCompactionNotSupported):
+		http.Error(writer, err.Error(), http.StatusNotImplemented)
+	case errors.Is(err, delete_handlers.ErrFlusherStopped):
+		http.Error(writer, err.Error(), http.StatusServiceUnavailable)
+	case errors.Is(err, delete_handlers.ErrQueueFull):
+		writer.Header().Set("Retry-After", strconv.Itoa(int(delete_handlers.RetryAfter.Seconds())))
+		http.Error(writer, delete_handlers.ErrQueueFull.Error(), http.StatusServiceUnavailable)
+	default:
+		logger.FromContext(ctx).Error("error while processing admin request", zap.Error(err))
+
+		http.Error(writer, "internal server error", http.StatusInternalServerError)
+	}
+}
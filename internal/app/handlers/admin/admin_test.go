@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/handlers/admin/mock"
+	delete_handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	usecase "github.com/avGenie/url-shortener/internal/app/usecase/admin"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+func TestRoutes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := mock.NewMockAdministrator(ctrl)
+
+	found := entity.Link{
+		ShortURL:    "aHR0cHM6",
+		OriginalURL: "https://practicum.yandex.ru/",
+		UserID:      "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+	}
+	disabled := found
+	disabled.Disabled = true
+
+	s.EXPECT().ShortURL(gomock.Any()).Return("http://localhost:8080/aHR0cHM6").AnyTimes()
+
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		body    string
+		prepare func()
+		want    want
+	}{
+		{
+			name:   "get link",
+			method: http.MethodGet,
+			target: "/links/aHR0cHM6",
+			prepare: func() {
+				s.EXPECT().GetLink(gomock.Any(), "aHR0cHM6").Return(found, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `"alias":"aHR0cHM6"`,
+			},
+		},
+		{
+			name:   "link not found",
+			method: http.MethodGet,
+			target: "/links/unknown",
+			prepare: func() {
+				s.EXPECT().GetLink(gomock.Any(), "unknown").Return(entity.Link{}, link.ErrLinkNotFound)
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name:   "disable link",
+			method: http.MethodPost,
+			target: "/links/aHR0cHM6/disable",
+			prepare: func() {
+				s.EXPECT().SetLinkDisabled(gomock.Any(), "aHR0cHM6", true).Return(disabled, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `"disabled":true`,
+			},
+		},
+		{
+			name:   "enable link",
+			method: http.MethodPost,
+			target: "/links/aHR0cHM6/enable",
+			prepare: func() {
+				s.EXPECT().SetLinkDisabled(gomock.Any(), "aHR0cHM6", false).Return(found, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `"disabled":false`,
+			},
+		},
+		{
+			name:   "delete user links",
+			method: http.MethodDelete,
+			target: "/users/ac2a4811-4f10-487f-bde3-e39a14af7cd8/links",
+			prepare: func() {
+				s.EXPECT().DeleteUserLinks(gomock.Any(), entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")).
+					Return(models.AdminDeletion{JobID: "job", Count: 2}, nil)
+			},
+			want: want{
+				statusCode: http.StatusAccepted,
+				body:       `{"job_id":"job","count":2}`,
+			},
+		},
+		{
+			name:   "delete user links with full queue",
+			method: http.MethodDelete,
+			target: "/users/ac2a4811-4f10-487f-bde3-e39a14af7cd8/links",
+			prepare: func() {
+				s.EXPECT().DeleteUserLinks(gomock.Any(), entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")).
+					Return(models.AdminDeletion{}, delete_handlers.ErrQueueFull)
+			},
+			want: want{
+				statusCode: http.StatusServiceUnavailable,
+				body:       "delete queue is full",
+			},
+		},
+		{
+			name:   "get delete queue stats",
+			method: http.MethodGet,
+			target: "/delete-queue",
+			prepare: func() {
+				s.EXPECT().DeleteQueueStats(gomock.Any()).Return(models.DeleteQueueStats{QueuedJobs: 2, Capacity: 10, Workers: 4})
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `"queued_jobs":2,"queued_urls":0,"capacity":10`,
+			},
+		},
+		{
+			name:   "flush stopped delete queue",
+			method: http.MethodPost,
+			target: "/delete-queue/flush",
+			prepare: func() {
+				s.EXPECT().FlushDeleteQueue(gomock.Any()).Return(models.AdminFlush{}, delete_handlers.ErrFlusherStopped)
+			},
+			want: want{
+				statusCode: http.StatusServiceUnavailable,
+			},
+		},
+		{
+			name:   "compaction not supported",
+			method: http.MethodPost,
+			target: "/storage/compact",
+			prepare: func() {
+				s.EXPECT().CompactStorage(gomock.Any()).Return(models.CompactionResult{}, usecase.ErrCompactionNotSupported)
+			},
+			want: want{
+				statusCode: http.StatusNotImplemented,
+			},
+		},
+		{
+			name:   "compaction error",
+			method: http.MethodPost,
+			target: "/storage/compact",
+			prepare: func() {
+				s.EXPECT().CompactStorage(gomock.Any()).Return(models.CompactionResult{}, errors.New("disk is full"))
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body:       "internal server error",
+			},
+		},
+		{
+			name:   "get log level",
+			method: http.MethodGet,
+			target: "/log-level",
+			prepare: func() {
+				s.EXPECT().LogLevel(gomock.Any()).Return(models.LogLevel{Level: "info"})
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `{"level":"info"}`,
+			},
+		},
+		{
+			name:   "set log level",
+			method: http.MethodPut,
+			target: "/log-level",
+			body:   `{"level":"debug"}`,
+			prepare: func() {
+				s.EXPECT().SetLogLevel(gomock.Any(), "debug").Return(models.LogLevel{Level: "debug"}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `{"level":"debug"}`,
+			},
+		},
+		{
+			name:   "set invalid log level",
+			method: http.MethodPut,
+			target: "/log-level",
+			body:   `{"level":"loud"}`,
+			prepare: func() {
+				s.EXPECT().SetLogLevel(gomock.Any(), "loud").
+					Return(models.LogLevel{}, &link.FieldError{Field: "level", Err: usecase.ErrInvalidLogLevel})
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body:       "level: invalid log level",
+			},
+		},
+		{
+			name:    "set log level with wrong json",
+			method:  http.MethodPut,
+			target:  "/log-level",
+			body:    `<invalid json>`,
+			prepare: func() {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:   "list audit records",
+			method: http.MethodGet,
+			target: "/audit?actor=alice&action=admin.link.disable&from=2026-10-19T00:00:00Z&page_size=1",
+			prepare: func() {
+				filter := entity.AuditFilter{
+					Actor:  "alice",
+					Action: "admin.link.disable",
+					From:   time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+				}
+				s.EXPECT().ListAuditRecords(gomock.Any(), filter, 1, "").Return([]entity.AuditRecord{
+					{
+						ID:        "record",
+						ActorType: entity.AuditActorAdmin,
+						Actor:     "alice",
+						Action:    "admin.link.disable",
+						Targets:   []string{"aHR0cHM6"},
+						Result:    "success",
+						CreatedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+					},
+				}, "next", nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `"targets":["aHR0cHM6"],"result":"success","created_at":"2026-10-19T12:00:00Z"}],"next_page_token":"next"`,
+			},
+		},
+		{
+			name:    "list audit records with invalid period",
+			method:  http.MethodGet,
+			target:  "/audit?from=yesterday",
+			prepare: func() {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body:       "from: must be time in RFC 3339 format",
+			},
+		},
+		{
+			name:   "list audit records with invalid page token",
+			method: http.MethodGet,
+			target: "/audit?page_token=broken",
+			prepare: func() {
+				s.EXPECT().ListAuditRecords(gomock.Any(), entity.AuditFilter{}, 0, "broken").
+					Return(nil, "", &link.FieldError{Field: "page_token", Err: link.ErrInvalidPageToken})
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body:       "page_token",
+			},
+		},
+	}
+
+	router := Routes(s)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.prepare()
+
+			request := httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+			writer := httptest.NewRecorder()
+
+			router.ServeHTTP(writer, request)
+
+			res := writer.Result()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			assert.Contains(t, string(body), test.want.body)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: admin.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/avGenie/url-shortener/internal/app/entity"
+	models "github.com/avGenie/url-shortener/internal/app/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAdministrator is a mock of Administrator interface.
+type MockAdministrator struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdministratorMockRecorder
+}
+
+// MockAdministratorMockRecorder is the mock recorder for MockAdministrator.
+type MockAdministratorMockRecorder struct {
+	mock *MockAdministrator
+}
+
+// NewMockAdministrator creates a new mock instance.
+func NewMockAdministrator(ctrl *gomock.Controller) *MockAdministrator {
+	mock := &MockAdministrator{ctrl: ctrl}
+	mock.recorder = &MockAdministratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdministrator) EXPECT() *MockAdministratorMockRecorder {
+	return m.recorder
+}
+
+// CompactStorage mocks base method.
+func (m *MockAdministrator) CompactStorage(ctx context.Context) (models.CompactionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompactStorage", ctx)
+	ret0, _ := ret[0].(models.CompactionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompactStorage indicates an expected call of CompactStorage.
+func (mr *MockAdministratorMockRecorder) CompactStorage(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompactStorage", reflect.TypeOf((*MockAdministrator)(nil).CompactStorage), ctx)
+}
+
+// DeleteQueueStats mocks base method.
+func (m *MockAdministrator) DeleteQueueStats(ctx context.Context) models.DeleteQueueStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteQueueStats", ctx)
+	ret0, _ := ret[0].(models.DeleteQueueStats)
+	return ret0
+}
+
+// DeleteQueueStats indicates an expected call of DeleteQueueStats.
+func (mr *MockAdministratorMockRecorder) DeleteQueueStats(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteQueueStats", reflect.TypeOf((*MockAdministrator)(nil).DeleteQueueStats), ctx)
+}
+
+// DeleteUserLinks mocks base method.
+func (m *MockAdministrator) DeleteUserLinks(ctx context.Context, userID entity.UserID) (models.AdminDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserLinks", ctx, userID)
+	ret0, _ := ret[0].(models.AdminDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUserLinks indicates an expected call of DeleteUserLinks.
+func (mr *MockAdministratorMockRecorder) DeleteUserLinks(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserLinks", reflect.TypeOf((*MockAdministrator)(nil).DeleteUserLinks), ctx, userID)
+}
+
+// FlushDeleteQueue mocks base method.
+func (m *MockAdministrator) FlushDeleteQueue(ctx context.Context) (models.AdminFlush, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushDeleteQueue", ctx)
+	ret0, _ := ret[0].(models.AdminFlush)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlushDeleteQueue indicates an expected call of FlushDeleteQueue.
+func (mr *MockAdministratorMockRecorder) FlushDeleteQueue(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushDeleteQueue", reflect.TypeOf((*MockAdministrator)(nil).FlushDeleteQueue), ctx)
+}
+
+// GetLink mocks base method.
+func (m *MockAdministrator) GetLink(ctx context.Context, shortURL string) (entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLink", ctx, shortURL)
+	ret0, _ := ret[0].(entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLink indicates an expected call of GetLink.
+func (mr *MockAdministratorMockRecorder) GetLink(ctx, shortURL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLink", reflect.TypeOf((*MockAdministrator)(nil).GetLink), ctx, shortURL)
+}
+
+// ListAuditRecords mocks base method.
+func (m *MockAdministrator) ListAuditRecords(ctx context.Context, filter entity.AuditFilter, pageSize int, pageToken string) ([]entity.AuditRecord, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditRecords", ctx, filter, pageSize, pageToken)
+	ret0, _ := ret[0].([]entity.AuditRecord)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAuditRecords indicates an expected call of ListAuditRecords.
+func (mr *MockAdministratorMockRecorder) ListAuditRecords(ctx, filter, pageSize, pageToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditRecords", reflect.TypeOf((*MockAdministrator)(nil).ListAuditRecords), ctx, filter, pageSize, pageToken)
+}
+
+// LogLevel mocks base method.
+func (m *MockAdministrator) LogLevel(ctx context.Context) models.LogLevel {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogLevel", ctx)
+	ret0, _ := ret[0].(models.LogLevel)
+	return ret0
+}
+
+// LogLevel indicates an expected call of LogLevel.
+func (mr *MockAdministratorMockRecorder) LogLevel(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogLevel", reflect.TypeOf((*MockAdministrator)(nil).LogLevel), ctx)
+}
+
+// SetLinkDisabled mocks base method.
+func (m *MockAdministrator) SetLinkDisabled(ctx context.Context, shortURL string, disabled bool) (entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLinkDisabled", ctx, shortURL, disabled)
+	ret0, _ := ret[0].(entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLinkDisabled indicates an expected call of SetLinkDisabled.
+func (mr *MockAdministratorMockRecorder) SetLinkDisabled(ctx, shortURL, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLinkDisabled", reflect.TypeOf((*MockAdministrator)(nil).SetLinkDisabled), ctx, shortURL, disabled)
+}
+
+// SetLogLevel mocks base method.
+func (m *MockAdministrator) SetLogLevel(ctx context.Context, level string) (models.LogLevel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLogLevel", ctx, level)
+	ret0, _ := ret[0].(models.LogLevel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLogLevel indicates an expected call of SetLogLevel.
+func (mr *MockAdministratorMockRecorder) SetLogLevel(ctx, level interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogLevel", reflect.TypeOf((*MockAdministrator)(nil).SetLogLevel), ctx, level)
+}
+
+// ShortURL mocks base method.
+func (m *MockAdministrator) ShortURL(link entity.Link) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShortURL", link)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ShortURL indicates an expected call of ShortURL.
+func (mr *MockAdministratorMockRecorder) ShortURL(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortURL", reflect.TypeOf((*MockAdministrator)(nil).ShortURL), link)
+}
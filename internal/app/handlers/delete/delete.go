@@ -5,12 +5,25 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/avGenie/url-shortener/internal/app/audit"
+	"github.com/avGenie/url-shortener/internal/app/config"
 	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/metrics"
 	"github.com/avGenie/url-shortener/internal/app/models"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/tracing"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -20,57 +33,145 @@ const (
 	tickerTime  = 5 * time.Second
 	contextTime = 3 * time.Second
 	stopTimeout = 5 * time.Second
+
+	// maxAttempts Count of failed attempts after which deletion job is moved to dead letters
+	maxAttempts   = 10
+	maxRetryDelay = 10 * time.Minute
+
+	// claimLease Time for which due job is claimed by instance, job is claimed again if it isn't completed until then
+	claimLease = time.Minute
+)
+
+// JobsPath Path of deletion jobs endpoint, job ID is appended to it
+const JobsPath = "/api/user/jobs/"
+
+// RetryAfter Time after which client may retry deletion rejected because queue is full
+const RetryAfter = tickerTime
+
+// Errors returning by delete handler
+//
+// ErrFlusherStopped - flusher of deleted URLs is stopped, it is also returned by health check
+// ErrQueueFull - queue of deletion jobs or part of queue of user is full, deletion should be retried later
+var (
+	ErrFlusherStopped = errors.New("flusher of deleted urls is stopped")
+	ErrQueueFull      = errors.New("delete queue is full")
 )
 
 // AllURLDeleter Storage interface for delete handler
+//
+// Deletion jobs are kept in outbox of storage until their URLs are deleted,
+// so they are retried after failures and restarts.
+// Due jobs are claimed with lease, so instances sharing storage don't process the same jobs.
+// States of jobs are updated only while jobs are kept in outbox, so erased jobs aren't restored
 type AllURLDeleter interface {
 	DeleteBatchURL(ctx context.Context, urls entity.DeletedURLBatch) error
+	SaveDeleteJob(ctx context.Context, job entity.DeleteJob) error
+	UpdateDeleteJob(ctx context.Context, job entity.DeleteJob) error
+	ClaimPendingDeleteJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.DeleteJob, error)
 }
 
 // DeleteHandler Endpoints for delete operations
+//
+// Deletion jobs are queued in bounded queue and flushed to the storage by several workers
 type DeleteHandler struct {
 	deleter AllURLDeleter
+	workers int
+
+	wg   *sync.WaitGroup
+	done chan struct{}
+	once sync.Once
+	work chan struct{}
+
+	queueMutex sync.Mutex
+	queue      *jobQueue
+	inFlight   map[string]struct{}
+	busy       int
+	rejected   int64
+
+	mutex    sync.RWMutex
+	flushErr error
+}
 
-	wg      *sync.WaitGroup
-	done    chan struct{}
-	msgChan chan entity.DeletedURLBatch
+// jobBatch Deletion jobs flushed to the storage together
+type jobBatch struct {
+	jobs       []entity.DeleteJob
+	links      []trace.Link
+	requestIDs []string
+	urlsCount  int
+}
+
+// ValidateConfig Validates settings of deletion queue from config
+func ValidateConfig(config config.Config) error {
+	var errs []error
+
+	if config.DeleteQueueSize <= 0 {
+		errs = append(errs, fmt.Errorf("delete_queue_size must be positive, got %d", config.DeleteQueueSize))
+	}
+
+	if config.DeleteQueueUserSize < 0 {
+		errs = append(errs, fmt.Errorf("delete_queue_user_size must not be negative, got %d", config.DeleteQueueUserSize))
+	}
+
+	if config.DeleteWorkers <= 0 {
+		errs = append(errs, fmt.Errorf("delete_workers must be positive, got %d", config.DeleteWorkers))
+	}
+
+	return errors.Join(errs...)
 }
 
 // NewDeleteHandler Creates delete handler using obtained storage
-func NewDeleteHandler(deleter AllURLDeleter) *DeleteHandler {
+//
+// Size of queue, part of queue available to one user and count of workers are taken from config
+func NewDeleteHandler(deleter AllURLDeleter, config config.Config) *DeleteHandler {
 	instance := &DeleteHandler{
-		deleter: deleter,
-		wg:      &sync.WaitGroup{},
-		done:    make(chan struct{}),
-		msgChan: make(chan entity.DeletedURLBatch),
+		deleter:  deleter,
+		workers:  config.DeleteWorkers,
+		wg:       &sync.WaitGroup{},
+		done:     make(chan struct{}),
+		work:     make(chan struct{}, config.DeleteWorkers),
+		queue:    newJobQueue(config.DeleteQueueSize, config.DeleteQueueUserSize),
+		inFlight: make(map[string]struct{}),
 	}
+	metrics.SetDeleteQueueCapacity(config.DeleteQueueSize)
 
 	instance.wg.Add(1)
 	go func() {
 		defer instance.wg.Done()
-		instance.flushDeletedURLs()
+		instance.scheduleDueJobs()
 	}()
 
+	for i := 0; i < config.DeleteWorkers; i++ {
+		instance.wg.Add(1)
+		go func() {
+			defer instance.wg.Done()
+			instance.runWorker()
+		}()
+	}
+
 	return instance
 }
 
 // DeleteUserURLHandler Process endpoint for user deletion
 //
-// Returns 202(StatusAccepted) if deletion was successful
+// Short codes are deleted on domain which received request, full short URLs are deleted on their domains.
+// Returns 202(StatusAccepted) with ID of deletion job if deletion was accepted,
+// state of job is available by URL from Location header
 // Returns 500(StatusInternalServerError) if user id is incorrect
 // Returns 500(StatusInternalServerError) if user id could not be parsed
+// Returns 500(StatusInternalServerError) if deletion job could not be saved
+// Returns 503(StatusServiceUnavailable) with Retry-After header if queue of deletion jobs is full
 // Returns 400(StatusBadRequest) if user id could not be processed for deletion
-func (h *DeleteHandler) DeleteUserURLHandler() http.HandlerFunc {
+func (h *DeleteHandler) DeleteUserURLHandler(domains *domain.Registry) http.HandlerFunc {
 	return func(writer http.ResponseWriter, req *http.Request) {
 		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
 		if !ok {
-			zap.L().Error("user id couldn't obtain from context while all user urls deleting")
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context while all user urls deleting")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
 		if len(userIDCtx.UserID.String()) == 0 {
-			zap.L().Error("empty user id from context while posting all user urls deleting")
+			logger.FromContext(req.Context()).Error("empty user id from context while posting all user urls deleting")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -78,23 +179,105 @@ func (h *DeleteHandler) DeleteUserURLHandler() http.HandlerFunc {
 		var batch models.ReqDeletedURLBatch
 		err := json.NewDecoder(req.Body).Decode(&batch)
 		if err != nil {
-			zap.L().Error("cannot process input user urls for deleting", zap.Error(err))
+			logger.FromContext(req.Context()).Error("cannot process input user urls for deleting", zap.Error(err))
 			writer.WriteHeader(http.StatusBadRequest)
 			return
 		}
 		defer req.Body.Close()
 
-		h.ProcessDeletedURLs(userIDCtx.UserID, batch)
+		for i, shortURL := range batch {
+			batch[i] = domains.HostKey(req.Host, shortURL)
+		}
+
+		jobID, err := h.ProcessDeletedURLs(req.Context(), userIDCtx.UserID, batch)
+		audit.LogUser(req.Context(), userIDCtx.UserID, audit.ActionLinkDelete, batch, err)
+		if errors.Is(err, ErrQueueFull) {
+			logger.FromContext(req.Context()).Info("user urls are rejected for deleting", zap.Error(err))
+			writer.Header().Set("Retry-After", strconv.Itoa(int(RetryAfter.Seconds())))
+			writer.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if err != nil {
+			logger.FromContext(req.Context()).Error("cannot queue user urls for deleting", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		out, err := json.Marshal(models.DeleteJobAccepted{JobID: jobID})
+		if err != nil {
+			logger.FromContext(req.Context()).Error("error while converting delete job to output", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
 
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Header().Set("Location", JobsPath+jobID)
 		writer.WriteHeader(http.StatusAccepted)
+		writer.Write(out)
+	}
+}
+
+// Check Returns error if deleted URLs couldn't be flushed to the storage
+//
+// Flusher is unavailable if it is stopped or the last flush failed
+func (h *DeleteHandler) Check(_ context.Context) error {
+	select {
+	case <-h.done:
+		return ErrFlusherStopped
+	default:
+	}
+
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if h.flushErr != nil {
+		return fmt.Errorf("last flush of deleted urls failed: %w", h.flushErr)
+	}
+
+	return nil
+}
+
+// Flush Flushes queued deleted URLs and due jobs from outbox to the storage without waiting for ticker
+//
+// Returns count of flushed URLs.
+// Returns ErrFlusherStopped error if flusher is stopped
+func (h *DeleteHandler) Flush(ctx context.Context) (int, error) {
+	select {
+	case <-h.done:
+		return 0, ErrFlusherStopped
+	default:
+	}
+
+	h.loadDueJobs(ctx)
+
+	return h.drain()
+}
+
+// Stats Returns state of queue of deletion jobs and its workers
+func (h *DeleteHandler) Stats() models.DeleteQueueStats {
+	h.queueMutex.Lock()
+	defer h.queueMutex.Unlock()
+
+	return models.DeleteQueueStats{
+		QueuedJobs:   h.queue.len(),
+		QueuedURLs:   h.queue.urlsCount,
+		Capacity:     h.queue.size,
+		UserCapacity: h.queue.userSize,
+		InFlightJobs: len(h.inFlight),
+		Workers:      h.workers,
+		BusyWorkers:  h.busy,
+		Rejected:     h.rejected,
 	}
 }
 
 // Stop Stops user deletion process
+//
+// Queued jobs are flushed by workers, jobs which are not flushed before timeout are kept in outbox until restart
 func (h *DeleteHandler) Stop() {
-	sync.OnceFunc(func() {
+	h.once.Do(func() {
+		zap.L().Info("shutting down server; last flushing")
 		close(h.done)
-	})()
+	})
 
 	ready := make(chan bool)
 	go func() {
@@ -113,68 +296,386 @@ func (h *DeleteHandler) Stop() {
 	}
 }
 
-func (h *DeleteHandler) flushDeletedURLs() {
+// scheduleDueJobs Loads due jobs from outbox to free part of queue and wakes up workers by ticker
+func (h *DeleteHandler) scheduleDueJobs() {
 	ticker := time.NewTicker(tickerTime)
-	storageBatch := make([]entity.DeletedURL, 0, flushBufLen)
-
-	flush := func() {
-		ctx, cancel := context.WithTimeout(context.Background(), contextTime)
-		defer cancel()
-
-		zap.L().Debug("flushing deleted urls", zap.Int("urls_count", len(storageBatch)))
-
-		err := h.deleter.DeleteBatchURL(ctx, storageBatch)
-		if err != nil {
-			switch {
-			case errors.Is(err, context.Canceled):
-				zap.L().Error("context canceled while flushing deleted urls", zap.String("error", err.Error()))
-			case errors.Is(err, context.DeadlineExceeded):
-				zap.L().Error("context deadline exceeded while flushing deleted urls", zap.String("error", err.Error()))
-			default:
-				zap.L().Error("error while flushing deleted urls", zap.Error(err))
-			}
+	defer ticker.Stop()
 
+	for {
+		select {
+		case <-h.done:
 			return
+		case <-ticker.C:
+			h.loadDueJobs(context.Background())
+			h.signal(h.workers)
 		}
-
-		storageBatch = storageBatch[:0:flushBufLen]
 	}
+}
 
+// runWorker Flushes queued jobs when it is woken up and flushes the rest of queue on stop
+func (h *DeleteHandler) runWorker() {
 	for {
 		select {
 		case <-h.done:
-			zap.L().Info("shutting down server; last flushing")
-			flush()
+			h.drain()
+			return
+		case <-h.work:
+			h.setBusy(1)
+			h.drain()
+			h.setBusy(-1)
+		}
+	}
+}
+
+// signal Wakes up count of idle workers
+func (h *DeleteHandler) signal(count int) {
+	for i := 0; i < count; i++ {
+		select {
+		case h.work <- struct{}{}:
+		default:
 			return
-		case urls, ok := <-h.msgChan:
-			if !ok {
-				return
-			}
+		}
+	}
+}
 
-			if len(storageBatch)+len(urls) > flushBufLen {
-				flush()
-			}
+func (h *DeleteHandler) setBusy(delta int) {
+	h.queueMutex.Lock()
+	h.busy += delta
+	h.queueMutex.Unlock()
 
-			storageBatch = append(storageBatch, urls...)
-		case <-ticker.C:
-			if len(storageBatch) == 0 {
-				continue
-			}
+	metrics.AddDeleteWorkersBusy(delta)
+}
+
+// loadDueJobs Queues due jobs from outbox which are neither queued nor flushed now
+func (h *DeleteHandler) loadDueJobs(ctx context.Context) {
+	h.queueMutex.Lock()
+	free := h.queue.size - h.queue.len()
+	h.queueMutex.Unlock()
+
+	if free <= 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, contextTime)
+	defer cancel()
+
+	jobs, err := h.deleter.ClaimPendingDeleteJobs(ctx, time.Now().UTC(), claimLease, free)
+	if err != nil {
+		zap.L().Error("error while loading pending delete jobs", zap.Error(err))
+		return
+	}
+
+	for _, job := range jobs {
+		// jobs exceeding part of queue of user are loaded by next ticks
+		h.enqueue(queuedJob{job: job})
+	}
+}
+
+// enqueue Adds job to queue and wakes up worker if queue contains enough URLs for flush
+//
+// Returns ErrQueueFull error if queue or part of queue of user is full
+func (h *DeleteHandler) enqueue(item queuedJob) error {
+	h.queueMutex.Lock()
+	defer h.queueMutex.Unlock()
+
+	if _, ok := h.inFlight[item.job.ID]; ok || h.queue.has(item.job.ID) {
+		return nil
+	}
+
+	if !h.queue.push(item) {
+		return ErrQueueFull
+	}
+	metrics.AddDeleteQueueJobs(1)
+	metrics.AddDeleteQueueDepth(len(item.job.ShortURLs))
 
-			zap.L().Debug("flushing deleted urls by ticker")
-			flush()
+	if h.queue.urlsCount >= flushBufLen {
+		h.signal(1)
+	}
+
+	return nil
+}
+
+// drain Flushes queued jobs by batches until queue is empty
+//
+// Returns count of flushed URLs and error of the last failed flush
+func (h *DeleteHandler) drain() (int, error) {
+	var count int
+	var lastErr error
+
+	for {
+		batch := h.popBatch()
+		if len(batch.jobs) == 0 {
+			return count, lastErr
+		}
+
+		err := h.flush(batch)
+		if err != nil {
+			lastErr = err
+		}
+		count += batch.urlsCount
+
+		h.releaseBatch(batch)
+	}
+}
+
+// popBatch Removes jobs of the next batch from queue and marks them as flushed now
+func (h *DeleteHandler) popBatch() *jobBatch {
+	h.queueMutex.Lock()
+	defer h.queueMutex.Unlock()
+
+	batch := newJobBatch(h.queue.pop(flushBufLen))
+	for _, job := range batch.jobs {
+		h.inFlight[job.ID] = struct{}{}
+	}
+	metrics.AddDeleteQueueJobs(-len(batch.jobs))
+	metrics.AddDeleteQueueDepth(-batch.urlsCount)
+
+	return batch
+}
+
+func (h *DeleteHandler) releaseBatch(batch *jobBatch) {
+	h.queueMutex.Lock()
+	defer h.queueMutex.Unlock()
+
+	for _, job := range batch.jobs {
+		delete(h.inFlight, job.ID)
+	}
+}
+
+// flush Deletes URLs of batch in the storage and saves states of its jobs
+func (h *DeleteHandler) flush(batch *jobBatch) error {
+	flushLogger := zap.L().With(zap.Strings("request_ids", batch.requestIDs), zap.Strings("job_ids", batch.jobIDs()))
+
+	ctx, cancel := context.WithTimeout(logger.WithLogger(context.Background(), flushLogger), contextTime)
+	defer cancel()
+
+	ctx, span := tracing.Tracer().Start(
+		ctx,
+		"delete.flush",
+		trace.WithLinks(batch.links...),
+		trace.WithAttributes(attribute.Int("urls_count", batch.urlsCount)),
+	)
+	defer span.End()
+
+	flushLogger.Debug("flushing deleted urls", zap.Int("urls_count", batch.urlsCount))
+
+	err := h.deleter.DeleteBatchURL(ctx, batch.urls())
+	metrics.ObserveDeleteFlush(batch.urlsCount, err)
+	h.setFlushErr(err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		switch {
+		case errors.Is(err, context.Canceled):
+			flushLogger.Error("context canceled while flushing deleted urls", zap.String("error", err.Error()))
+		case errors.Is(err, context.DeadlineExceeded):
+			flushLogger.Error("context deadline exceeded while flushing deleted urls", zap.String("error", err.Error()))
+		default:
+			flushLogger.Error("error while flushing deleted urls", zap.Error(err))
+		}
+	}
+
+	// failed jobs are rescheduled in outbox and are loaded again when they are due
+	h.completeJobs(logger.WithLogger(context.Background(), flushLogger), batch.jobs, err)
+
+	return err
+}
+
+// completeJobs Saves states of jobs after attempt to delete their URLs
+//
+// Jobs are done if attempt is successful, otherwise they are rescheduled with exponential backoff
+// or moved to dead letters after maxAttempts attempts
+func (h *DeleteHandler) completeJobs(ctx context.Context, jobs []entity.DeleteJob, err error) {
+	ctx, cancel := context.WithTimeout(ctx, contextTime)
+	defer cancel()
+
+	now := time.Now().UTC()
+	for _, job := range jobs {
+		job.UpdatedAt = now
+		job.Attempts++
+
+		switch {
+		case err == nil:
+			job.Status = entity.DeleteJobDone
+			job.LastError = ""
+		case job.Attempts >= maxAttempts:
+			job.Status = entity.DeleteJobFailed
+			job.LastError = err.Error()
+
+			logger.FromContext(ctx).Error(
+				"delete job is moved to dead letters",
+				zap.String("job_id", job.ID),
+				zap.Int("attempts", job.Attempts),
+				zap.Error(err),
+			)
+		default:
+			job.LastError = err.Error()
+			job.NextAttemptAt = now.Add(retryDelay(job.Attempts))
+		}
+		metrics.ObserveDeleteJob(string(job.Status))
+
+		saveErr := h.deleter.UpdateDeleteJob(ctx, job)
+		if errors.Is(saveErr, api.ErrDeleteJobNotFound) {
+			logger.FromContext(ctx).Debug("state of erased delete job is not saved", zap.String("job_id", job.ID))
+			continue
+		}
+		if saveErr != nil {
+			logger.FromContext(ctx).Error("error while saving state of delete job", zap.String("job_id", job.ID), zap.Error(saveErr))
+		}
+	}
+}
+
+// DropUser Removes queued deletion jobs of user after erasure of user
+//
+// Jobs flushed now are completed, but their states aren't saved because jobs are removed from outbox.
+// Returns count of removed jobs
+func (h *DeleteHandler) DropUser(userID entity.UserID) int {
+	h.queueMutex.Lock()
+	defer h.queueMutex.Unlock()
+
+	items := h.queue.removeUser(userID)
+
+	urlsCount := 0
+	for _, item := range items {
+		urlsCount += len(item.job.ShortURLs)
+	}
+	metrics.AddDeleteQueueJobs(-len(items))
+	metrics.AddDeleteQueueDepth(-urlsCount)
+
+	return len(items)
+}
+
+// retryDelay Returns delay before the next attempt of job doubled after every failed attempt
+func retryDelay(attempts int) time.Duration {
+	delay := tickerTime
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
+
+func (h *DeleteHandler) setFlushErr(err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.flushErr = err
+}
+
+// ProcessDeletedURLs Saves user URLs to outbox of storage to be deleted in background and returns ID of deletion job
+//
+// Flush span of deleted URLs is linked to the span from context
+// and flush is logged with job ID and request ID from context.
+// Returns ErrQueueFull error without saving job if queue or part of queue of user is full.
+// Job is saved as claimed by this instance, job which isn't queued is processed when its lease expires
+func (h *DeleteHandler) ProcessDeletedURLs(ctx context.Context, userID entity.UserID, batch models.ReqDeletedURLBatch) (string, error) {
+	if !h.canQueue(userID) {
+		return "", ErrQueueFull
+	}
+
+	now := time.Now().UTC()
+	job := entity.DeleteJob{
+		ID:            uuid.New().String(),
+		UserID:        userID,
+		ShortURLs:     batch,
+		Status:        entity.DeleteJobPending,
+		NextAttemptAt: now.Add(claimLease),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	err := h.deleter.SaveDeleteJob(ctx, job)
+	if err != nil {
+		return "", fmt.Errorf("error while saving delete job: %w", err)
+	}
+
+	select {
+	case <-h.done:
+		logger.FromContext(ctx).Info("delete job is kept in outbox until restart", zap.String("job_id", job.ID))
+
+		return job.ID, nil
+	default:
+	}
+
+	err = h.enqueue(queuedJob{
+		job:       job,
+		requestID: logger.RequestIDFromContext(ctx),
+		link:      trace.LinkFromContext(ctx),
+	})
+	if err != nil {
+		// queue is filled by concurrent deletions after check, job is loaded from outbox when its lease expires
+		logger.FromContext(ctx).Info("delete job is kept in outbox until queue is freed", zap.String("job_id", job.ID))
+
+		return job.ID, nil
+	}
+
+	logger.FromContext(ctx).Debug(
+		"user urls are queued for deletion",
+		zap.Int("urls_count", len(job.ShortURLs)),
+		zap.String("job_id", job.ID),
+	)
+
+	return job.ID, nil
+}
+
+// canQueue Returns true if job of user could be queued, otherwise counts rejected deletion
+//
+// Jobs are accepted after flusher is stopped to be kept in outbox
+func (h *DeleteHandler) canQueue(userID entity.UserID) bool {
+	select {
+	case <-h.done:
+		return true
+	default:
+	}
+
+	h.queueMutex.Lock()
+	defer h.queueMutex.Unlock()
+
+	if h.queue.canPush(userID) {
+		return true
+	}
+
+	h.rejected++
+	metrics.ObserveDeleteRejected()
+
+	return false
+}
+
+func newJobBatch(items []queuedJob) *jobBatch {
+	batch := &jobBatch{
+		jobs: make([]entity.DeleteJob, 0, len(items)),
+	}
+
+	for _, item := range items {
+		batch.jobs = append(batch.jobs, item.job)
+		batch.urlsCount += len(item.job.ShortURLs)
+
+		if item.link.SpanContext.IsValid() {
+			batch.links = append(batch.links, item.link)
 		}
+		if item.requestID != "" {
+			batch.requestIDs = append(batch.requestIDs, item.requestID)
+		}
+	}
+
+	return batch
+}
+
+func (b *jobBatch) urls() entity.DeletedURLBatch {
+	urls := make(entity.DeletedURLBatch, 0, b.urlsCount)
+	for _, job := range b.jobs {
+		urls = append(urls, job.DeletedURLs()...)
 	}
+
+	return urls
 }
 
-func (h *DeleteHandler) ProcessDeletedURLs(userID entity.UserID, batch models.ReqDeletedURLBatch) {
-	resURLBatch := make([]entity.DeletedURL, 0, len(batch))
-	for _, url := range batch {
-		resURLBatch = append(resURLBatch, entity.DeletedURL{
-			UserID:   userID.String(),
-			ShortURL: url,
-		})
+func (b *jobBatch) jobIDs() []string {
+	ids := make([]string, 0, len(b.jobs))
+	for _, job := range b.jobs {
+		ids = append(ids, job.ID)
 	}
 
-	h.msgChan <- resURLBatch
+	return ids
 }
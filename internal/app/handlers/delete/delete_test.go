@@ -2,33 +2,42 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/avGenie/url-shortener/internal/app/config"
 	"github.com/avGenie/url-shortener/internal/app/entity"
 	"github.com/avGenie/url-shortener/internal/app/handlers/delete/mock"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/local"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDeleteHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	s := mock.NewMockAllURLDeleter(ctrl)
-
 	inputBatch := `["42b3e75f", "77fca595", "ac6bb669"]`
 	invalidBatch := `<invalid json>`
 
 	type want struct {
 		statusCode int
+		jobSaved   bool
 	}
 	tests := []struct {
 		name      string
 		inputBody string
 		userIDCtx entity.UserIDCtx
+		saveErr   error
 		want      want
 	}{
 		{
@@ -41,6 +50,20 @@ func TestDeleteHandler(t *testing.T) {
 
 			want: want{
 				statusCode: http.StatusAccepted,
+				jobSaved:   true,
+			},
+		},
+		{
+			name:      "error while saving job",
+			inputBody: inputBatch,
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+			saveErr: errors.New("storage is unavailable"),
+
+			want: want{
+				statusCode: http.StatusInternalServerError,
 			},
 		},
 		{
@@ -76,14 +99,392 @@ func TestDeleteHandler(t *testing.T) {
 
 			request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, test.userIDCtx))
 
-			deleteHandler := NewDeleteHandler(s)
-			handler := deleteHandler.DeleteUserURLHandler()
+			s := mock.NewMockAllURLDeleter(ctrl)
+			s.EXPECT().ClaimPendingDeleteJobs(gomock.Any(), gomock.Any(), claimLease, gomock.Any()).Return(nil, nil).AnyTimes()
+			s.EXPECT().DeleteBatchURL(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			var saved entity.DeleteJob
+			if test.want.jobSaved || test.saveErr != nil {
+				s.EXPECT().SaveDeleteJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job entity.DeleteJob) error {
+					saved = job
+					return test.saveErr
+				})
+				if test.want.jobSaved {
+					s.EXPECT().UpdateDeleteJob(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+				}
+			}
+
+			deleteHandler := NewDeleteHandler(s, testConfig())
+			handler := deleteHandler.DeleteUserURLHandler(testDomains(t))
 			handler(writer, request)
+			deleteHandler.Stop()
 
 			res := writer.Result()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
 			assert.NoError(t, res.Body.Close())
 
 			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			if !test.want.jobSaved {
+				return
+			}
+
+			assert.JSONEq(t, `{"job_id":"`+saved.ID+`"}`, string(body))
+			assert.Equal(t, JobsPath+saved.ID, res.Header.Get("Location"))
+			assert.Equal(t, entity.DeleteJobPending, saved.Status)
+			assert.Equal(t, []string{"42b3e75f", "77fca595", "ac6bb669"}, saved.ShortURLs)
+		})
+	}
+}
+
+func TestDeleteHandlerCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := mock.NewMockAllURLDeleter(ctrl)
+	s.EXPECT().DeleteBatchURL(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	s.EXPECT().ClaimPendingDeleteJobs(gomock.Any(), gomock.Any(), claimLease, gomock.Any()).Return(nil, nil).AnyTimes()
+
+	deleteHandler := NewDeleteHandler(s, testConfig())
+	assert.NoError(t, deleteHandler.Check(context.Background()))
+
+	errFlush := errors.New("storage is unavailable")
+	deleteHandler.setFlushErr(errFlush)
+	assert.ErrorIs(t, deleteHandler.Check(context.Background()), errFlush)
+
+	deleteHandler.setFlushErr(nil)
+	deleteHandler.Stop()
+	assert.ErrorIs(t, deleteHandler.Check(context.Background()), ErrFlusherStopped)
+}
+
+func TestDeleteHandlerFlush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+
+	s := mock.NewMockAllURLDeleter(ctrl)
+	s.EXPECT().DeleteBatchURL(gomock.Any(), entity.DeletedURLBatch{
+		{UserID: userID.String(), ShortURL: "42b3e75f"},
+		{UserID: userID.String(), ShortURL: "77fca595"},
+	}).Return(nil)
+	s.EXPECT().ClaimPendingDeleteJobs(gomock.Any(), gomock.Any(), claimLease, gomock.Any()).Return(nil, nil).AnyTimes()
+
+	var states []entity.DeleteJob
+	saveState := func(_ context.Context, job entity.DeleteJob) error {
+		states = append(states, job)
+		return nil
+	}
+	s.EXPECT().SaveDeleteJob(gomock.Any(), gomock.Any()).DoAndReturn(saveState)
+	s.EXPECT().UpdateDeleteJob(gomock.Any(), gomock.Any()).DoAndReturn(saveState)
+
+	deleteHandler := NewDeleteHandler(s, testConfig())
+
+	count, err := deleteHandler.Flush(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	jobID, err := deleteHandler.ProcessDeletedURLs(context.Background(), userID, []string{"42b3e75f", "77fca595"})
+	require.NoError(t, err)
+
+	count, err = deleteHandler.Flush(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	require.Len(t, states, 2)
+	assert.Equal(t, jobID, states[1].ID)
+	assert.Equal(t, entity.DeleteJobDone, states[1].Status)
+	assert.Equal(t, 1, states[1].Attempts)
+
+	deleteHandler.Stop()
+
+	_, err = deleteHandler.Flush(context.Background())
+	assert.ErrorIs(t, err, ErrFlusherStopped)
+}
+
+func TestDeleteHandlerRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	errFlush := errors.New("storage is unavailable")
+
+	job := entity.DeleteJob{
+		ID:        "9bb86afd-62da-43af-81f2-f37400820a2d",
+		UserID:    userID,
+		ShortURLs: []string{"42b3e75f"},
+		Status:    entity.DeleteJobPending,
+		Attempts:  1,
+	}
+
+	s := mock.NewMockAllURLDeleter(ctrl)
+	s.EXPECT().ClaimPendingDeleteJobs(gomock.Any(), gomock.Any(), claimLease, testConfig().DeleteQueueSize).Return([]entity.DeleteJob{job}, nil)
+	s.EXPECT().ClaimPendingDeleteJobs(gomock.Any(), gomock.Any(), claimLease, gomock.Any()).Return(nil, nil).AnyTimes()
+	s.EXPECT().DeleteBatchURL(gomock.Any(), job.DeletedURLs()).Return(errFlush)
+
+	var saved entity.DeleteJob
+	s.EXPECT().UpdateDeleteJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job entity.DeleteJob) error {
+		saved = job
+		return nil
+	})
+
+	deleteHandler := NewDeleteHandler(s, testConfig())
+
+	count, err := deleteHandler.Flush(context.Background())
+	require.ErrorIs(t, err, errFlush)
+	assert.Equal(t, 1, count)
+	assert.ErrorIs(t, deleteHandler.Check(context.Background()), errFlush)
+
+	assert.Equal(t, entity.DeleteJobPending, saved.Status)
+	assert.Equal(t, 2, saved.Attempts)
+	assert.Equal(t, errFlush.Error(), saved.LastError)
+	assert.WithinDuration(t, time.Now().Add(retryDelay(2)), saved.NextAttemptAt, time.Second)
+
+	deleteHandler.Stop()
+}
+
+func TestCompleteJobs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	errFlush := errors.New("storage is unavailable")
+
+	tests := []struct {
+		name         string
+		attempts     int
+		err          error
+		wantStatus   entity.DeleteJobStatus
+		wantAttempts int
+	}{
+		{
+			name:         "successful attempt",
+			attempts:     3,
+			wantStatus:   entity.DeleteJobDone,
+			wantAttempts: 4,
+		},
+		{
+			name:         "failed attempt",
+			attempts:     0,
+			err:          errFlush,
+			wantStatus:   entity.DeleteJobPending,
+			wantAttempts: 1,
+		},
+		{
+			name:         "last failed attempt",
+			attempts:     maxAttempts - 1,
+			err:          errFlush,
+			wantStatus:   entity.DeleteJobFailed,
+			wantAttempts: maxAttempts,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := mock.NewMockAllURLDeleter(ctrl)
+
+			var saved entity.DeleteJob
+			s.EXPECT().UpdateDeleteJob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, job entity.DeleteJob) error {
+				saved = job
+				return nil
+			})
+
+			h := &DeleteHandler{deleter: s}
+			h.completeJobs(context.Background(), []entity.DeleteJob{{
+				ID:       "9bb86afd-62da-43af-81f2-f37400820a2d",
+				Status:   entity.DeleteJobPending,
+				Attempts: test.attempts,
+			}}, test.err)
+
+			assert.Equal(t, test.wantStatus, saved.Status)
+			assert.Equal(t, test.wantAttempts, saved.Attempts)
 		})
 	}
 }
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, tickerTime, retryDelay(1))
+	assert.Equal(t, 2*tickerTime, retryDelay(2))
+	assert.Equal(t, 8*tickerTime, retryDelay(4))
+	assert.Equal(t, maxRetryDelay, retryDelay(maxAttempts))
+}
+
+func TestDeleteHandlerQueueFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	otherUserID := entity.UserID("5b1a9e6c-0b7e-4f0e-9a53-0c2e8f3d9a41")
+
+	s := mock.NewMockAllURLDeleter(ctrl)
+	s.EXPECT().ClaimPendingDeleteJobs(gomock.Any(), gomock.Any(), claimLease, gomock.Any()).Return(nil, nil).AnyTimes()
+	s.EXPECT().SaveDeleteJob(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	s.EXPECT().UpdateDeleteJob(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	s.EXPECT().DeleteBatchURL(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	cfg := testConfig()
+	cfg.DeleteQueueSize = 3
+	cfg.DeleteQueueUserSize = 2
+
+	deleteHandler := NewDeleteHandler(s, cfg)
+	defer deleteHandler.Stop()
+
+	for i := 0; i < cfg.DeleteQueueUserSize; i++ {
+		_, err := deleteHandler.ProcessDeletedURLs(context.Background(), userID, []string{"42b3e75f"})
+		require.NoError(t, err)
+	}
+
+	_, err := deleteHandler.ProcessDeletedURLs(context.Background(), userID, []string{"77fca595"})
+	assert.ErrorIs(t, err, ErrQueueFull)
+
+	_, err = deleteHandler.ProcessDeletedURLs(context.Background(), otherUserID, []string{"ac6bb669"})
+	require.NoError(t, err)
+
+	request := httptest.NewRequest(http.MethodDelete, "/api/user/urls", strings.NewReader(`["77fca595"]`))
+	request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, entity.UserIDCtx{
+		UserID:     otherUserID,
+		StatusCode: http.StatusOK,
+	}))
+	writer := httptest.NewRecorder()
+
+	deleteHandler.DeleteUserURLHandler(testDomains(t))(writer, request)
+
+	res := writer.Result()
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+	assert.Equal(t, "5", res.Header.Get("Retry-After"))
+
+	assert.Equal(t, models.DeleteQueueStats{
+		QueuedJobs:   3,
+		QueuedURLs:   3,
+		Capacity:     3,
+		UserCapacity: 2,
+		Workers:      cfg.DeleteWorkers,
+		Rejected:     2,
+	}, deleteHandler.Stats())
+
+	count, err := deleteHandler.Flush(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Zero(t, deleteHandler.Stats().QueuedJobs)
+}
+
+func TestDeleteHandlerDomains(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	ctx := context.Background()
+
+	storage := local.NewTSLocalStorage(0)
+	for _, shortURL := range []string{"42b3e75f", "go.brand.io/42b3e75f"} {
+		require.NoError(t, storage.SaveLink(ctx, entity.Link{
+			ShortURL:    shortURL,
+			OriginalURL: "https://practicum.yandex.ru/",
+			UserID:      userID,
+		}))
+	}
+
+	deleteHandler := NewDeleteHandler(storage, testConfig())
+	defer deleteHandler.Stop()
+
+	request := httptest.NewRequest(http.MethodDelete, "https://go.brand.io/api/user/urls", strings.NewReader(`["42b3e75f"]`))
+	request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, entity.UserIDCtx{
+		UserID:     userID,
+		StatusCode: http.StatusOK,
+	}))
+	writer := httptest.NewRecorder()
+
+	deleteHandler.DeleteUserURLHandler(testDomains(t))(writer, request)
+
+	res := writer.Result()
+	require.NoError(t, res.Body.Close())
+	require.Equal(t, http.StatusAccepted, res.StatusCode)
+
+	_, err := deleteHandler.Flush(ctx)
+	require.NoError(t, err)
+
+	link, err := storage.GetLink(ctx, "", "go.brand.io/42b3e75f")
+	require.NoError(t, err)
+	assert.True(t, link.Deleted)
+
+	link, err = storage.GetLink(ctx, "", "42b3e75f")
+	require.NoError(t, err)
+	assert.False(t, link.Deleted)
+}
+
+func TestDeleteHandlerErasedUser(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	ctx := context.Background()
+
+	t.Run("job flushed after erasure", func(t *testing.T) {
+		storage := local.NewTSLocalStorage(0)
+		deleteHandler := NewDeleteHandler(storage, testConfig())
+
+		jobID, err := deleteHandler.ProcessDeletedURLs(ctx, userID, []string{"42b3e75f"})
+		require.NoError(t, err)
+
+		erasure, err := storage.EraseUser(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, erasure.DeleteJobs)
+
+		// queued job is flushed on stop, its state mustn't restore erased job
+		deleteHandler.Stop()
+
+		_, err = storage.GetDeleteJob(ctx, "", jobID)
+		assert.ErrorIs(t, err, api.ErrDeleteJobNotFound)
+
+		jobs, err := storage.ClaimPendingDeleteJobs(ctx, time.Now().Add(time.Hour), claimLease, 10)
+		require.NoError(t, err)
+		assert.Empty(t, jobs)
+	})
+
+	t.Run("queued jobs dropped", func(t *testing.T) {
+		otherUserID := entity.UserID("5b1a9e6c-0b7e-4f0e-9a53-0c2e8f3d9a41")
+
+		storage := local.NewTSLocalStorage(0)
+		deleteHandler := NewDeleteHandler(storage, testConfig())
+		defer deleteHandler.Stop()
+
+		_, err := deleteHandler.ProcessDeletedURLs(ctx, userID, []string{"42b3e75f", "77fca595"})
+		require.NoError(t, err)
+		_, err = deleteHandler.ProcessDeletedURLs(ctx, otherUserID, []string{"ac6bb669"})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, deleteHandler.DropUser(userID))
+		assert.Zero(t, deleteHandler.DropUser(userID))
+
+		count, err := deleteHandler.Flush(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.NoError(t, ValidateConfig(testConfig()))
+
+	cfg := testConfig()
+	cfg.DeleteQueueSize = 0
+	cfg.DeleteQueueUserSize = -1
+	cfg.DeleteWorkers = 0
+
+	err := ValidateConfig(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "delete_queue_size")
+	assert.Contains(t, err.Error(), "delete_queue_user_size")
+	assert.Contains(t, err.Error(), "delete_workers")
+}
+
+func testConfig() config.Config {
+	return config.Config{
+		DeleteQueueSize:     10,
+		DeleteQueueUserSize: 5,
+		DeleteWorkers:       2,
+	}
+}
+
+func testDomains(t *testing.T) *domain.Registry {
+	domains, err := domain.NewRegistry(config.Config{
+		BaseURIPrefix: "http://localhost:8080",
+		Domains:       "https://go.brand.io",
+	})
+	require.NoError(t, err)
+
+	return domains
+}
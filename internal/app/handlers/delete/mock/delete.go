@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/avGenie/url-shortener/internal/app/entity"
 	gomock "github.com/golang/mock/gomock"
@@ -35,6 +36,21 @@ func (m *MockAllURLDeleter) EXPECT() *MockAllURLDeleterMockRecorder {
 	return m.recorder
 }
 
+// ClaimPendingDeleteJobs mocks base method.
+func (m *MockAllURLDeleter) ClaimPendingDeleteJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.DeleteJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingDeleteJobs", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]entity.DeleteJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingDeleteJobs indicates an expected call of ClaimPendingDeleteJobs.
+func (mr *MockAllURLDeleterMockRecorder) ClaimPendingDeleteJobs(ctx, now, lease, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingDeleteJobs", reflect.TypeOf((*MockAllURLDeleter)(nil).ClaimPendingDeleteJobs), ctx, now, lease, limit)
+}
+
 // DeleteBatchURL mocks base method.
 func (m *MockAllURLDeleter) DeleteBatchURL(ctx context.Context, urls entity.DeletedURLBatch) error {
 	m.ctrl.T.Helper()
@@ -48,3 +64,31 @@ func (mr *MockAllURLDeleterMockRecorder) DeleteBatchURL(ctx, urls interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatchURL", reflect.TypeOf((*MockAllURLDeleter)(nil).DeleteBatchURL), ctx, urls)
 }
+
+// SaveDeleteJob mocks base method.
+func (m *MockAllURLDeleter) SaveDeleteJob(ctx context.Context, job entity.DeleteJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDeleteJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDeleteJob indicates an expected call of SaveDeleteJob.
+func (mr *MockAllURLDeleterMockRecorder) SaveDeleteJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDeleteJob", reflect.TypeOf((*MockAllURLDeleter)(nil).SaveDeleteJob), ctx, job)
+}
+
+// UpdateDeleteJob mocks base method.
+func (m *MockAllURLDeleter) UpdateDeleteJob(ctx context.Context, job entity.DeleteJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeleteJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeleteJob indicates an expected call of UpdateDeleteJob.
+func (mr *MockAllURLDeleterMockRecorder) UpdateDeleteJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeleteJob", reflect.TypeOf((*MockAllURLDeleter)(nil).UpdateDeleteJob), ctx, job)
+}
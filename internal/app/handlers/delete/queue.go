@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// queuedJob Contains deletion job, request ID and link to the span of request
+type queuedJob struct {
+	job       entity.DeleteJob
+	requestID string
+	link      trace.Link
+}
+
+// jobQueue Bounded queue of deletion jobs drained in round robin order of users
+//
+// Every user may occupy only limited part of queue, so one user couldn't block deletions of others.
+// Queue is not thread safe
+type jobQueue struct {
+	size     int
+	userSize int
+
+	users     []entity.UserID
+	jobs      map[entity.UserID][]queuedJob
+	ids       map[string]struct{}
+	urlsCount int
+}
+
+func newJobQueue(size, userSize int) *jobQueue {
+	return &jobQueue{
+		size:     size,
+		userSize: userSize,
+		jobs:     make(map[entity.UserID][]queuedJob),
+		ids:      make(map[string]struct{}),
+	}
+}
+
+// push Adds job to the end of user jobs
+//
+// Returns false if queue or part of queue of user is full
+func (q *jobQueue) push(item queuedJob) bool {
+	if !q.canPush(item.job.UserID) {
+		return false
+	}
+
+	userJobs, ok := q.jobs[item.job.UserID]
+	if !ok {
+		q.users = append(q.users, item.job.UserID)
+	}
+
+	q.jobs[item.job.UserID] = append(userJobs, item)
+	q.ids[item.job.ID] = struct{}{}
+	q.urlsCount += len(item.job.ShortURLs)
+
+	return true
+}
+
+// canPush Returns true if job of user could be added to queue
+func (q *jobQueue) canPush(userID entity.UserID) bool {
+	if q.len() >= q.size {
+		return false
+	}
+
+	return q.userSize == 0 || len(q.jobs[userID]) < q.userSize
+}
+
+// pop Removes jobs from queue taking one job of every user in turn until batch contains maxURLs URLs
+//
+// Job with more than maxURLs URLs is returned alone
+func (q *jobQueue) pop(maxURLs int) []queuedJob {
+	var batch []queuedJob
+	var urlsCount int
+
+	for len(q.users) != 0 {
+		userID := q.users[0]
+		userJobs := q.jobs[userID]
+		next := userJobs[0]
+
+		if len(batch) != 0 && urlsCount+len(next.job.ShortURLs) > maxURLs {
+			break
+		}
+
+		batch = append(batch, next)
+		urlsCount += len(next.job.ShortURLs)
+
+		q.users = q.users[1:]
+		if len(userJobs) == 1 {
+			delete(q.jobs, userID)
+		} else {
+			q.jobs[userID] = userJobs[1:]
+			q.users = append(q.users, userID)
+		}
+	}
+
+	for _, item := range batch {
+		delete(q.ids, item.job.ID)
+	}
+	q.urlsCount -= urlsCount
+
+	return batch
+}
+
+// removeUser Removes all jobs of user from queue
+//
+// Returns removed jobs
+func (q *jobQueue) removeUser(userID entity.UserID) []queuedJob {
+	userJobs, ok := q.jobs[userID]
+	if !ok {
+		return nil
+	}
+
+	delete(q.jobs, userID)
+	q.users = slices.DeleteFunc(q.users, func(id entity.UserID) bool {
+		return id == userID
+	})
+
+	for _, item := range userJobs {
+		delete(q.ids, item.job.ID)
+		q.urlsCount -= len(item.job.ShortURLs)
+	}
+
+	return userJobs
+}
+
+// has Returns true if job is in queue
+func (q *jobQueue) has(jobID string) bool {
+	_, ok := q.ids[jobID]
+
+	return ok
+}
+
+// len Returns count of queued jobs
+func (q *jobQueue) len() int {
+	return len(q.ids)
+}
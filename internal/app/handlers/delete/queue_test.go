@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+func TestJobQueue(t *testing.T) {
+	job := func(id string, userID entity.UserID, urlsCount int) queuedJob {
+		return queuedJob{job: entity.DeleteJob{
+			ID:        id,
+			UserID:    userID,
+			ShortURLs: make([]string, urlsCount),
+		}}
+	}
+
+	jobIDs := func(items []queuedJob) []string {
+		ids := make([]string, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.job.ID)
+		}
+
+		return ids
+	}
+
+	t.Run("round robin of users", func(t *testing.T) {
+		q := newJobQueue(10, 0)
+
+		require.True(t, q.push(job("a1", "a", 1)))
+		require.True(t, q.push(job("a2", "a", 1)))
+		require.True(t, q.push(job("a3", "a", 1)))
+		require.True(t, q.push(job("b1", "b", 1)))
+		require.True(t, q.push(job("c1", "c", 1)))
+		assert.Equal(t, 5, q.len())
+		assert.Equal(t, 5, q.urlsCount)
+		assert.True(t, q.has("b1"))
+
+		assert.Equal(t, []string{"a1", "b1", "c1", "a2"}, jobIDs(q.pop(4)))
+		assert.Equal(t, []string{"a3"}, jobIDs(q.pop(4)))
+		assert.Empty(t, q.pop(4))
+		assert.Zero(t, q.len())
+		assert.Zero(t, q.urlsCount)
+		assert.False(t, q.has("b1"))
+	})
+
+	t.Run("batch limited by urls", func(t *testing.T) {
+		q := newJobQueue(10, 0)
+
+		require.True(t, q.push(job("a1", "a", 150)))
+		require.True(t, q.push(job("b1", "b", 60)))
+		require.True(t, q.push(job("c1", "c", 50)))
+
+		assert.Equal(t, []string{"a1"}, jobIDs(q.pop(100)))
+		assert.Equal(t, []string{"b1"}, jobIDs(q.pop(100)))
+		assert.Equal(t, []string{"c1"}, jobIDs(q.pop(100)))
+	})
+
+	t.Run("bounded size", func(t *testing.T) {
+		q := newJobQueue(3, 2)
+
+		require.True(t, q.push(job("a1", "a", 1)))
+		require.True(t, q.push(job("a2", "a", 1)))
+		assert.False(t, q.push(job("a3", "a", 1)))
+		assert.False(t, q.canPush("a"))
+
+		require.True(t, q.push(job("b1", "b", 1)))
+		assert.False(t, q.push(job("c1", "c", 1)))
+		assert.False(t, q.canPush("c"))
+
+		q.pop(1)
+		assert.True(t, q.canPush("c"))
+	})
+
+	t.Run("remove jobs of user", func(t *testing.T) {
+		q := newJobQueue(10, 0)
+
+		require.True(t, q.push(job("a1", "a", 2)))
+		require.True(t, q.push(job("b1", "b", 1)))
+		require.True(t, q.push(job("a2", "a", 3)))
+
+		assert.Equal(t, []string{"a1", "a2"}, jobIDs(q.removeUser("a")))
+		assert.Empty(t, q.removeUser("a"))
+		assert.Equal(t, 1, q.len())
+		assert.Equal(t, 1, q.urlsCount)
+		assert.False(t, q.has("a2"))
+
+		assert.Equal(t, []string{"b1"}, jobIDs(q.pop(10)))
+		assert.Empty(t, q.pop(10))
+	})
+}
@@ -7,6 +7,7 @@ import "errors"
 // WrongURLFormat - returned as HTTP output if an invalid URL format was received
 // WrongJSONFormat - returned as HTTP output if an invalid URL JSON format was received
 // ShortURLNotInDB - returned as HTTP output if given short URL is not found in DB
+// ShortURLDisabled - returned as HTTP output if given short URL is disabled by admin
 // CannotProcessURL - returned as log message if URL couldn't be processed
 // CannotProcessJSON - returned as log message if URL couldn't be processed in JSON format
 // InternalServerError - returned as HTTP output due to internal server error
@@ -15,6 +16,7 @@ var (
 	WrongURLFormat    = "wrong URL format"
 	WrongJSONFormat   = "wrong JSON format"
 	ShortURLNotInDB   = "given short URL did not find in database"
+	ShortURLDisabled  = "given short URL is disabled"
 	CannotProcessURL  = "cannot process URL"
 	CannotProcessJSON = "cannot process JSON"
 
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/audit"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+// ActivityLister Lister of actions of user from audit trail
+type ActivityLister interface {
+	Activity(
+		ctx context.Context,
+		userID entity.UserID,
+		filter entity.AuditFilter,
+		pageSize int,
+		pageToken string,
+	) ([]entity.AuditRecord, string, error)
+}
+
+// ActivityHandler Processes GET "/api/user/activity" endpoint. Sends page of actions of user changing its links
+//
+// Actions are sent from audit trail, the newest first. They are filtered by query parameters action, target,
+// from and to in RFC 3339 format, page is selected by query parameters page_size and page_token.
+// Returns 200(StatusOK) if processing was successful
+// Returns 400(StatusBadRequest) if period, page size or page token is invalid
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 500(StatusInternalServerError) if user ID is invalid
+// Returns 500(StatusInternalServerError) when database error
+func ActivityHandler(lister ActivityLister) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
+		if !ok {
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context while activity processing")
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if code := validateUserIDCtx(req.Context(), userIDCtx); code != http.StatusOK {
+			writer.WriteHeader(code)
+			return
+		}
+
+		query, err := audit.ParseQuery(req.URL.Query())
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		records, next, err := lister.Activity(ctx, userIDCtx.UserID, query.Filter, query.PageSize, query.PageToken)
+		if err != nil {
+			var fieldErr *link.FieldError
+			if errors.As(err, &fieldErr) {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			logger.FromContext(req.Context()).Error("error while getting activity of user", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		out, err := json.Marshal(audit.PageToModel(records, next))
+		if err != nil {
+			logger.FromContext(req.Context()).Error("error while converting activity of user to output", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		writer.Write(out)
+	}
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/handlers/get/mock"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+func TestActivityHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := mock.NewMockActivityLister(ctrl)
+
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	authorized := entity.UserIDCtx{
+		UserID:     userID,
+		StatusCode: http.StatusOK,
+	}
+
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name      string
+		target    string
+		userIDCtx entity.UserIDCtx
+		prepare   func()
+		want      want
+	}{
+		{
+			name:      "page of activity",
+			target:    "/api/user/activity?action=link.delete&page_size=1",
+			userIDCtx: authorized,
+			prepare: func() {
+				s.EXPECT().Activity(gomock.Any(), userID, entity.AuditFilter{Action: "link.delete"}, 1, "").
+					Return([]entity.AuditRecord{
+						{
+							ID:        "record",
+							ActorType: entity.AuditActorUser,
+							Actor:     userID.String(),
+							Action:    "link.delete",
+							Targets:   []string{"aHR0cHM6"},
+							ClientIP:  "192.168.1.14",
+							Result:    "success",
+							CreatedAt: time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC),
+						},
+					}, "next", nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{"records":[{"id":"record","actor_type":"user","actor":"ac2a4811-4f10-487f-bde3-e39a14af7cd8",` +
+					`"action":"link.delete","targets":["aHR0cHM6"],"client_ip":"192.168.1.14","result":"success",` +
+					`"created_at":"2026-10-19T12:00:00Z"}],"next_page_token":"next"}`,
+			},
+		},
+		{
+			name:      "empty activity",
+			target:    "/api/user/activity",
+			userIDCtx: authorized,
+			prepare: func() {
+				s.EXPECT().Activity(gomock.Any(), userID, entity.AuditFilter{}, 0, "").Return(nil, "", nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `{"records":[]}`,
+			},
+		},
+		{
+			name:      "invalid period",
+			target:    "/api/user/activity?to=tomorrow",
+			userIDCtx: authorized,
+			prepare:   func() {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:      "invalid page token",
+			target:    "/api/user/activity?page_token=broken",
+			userIDCtx: authorized,
+			prepare: func() {
+				s.EXPECT().Activity(gomock.Any(), userID, entity.AuditFilter{}, 0, "broken").
+					Return(nil, "", &link.FieldError{Field: "page_token", Err: link.ErrInvalidPageToken})
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:      "storage error",
+			target:    "/api/user/activity",
+			userIDCtx: authorized,
+			prepare: func() {
+				s.EXPECT().Activity(gomock.Any(), userID, entity.AuditFilter{}, 0, "").
+					Return(nil, "", errors.New("storage is unavailable"))
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name:   "unauthorized user",
+			target: "/api/user/activity",
+			userIDCtx: entity.UserIDCtx{
+				StatusCode: http.StatusUnauthorized,
+			},
+			prepare: func() {},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.prepare()
+
+			request := httptest.NewRequest(http.MethodGet, test.target, nil)
+			request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, test.userIDCtx))
+			writer := httptest.NewRecorder()
+
+			ActivityHandler(s)(writer, request)
+
+			res := writer.Result()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			if test.want.body != "" {
+				assert.JSONEq(t, test.want.body, string(body))
+			}
+		})
+	}
+}
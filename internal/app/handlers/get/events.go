@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/events"
+)
+
+const (
+	keepAliveTime = 15 * time.Second
+	retryTime     = 3 * time.Second
+
+	lastEventIDHeader = "Last-Event-ID"
+	lastEventIDQuery  = "last_event_id"
+)
+
+// EventSubscriber Subscriber to live link events of user
+type EventSubscriber interface {
+	Subscribe(userID entity.UserID, lastEventID uint64) (*events.Subscription, []events.Event, error)
+}
+
+// EventsHandler Processes GET "/api/user/events" endpoint. Streams link events of user as Server-Sent Events
+//
+// Stream is resumed after event from Last-Event-ID header or last_event_id query parameter
+// if event is still kept in buffer of bus. Stream is finished if client doesn't keep up with events
+//
+// Returns 200(StatusOK) with stream of events
+// Returns 400(StatusBadRequest) if last event ID is invalid
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 500(StatusInternalServerError) if user ID is invalid or response couldn't be streamed
+// Returns 503(StatusServiceUnavailable) if server is shutting down
+func EventsHandler(subscriber EventSubscriber, domains *domain.Registry) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
+		if !ok {
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context while events processing")
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if code := validateUserIDCtx(req.Context(), userIDCtx); code != http.StatusOK {
+			writer.WriteHeader(code)
+			return
+		}
+
+		lastEventID, err := parseLastEventID(req)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		sub, backlog, err := subscriber.Subscribe(userIDCtx.UserID, lastEventID)
+		if err != nil {
+			if errors.Is(err, events.ErrBusClosed) {
+				http.Error(writer, err.Error(), http.StatusServiceUnavailable)
+				return
+			}
+
+			logger.FromContext(req.Context()).Error("error while subscribing to events", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		defer sub.Close()
+
+		writer.Header().Set("Content-Type", "text/event-stream")
+		writer.Header().Set("Cache-Control", "no-cache")
+		writer.Header().Set("Connection", "keep-alive")
+		writer.Header().Set("X-Accel-Buffering", "no")
+		writer.WriteHeader(http.StatusOK)
+
+		controller := http.NewResponseController(writer)
+		fmt.Fprintf(writer, "retry: %d\n\n", retryTime.Milliseconds())
+		for _, event := range backlog {
+			writeEvent(writer, event, domains)
+		}
+
+		if err := controller.Flush(); err != nil {
+			logger.FromContext(req.Context()).Error("events couldn't be streamed", zap.Error(err))
+			return
+		}
+
+		ticker := time.NewTicker(keepAliveTime)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-req.Context().Done():
+				return
+			case <-ticker.C:
+				fmt.Fprint(writer, ": keepalive\n\n")
+			case event, ok := <-sub.Events():
+				if !ok {
+					logger.FromContext(req.Context()).Info("events stream is closed", zap.Error(sub.Err()))
+					return
+				}
+
+				writeEvent(writer, event, domains)
+			}
+
+			if err := controller.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func parseLastEventID(req *http.Request) (uint64, error) {
+	value := req.Header.Get(lastEventIDHeader)
+	if value == "" {
+		value = req.URL.Query().Get(lastEventIDQuery)
+	}
+
+	if value == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid last event id %q", value)
+	}
+
+	return id, nil
+}
+
+func writeEvent(writer http.ResponseWriter, event events.Event, domains *domain.Registry) {
+	data, err := json.Marshal(models.LinkEventResponse{
+		Type:        event.Type,
+		ShortURL:    domains.ShortURL(event.ShortURL),
+		Alias:       event.ShortURL,
+		OriginalURL: event.OriginalURL,
+		Time:        event.Time,
+	})
+	if err != nil {
+		return
+	}
+
+	fmt.Fprintf(writer, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/encoding"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/usecase/events"
+)
+
+// readEvent Reads lines of the next event of stream skipping comments and retry field
+func readEvent(t *testing.T, reader *bufio.Reader) []string {
+	var lines []string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && len(lines) != 0:
+			return lines
+		case line == "", strings.HasPrefix(line, ":"), strings.HasPrefix(line, "retry:"):
+		default:
+			lines = append(lines, line)
+		}
+	}
+}
+
+func TestEventsHandler(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+
+	bus, err := events.NewBus(nil, config.Config{EventBufferSize: 10, EventStreamBuffer: 10})
+	require.NoError(t, err)
+	bus.Start()
+	defer bus.Close()
+
+	domains := newTestDomains(t, baseURIPrefix)
+
+	handler := encoding.GzipMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, req *http.Request) {
+		ctx := context.WithValue(req.Context(), entity.UserIDCtxKey{}, entity.UserIDCtx{
+			UserID:     userID,
+			StatusCode: http.StatusOK,
+		})
+
+		EventsHandler(bus, domains).ServeHTTP(writer, req.WithContext(ctx))
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	publish := func(shortURL string) {
+		bus.Publish(entity.LinkEvent{
+			Type:        entity.EventLinkCreated,
+			UserID:      userID,
+			ShortURL:    shortURL,
+			OriginalURL: "https://practicum.yandex.ru/",
+		})
+	}
+
+	// compressed stream is flushed after every event
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	res, err := http.DefaultTransport.RoundTrip(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	zr, err := gzip.NewReader(res.Body)
+	require.NoError(t, err)
+	reader := bufio.NewReader(zr)
+
+	publish("42b3e75f")
+	first := readEvent(t, reader)
+	require.Len(t, first, 3)
+	assert.True(t, strings.HasPrefix(first[0], "id: "))
+	assert.Equal(t, "event: link.created", first[1])
+	assert.Contains(t, first[2], `"short_url":"http://localhost:8080/42b3e75f"`)
+
+	publish("77fca595")
+	second := readEvent(t, reader)
+	assert.Contains(t, second[2], `"alias":"77fca595"`)
+
+	// stream is resumed after event from Last-Event-ID header
+	req, err = http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", strings.TrimPrefix(first[0], "id: "))
+
+	resumed, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resumed.Body.Close()
+
+	assert.Equal(t, second, readEvent(t, bufio.NewReader(resumed.Body)))
+
+	invalid, err := http.Get(server.URL + "?last_event_id=abc")
+	require.NoError(t, err)
+	body, err := io.ReadAll(invalid.Body)
+	require.NoError(t, err)
+	require.NoError(t, invalid.Body.Close())
+
+	assert.Equal(t, http.StatusBadRequest, invalid.StatusCode)
+	assert.Contains(t, string(body), `invalid last event id "abc"`)
+}
@@ -4,10 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
 	"go.uber.org/zap"
 )
 
@@ -25,11 +26,11 @@ var (
 	ErrAllURLNotFound = errors.New("urls for this user not found")
 )
 
-// ProcessAllUserURL Returns all URLs for given user
-func ProcessAllUserURL(getter AllURLGetter, ctx context.Context, userID entity.UserID, baseURIPrefix string) ([]byte, error) {
+// ProcessAllUserURL Returns all URLs for given user with short URLs on their domains
+func ProcessAllUserURL(getter AllURLGetter, ctx context.Context, userID entity.UserID, domains *domain.Registry) ([]byte, error) {
 	urls, err := getter.GetAllURLByUserID(ctx, userID)
 	if err != nil {
-		zap.L().Error("couldn't get all user urls", zap.Error(err), zap.String("user_id", userID.String()))
+		logger.FromContext(ctx).Error("couldn't get all user urls", zap.Error(err), zap.String("user_id", userID.String()))
 		return nil, ErrInternal
 	}
 
@@ -38,13 +39,13 @@ func ProcessAllUserURL(getter AllURLGetter, ctx context.Context, userID entity.U
 	}
 
 	for index, url := range urls {
-		url.ShortURL = fmt.Sprintf("%s/%s", baseURIPrefix, url.ShortURL)
+		url.ShortURL = domains.ShortURL(url.ShortURL)
 		urls[index] = url
 	}
 
 	out, err := json.Marshal(urls)
 	if err != nil {
-		zap.L().Error("error while converting all user urls to output", zap.Error(err))
+		logger.FromContext(ctx).Error("error while converting all user urls to output", zap.Error(err))
 		return nil, ErrInternal
 	}
 
@@ -21,12 +21,29 @@ import (
 	"github.com/avGenie/url-shortener/internal/app/handlers/get/mock"
 	"github.com/avGenie/url-shortener/internal/app/logger"
 	"github.com/avGenie/url-shortener/internal/app/models"
+	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
 )
 
 const (
 	baseURIPrefix = "http://localhost:8080"
+	brandDomains  = "https://go.brand.io;not_found=https://brand.io/404"
 )
 
+func newTestDomains(t *testing.T, baseURIPrefix string) *domain.Registry {
+	if baseURIPrefix == "" {
+		return nil
+	}
+
+	domains, err := domain.NewRegistry(config.Config{
+		BaseURIPrefix: baseURIPrefix,
+		Domains:       brandDomains,
+	})
+	require.NoError(t, err)
+
+	return domains
+}
+
 func TestGetHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -44,6 +61,8 @@ func TestGetHandler(t *testing.T) {
 	tests := []struct {
 		name              string
 		request           string
+		host              string
+		key               string
 		userIDCtx         entity.UserIDCtx
 		want              want
 		exitBeforeGetting bool
@@ -65,6 +84,44 @@ func TestGetHandler(t *testing.T) {
 				message:     "",
 			},
 		},
+		{
+			name:    "URL on branded domain",
+			request: "aHR0cHM6",
+			host:    "GO.brand.io:443",
+			key:     "go.brand.io/aHR0cHM6",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+
+			want: want{
+				statusCode:  http.StatusTemporaryRedirect,
+				contentType: "text/plain; charset=utf-8",
+				location:    "https://practicum.yandex.ru/",
+				expectURL:   makeOKURLResponse("https://practicum.yandex.ru/"),
+				expectErr:   nil,
+				message:     "",
+			},
+		},
+		{
+			name:    "missing URL on branded domain",
+			request: "fsdfuytu",
+			host:    "go.brand.io",
+			key:     "go.brand.io/fsdfuytu",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+
+			want: want{
+				statusCode:  http.StatusFound,
+				contentType: "text/html; charset=utf-8",
+				location:    "https://brand.io/404",
+				expectURL:   nil,
+				expectErr:   fmt.Errorf("error while getting url: %w", storage_err.ErrShortURLNotFound),
+				message:     "<a href=\"https://brand.io/404\">Found</a>.\n\n",
+			},
+		},
 		{
 			name:    "request without id",
 			request: "",
@@ -82,6 +139,23 @@ func TestGetHandler(t *testing.T) {
 				message:     errors.ShortURLNotInDB + "\n",
 			},
 		},
+		{
+			name:    "disabled URL",
+			request: "aHR0cHM6",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+
+			want: want{
+				statusCode:  http.StatusForbidden,
+				contentType: "text/plain; charset=utf-8",
+				location:    "",
+				expectURL:   nil,
+				expectErr:   fmt.Errorf("error while getting url: %w", storage_err.ErrURLDisabled),
+				message:     errors.ShortURLDisabled + "\n",
+			},
+		},
 		{
 			name:    "missing URL",
 			request: "/fsdfuytu",
@@ -104,22 +178,37 @@ func TestGetHandler(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			request := httptest.NewRequest(http.MethodGet, "/{url}", nil)
+			if test.host != "" {
+				request.Host = test.host
+			}
 			writer := httptest.NewRecorder()
 
 			rctx := chi.NewRouteContext()
 			rctx.URLParams.Add("url", test.request)
 
+			key := test.key
+			if key == "" {
+				key = test.request
+			}
+
 			if test.exitBeforeGetting {
 				s.EXPECT().GetURL(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
 			} else {
-				s.EXPECT().GetURL(gomock.Any(), gomock.Any(), gomock.Any()).
+				eKey, err := entity.ParseURL(key)
+				require.NoError(t, err)
+
+				s.EXPECT().GetURL(gomock.Any(), gomock.Any(), *eKey).
 					Return(test.want.expectURL, test.want.expectErr)
 			}
 
+			if test.want.expectErr == nil && !test.exitBeforeGetting {
+				s.EXPECT().RecordClick(gomock.Any(), key).Return(nil)
+			}
+
 			request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, rctx))
 			request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, test.userIDCtx))
 
-			handler := URLHandler(s)
+			handler := URLHandler(s, newTestDomains(t, baseURIPrefix))
 			handler(writer, request)
 
 			res := writer.Result()
@@ -159,7 +248,7 @@ func TestGetUserURLHandler(t *testing.T) {
 			OriginalURL: "https://yandex.ru/",
 		},
 		{
-			ShortURL:    "ac6bb669",
+			ShortURL:    "go.brand.io/ac6bb669",
 			OriginalURL: "https://www.google.com",
 		},
 	}
@@ -175,7 +264,7 @@ func TestGetUserURLHandler(t *testing.T) {
 			"original_url": "https://yandex.ru/"
 		},
 		{
-			"short_url": "http://localhost:8080/ac6bb669",
+			"short_url": "https://go.brand.io/ac6bb669",
 			"original_url": "https://www.google.com"
 		}
 	]`)
@@ -299,7 +388,7 @@ func TestGetUserURLHandler(t *testing.T) {
 				s.EXPECT().GetAllURLByUserID(gomock.Any(), gomock.Any()).Return(test.outputStorageBatch, test.want.expectErr)
 			}
 
-			handler := UserURLsHandler(s, test.baseURIPrefix)
+			handler := UserURLsHandler(s, newTestDomains(t, test.baseURIPrefix))
 			handler(writer, request)
 
 			res := writer.Result()
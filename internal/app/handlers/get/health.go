@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/health"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+)
+
+// LivenessHandler Processes GET "/healthz" endpoint
+//
+// Returns 200(StatusOK) while application process is running
+func LivenessHandler() http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		writeHealthResponse(writer, req, http.StatusOK, health.Report{
+			Status: health.StatusUp,
+		})
+	}
+}
+
+// ReadinessHandler Processes GET "/readyz" endpoint. Checks application components
+//
+// Returns 200(StatusOK) if all components are ready
+// Returns 503(StatusServiceUnavailable) if at least one component is not ready
+func ReadinessHandler(checker *health.Checker) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		report := checker.Check(req.Context())
+
+		statusCode := http.StatusOK
+		if !report.IsUp() {
+			logger.FromContext(req.Context()).Warn("application is not ready", zap.Any("components", report.Components))
+			statusCode = http.StatusServiceUnavailable
+		}
+
+		writeHealthResponse(writer, req, statusCode, report)
+	}
+}
+
+func writeHealthResponse(writer http.ResponseWriter, req *http.Request, statusCode int, report health.Report) {
+	out, err := json.Marshal(report)
+	if err != nil {
+		logger.FromContext(req.Context()).Error("error while converting health report to output", zap.Error(err))
+		writer.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.Header().Set("Cache-Control", "no-store")
+	writer.WriteHeader(statusCode)
+	writer.Write(out)
+}
@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/health"
+)
+
+func TestLivenessHandler(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	writer := httptest.NewRecorder()
+
+	LivenessHandler()(writer, request)
+
+	res := writer.Result()
+	defer res.Body.Close()
+
+	var report health.Report
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&report))
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, health.StatusUp, report.Status)
+}
+
+func TestReadinessHandler(t *testing.T) {
+	type want struct {
+		statusCode int
+		status     string
+		storage    string
+	}
+	tests := []struct {
+		name       string
+		storageErr error
+		want       want
+	}{
+		{
+			name:       "storage is up",
+			storageErr: nil,
+			want: want{
+				statusCode: http.StatusOK,
+				status:     health.StatusUp,
+				storage:    health.StatusUp,
+			},
+		},
+		{
+			name:       "storage is down",
+			storageErr: errors.New("connection refused"),
+			want: want{
+				statusCode: http.StatusServiceUnavailable,
+				status:     health.StatusDown,
+				storage:    health.StatusDown,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checker := health.NewChecker()
+			checker.Add(health.ComponentStorage, func(context.Context) error { return test.storageErr })
+			checker.Add(health.ComponentDeleteFlusher, func(context.Context) error { return nil })
+
+			request := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			writer := httptest.NewRecorder()
+
+			ReadinessHandler(checker)(writer, request)
+
+			res := writer.Result()
+			defer res.Body.Close()
+
+			var report health.Report
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&report))
+
+			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+			assert.Equal(t, test.want.status, report.Status)
+			assert.Equal(t, test.want.storage, report.Components[health.ComponentStorage].Status)
+			assert.Equal(t, health.StatusUp, report.Components[health.ComponentDeleteFlusher].Status)
+		})
+	}
+}
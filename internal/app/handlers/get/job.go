@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// DeleteJobGetter Getter for deletion job request
+type DeleteJobGetter interface {
+	GetDeleteJob(ctx context.Context, userID entity.UserID, jobID string) (entity.DeleteJob, error)
+}
+
+// DeleteJobHandler Processes GET "/api/user/jobs/{id}" endpoint. Sends state of user deletion job
+//
+// Returns 200(StatusOK) if processing was successful
+// Returns 404(StatusNotFound) if job is not found for user
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 500(StatusInternalServerError) if user ID is invalid
+// Returns 500(StatusInternalServerError) when database error
+func DeleteJobHandler(getter DeleteJobGetter) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
+		if !ok {
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context while delete job processing")
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if code := validateUserIDCtx(req.Context(), userIDCtx); code != http.StatusOK {
+			writer.WriteHeader(code)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		job, err := getter.GetDeleteJob(ctx, userIDCtx.UserID, chi.URLParam(req, "id"))
+		if err != nil {
+			if errors.Is(err, storage_err.ErrDeleteJobNotFound) {
+				http.Error(writer, "delete job is not found", http.StatusNotFound)
+				return
+			}
+
+			logger.FromContext(req.Context()).Error("error while getting delete job", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		out, err := json.Marshal(deleteJobToResponse(job))
+		if err != nil {
+			logger.FromContext(req.Context()).Error("error while converting delete job to output", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		writer.Write(out)
+	}
+}
+
+func deleteJobToResponse(job entity.DeleteJob) models.DeleteJobResponse {
+	res := models.DeleteJobResponse{
+		JobID:     job.ID,
+		Status:    string(job.Status),
+		URLsCount: len(job.ShortURLs),
+		Attempts:  job.Attempts,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	}
+
+	if job.Status == entity.DeleteJobPending && job.Attempts != 0 {
+		res.NextAttemptAt = &job.NextAttemptAt
+	}
+
+	return res
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/handlers/get/mock"
+	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+func TestDeleteJobHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := mock.NewMockDeleteJobGetter(ctrl)
+
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name      string
+		jobID     string
+		userIDCtx entity.UserIDCtx
+		job       entity.DeleteJob
+		err       error
+		want      want
+	}{
+		{
+			name:  "done job",
+			jobID: "job",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			job: entity.DeleteJob{
+				ID:        "job",
+				UserID:    userID,
+				ShortURLs: []string{"42b3e75f", "77fca595"},
+				Status:    entity.DeleteJobDone,
+				Attempts:  1,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{"job_id":"job","status":"done","urls_count":2,"attempts":1,` +
+					`"created_at":"2026-10-19T12:00:00Z","updated_at":"2026-10-19T12:00:00Z"}`,
+			},
+		},
+		{
+			name:  "retried job",
+			jobID: "job",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			job: entity.DeleteJob{
+				ID:            "job",
+				UserID:        userID,
+				ShortURLs:     []string{"42b3e75f"},
+				Status:        entity.DeleteJobPending,
+				Attempts:      2,
+				LastError:     "storage is unavailable",
+				NextAttemptAt: createdAt.Add(time.Minute),
+				CreatedAt:     createdAt,
+				UpdatedAt:     createdAt,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{"job_id":"job","status":"pending","urls_count":1,"attempts":2,"next_attempt_at":"2026-10-19T12:01:00Z",` +
+					`"created_at":"2026-10-19T12:00:00Z","updated_at":"2026-10-19T12:00:00Z"}`,
+			},
+		},
+		{
+			name:  "job not found",
+			jobID: "unknown",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			err: storage_err.ErrDeleteJobNotFound,
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name:  "storage error",
+			jobID: "job",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			err: errors.New("storage is unavailable"),
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "unauthorized user",
+			userIDCtx: entity.UserIDCtx{
+				StatusCode: http.StatusUnauthorized,
+			},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.jobID != "" {
+				s.EXPECT().GetDeleteJob(gomock.Any(), userID, test.jobID).Return(test.job, test.err)
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/api/user/jobs/"+test.jobID, nil)
+			writer := httptest.NewRecorder()
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", test.jobID)
+			ctx := context.WithValue(request.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, entity.UserIDCtxKey{}, test.userIDCtx)
+
+			DeleteJobHandler(s)(writer, request.WithContext(ctx))
+
+			res := writer.Result()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			if test.want.body != "" {
+				assert.JSONEq(t, test.want.body, string(body))
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: activity.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/avGenie/url-shortener/internal/app/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockActivityLister is a mock of ActivityLister interface.
+type MockActivityLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityListerMockRecorder
+}
+
+// MockActivityListerMockRecorder is the mock recorder for MockActivityLister.
+type MockActivityListerMockRecorder struct {
+	mock *MockActivityLister
+}
+
+// NewMockActivityLister creates a new mock instance.
+func NewMockActivityLister(ctrl *gomock.Controller) *MockActivityLister {
+	mock := &MockActivityLister{ctrl: ctrl}
+	mock.recorder = &MockActivityListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityLister) EXPECT() *MockActivityListerMockRecorder {
+	return m.recorder
+}
+
+// Activity mocks base method.
+func (m *MockActivityLister) Activity(ctx context.Context, userID entity.UserID, filter entity.AuditFilter, pageSize int, pageToken string) ([]entity.AuditRecord, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Activity", ctx, userID, filter, pageSize, pageToken)
+	ret0, _ := ret[0].([]entity.AuditRecord)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Activity indicates an expected call of Activity.
+func (mr *MockActivityListerMockRecorder) Activity(ctx, userID, filter, pageSize, pageToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Activity", reflect.TypeOf((*MockActivityLister)(nil).Activity), ctx, userID, filter, pageSize, pageToken)
+}
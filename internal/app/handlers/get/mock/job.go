@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/handlers/get/job.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/avGenie/url-shortener/internal/app/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDeleteJobGetter is a mock of DeleteJobGetter interface.
+type MockDeleteJobGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeleteJobGetterMockRecorder
+}
+
+// MockDeleteJobGetterMockRecorder is the mock recorder for MockDeleteJobGetter.
+type MockDeleteJobGetterMockRecorder struct {
+	mock *MockDeleteJobGetter
+}
+
+// NewMockDeleteJobGetter creates a new mock instance.
+func NewMockDeleteJobGetter(ctrl *gomock.Controller) *MockDeleteJobGetter {
+	mock := &MockDeleteJobGetter{ctrl: ctrl}
+	mock.recorder = &MockDeleteJobGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeleteJobGetter) EXPECT() *MockDeleteJobGetterMockRecorder {
+	return m.recorder
+}
+
+// GetDeleteJob mocks base method.
+func (m *MockDeleteJobGetter) GetDeleteJob(ctx context.Context, userID entity.UserID, jobID string) (entity.DeleteJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleteJob", ctx, userID, jobID)
+	ret0, _ := ret[0].(entity.DeleteJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleteJob indicates an expected call of GetDeleteJob.
+func (mr *MockDeleteJobGetterMockRecorder) GetDeleteJob(ctx, userID, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleteJob", reflect.TypeOf((*MockDeleteJobGetter)(nil).GetDeleteJob), ctx, userID, jobID)
+}
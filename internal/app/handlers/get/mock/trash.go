@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/handlers/get/trash.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/avGenie/url-shortener/internal/app/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTrashLister is a mock of TrashLister interface.
+type MockTrashLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashListerMockRecorder
+}
+
+// MockTrashListerMockRecorder is the mock recorder for MockTrashLister.
+type MockTrashListerMockRecorder struct {
+	mock *MockTrashLister
+}
+
+// NewMockTrashLister creates a new mock instance.
+func NewMockTrashLister(ctrl *gomock.Controller) *MockTrashLister {
+	mock := &MockTrashLister{ctrl: ctrl}
+	mock.recorder = &MockTrashListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashLister) EXPECT() *MockTrashListerMockRecorder {
+	return m.recorder
+}
+
+// ExpiresAt mocks base method.
+func (m *MockTrashLister) ExpiresAt(link entity.Link) time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpiresAt", link)
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// ExpiresAt indicates an expected call of ExpiresAt.
+func (mr *MockTrashListerMockRecorder) ExpiresAt(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpiresAt", reflect.TypeOf((*MockTrashLister)(nil).ExpiresAt), link)
+}
+
+// List mocks base method.
+func (m *MockTrashLister) List(ctx context.Context, userID entity.UserID) ([]entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTrashListerMockRecorder) List(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTrashLister)(nil).List), ctx, userID)
+}
+
+// ShortURL mocks base method.
+func (m *MockTrashLister) ShortURL(link entity.Link) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShortURL", link)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ShortURL indicates an expected call of ShortURL.
+func (mr *MockTrashListerMockRecorder) ShortURL(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortURL", reflect.TypeOf((*MockTrashLister)(nil).ShortURL), link)
+}
@@ -51,6 +51,20 @@ func (mr *MockURLGetterMockRecorder) GetURL(ctx, userID, key interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURL", reflect.TypeOf((*MockURLGetter)(nil).GetURL), ctx, userID, key)
 }
 
+// RecordClick mocks base method.
+func (m *MockURLGetter) RecordClick(ctx context.Context, shortURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClick", ctx, shortURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClick indicates an expected call of RecordClick.
+func (mr *MockURLGetterMockRecorder) RecordClick(ctx, shortURL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClick", reflect.TypeOf((*MockURLGetter)(nil).RecordClick), ctx, shortURL)
+}
+
 // MockAllURLGetter is a mock of AllURLGetter interface.
 type MockAllURLGetter struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/handlers/get/usage.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/avGenie/url-shortener/internal/app/entity"
+	models "github.com/avGenie/url-shortener/internal/app/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUsageGetter is a mock of UsageGetter interface.
+type MockUsageGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsageGetterMockRecorder
+}
+
+// MockUsageGetterMockRecorder is the mock recorder for MockUsageGetter.
+type MockUsageGetterMockRecorder struct {
+	mock *MockUsageGetter
+}
+
+// NewMockUsageGetter creates a new mock instance.
+func NewMockUsageGetter(ctrl *gomock.Controller) *MockUsageGetter {
+	mock := &MockUsageGetter{ctrl: ctrl}
+	mock.recorder = &MockUsageGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsageGetter) EXPECT() *MockUsageGetterMockRecorder {
+	return m.recorder
+}
+
+// Usage mocks base method.
+func (m *MockUsageGetter) Usage(ctx context.Context, userID entity.UserID) (models.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Usage", ctx, userID)
+	ret0, _ := ret[0].(models.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Usage indicates an expected call of Usage.
+func (mr *MockUsageGetterMockRecorder) Usage(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Usage", reflect.TypeOf((*MockUsageGetter)(nil).Usage), ctx, userID)
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/logger"
+)
+
+// OpenAPIHandler Processes GET "/api/v2/openapi.json" endpoint
+//
+// Returns 200(StatusOK) with OpenAPI document of REST API
+func OpenAPIHandler(document []byte) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+
+		_, err := writer.Write(document)
+		if err != nil {
+			logger.FromContext(req.Context()).Error("error while writing openapi document", zap.Error(err))
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"net/http"
 
 	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/logger"
 )
 
 // StoragePinger Interface to ping storage
@@ -26,17 +28,17 @@ func PingDBHandler(pinger StoragePinger) http.HandlerFunc {
 		if err != nil {
 			switch {
 			case errors.Is(err, context.Canceled):
-				zap.L().Error("context canceled", zap.String("error", err.Error()))
+				logger.FromContext(req.Context()).Error("context canceled", zap.String("error", err.Error()))
 			case errors.Is(err, context.DeadlineExceeded):
-				zap.L().Error("context deadline exceeded", zap.String("error", err.Error()))
+				logger.FromContext(req.Context()).Error("context deadline exceeded", zap.String("error", err.Error()))
 			default:
-				zap.L().Error(err.Error())
+				logger.FromContext(req.Context()).Error(err.Error())
 			}
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		zap.L().Info("storage works after ping")
+		logger.FromContext(req.Context()).Info("storage works after ping")
 
 		writer.WriteHeader(http.StatusOK)
 	}
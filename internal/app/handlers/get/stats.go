@@ -3,41 +3,65 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/avGenie/url-shortener/internal/app/logger"
 	"github.com/avGenie/url-shortener/internal/app/models"
 	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
 	"go.uber.org/zap"
 )
 
-const realIPKey = "X-Real-IP"
+// dateLayout Layout of date accepted in statistic range in addition to RFC 3339 time
+const dateLayout = "2006-01-02"
 
 // StatisticGetter Getter for service statistic request
 type StatisticGetter interface {
-	GetStatistic(ctx context.Context) (models.CountStatistic, error)
+	DetailedStatistic(ctx context.Context, query models.StatisticQuery) (models.ServiceStatistic, error)
 }
 
 // StatsHandler Processes service statistic request
 //
+// Query parameters:
+// from, to - range of counted created links in RFC 3339 or YYYY-MM-DD format,
+// granularity - "hour" or "day" buckets of created links,
+// top - count of top domains and top clicked links.
 // Returns 200(StatusOk) if processing was successful
+// Returns 400(StatusBadRequest) when query parameters are invalid
 // Returns 500(StatusInternalServerError) when parsing or DB request errors
 // Returns 403(StatusForbidden) when request forbidden for given IP
-func StatsHandler(statGetter StatisticGetter, cidr *cidr.CIDR) http.HandlerFunc {
+func StatsHandler(statGetter StatisticGetter, control *cidr.AccessControl) http.HandlerFunc {
 	return func(writer http.ResponseWriter, req *http.Request) {
-		zap.L().Debug("stats handler URL processing")
+		logger.FromContext(req.Context()).Debug("stats handler URL processing")
 
-		err := processCIDR(req, cidr)
+		err := processCIDR(req, control)
 		if err != nil {
-			zap.L().Info("forbidden to get statistic", zap.Error(err))
+			logger.FromContext(req.Context()).Info("forbidden to get statistic", zap.Error(err))
 
 			writer.WriteHeader(http.StatusForbidden)
 
 			return
 		}
 
-		stat, err := ProcessServiceStatistic(req.Context(), statGetter)
+		query, err := parseStatisticQuery(req)
 		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		stat, err := ProcessServiceStatistic(req.Context(), statGetter, query)
+		if err != nil {
+			if errors.Is(err, link.ErrInvalidStatisticQuery) {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+
+				return
+			}
+
 			writer.WriteHeader(http.StatusInternalServerError)
 
 			return
@@ -45,7 +69,7 @@ func StatsHandler(statGetter StatisticGetter, cidr *cidr.CIDR) http.HandlerFunc
 
 		out, err := json.Marshal(stat)
 		if err != nil {
-			zap.L().Error("error while converting service statistic to output", zap.Error(err))
+			logger.FromContext(req.Context()).Error("error while converting service statistic to output", zap.Error(err))
 
 			writer.WriteHeader(http.StatusInternalServerError)
 
@@ -58,33 +82,80 @@ func StatsHandler(statGetter StatisticGetter, cidr *cidr.CIDR) http.HandlerFunc
 	}
 }
 
-func processCIDR(req *http.Request, cidr *cidr.CIDR) error {
-	if cidr == nil {
+func processCIDR(req *http.Request, control *cidr.AccessControl) error {
+	if control == nil {
 		return fmt.Errorf("subnet unknown")
 	}
 
-	userIP := req.Header.Get(realIPKey)
-	isSubnet := cidr.Contains(userIP)
+	userIP := control.RequestIP(req)
+	isSubnet := control.Allowed(userIP)
 	if !isSubnet {
-		return fmt.Errorf("user ip is not in subnet")
+		return fmt.Errorf("user ip %s is not in subnet", userIP)
 	}
 
 	return nil
 }
 
-func ProcessServiceStatistic(ctx context.Context, statGetter StatisticGetter) (models.CountStatistic, error) {
+// ProcessServiceStatistic Returns service statistic by query
+func ProcessServiceStatistic(ctx context.Context, statGetter StatisticGetter, query models.StatisticQuery) (models.ServiceStatistic, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	stat, err := statGetter.GetStatistic(ctx)
+	stat, err := statGetter.DetailedStatistic(ctx, query)
 	if err != nil {
-		zap.L().Error(
+		logger.FromContext(ctx).Error(
 			"error while getting statistic",
 			zap.String("error", err.Error()),
 		)
 
-		return models.CountStatistic{}, err
+		return models.ServiceStatistic{}, err
 	}
 
 	return stat, nil
 }
+
+func parseStatisticQuery(req *http.Request) (models.StatisticQuery, error) {
+	values := req.URL.Query()
+
+	from, err := parseStatisticTime("from", values.Get("from"))
+	if err != nil {
+		return models.StatisticQuery{}, err
+	}
+
+	to, err := parseStatisticTime("to", values.Get("to"))
+	if err != nil {
+		return models.StatisticQuery{}, err
+	}
+
+	var top int
+	if value := values.Get("top"); value != "" {
+		top, err = strconv.Atoi(value)
+		if err != nil {
+			return models.StatisticQuery{}, fmt.Errorf("top: invalid number %q", value)
+		}
+	}
+
+	return models.StatisticQuery{
+		From:        from,
+		To:          to,
+		Granularity: values.Get("granularity"),
+		Top:         top,
+	}, nil
+}
+
+func parseStatisticTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: time %q must be in RFC 3339 or YYYY-MM-DD format", name, value)
+	}
+
+	return t, nil
+}
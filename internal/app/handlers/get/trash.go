@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+)
+
+// TrashLister Lister of deleted user links which can be restored
+type TrashLister interface {
+	List(ctx context.Context, userID entity.UserID) ([]entity.Link, error)
+	ShortURL(link entity.Link) string
+	ExpiresAt(link entity.Link) time.Time
+}
+
+// TrashHandler Processes GET "/api/user/urls/trash" endpoint. Sends deleted user URLs which can be restored
+//
+// Returns 200(StatusOK) if processing was successful
+// Returns 204(StatusNoContent) if trash of user is empty
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 500(StatusInternalServerError) if user ID is invalid
+// Returns 500(StatusInternalServerError) when database error
+func TrashHandler(lister TrashLister) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
+		if !ok {
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context while trash processing")
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if code := validateUserIDCtx(req.Context(), userIDCtx); code != http.StatusOK {
+			writer.WriteHeader(code)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		links, err := lister.List(ctx, userIDCtx.UserID)
+		if err != nil {
+			logger.FromContext(req.Context()).Error("error while listing deleted user urls", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if len(links) == 0 {
+			writer.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		response := make([]models.TrashURLResponse, 0, len(links))
+		for _, link := range links {
+			response = append(response, models.TrashURLResponse{
+				ShortURL:    lister.ShortURL(link),
+				OriginalURL: link.OriginalURL,
+				DeletedAt:   link.DeletedAt,
+				ExpiresAt:   lister.ExpiresAt(link),
+			})
+		}
+
+		out, err := json.Marshal(response)
+		if err != nil {
+			logger.FromContext(req.Context()).Error("error while converting deleted user urls to output", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		writer.Write(out)
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/handlers/get/mock"
+)
+
+func TestTrashHandler(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	deletedAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name      string
+		userIDCtx entity.UserIDCtx
+		links     []entity.Link
+		err       error
+		want      want
+	}{
+		{
+			name: "deleted links",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			links: []entity.Link{
+				{
+					ShortURL:    "42b3e75f",
+					OriginalURL: "https://practicum.yandex.ru/",
+					UserID:      userID,
+					Deleted:     true,
+					DeletedAt:   deletedAt,
+				},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `[{"short_url":"http://localhost:8080/42b3e75f","original_url":"https://practicum.yandex.ru/",` +
+					`"deleted_at":"2026-10-19T12:00:00Z","expires_at":"2026-10-26T12:00:00Z"}]`,
+			},
+		},
+		{
+			name: "empty trash",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name: "storage error",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			err: errors.New("storage is unavailable"),
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "unauthorized user",
+			userIDCtx: entity.UserIDCtx{
+				StatusCode: http.StatusUnauthorized,
+			},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mock.NewMockTrashLister(ctrl)
+			if test.userIDCtx.StatusCode == http.StatusOK {
+				s.EXPECT().List(gomock.Any(), userID).Return(test.links, test.err)
+			}
+			s.EXPECT().ShortURL(gomock.Any()).DoAndReturn(func(link entity.Link) string {
+				return "http://localhost:8080/" + link.ShortURL
+			}).AnyTimes()
+			s.EXPECT().ExpiresAt(gomock.Any()).DoAndReturn(func(link entity.Link) time.Time {
+				return link.DeletedAt.Add(7 * 24 * time.Hour)
+			}).AnyTimes()
+
+			request := httptest.NewRequest(http.MethodGet, "/api/user/urls/trash", nil)
+			writer := httptest.NewRecorder()
+
+			ctx := context.WithValue(request.Context(), entity.UserIDCtxKey{}, test.userIDCtx)
+
+			TrashHandler(s)(writer, request.WithContext(ctx))
+
+			res := writer.Result()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			if test.want.body != "" {
+				assert.JSONEq(t, test.want.body, string(body))
+			}
+		})
+	}
+}
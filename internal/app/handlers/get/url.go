@@ -7,15 +7,18 @@ import (
 
 	"github.com/avGenie/url-shortener/internal/app/entity"
 	handler_err "github.com/avGenie/url-shortener/internal/app/handlers/errors"
+	"github.com/avGenie/url-shortener/internal/app/logger"
 	"github.com/avGenie/url-shortener/internal/app/models"
 	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
 	"github.com/go-chi/chi/v5"
 	"go.uber.org/zap"
 )
 
-// URLGetter Interface to get URL from storage
+// URLGetter Interface to get URL from storage and to record redirects by short URL
 type URLGetter interface {
 	GetURL(ctx context.Context, userID entity.UserID, key entity.URL) (*entity.URL, error)
+	RecordClick(ctx context.Context, shortURL string) error
 }
 
 // AllURLGetter Interface to get all URLs from storage
@@ -25,13 +28,18 @@ type AllURLGetter interface {
 
 // URLHandler Processes GET "/" endpoint. Sends the source address at the given short address
 //
+// Short address is resolved within domain which received request.
+// Redirect is counted in click statistic of short URL.
 // Returns 307(StatusTemporaryRedirect) if processing was successful
+// Returns 302(StatusFound) with not found page of domain if requested URL is not found
 // Returns 500(StatusInternalServerError) when URL parsing fails
 // Returns 410(StatusGone) if requested URL has been deleted
-// Returns 400(StatusBadRequest) if requested URL is not found
-func URLHandler(getter URLGetter) http.HandlerFunc {
+// Returns 403(StatusForbidden) if requested URL is disabled by admin
+// Returns 400(StatusBadRequest) if requested URL is not found and domain has no not found page
+func URLHandler(getter URLGetter, domains *domain.Registry) http.HandlerFunc {
 	return func(writer http.ResponseWriter, req *http.Request) {
-		shortURL := chi.URLParam(req, "url")
+		dom := domains.Resolve(req.Host)
+		shortURL := dom.Key(chi.URLParam(req, "url"))
 
 		var userID entity.UserID
 		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
@@ -39,15 +47,15 @@ func URLHandler(getter URLGetter) http.HandlerFunc {
 			if userIDCtx.StatusCode == http.StatusOK {
 				userID = userIDCtx.UserID
 			} else {
-				zap.L().Info("user id couldn't obtain from context")
+				logger.FromContext(req.Context()).Info("user id couldn't obtain from context")
 			}
 		} else {
-			zap.L().Info("user id is empty from context")
+			logger.FromContext(req.Context()).Info("user id is empty from context")
 		}
 
 		eShortURL, err := entity.ParseURL(shortURL)
 		if err != nil {
-			zap.L().Error(
+			logger.FromContext(req.Context()).Error(
 				"error while parsing short url",
 				zap.String("error", err.Error()),
 				zap.String("short_url", shortURL),
@@ -66,7 +74,17 @@ func URLHandler(getter URLGetter) http.HandlerFunc {
 				return
 			}
 
-			zap.L().Error(
+			if errors.Is(err, storage_err.ErrURLDisabled) {
+				http.Error(writer, handler_err.ShortURLDisabled, http.StatusForbidden)
+				return
+			}
+
+			if errors.Is(err, storage_err.ErrShortURLNotFound) && dom.NotFoundURL != "" {
+				http.Redirect(writer, req, dom.NotFoundURL, http.StatusFound)
+				return
+			}
+
+			logger.FromContext(req.Context()).Error(
 				"error while getting url",
 				zap.String("error", err.Error()),
 				zap.String("short_url", shortURL),
@@ -76,6 +94,15 @@ func URLHandler(getter URLGetter) http.HandlerFunc {
 			return
 		}
 
+		err = getter.RecordClick(ctx, eShortURL.String())
+		if err != nil {
+			logger.FromContext(req.Context()).Warn(
+				"error while recording click",
+				zap.Error(err),
+				zap.String("short_url", shortURL),
+			)
+		}
+
 		writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
 		writer.Header().Set("Location", url.String())
 		writer.WriteHeader(http.StatusTemporaryRedirect)
@@ -85,27 +112,27 @@ func URLHandler(getter URLGetter) http.HandlerFunc {
 // UserURLsHandler Processes GET "/api/user/urls" endpoint. Sends all user URLs
 //
 // Returns 200(StatusOK) if processing was successful
-// Returns 500(StatusInternalServerError) if base URI prefix is invalid
+// Returns 500(StatusInternalServerError) if domains are not set
 // Returns 500(StatusInternalServerError) if user ID is invalid
 // Returns 500(StatusInternalServerError) when database error
 // Returns 401(StatusUnauthorized) if requested URL has been deleted
 // Returns 204(StatusNoContent) if URLs for user is not found
-func UserURLsHandler(getter AllURLGetter, baseURIPrefix string) http.HandlerFunc {
+func UserURLsHandler(getter AllURLGetter, domains *domain.Registry) http.HandlerFunc {
 	return func(writer http.ResponseWriter, req *http.Request) {
-		if baseURIPrefix == "" {
-			zap.L().Error("invalid base URI prefix", zap.String("base URI prefix", baseURIPrefix))
+		if domains == nil {
+			logger.FromContext(req.Context()).Error("domains of short URLs are not set")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
 		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
 		if !ok {
-			zap.L().Error("user id couldn't obtain from context while all user urls processing")
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context while all user urls processing")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		if code := validateUserIDCtx(userIDCtx); code != http.StatusOK {
+		if code := validateUserIDCtx(req.Context(), userIDCtx); code != http.StatusOK {
 			writer.WriteHeader(code)
 			return
 		}
@@ -113,7 +140,7 @@ func UserURLsHandler(getter AllURLGetter, baseURIPrefix string) http.HandlerFunc
 		ctx, cancel := context.WithTimeout(req.Context(), timeout)
 		defer cancel()
 
-		out, err := ProcessAllUserURL(getter, ctx, userIDCtx.UserID, baseURIPrefix)
+		out, err := ProcessAllUserURL(getter, ctx, userIDCtx.UserID, domains)
 		if err != nil {
 			if errors.Is(err, ErrAllURLNotFound) {
 				writer.WriteHeader(http.StatusNoContent)
@@ -130,14 +157,14 @@ func UserURLsHandler(getter AllURLGetter, baseURIPrefix string) http.HandlerFunc
 	}
 }
 
-func validateUserIDCtx(userIDCtx entity.UserIDCtx) int {
+func validateUserIDCtx(ctx context.Context, userIDCtx entity.UserIDCtx) int {
 	if userIDCtx.StatusCode == http.StatusUnauthorized {
-		zap.L().Error("user id couldn't obtain from context")
+		logger.FromContext(ctx).Error("user id couldn't obtain from context")
 		return userIDCtx.StatusCode
 	}
 
 	if len(userIDCtx.UserID.String()) == 0 {
-		zap.L().Error("empty user id from context")
+		logger.FromContext(ctx).Error("empty user id from context")
 		return http.StatusInternalServerError
 	}
 
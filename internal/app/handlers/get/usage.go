@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+)
+
+// UsageGetter Getter for usage of user quota
+type UsageGetter interface {
+	Usage(ctx context.Context, userID entity.UserID) (models.Usage, error)
+}
+
+// UsageHandler Processes GET "/api/user/usage" endpoint. Sends usage of user quota with limits of user plan
+//
+// Limits which aren't set in user plan are omitted.
+// Returns 200(StatusOK) if processing was successful
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 500(StatusInternalServerError) if user ID is invalid
+// Returns 500(StatusInternalServerError) when database error
+func UsageHandler(getter UsageGetter) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
+		if !ok {
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context while usage processing")
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if code := validateUserIDCtx(req.Context(), userIDCtx); code != http.StatusOK {
+			writer.WriteHeader(code)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		usage, err := getter.Usage(ctx, userIDCtx.UserID)
+		if err != nil {
+			logger.FromContext(req.Context()).Error("error while getting usage of user quota", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		out, err := json.Marshal(usage)
+		if err != nil {
+			logger.FromContext(req.Context()).Error("error while converting usage of user quota to output", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		writer.Write(out)
+	}
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/handlers/get/mock"
+	"github.com/avGenie/url-shortener/internal/app/models"
+)
+
+func TestUsageHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := mock.NewMockUsageGetter(ctrl)
+
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	resetAt := time.Date(2026, time.October, 20, 0, 0, 0, 0, time.UTC)
+
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name      string
+		userIDCtx entity.UserIDCtx
+		usage     *models.Usage
+		err       error
+		want      want
+	}{
+		{
+			name: "usage of plan",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			usage: &models.Usage{
+				Plan:          "free",
+				ActiveLinks:   models.QuotaCounter{Used: 3, Limit: 100},
+				LinksToday:    models.QuotaCounter{Used: 2, Limit: 10, ResetAt: &resetAt},
+				TrackedClicks: models.QuotaCounter{Used: 7},
+				MaxBatchSize:  5,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body: `{"plan":"free","active_links":{"used":3,"limit":100},` +
+					`"links_today":{"used":2,"limit":10,"reset_at":"2026-10-20T00:00:00Z"},` +
+					`"tracked_clicks":{"used":7},"max_batch_size":5}`,
+			},
+		},
+		{
+			name: "storage error",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			usage: &models.Usage{},
+			err:   errors.New("storage is unavailable"),
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name: "unauthorized user",
+			userIDCtx: entity.UserIDCtx{
+				StatusCode: http.StatusUnauthorized,
+			},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.usage != nil {
+				s.EXPECT().Usage(gomock.Any(), userID).Return(*test.usage, test.err)
+			}
+
+			request := httptest.NewRequest(http.MethodGet, "/api/user/usage", nil)
+			request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, test.userIDCtx))
+			writer := httptest.NewRecorder()
+
+			UsageHandler(s)(writer, request)
+
+			res := writer.Result()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			if test.want.body != "" {
+				assert.JSONEq(t, test.want.body, string(body))
+			}
+		})
+	}
+}
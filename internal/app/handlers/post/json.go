@@ -8,38 +8,45 @@ import (
 
 	"github.com/avGenie/url-shortener/internal/app/entity"
 	post_err "github.com/avGenie/url-shortener/internal/app/handlers/errors"
+	"github.com/avGenie/url-shortener/internal/app/logger"
 	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/quota"
 	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
 	"go.uber.org/zap"
 )
 
 // JSONHandler Processes POST "/api/shorten" endpoint. Save original and short URLs to storage
 //
+// Short URL is created on domain from request, otherwise on domain which received request.
 // Returns 201(StatusCreated) if processing was successfully
-// Returns 500(StatusInternalServerError) if base URI prefix is invalid
+// Returns 500(StatusInternalServerError) if domains are not set
 // Returns 500(StatusInternalServerError) if user ID is invalid
 // Returns 500(StatusInternalServerError) when database error
-// Returns 400(StatusBadRequest) if original URL is invalid
+// Returns 400(StatusBadRequest) if original URL or domain is invalid
+// Returns 403(StatusForbidden) if user is not allowed to create short URLs on domain
+// Returns 403(StatusForbidden) if user exceeded quota of active links
 // Returns 409(StatusConflict) if original URL exists in storage for this user
-func JSONHandler(saver URLSaver, baseURIPrefix string) http.HandlerFunc {
+// Returns 429(StatusTooManyRequests) if user exceeded quota of links per day
+func JSONHandler(saver URLSaver, domains *domain.Registry) http.HandlerFunc {
 	return func(writer http.ResponseWriter, req *http.Request) {
-		zap.L().Debug("POST handler JSON processing")
+		logger.FromContext(req.Context()).Debug("POST handler JSON processing")
 
-		if baseURIPrefix == "" {
-			zap.L().Error("invalid base URI prefix", zap.String("base URI prefix", baseURIPrefix))
+		if domains == nil {
+			logger.FromContext(req.Context()).Error("domains of short URLs are not set")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
 		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
 		if !ok {
-			zap.L().Error("user id couldn't obtain from context while json processing")
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context while json processing")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
 		if len(userIDCtx.UserID.String()) == 0 {
-			zap.L().Error("empty user id from context while posting user url")
+			logger.FromContext(req.Context()).Error("empty user id from context while posting user url")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -48,30 +55,41 @@ func JSONHandler(saver URLSaver, baseURIPrefix string) http.HandlerFunc {
 		err := json.NewDecoder(req.Body).Decode(&inputRequest)
 		defer req.Body.Close()
 		if err != nil {
-			zap.L().Error(post_err.CannotProcessJSON, zap.Error(err))
+			logger.FromContext(req.Context()).Error(post_err.CannotProcessJSON, zap.Error(err))
 			http.Error(writer, post_err.WrongJSONFormat, http.StatusBadRequest)
 			return
 		}
 
 		if ok := entity.IsValidURL(inputRequest.URL); !ok {
-			zap.L().Error(post_err.WrongJSONFormat, zap.Error(err))
+			logger.FromContext(req.Context()).Error(post_err.WrongJSONFormat, zap.Error(err))
 			http.Error(writer, post_err.WrongJSONFormat, http.StatusBadRequest)
 			return
 		}
 
+		dom, err := domains.Select(userIDCtx.UserID, inputRequest.Domain, req.Host)
+		if err != nil {
+			logger.FromContext(req.Context()).Error("domain of short URL couldn't be selected", zap.Error(err))
+			http.Error(writer, err.Error(), domainErrorStatus(err))
+			return
+		}
+
 		ctx, cancel := context.WithTimeout(req.Context(), timeout)
 		defer cancel()
 
-		outputURL, err := PostURLProcessing(saver, ctx, userIDCtx.UserID, inputRequest.URL, baseURIPrefix)
+		outputURL, err := PostURLProcessing(saver, ctx, userIDCtx.UserID, inputRequest.URL, dom)
 
 		response := models.Response{
 			URL: outputURL,
 		}
 
 		if err != nil {
-			zap.L().Error("could not create a short URL", zap.String("error", err.Error()))
+			logger.FromContext(req.Context()).Error("could not create a short URL", zap.String("error", err.Error()))
 			if errors.Is(err, storage_err.ErrURLAlreadyExists) {
-				successJSONResponse(writer, response, http.StatusConflict)
+				successJSONResponse(req.Context(), writer, response, http.StatusConflict)
+				return
+			}
+
+			if quota.WriteHTTPError(writer, err) {
 				return
 			}
 
@@ -79,38 +97,42 @@ func JSONHandler(saver URLSaver, baseURIPrefix string) http.HandlerFunc {
 			return
 		}
 
-		successJSONResponse(writer, response, http.StatusCreated)
+		successJSONResponse(req.Context(), writer, response, http.StatusCreated)
 
-		zap.L().Debug("sending HTTP 200 response")
+		logger.FromContext(req.Context()).Debug("sending HTTP 200 response")
 	}
 }
 
 // JSONBatchHandler Processes POST "/api/shorten/batch" endpoint. Save original and short URLs to storage
 //
+// Every short URL is created on domain from its request, otherwise on domain which received request.
 // Returns 201(StatusCreated) if processing was successfully
-// Returns 500(StatusInternalServerError) if base URI prefix is invalid
+// Returns 500(StatusInternalServerError) if domains are not set
 // Returns 500(StatusInternalServerError) if user ID is invalid
 // Returns 500(StatusInternalServerError) when database error
-// Returns 400(StatusBadRequest) if input URLs is invalid
-func JSONBatchHandler(saver URLBatchSaver, baseURIPrefix string) http.HandlerFunc {
+// Returns 400(StatusBadRequest) if input URLs or domains are invalid
+// Returns 403(StatusForbidden) if user is not allowed to create short URLs on domain
+// Returns 403(StatusForbidden) if user exceeded quota of batch size or active links
+// Returns 429(StatusTooManyRequests) if user exceeded quota of links per day
+func JSONBatchHandler(saver URLBatchSaver, domains *domain.Registry) http.HandlerFunc {
 	return func(writer http.ResponseWriter, req *http.Request) {
-		zap.L().Debug("POST JSON batch handler processing")
+		logger.FromContext(req.Context()).Debug("POST JSON batch handler processing")
 
-		if baseURIPrefix == "" {
-			zap.L().Error("invalid base URI prefix", zap.String("base URI prefix", baseURIPrefix))
+		if domains == nil {
+			logger.FromContext(req.Context()).Error("domains of short URLs are not set")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
 		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
 		if !ok {
-			zap.L().Error("user id couldn't obtain from context while json batch processing")
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context while json batch processing")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
 		if len(userIDCtx.UserID.String()) == 0 {
-			zap.L().Error("empty user id from context while posting user url")
+			logger.FromContext(req.Context()).Error("empty user id from context while posting user url")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -118,7 +140,7 @@ func JSONBatchHandler(saver URLBatchSaver, baseURIPrefix string) http.HandlerFun
 		var batch models.ReqBatch
 		err := json.NewDecoder(req.Body).Decode(&batch)
 		if err != nil {
-			zap.L().Error(post_err.CannotProcessJSON, zap.Error(err))
+			logger.FromContext(req.Context()).Error(post_err.CannotProcessJSON, zap.Error(err))
 			http.Error(writer, post_err.WrongJSONFormat, http.StatusBadRequest)
 			return
 		}
@@ -127,15 +149,24 @@ func JSONBatchHandler(saver URLBatchSaver, baseURIPrefix string) http.HandlerFun
 		ctx, cancel := context.WithTimeout(req.Context(), timeout)
 		defer cancel()
 
-		outBatch, err := BatchURLProcessing(saver, ctx, userIDCtx.UserID, batch, baseURIPrefix)
+		outBatch, err := BatchURLProcessing(saver, ctx, userIDCtx.UserID, batch, domains, req.Host)
 		if err != nil {
+			if errors.Is(err, domain.ErrUnknownDomain) || errors.Is(err, domain.ErrDomainForbidden) {
+				http.Error(writer, err.Error(), domainErrorStatus(err))
+				return
+			}
+
+			if quota.WriteHTTPError(writer, err) {
+				return
+			}
+
 			http.Error(writer, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
 		out, err := json.Marshal(outBatch)
 		if err != nil {
-			zap.L().Error("error while converting storage url to output", zap.Error(err))
+			logger.FromContext(req.Context()).Error("error while converting storage url to output", zap.Error(err))
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -146,13 +177,13 @@ func JSONBatchHandler(saver URLBatchSaver, baseURIPrefix string) http.HandlerFun
 	}
 }
 
-func successJSONResponse(writer http.ResponseWriter, response models.Response, status int) {
-	zap.L().Info("url has been created succeessfully", zap.String("output url", response.URL))
+func successJSONResponse(ctx context.Context, writer http.ResponseWriter, response models.Response, status int) {
+	logger.FromContext(ctx).Info("url has been created succeessfully", zap.String("output url", response.URL))
 
 	writer.Header().Set("Content-Type", "application/json")
 	writer.WriteHeader(status)
 	if err := json.NewEncoder(writer).Encode(response); err != nil {
-		zap.L().Error("invalid response", zap.Any("response", response))
+		logger.FromContext(ctx).Error("invalid response", zap.Any("response", response))
 		http.Error(writer, "internal server error", http.StatusInternalServerError)
 		return
 	}
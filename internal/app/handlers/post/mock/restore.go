@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/handlers/post/restore.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/avGenie/url-shortener/internal/app/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockLinkRestorer is a mock of LinkRestorer interface.
+type MockLinkRestorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkRestorerMockRecorder
+}
+
+// MockLinkRestorerMockRecorder is the mock recorder for MockLinkRestorer.
+type MockLinkRestorerMockRecorder struct {
+	mock *MockLinkRestorer
+}
+
+// NewMockLinkRestorer creates a new mock instance.
+func NewMockLinkRestorer(ctrl *gomock.Controller) *MockLinkRestorer {
+	mock := &MockLinkRestorer{ctrl: ctrl}
+	mock.recorder = &MockLinkRestorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkRestorer) EXPECT() *MockLinkRestorerMockRecorder {
+	return m.recorder
+}
+
+// Restore mocks base method.
+func (m *MockLinkRestorer) Restore(ctx context.Context, userID entity.UserID, shortURLs []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, userID, shortURLs)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockLinkRestorerMockRecorder) Restore(ctx, userID, shortURLs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockLinkRestorer)(nil).Restore), ctx, userID, shortURLs)
+}
@@ -2,25 +2,26 @@ package handlers
 
 import (
 	"context"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/avGenie/url-shortener/internal/app/audit"
 	"github.com/avGenie/url-shortener/internal/app/converter"
-	"github.com/avGenie/url-shortener/internal/app/encoding"
 	"github.com/avGenie/url-shortener/internal/app/entity"
 	post_err "github.com/avGenie/url-shortener/internal/app/handlers/errors"
+	"github.com/avGenie/url-shortener/internal/app/logger"
 	"github.com/avGenie/url-shortener/internal/app/models"
 	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
 	storage "github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
 )
 
 const (
-	maxEncodedSize = 8
-	timeout        = 3 * time.Second
+	timeout = 3 * time.Second
 )
 
 // URLSaver Interface to save URL to storage
@@ -33,74 +34,94 @@ type URLBatchSaver interface {
 	SaveBatchURL(ctx context.Context, userID entity.UserID, batch storage.Batch) (storage.Batch, error)
 }
 
-// PostURLProcessing Creates URL and saves in storage
+// PostURLProcessing Creates URL on domain and saves in storage
 func PostURLProcessing(saver URLSaver, ctx context.Context, userID entity.UserID,
-	inputURL, baseURIPrefix string) (string, error) {
+	inputURL string, dom domain.Domain) (string, error) {
 	hash := createHash(inputURL)
 	if hash == "" {
 		return "", fmt.Errorf("exit to create hash")
 	}
 
-	shortURL, err := entity.ParseURL(hash)
+	shortURL, err := entity.ParseURL(dom.Key(hash))
 	if err != nil {
-		zap.L().Error("error while parsing short url")
+		logger.FromContext(ctx).Error("error while parsing short url")
 		return "", err
 	}
 
 	userURL, err := entity.ParseURL(inputURL)
 	if err != nil {
-		zap.L().Error("error while parsing user url")
+		logger.FromContext(ctx).Error("error while parsing user url")
 		return "", err
 	}
 
 	err = saver.SaveURL(ctx, userID, *shortURL, *userURL)
+	audit.LogUser(ctx, userID, audit.ActionLinkCreate, []string{dom.Key(hash)}, err)
 	if err != nil {
 		if errors.Is(err, storage_err.ErrURLAlreadyExists) {
-			return createOutputPostString(baseURIPrefix, shortURL.String()), err
+			return dom.ShortURL(hash), err
 		}
 		return "", err
 	}
 
-	return createOutputPostString(baseURIPrefix, shortURL.String()), nil
+	return dom.ShortURL(hash), nil
 }
 
 // BatchURLProcessing Processes batch URLs and saves in storage
+//
+// Domain of URL is taken from its request, otherwise from host which received request.
+// Returns ErrUnknownDomain or ErrDomainForbidden error if domain of URL couldn't be used by user.
+// Returns QuotaError error if user exceeded quota
 func BatchURLProcessing(saver URLBatchSaver, ctx context.Context, userID entity.UserID,
-	batch models.ReqBatch, baseURIPrefix string) (models.ResBatch, error) {
+	batch models.ReqBatch, domains *domain.Registry, host string) (models.ResBatch, error) {
 	urls, err := converter.ConvertBatchReqToURL(batch)
 	if err != nil {
-		zap.L().Error(post_err.CannotProcessURL, zap.Error(err))
+		logger.FromContext(ctx).Error(post_err.CannotProcessURL, zap.Error(err))
 		return nil, fmt.Errorf(post_err.WrongJSONFormat)
 	}
 
-	sBatch, err := createStorageBatch(urls)
+	sBatch, err := createStorageBatch(urls, func(name string) (domain.Domain, error) {
+		return domains.Select(userID, name, host)
+	})
 	if err != nil {
-		zap.L().Error("error while creating storage batch", zap.Error(err))
+		logger.FromContext(ctx).Error("error while creating storage batch", zap.Error(err))
+		if errors.Is(err, domain.ErrUnknownDomain) || errors.Is(err, domain.ErrDomainForbidden) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf(post_err.InternalServerError)
 	}
 
 	savedBatch, err := saver.SaveBatchURL(ctx, userID, sBatch)
+	audit.LogUser(ctx, userID, audit.ActionLinkBatchCreate, batchKeys(sBatch), err)
 	if err != nil {
-		zap.L().Error("error while saving url to storage", zap.Error(err))
+		logger.FromContext(ctx).Error("error while saving url to storage", zap.Error(err))
+		if errors.Is(err, storage_err.ErrQuotaExceeded) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf(post_err.InternalServerError)
 	}
 
-	outBatch := converter.ConvertStorageBatchToOutBatch(savedBatch, baseURIPrefix)
+	outBatch := converter.ConvertStorageBatchToOutBatch(savedBatch, domains.ShortURL)
 
 	return outBatch, nil
 }
 
-func createOutputPostString(baseURIPrefix, url string) string {
-	return fmt.Sprintf("%s/%s", baseURIPrefix, url)
+// batchKeys Returns short IDs of batch URLs
+func batchKeys(batch storage.Batch) []string {
+	keys := make([]string, 0, len(batch))
+	for _, obj := range batch {
+		keys = append(keys, obj.ShortURL)
+	}
+
+	return keys
 }
 
 func createHash(url string) string {
-	bs := encoding.NewSHA256([]byte(url))
-
-	return hex.EncodeToString(bs)[:maxEncodedSize]
+	return link.ShortID(url)
 }
 
-func createStorageBatch(urls models.ReqURLBatch) (storage.Batch, error) {
+func createStorageBatch(urls models.ReqURLBatch, selectDomain func(name string) (domain.Domain, error)) (storage.Batch, error) {
 	dbBatch := make(storage.Batch, 0, len(urls))
 	for _, url := range urls {
 		shortURL := createHash(url.URL.String())
@@ -108,10 +129,15 @@ func createStorageBatch(urls models.ReqURLBatch) (storage.Batch, error) {
 			return nil, fmt.Errorf("exit to create hash")
 		}
 
+		dom, err := selectDomain(url.Obj.Domain)
+		if err != nil {
+			return nil, err
+		}
+
 		obj := storage.BatchObject{
 			ID:       url.Obj.ID,
 			InputURL: url.URL.String(),
-			ShortURL: shortURL,
+			ShortURL: dom.Key(shortURL),
 		}
 
 		dbBatch = append(dbBatch, obj)
@@ -9,12 +9,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/avGenie/url-shortener/internal/app/config"
 	"github.com/avGenie/url-shortener/internal/app/entity"
 	"github.com/avGenie/url-shortener/internal/app/handlers/errors"
 	"github.com/avGenie/url-shortener/internal/app/handlers/post/mock"
 	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
 
 	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,8 +24,23 @@ import (
 
 const (
 	baseURIPrefix = "http://localhost:8080"
+	brandDomains  = "https://go.brand.io,https://vip.brand.io;users=0c0a4811-4f10-487f-bde3-e39a14af7cd8"
 )
 
+func newTestDomains(t *testing.T, baseURIPrefix string) *domain.Registry {
+	if baseURIPrefix == "" {
+		return nil
+	}
+
+	domains, err := domain.NewRegistry(config.Config{
+		BaseURIPrefix: baseURIPrefix,
+		Domains:       brandDomains,
+	})
+	require.NoError(t, err)
+
+	return domains
+}
+
 func TestPostHandlerURL(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -157,7 +174,7 @@ func TestPostHandlerURL(t *testing.T) {
 					Return(test.want.expectedErr)
 			}
 
-			handler := URLHandler(s, test.baseURIPrefix)
+			handler := URLHandler(s, newTestDomains(t, test.baseURIPrefix))
 			handler(writer, request)
 
 			res := writer.Result()
@@ -193,6 +210,7 @@ func TestPostHandlerJSON(t *testing.T) {
 	tests := []struct {
 		name          string
 		request       string
+		host          string
 		body          string
 		baseURIPrefix string
 		urlsKey       string
@@ -255,6 +273,77 @@ func TestPostHandlerJSON(t *testing.T) {
 				expectedBody: errors.WrongJSONFormat + "\n",
 			},
 		},
+		{
+			name:          "domain from request",
+			request:       "/",
+			body:          `{"url":"https://practicum.yandex.ru/","domain":"go.brand.io"}`,
+			baseURIPrefix: baseURIPrefix,
+			urlsKey:       "go.brand.io/42b3e75f",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+
+			want: want{
+				statusCode:   http.StatusCreated,
+				contentType:  "application/json",
+				expectedBody: `{"result":"https://go.brand.io/42b3e75f"}` + "\n",
+				urlsValue:    "https://practicum.yandex.ru/",
+				isSaveURL:    true,
+			},
+		},
+		{
+			name:          "domain from host",
+			request:       "/",
+			host:          "go.brand.io",
+			body:          `{"url":"https://practicum.yandex.ru/"}`,
+			baseURIPrefix: baseURIPrefix,
+			urlsKey:       "go.brand.io/42b3e75f",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+
+			want: want{
+				statusCode:   http.StatusCreated,
+				contentType:  "application/json",
+				expectedBody: `{"result":"https://go.brand.io/42b3e75f"}` + "\n",
+				urlsValue:    "https://practicum.yandex.ru/",
+				isSaveURL:    true,
+			},
+		},
+		{
+			name:          "unknown domain",
+			request:       "/",
+			body:          `{"url":"https://practicum.yandex.ru/","domain":"unknown.io"}`,
+			baseURIPrefix: baseURIPrefix,
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+
+			want: want{
+				statusCode:   http.StatusBadRequest,
+				contentType:  "text/plain; charset=utf-8",
+				expectedBody: `unknown domain: "unknown.io"` + "\n",
+			},
+		},
+		{
+			name:          "domain is not allowed for user",
+			request:       "/",
+			body:          `{"url":"https://practicum.yandex.ru/","domain":"vip.brand.io"}`,
+			baseURIPrefix: baseURIPrefix,
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+
+			want: want{
+				statusCode:   http.StatusForbidden,
+				contentType:  "text/plain; charset=utf-8",
+				expectedBody: `domain is not allowed for user: "vip.brand.io"` + "\n",
+			},
+		},
 		{
 			name:          "cannot process JSON",
 			request:       "/",
@@ -321,6 +410,9 @@ func TestPostHandlerJSON(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			request := httptest.NewRequest(http.MethodPost, test.request, strings.NewReader(test.body))
+			if test.host != "" {
+				request.Host = test.host
+			}
 			writer := httptest.NewRecorder()
 
 			request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, test.userIDCtx))
@@ -330,13 +422,16 @@ func TestPostHandlerJSON(t *testing.T) {
 					SaveURL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Times(0)
 			} else {
+				key, err := entity.ParseURL(test.urlsKey)
+				require.NoError(t, err)
+
 				s.EXPECT().
-					SaveURL(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					SaveURL(gomock.Any(), gomock.Any(), *key, gomock.Any()).
 					Times(1).
 					Return(test.want.expectedErr)
 			}
 
-			handler := JSONHandler(s, test.baseURIPrefix)
+			handler := JSONHandler(s, newTestDomains(t, test.baseURIPrefix))
 			handler(writer, request)
 
 			res := writer.Result()
@@ -448,6 +543,45 @@ func TestPostHandlerJSONBatch(t *testing.T) {
 				expectedErr:   nil,
 			},
 		},
+		{
+			name:          "domain from request",
+			request:       "/",
+			body:          `[{"correlation_id": "practicum_id", "original_url": "https://practicum.yandex.ru/", "domain": "go.brand.io"}]`,
+			baseURIPrefix: baseURIPrefix,
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+			isSaveURL: true,
+
+			want: want{
+				statusCode:   201,
+				contentType:  "application/json",
+				expectedBody: `[{"correlation_id": "practicum_id", "short_url": "https://go.brand.io/42b3e75f"}]`,
+				expectedBatch: model.Batch{
+					{
+						ID:       "practicum_id",
+						InputURL: "https://practicum.yandex.ru/",
+						ShortURL: "go.brand.io/42b3e75f",
+					},
+				},
+			},
+		},
+		{
+			name:          "unknown domain",
+			request:       "/",
+			body:          `[{"correlation_id": "practicum_id", "original_url": "https://practicum.yandex.ru/", "domain": "unknown.io"}]`,
+			baseURIPrefix: baseURIPrefix,
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+
+			want: want{
+				statusCode:  http.StatusBadRequest,
+				contentType: "text/plain; charset=utf-8",
+			},
+		},
 		{
 			name:    "empty base URI prefix",
 			request: "/",
@@ -490,12 +624,12 @@ func TestPostHandlerJSONBatch(t *testing.T) {
 					Times(0)
 			} else {
 				s.EXPECT().
-					SaveBatchURL(gomock.Any(), gomock.Any(), gomock.Any()).
+					SaveBatchURL(gomock.Any(), gomock.Any(), test.want.expectedBatch).
 					Times(1).
 					Return(test.want.expectedBatch, test.want.expectedErr)
 			}
 
-			handler := JSONBatchHandler(s, test.baseURIPrefix)
+			handler := JSONBatchHandler(s, newTestDomains(t, test.baseURIPrefix))
 			handler(writer, request)
 
 			res := writer.Result()
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/audit"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	post_err "github.com/avGenie/url-shortener/internal/app/handlers/errors"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/quota"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+// LinkRestorer Restorer of deleted user links
+type LinkRestorer interface {
+	Restore(ctx context.Context, userID entity.UserID, shortURLs []string) ([]string, error)
+}
+
+// RestoreHandler Processes POST "/api/user/urls/restore" endpoint. Restores deleted user URLs from trash
+//
+// Request body is JSON array of short IDs or full short URLs.
+// URLs which are not in trash of user or whose retention is expired are skipped.
+// Returns 200(StatusOK) with short IDs of restored URLs if processing was successful
+// Returns 400(StatusBadRequest) if request body is invalid
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 403(StatusForbidden) if restored URLs exceed quota of active links
+// Returns 500(StatusInternalServerError) if user ID is invalid
+// Returns 500(StatusInternalServerError) when database error
+func RestoreHandler(restorer LinkRestorer) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
+		if !ok {
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context while restore processing")
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if userIDCtx.StatusCode == http.StatusUnauthorized {
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context while restore processing")
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if len(userIDCtx.UserID.String()) == 0 {
+			logger.FromContext(req.Context()).Error("empty user id from context while restoring user urls")
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var shortURLs []string
+		err := json.NewDecoder(req.Body).Decode(&shortURLs)
+		defer req.Body.Close()
+		if err != nil {
+			logger.FromContext(req.Context()).Error(post_err.CannotProcessJSON, zap.Error(err))
+			http.Error(writer, post_err.WrongJSONFormat, http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		restored, err := restorer.Restore(ctx, userIDCtx.UserID, shortURLs)
+		if err != nil {
+			audit.LogUser(ctx, userIDCtx.UserID, audit.ActionLinkRestore, shortURLs, err)
+
+			var fieldErr *link.FieldError
+			if errors.As(err, &fieldErr) {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			if quota.WriteHTTPError(writer, err) {
+				return
+			}
+
+			logger.FromContext(req.Context()).Error("error while restoring user urls", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		audit.LogUser(ctx, userIDCtx.UserID, audit.ActionLinkRestore, restored, nil)
+
+		out, err := json.Marshal(models.RestoredURLs{Restored: restored})
+		if err != nil {
+			logger.FromContext(req.Context()).Error("error while converting restored user urls to output", zap.Error(err))
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		writer.Write(out)
+	}
+}
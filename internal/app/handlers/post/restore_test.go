@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/handlers/post/mock"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+func TestRestoreHandler(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name      string
+		request   string
+		userIDCtx entity.UserIDCtx
+		shortURLs []string
+		restored  []string
+		err       error
+		want      want
+	}{
+		{
+			name:    "restored links",
+			request: `["42b3e75f", "http://localhost:8080/77fca595"]`,
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			shortURLs: []string{"42b3e75f", "http://localhost:8080/77fca595"},
+			restored:  []string{"42b3e75f"},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `{"restored":["42b3e75f"]}`,
+			},
+		},
+		{
+			name:    "nothing restored",
+			request: `["42b3e75f"]`,
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			shortURLs: []string{"42b3e75f"},
+			restored:  []string{},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `{"restored":[]}`,
+			},
+		},
+		{
+			name:    "empty links",
+			request: `[]`,
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			shortURLs: []string{},
+			err:       &link.FieldError{Field: "short_urls", Err: errors.New("count must be from 1 to 1000, got 0")},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:    "invalid json",
+			request: `{"short_url":"42b3e75f"}`,
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:    "storage error",
+			request: `["42b3e75f"]`,
+			userIDCtx: entity.UserIDCtx{
+				UserID:     userID,
+				StatusCode: http.StatusOK,
+			},
+			shortURLs: []string{"42b3e75f"},
+			err:       errors.New("storage is unavailable"),
+			want: want{
+				statusCode: http.StatusInternalServerError,
+			},
+		},
+		{
+			name:    "unauthorized user",
+			request: `["42b3e75f"]`,
+			userIDCtx: entity.UserIDCtx{
+				StatusCode: http.StatusUnauthorized,
+			},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mock.NewMockLinkRestorer(ctrl)
+			if test.shortURLs != nil {
+				s.EXPECT().Restore(gomock.Any(), userID, test.shortURLs).Return(test.restored, test.err)
+			}
+
+			request := httptest.NewRequest(http.MethodPost, "/api/user/urls/restore", strings.NewReader(test.request))
+			writer := httptest.NewRecorder()
+
+			ctx := context.WithValue(request.Context(), entity.UserIDCtxKey{}, test.userIDCtx)
+
+			RestoreHandler(s)(writer, request.WithContext(ctx))
+
+			res := writer.Result()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			if test.want.body != "" {
+				assert.JSONEq(t, test.want.body, string(body))
+			}
+		})
+	}
+}
@@ -8,37 +8,44 @@ import (
 
 	"github.com/avGenie/url-shortener/internal/app/entity"
 	post_err "github.com/avGenie/url-shortener/internal/app/handlers/errors"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/quota"
 	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
 	"go.uber.org/zap"
 )
 
 // URLHandler Processes POST "/id" endpoint. Save original and short URLs to storage
 //
+// Short URL is created on domain which received request.
 // Returns 201(StatusCreated) if processing was successfully
-// Returns 500(StatusInternalServerError) if base URI prefix is invalid
+// Returns 500(StatusInternalServerError) if domains are not set
 // Returns 500(StatusInternalServerError) if user ID is invalid
 // Returns 500(StatusInternalServerError) when database error
 // Returns 400(StatusBadRequest) if original URL is invalid
+// Returns 403(StatusForbidden) if user is not allowed to create short URLs on domain
+// Returns 403(StatusForbidden) if user exceeded quota of active links
 // Returns 409(StatusConflict) if original URL exists in storage for this user
-func URLHandler(saver URLSaver, baseURIPrefix string) http.HandlerFunc {
+// Returns 429(StatusTooManyRequests) if user exceeded quota of links per day
+func URLHandler(saver URLSaver, domains *domain.Registry) http.HandlerFunc {
 	return func(writer http.ResponseWriter, req *http.Request) {
-		zap.L().Debug("POST handler URL processing")
+		logger.FromContext(req.Context()).Debug("POST handler URL processing")
 
-		if baseURIPrefix == "" {
-			zap.L().Error("invalid base URI prefix", zap.String("base URI prefix", baseURIPrefix))
+		if domains == nil {
+			logger.FromContext(req.Context()).Error("domains of short URLs are not set")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
 		userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
 		if !ok {
-			zap.L().Error("user id couldn't obtain from context")
+			logger.FromContext(req.Context()).Error("user id couldn't obtain from context")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
 		if len(userIDCtx.UserID.String()) == 0 {
-			zap.L().Error("empty user id from context while posting user url")
+			logger.FromContext(req.Context()).Error("empty user id from context while posting user url")
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
@@ -47,38 +54,58 @@ func URLHandler(saver URLSaver, baseURIPrefix string) http.HandlerFunc {
 		defer req.Body.Close()
 
 		if err != nil {
-			zap.L().Error(post_err.CannotProcessURL, zap.Error(err))
+			logger.FromContext(req.Context()).Error(post_err.CannotProcessURL, zap.Error(err))
 			http.Error(writer, post_err.WrongURLFormat, http.StatusBadRequest)
 			return
 		}
 
 		if ok := entity.IsValidURL(string(inputURL)); !ok {
-			zap.L().Error(post_err.WrongURLFormat, zap.Error(err))
+			logger.FromContext(req.Context()).Error(post_err.WrongURLFormat, zap.Error(err))
 			http.Error(writer, post_err.WrongURLFormat, http.StatusBadRequest)
 			return
 		}
 
+		dom, err := domains.Select(userIDCtx.UserID, "", req.Host)
+		if err != nil {
+			logger.FromContext(req.Context()).Error("domain of short URL couldn't be selected", zap.Error(err))
+			http.Error(writer, err.Error(), domainErrorStatus(err))
+			return
+		}
+
 		ctx, cancel := context.WithTimeout(req.Context(), timeout)
 		defer cancel()
 
-		outputURL, err := PostURLProcessing(saver, ctx, userIDCtx.UserID, string(inputURL), baseURIPrefix)
+		outputURL, err := PostURLProcessing(saver, ctx, userIDCtx.UserID, string(inputURL), dom)
 		if err != nil {
-			zap.L().Error("could not create a short URL", zap.String("error", err.Error()))
+			logger.FromContext(req.Context()).Error("could not create a short URL", zap.String("error", err.Error()))
 			if errors.Is(err, storage_err.ErrURLAlreadyExists) {
 				successRawResponse(writer, outputURL, http.StatusConflict)
 				return
 			}
 
+			if quota.WriteHTTPError(writer, err) {
+				return
+			}
+
 			writer.WriteHeader(http.StatusInternalServerError)
 			return
 		}
 
-		zap.L().Info("url has been created successfully", zap.String("output url", outputURL))
+		logger.FromContext(req.Context()).Info("url has been created successfully", zap.String("output url", outputURL))
 
 		successRawResponse(writer, outputURL, http.StatusCreated)
 	}
 }
 
+// domainErrorStatus Returns HTTP status of error of domain selection
+func domainErrorStatus(err error) int {
+	if errors.Is(err, domain.ErrDomainForbidden) {
+		return http.StatusForbidden
+	}
+
+	return http.StatusBadRequest
+}
+
 func successRawResponse(writer http.ResponseWriter, url string, status int) {
 	writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	writer.WriteHeader(status)
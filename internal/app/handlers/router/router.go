@@ -1,15 +1,37 @@
 package handlers
 
 import (
+	"net/http"
+
+	"github.com/avGenie/url-shortener/internal/app/audit"
 	"github.com/avGenie/url-shortener/internal/app/auth"
 	"github.com/avGenie/url-shortener/internal/app/config"
 	"github.com/avGenie/url-shortener/internal/app/encoding"
+	account_handlers "github.com/avGenie/url-shortener/internal/app/handlers/account"
+	admin_handlers "github.com/avGenie/url-shortener/internal/app/handlers/admin"
 	handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
 	get "github.com/avGenie/url-shortener/internal/app/handlers/get"
 	post "github.com/avGenie/url-shortener/internal/app/handlers/post"
+	webhook_handlers "github.com/avGenie/url-shortener/internal/app/handlers/webhook"
+	workspace_handlers "github.com/avGenie/url-shortener/internal/app/handlers/workspace"
+	"github.com/avGenie/url-shortener/internal/app/health"
 	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/metrics"
+	"github.com/avGenie/url-shortener/internal/app/quota"
+	"github.com/avGenie/url-shortener/internal/app/ratelimit"
 	storage "github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	"github.com/avGenie/url-shortener/internal/app/tracing"
 	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+	"github.com/avGenie/url-shortener/internal/app/usecase/account"
+	"github.com/avGenie/url-shortener/internal/app/usecase/admin"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/events"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+	"github.com/avGenie/url-shortener/internal/app/usecase/trash"
+	"github.com/avGenie/url-shortener/internal/app/usecase/webhook"
+	"github.com/avGenie/url-shortener/internal/app/usecase/workspace"
+	pb "github.com/avGenie/url-shortener/proto"
+	pbv2 "github.com/avGenie/url-shortener/proto/v2"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
@@ -17,48 +39,179 @@ import (
 // Router Routes endpoint handlers
 type Router struct {
 	Mux *chi.Mux
+}
 
-	deleteHandler *handlers.DeleteHandler
+// RPCHandlers HTTP handlers of GRPC services served by main listener
+//
+// Gateway - REST gateway of v2 API, mounted under "/api/v2" if it is not nil
+// Web - gRPC-Web and Connect handler of shortener services, mounted under service paths if it is not nil
+type RPCHandlers struct {
+	Gateway http.Handler
+	Web     http.Handler
+}
+
+// webServices Names of GRPC services served by gRPC-Web and Connect handler
+var webServices = []string{
+	pb.Shortener_ServiceDesc.ServiceName,
+	pbv2.Shortener_ServiceDesc.ServiceName,
 }
 
 // NewRouter Creates router
-func NewRouter(config config.Config, db storage.Storage, cidr *cidr.CIDR) *Router {
-	deleteHandler := handlers.NewDeleteHandler(db)
+//
+// Deleted URLs are queued to delete handler, which is not stopped with router.
+// Deleted URLs are listed and restored by trash service.
+// Usage of user quota is reported by usage service under "/api/user/usage".
+// Actions of user changing its links are listed from audit trail under "/api/user/activity".
+// All data of user is exported under "/api/user/export" and erased by DELETE "/api/user".
+// Webhooks of user are managed by webhook service under "/api/user/webhooks".
+// Workspaces of user and their links are managed under "/api/user/workspaces".
+// Link events of user are streamed from event bus under "/api/user/events".
+// Short URLs are created and resolved within domain which received request.
+// Admin API is mounted under "/api/admin" and is available only from trusted subnets with admin tokens
+func NewRouter(
+	config config.Config,
+	db storage.Storage,
+	deleteHandler *handlers.DeleteHandler,
+	trashService *trash.Service,
+	usageService *quota.Service,
+	webhookService *webhook.Service,
+	bus *events.Bus,
+	domains *domain.Registry,
+	control *cidr.AccessControl,
+	limiter *ratelimit.Limiter,
+	authenticator *admin.Authenticator,
+	rpc RPCHandlers,
+) *Router {
 	return &Router{
-		Mux:           createRouter(config, deleteHandler, db, cidr),
-		deleteHandler: deleteHandler,
+		Mux: createRouter(config, deleteHandler, trashService, usageService, webhookService, bus, domains, db, control, limiter, authenticator, rpc),
 	}
 }
 
-// Stop Stops router
-func (r *Router) Stop() {
-	r.deleteHandler.Stop()
+// NewMetricsRouter Creates router of separate metrics listener
+func NewMetricsRouter(config config.Config, control *cidr.AccessControl) *chi.Mux {
+	r := chi.NewRouter()
+
+	r.Handle("/metrics", metricsHandler(config, control))
+
+	return r
 }
 
 func createRouter(
 	config config.Config,
 	deleteHandler *handlers.DeleteHandler,
+	trashService *trash.Service,
+	usageService *quota.Service,
+	webhookService *webhook.Service,
+	bus *events.Bus,
+	domains *domain.Registry,
 	db storage.Storage,
-	cidr *cidr.CIDR,
+	control *cidr.AccessControl,
+	limiter *ratelimit.Limiter,
+	authenticator *admin.Authenticator,
+	rpc RPCHandlers,
 ) *chi.Mux {
 	r := chi.NewRouter()
 
+	r.Use(tracing.Middleware)
+	r.Use(logger.RequestIDMiddleware)
+	r.Use(control.ClientIPMiddleware)
+	r.Use(metrics.Middleware)
 	r.Use(logger.LoggerMiddleware)
-	r.Use(encoding.GzipMiddleware)
-	r.Use(auth.AuthMiddleware)
-
-	r.Mount("/debug", middleware.Profiler())
 
-	r.Post("/", post.URLHandler(db, config.BaseURIPrefix))
-	r.Post("/api/shorten", post.JSONHandler(db, config.BaseURIPrefix))
-	r.Post("/api/shorten/batch", post.JSONBatchHandler(db, config.BaseURIPrefix))
+	// gRPC-Web and Connect protocols negotiate compression by themselves
+	if rpc.Web != nil {
+		web := r.With(auth.AuthMiddleware, tracing.HandlerMiddleware, logger.ContextMiddleware)
+		for _, service := range webServices {
+			web.Handle("/"+service+"/*", rpc.Web)
+		}
+	}
 
-	r.Get("/{url}", get.URLHandler(db))
-	r.Get("/ping", get.PingDBHandler(db))
-	r.Get("/api/internal/stats", get.StatsHandler(db, cidr))
-	r.Get("/api/user/urls", get.UserURLsHandler(db, config.BaseURIPrefix))
+	r.Group(func(r chi.Router) {
+		r.Use(encoding.GzipMiddleware)
+		r.Use(auth.AuthMiddleware)
 
-	r.Delete("/api/user/urls", deleteHandler.DeleteUserURLHandler())
+		createHTTPRoutes(r, config, deleteHandler, trashService, usageService, webhookService, bus, domains, db, control, limiter, authenticator, rpc.Gateway)
+	})
 
 	return r
 }
+
+func createHTTPRoutes(
+	r chi.Router,
+	config config.Config,
+	deleteHandler *handlers.DeleteHandler,
+	trashService *trash.Service,
+	usageService *quota.Service,
+	webhookService *webhook.Service,
+	bus *events.Bus,
+	domains *domain.Registry,
+	db storage.Storage,
+	control *cidr.AccessControl,
+	limiter *ratelimit.Limiter,
+	authenticator *admin.Authenticator,
+	gateway http.Handler,
+) {
+	r.With(control.Middleware).Mount("/debug", middleware.Profiler())
+
+	if config.MetricsAddr == "" {
+		r.Handle("/metrics", metricsHandler(config, control))
+	}
+
+	routes := r.With(tracing.HandlerMiddleware, logger.ContextMiddleware)
+
+	routes.Group(func(r chi.Router) {
+		r.Use(limiter.Middleware(ratelimit.RouteCreate))
+
+		r.Post("/", post.URLHandler(db, domains))
+		r.Post("/api/shorten", post.JSONHandler(db, domains))
+		r.Post("/api/shorten/batch", post.JSONBatchHandler(db, domains))
+	})
+
+	routes.With(limiter.Middleware(ratelimit.RouteRedirect)).Get("/{url}", get.URLHandler(db, domains))
+	routes.Get("/ping", get.PingDBHandler(db))
+	routes.Get("/healthz", get.LivenessHandler())
+	routes.Get("/readyz", get.ReadinessHandler(newHealthChecker(db, deleteHandler)))
+	routes.Get("/api/internal/stats", get.StatsHandler(link.NewService(db, deleteHandler, domains), control))
+
+	routes.With(control.Middleware, authenticator.Middleware).
+		Mount("/api/admin", admin_handlers.Routes(admin.NewService(db, deleteHandler, domains)))
+
+	accountService := account.NewService(db, domains, deleteHandler, bus)
+	routes.Group(func(r chi.Router) {
+		r.Use(limiter.Middleware(ratelimit.RouteAPI))
+
+		r.Get("/api/user/urls", get.UserURLsHandler(db, domains))
+		r.Delete("/api/user/urls", deleteHandler.DeleteUserURLHandler(domains))
+		r.Get("/api/user/urls/trash", get.TrashHandler(trashService))
+		r.Post("/api/user/urls/restore", post.RestoreHandler(trashService))
+		r.Get("/api/user/usage", get.UsageHandler(usageService))
+		r.Get("/api/user/activity", get.ActivityHandler(audit.NewTrail(db)))
+		r.Get("/api/user/export", account_handlers.ExportHandler(accountService))
+		r.Delete("/api/user", account_handlers.EraseHandler(accountService))
+		r.Mount("/api/user/webhooks", webhook_handlers.Routes(webhookService))
+		r.Mount("/api/user/workspaces", workspace_handlers.Routes(workspace.NewService(db, link.NewService(db, deleteHandler, domains))))
+		r.Get("/api/user/events", get.EventsHandler(bus, domains))
+		r.Get(handlers.JobsPath+"{id}", get.DeleteJobHandler(db))
+	})
+
+	if gateway != nil {
+		routes.Get("/api/v2/openapi.json", get.OpenAPIHandler(pbv2.OpenAPI))
+		routes.Mount("/api/v2", gateway)
+	}
+}
+
+func newHealthChecker(db storage.Storage, deleteHandler *handlers.DeleteHandler) *health.Checker {
+	checker := health.NewChecker()
+	checker.Add(health.ComponentStorage, db.PingServer)
+	checker.Add(health.ComponentDeleteFlusher, deleteHandler.Check)
+
+	return checker
+}
+
+func metricsHandler(config config.Config, control *cidr.AccessControl) http.Handler {
+	if config.MetricsTrusted {
+		return control.Middleware(metrics.Handler())
+	}
+
+	return metrics.Handler()
+}
@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/handlers/webhook/webhook.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/avGenie/url-shortener/internal/app/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWebhookManager is a mock of WebhookManager interface.
+type MockWebhookManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookManagerMockRecorder
+}
+
+// MockWebhookManagerMockRecorder is the mock recorder for MockWebhookManager.
+type MockWebhookManagerMockRecorder struct {
+	mock *MockWebhookManager
+}
+
+// NewMockWebhookManager creates a new mock instance.
+func NewMockWebhookManager(ctrl *gomock.Controller) *MockWebhookManager {
+	mock := &MockWebhookManager{ctrl: ctrl}
+	mock.recorder = &MockWebhookManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookManager) EXPECT() *MockWebhookManagerMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookManager) Create(ctx context.Context, userID entity.UserID, url string, events []string, secret string) (entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, url, events, secret)
+	ret0, _ := ret[0].(entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookManagerMockRecorder) Create(ctx, userID, url, events, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookManager)(nil).Create), ctx, userID, url, events, secret)
+}
+
+// Delete mocks base method.
+func (m *MockWebhookManager) Delete(ctx context.Context, userID entity.UserID, webhookID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, webhookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockWebhookManagerMockRecorder) Delete(ctx, userID, webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockWebhookManager)(nil).Delete), ctx, userID, webhookID)
+}
+
+// Deliveries mocks base method.
+func (m *MockWebhookManager) Deliveries(ctx context.Context, userID entity.UserID, webhookID string) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deliveries", ctx, userID, webhookID)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deliveries indicates an expected call of Deliveries.
+func (mr *MockWebhookManagerMockRecorder) Deliveries(ctx, userID, webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deliveries", reflect.TypeOf((*MockWebhookManager)(nil).Deliveries), ctx, userID, webhookID)
+}
+
+// List mocks base method.
+func (m *MockWebhookManager) List(ctx context.Context, userID entity.UserID) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWebhookManagerMockRecorder) List(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWebhookManager)(nil).List), ctx, userID)
+}
+
+// Replay mocks base method.
+func (m *MockWebhookManager) Replay(ctx context.Context, userID entity.UserID, deliveryID string) (entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", ctx, userID, deliveryID)
+	ret0, _ := ret[0].(entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockWebhookManagerMockRecorder) Replay(ctx, userID, deliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockWebhookManager)(nil).Replay), ctx, userID, deliveryID)
+}
@@ -0,0 +1,297 @@
+// Package handlers implements HTTP API of user webhooks
+//
+// Webhooks receive signed JSON events of link lifecycle and clicks of their user
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+	usecase "github.com/avGenie/url-shortener/internal/app/usecase/webhook"
+)
+
+const timeout = 3 * time.Second
+
+// WebhookManager Use cases of webhooks of user
+type WebhookManager interface {
+	Create(ctx context.Context, userID entity.UserID, url string, events []string, secret string) (entity.Webhook, error)
+	List(ctx context.Context, userID entity.UserID) ([]entity.Webhook, error)
+	Delete(ctx context.Context, userID entity.UserID, webhookID string) error
+	Deliveries(ctx context.Context, userID entity.UserID, webhookID string) ([]entity.WebhookDelivery, error)
+	Replay(ctx context.Context, userID entity.UserID, deliveryID string) (entity.WebhookDelivery, error)
+}
+
+// Routes Returns router of webhooks of user
+//
+// POST / - creates webhook
+// GET / - returns webhooks of user
+// DELETE /{id} - deletes webhook with its deliveries
+// GET /{id}/deliveries - returns the latest deliveries of webhook
+// POST /deliveries/{id}/replay - sends payload of delivery again
+func Routes(manager WebhookManager) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", CreateWebhookHandler(manager))
+	r.Get("/", ListWebhooksHandler(manager))
+	r.Delete("/{id}", DeleteWebhookHandler(manager))
+	r.Get("/{id}/deliveries", ListDeliveriesHandler(manager))
+	r.Post("/deliveries/{id}/replay", ReplayDeliveryHandler(manager))
+
+	return r
+}
+
+// CreateWebhookHandler Creates webhook of user from JSON body {"url": "...", "events": [...], "secret": "..."}
+//
+// Returns 201(StatusCreated) with webhook and its secret if processing was successful
+// Returns 400(StatusBadRequest) if body couldn't be parsed, URL or event types are invalid or user has too many webhooks
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 500(StatusInternalServerError) when storage request errors
+func CreateWebhookHandler(manager WebhookManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		var request models.CreateWebhookRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(writer, "wrong JSON format", http.StatusBadRequest)
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		webhook, err := manager.Create(ctx, userID, request.URL, request.Events, request.Secret)
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		response := webhookToModel(webhook)
+		response.Secret = webhook.Secret
+
+		writeJSON(ctx, writer, http.StatusCreated, response)
+	}
+}
+
+// ListWebhooksHandler Returns webhooks of user without their secrets
+//
+// Returns 200(StatusOk) if processing was successful
+// Returns 204(StatusNoContent) if user has no webhooks
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 500(StatusInternalServerError) when storage request errors
+func ListWebhooksHandler(manager WebhookManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		webhooks, err := manager.List(ctx, userID)
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		if len(webhooks) == 0 {
+			writer.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		response := make([]models.Webhook, 0, len(webhooks))
+		for _, webhook := range webhooks {
+			response = append(response, webhookToModel(webhook))
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, response)
+	}
+}
+
+// DeleteWebhookHandler Deletes webhook of user with its deliveries
+//
+// Returns 204(StatusNoContent) if processing was successful
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 404(StatusNotFound) if webhook is not found
+// Returns 500(StatusInternalServerError) when storage request errors
+func DeleteWebhookHandler(manager WebhookManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		err := manager.Delete(ctx, userID, chi.URLParam(req, "id"))
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writer.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ListDeliveriesHandler Returns the latest deliveries of webhook of user, the newest first
+//
+// Returns 200(StatusOk) if processing was successful
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 404(StatusNotFound) if webhook is not found
+// Returns 500(StatusInternalServerError) when storage request errors
+func ListDeliveriesHandler(manager WebhookManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		deliveries, err := manager.Deliveries(ctx, userID, chi.URLParam(req, "id"))
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		response := make([]models.WebhookDelivery, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			response = append(response, deliveryToModel(delivery))
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, response)
+	}
+}
+
+// ReplayDeliveryHandler Sends payload of delivery of user to its webhook again
+//
+// Returns 202(StatusAccepted) with new pending delivery if processing was successful
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 404(StatusNotFound) if delivery or its webhook is not found
+// Returns 500(StatusInternalServerError) when storage request errors
+func ReplayDeliveryHandler(manager WebhookManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		delivery, err := manager.Replay(ctx, userID, chi.URLParam(req, "id"))
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusAccepted, deliveryToModel(delivery))
+	}
+}
+
+func userIDFromRequest(writer http.ResponseWriter, req *http.Request) (entity.UserID, bool) {
+	userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
+	if !ok {
+		logger.FromContext(req.Context()).Error("user id couldn't obtain from context while webhooks processing")
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return "", false
+	}
+
+	if userIDCtx.StatusCode == http.StatusUnauthorized {
+		logger.FromContext(req.Context()).Error("user id couldn't obtain from context")
+		writer.WriteHeader(http.StatusUnauthorized)
+
+		return "", false
+	}
+
+	if len(userIDCtx.UserID.String()) == 0 {
+		logger.FromContext(req.Context()).Error("empty user id from context")
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return "", false
+	}
+
+	return userIDCtx.UserID, true
+}
+
+func webhookToModel(webhook entity.Webhook) models.Webhook {
+	return models.Webhook{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+func deliveryToModel(delivery entity.WebhookDelivery) models.WebhookDelivery {
+	res := models.WebhookDelivery{
+		ID:           delivery.ID,
+		WebhookID:    delivery.WebhookID,
+		Event:        delivery.Event,
+		Payload:      delivery.Payload,
+		Status:       string(delivery.Status),
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode,
+		LastError:    delivery.LastError,
+		CreatedAt:    delivery.CreatedAt,
+		UpdatedAt:    delivery.UpdatedAt,
+	}
+
+	if delivery.Status == entity.WebhookDeliveryPending {
+		res.NextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	return res
+}
+
+func writeJSON(ctx context.Context, writer http.ResponseWriter, statusCode int, value any) {
+	out, err := json.Marshal(value)
+	if err != nil {
+		logger.FromContext(ctx).Error("error while converting webhook response to output", zap.Error(err))
+
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	writer.Write(out)
+}
+
+func writeError(ctx context.Context, writer http.ResponseWriter, err error) {
+	var fieldErr *link.FieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		http.Error(writer, fieldErr.Error(), http.StatusBadRequest)
+	case errors.Is(err, usecase.ErrWebhookNotFound), errors.Is(err, usecase.ErrDeliveryNotFound):
+		http.Error(writer, err.Error(), http.StatusNotFound)
+	default:
+		logger.FromContext(ctx).Error("error while processing webhook request", zap.Error(err))
+
+		http.Error(writer, "internal server error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/handlers/webhook/mock"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+	usecase "github.com/avGenie/url-shortener/internal/app/usecase/webhook"
+)
+
+func TestRoutes(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	webhook := entity.Webhook{
+		ID:        "9bb86afd-62da-43af-81f2-f37400820a2d",
+		UserID:    userID,
+		URL:       "https://example.com/hook",
+		Secret:    "secret",
+		Events:    []string{entity.EventLinkCreated},
+		CreatedAt: createdAt,
+	}
+	delivery := entity.WebhookDelivery{
+		ID:            "0f5a4e42-5d9b-4c4e-9f3c-4d6e2fb3c7aa",
+		WebhookID:     webhook.ID,
+		UserID:        userID,
+		Event:         entity.EventLinkCreated,
+		Payload:       []byte(`{"type":"link.created"}`),
+		Status:        entity.WebhookDeliveryPending,
+		NextAttemptAt: createdAt,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+
+	type want struct {
+		statusCode int
+		body       string
+		absent     string
+	}
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		userIDCtx entity.UserIDCtx
+		prepare   func(s *mock.MockWebhookManager)
+		want      want
+	}{
+		{
+			name:   "create webhook",
+			method: http.MethodPost,
+			target: "/",
+			body:   `{"url":"https://example.com/hook","events":["link.created"]}`,
+			prepare: func(s *mock.MockWebhookManager) {
+				s.EXPECT().Create(gomock.Any(), userID, "https://example.com/hook", []string{entity.EventLinkCreated}, "").Return(webhook, nil)
+			},
+			want: want{
+				statusCode: http.StatusCreated,
+				body:       `"secret":"secret"`,
+			},
+		},
+		{
+			name:   "create webhook with invalid url",
+			method: http.MethodPost,
+			target: "/",
+			body:   `{"url":"ftp://example.com"}`,
+			prepare: func(s *mock.MockWebhookManager) {
+				s.EXPECT().Create(gomock.Any(), userID, "ftp://example.com", nil, "").
+					Return(entity.Webhook{}, &link.FieldError{Field: "url", Err: usecase.ErrInvalidWebhookURL})
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body:       "url: invalid webhook url",
+			},
+		},
+		{
+			name:    "create webhook with wrong json",
+			method:  http.MethodPost,
+			target:  "/",
+			body:    `<invalid json>`,
+			prepare: func(s *mock.MockWebhookManager) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:   "list webhooks without secrets",
+			method: http.MethodGet,
+			target: "/",
+			prepare: func(s *mock.MockWebhookManager) {
+				s.EXPECT().List(gomock.Any(), userID).Return([]entity.Webhook{webhook}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `"url":"https://example.com/hook"`,
+				absent:     "secret",
+			},
+		},
+		{
+			name:   "no webhooks",
+			method: http.MethodGet,
+			target: "/",
+			prepare: func(s *mock.MockWebhookManager) {
+				s.EXPECT().List(gomock.Any(), userID).Return(nil, nil)
+			},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:   "delete webhook",
+			method: http.MethodDelete,
+			target: "/" + webhook.ID,
+			prepare: func(s *mock.MockWebhookManager) {
+				s.EXPECT().Delete(gomock.Any(), userID, webhook.ID).Return(nil)
+			},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:   "delete unknown webhook",
+			method: http.MethodDelete,
+			target: "/unknown",
+			prepare: func(s *mock.MockWebhookManager) {
+				s.EXPECT().Delete(gomock.Any(), userID, "unknown").Return(usecase.ErrWebhookNotFound)
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name:   "list deliveries",
+			method: http.MethodGet,
+			target: "/" + webhook.ID + "/deliveries",
+			prepare: func(s *mock.MockWebhookManager) {
+				s.EXPECT().Deliveries(gomock.Any(), userID, webhook.ID).Return([]entity.WebhookDelivery{delivery}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `"payload":{"type":"link.created"}`,
+			},
+		},
+		{
+			name:   "replay delivery",
+			method: http.MethodPost,
+			target: "/deliveries/" + delivery.ID + "/replay",
+			prepare: func(s *mock.MockWebhookManager) {
+				s.EXPECT().Replay(gomock.Any(), userID, delivery.ID).Return(delivery, nil)
+			},
+			want: want{
+				statusCode: http.StatusAccepted,
+				body:       `"status":"pending"`,
+			},
+		},
+		{
+			name:   "replay unknown delivery",
+			method: http.MethodPost,
+			target: "/deliveries/unknown/replay",
+			prepare: func(s *mock.MockWebhookManager) {
+				s.EXPECT().Replay(gomock.Any(), userID, "unknown").Return(entity.WebhookDelivery{}, usecase.ErrDeliveryNotFound)
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name:   "storage error",
+			method: http.MethodGet,
+			target: "/",
+			prepare: func(s *mock.MockWebhookManager) {
+				s.EXPECT().List(gomock.Any(), userID).Return(nil, errors.New("storage error"))
+			},
+			want: want{
+				statusCode: http.StatusInternalServerError,
+				body:       "internal server error",
+			},
+		},
+		{
+			name:   "unauthorized user",
+			method: http.MethodGet,
+			target: "/",
+			userIDCtx: entity.UserIDCtx{
+				StatusCode: http.StatusUnauthorized,
+			},
+			prepare: func(s *mock.MockWebhookManager) {},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mock.NewMockWebhookManager(ctrl)
+			test.prepare(s)
+
+			userIDCtx := test.userIDCtx
+			if userIDCtx.StatusCode == 0 {
+				userIDCtx = entity.UserIDCtx{UserID: userID, StatusCode: http.StatusOK}
+			}
+
+			request := httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+			request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, userIDCtx))
+			writer := httptest.NewRecorder()
+
+			Routes(s).ServeHTTP(writer, request)
+
+			res := writer.Result()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			assert.Contains(t, string(body), test.want.body)
+			if test.want.absent != "" {
+				assert.NotContains(t, string(body), test.want.absent)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/app/handlers/workspace/workspace.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/avGenie/url-shortener/internal/app/entity"
+	workspace "github.com/avGenie/url-shortener/internal/app/usecase/workspace"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWorkspaceManager is a mock of WorkspaceManager interface.
+type MockWorkspaceManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceManagerMockRecorder
+}
+
+// MockWorkspaceManagerMockRecorder is the mock recorder for MockWorkspaceManager.
+type MockWorkspaceManagerMockRecorder struct {
+	mock *MockWorkspaceManager
+}
+
+// NewMockWorkspaceManager creates a new mock instance.
+func NewMockWorkspaceManager(ctrl *gomock.Controller) *MockWorkspaceManager {
+	mock := &MockWorkspaceManager{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceManager) EXPECT() *MockWorkspaceManagerMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockWorkspaceManager) Accept(ctx context.Context, userID entity.UserID, inviteID string) (entity.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, userID, inviteID)
+	ret0, _ := ret[0].(entity.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockWorkspaceManagerMockRecorder) Accept(ctx, userID, inviteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockWorkspaceManager)(nil).Accept), ctx, userID, inviteID)
+}
+
+// Create mocks base method.
+func (m *MockWorkspaceManager) Create(ctx context.Context, userID entity.UserID, name string) (workspace.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, userID, name)
+	ret0, _ := ret[0].(workspace.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkspaceManagerMockRecorder) Create(ctx, userID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkspaceManager)(nil).Create), ctx, userID, name)
+}
+
+// CreateLink mocks base method.
+func (m *MockWorkspaceManager) CreateLink(ctx context.Context, userID entity.UserID, workspaceID, domainName, originalURL string, metadata map[string]string) (entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLink", ctx, userID, workspaceID, domainName, originalURL, metadata)
+	ret0, _ := ret[0].(entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLink indicates an expected call of CreateLink.
+func (mr *MockWorkspaceManagerMockRecorder) CreateLink(ctx, userID, workspaceID, domainName, originalURL, metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLink", reflect.TypeOf((*MockWorkspaceManager)(nil).CreateLink), ctx, userID, workspaceID, domainName, originalURL, metadata)
+}
+
+// DeleteLinks mocks base method.
+func (m *MockWorkspaceManager) DeleteLinks(ctx context.Context, userID entity.UserID, workspaceID string, shortURLs []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLinks", ctx, userID, workspaceID, shortURLs)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteLinks indicates an expected call of DeleteLinks.
+func (mr *MockWorkspaceManagerMockRecorder) DeleteLinks(ctx, userID, workspaceID, shortURLs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLinks", reflect.TypeOf((*MockWorkspaceManager)(nil).DeleteLinks), ctx, userID, workspaceID, shortURLs)
+}
+
+// Invite mocks base method.
+func (m *MockWorkspaceManager) Invite(ctx context.Context, userID entity.UserID, workspaceID string, role entity.WorkspaceRole) (entity.WorkspaceInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, userID, workspaceID, role)
+	ret0, _ := ret[0].(entity.WorkspaceInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockWorkspaceManagerMockRecorder) Invite(ctx, userID, workspaceID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockWorkspaceManager)(nil).Invite), ctx, userID, workspaceID, role)
+}
+
+// Links mocks base method.
+func (m *MockWorkspaceManager) Links(ctx context.Context, userID entity.UserID, workspaceID string, pageSize int, pageToken string) ([]entity.Link, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Links", ctx, userID, workspaceID, pageSize, pageToken)
+	ret0, _ := ret[0].([]entity.Link)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Links indicates an expected call of Links.
+func (mr *MockWorkspaceManagerMockRecorder) Links(ctx, userID, workspaceID, pageSize, pageToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Links", reflect.TypeOf((*MockWorkspaceManager)(nil).Links), ctx, userID, workspaceID, pageSize, pageToken)
+}
+
+// List mocks base method.
+func (m *MockWorkspaceManager) List(ctx context.Context, userID entity.UserID) ([]workspace.Membership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]workspace.Membership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockWorkspaceManagerMockRecorder) List(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWorkspaceManager)(nil).List), ctx, userID)
+}
+
+// Members mocks base method.
+func (m *MockWorkspaceManager) Members(ctx context.Context, userID entity.UserID, workspaceID string) ([]entity.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Members", ctx, userID, workspaceID)
+	ret0, _ := ret[0].([]entity.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Members indicates an expected call of Members.
+func (mr *MockWorkspaceManagerMockRecorder) Members(ctx, userID, workspaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Members", reflect.TypeOf((*MockWorkspaceManager)(nil).Members), ctx, userID, workspaceID)
+}
+
+// RemoveMember mocks base method.
+func (m *MockWorkspaceManager) RemoveMember(ctx context.Context, userID entity.UserID, workspaceID string, memberID entity.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, userID, workspaceID, memberID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockWorkspaceManagerMockRecorder) RemoveMember(ctx, userID, workspaceID, memberID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockWorkspaceManager)(nil).RemoveMember), ctx, userID, workspaceID, memberID)
+}
+
+// SetRole mocks base method.
+func (m *MockWorkspaceManager) SetRole(ctx context.Context, userID entity.UserID, workspaceID string, memberID entity.UserID, role entity.WorkspaceRole) (entity.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRole", ctx, userID, workspaceID, memberID, role)
+	ret0, _ := ret[0].(entity.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRole indicates an expected call of SetRole.
+func (mr *MockWorkspaceManagerMockRecorder) SetRole(ctx, userID, workspaceID, memberID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRole", reflect.TypeOf((*MockWorkspaceManager)(nil).SetRole), ctx, userID, workspaceID, memberID, role)
+}
+
+// ShortURL mocks base method.
+func (m *MockWorkspaceManager) ShortURL(link entity.Link) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShortURL", link)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ShortURL indicates an expected call of ShortURL.
+func (mr *MockWorkspaceManagerMockRecorder) ShortURL(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShortURL", reflect.TypeOf((*MockWorkspaceManager)(nil).ShortURL), link)
+}
+
+// UpdateLink mocks base method.
+func (m *MockWorkspaceManager) UpdateLink(ctx context.Context, userID entity.UserID, workspaceID, shortURL string, metadata map[string]string) (entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLink", ctx, userID, workspaceID, shortURL, metadata)
+	ret0, _ := ret[0].(entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLink indicates an expected call of UpdateLink.
+func (mr *MockWorkspaceManagerMockRecorder) UpdateLink(ctx, userID, workspaceID, shortURL, metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLink", reflect.TypeOf((*MockWorkspaceManager)(nil).UpdateLink), ctx, userID, workspaceID, shortURL, metadata)
+}
@@ -0,0 +1,592 @@
+// Package handlers implements HTTP API of workspaces
+//
+// Workspaces share ownership of links across their members with owner, editor and viewer roles
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/audit"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	delete_handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/quota"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+	usecase "github.com/avGenie/url-shortener/internal/app/usecase/workspace"
+)
+
+const timeout = 3 * time.Second
+
+// WorkspaceManager Use cases of workspaces of user
+type WorkspaceManager interface {
+	Create(ctx context.Context, userID entity.UserID, name string) (usecase.Membership, error)
+	List(ctx context.Context, userID entity.UserID) ([]usecase.Membership, error)
+	Members(ctx context.Context, userID entity.UserID, workspaceID string) ([]entity.WorkspaceMember, error)
+	SetRole(ctx context.Context, userID entity.UserID, workspaceID string, memberID entity.UserID, role entity.WorkspaceRole) (entity.WorkspaceMember, error)
+	RemoveMember(ctx context.Context, userID entity.UserID, workspaceID string, memberID entity.UserID) error
+	Invite(ctx context.Context, userID entity.UserID, workspaceID string, role entity.WorkspaceRole) (entity.WorkspaceInvite, error)
+	Accept(ctx context.Context, userID entity.UserID, inviteID string) (entity.WorkspaceMember, error)
+	CreateLink(ctx context.Context, userID entity.UserID, workspaceID, domainName, originalURL string, metadata map[string]string) (entity.Link, error)
+	Links(ctx context.Context, userID entity.UserID, workspaceID string, pageSize int, pageToken string) ([]entity.Link, string, error)
+	UpdateLink(ctx context.Context, userID entity.UserID, workspaceID, shortURL string, metadata map[string]string) (entity.Link, error)
+	DeleteLinks(ctx context.Context, userID entity.UserID, workspaceID string, shortURLs []string) ([]string, error)
+	ShortURL(link entity.Link) string
+}
+
+// Routes Returns router of workspaces of user
+//
+// POST / - creates workspace with user as its owner
+// GET / - returns workspaces user is member of
+// GET /{id}/members - returns members of workspace
+// PUT /{id}/members/{user_id} - changes role of member
+// DELETE /{id}/members/{user_id} - removes member from workspace
+// POST /{id}/invites - creates single use invite to workspace
+// POST /invites/{id}/accept - joins workspace of invite
+// GET /{id}/links - returns page of links of workspace
+// POST /{id}/links - creates link in workspace
+// PATCH /{id}/links - replaces metadata of link of workspace
+// DELETE /{id}/links - deletes links of workspace
+func Routes(manager WorkspaceManager) http.Handler {
+	r := chi.NewRouter()
+
+	r.Post("/", CreateWorkspaceHandler(manager))
+	r.Get("/", ListWorkspacesHandler(manager))
+	r.Get("/{id}/members", ListMembersHandler(manager))
+	r.Put("/{id}/members/{user_id}", SetRoleHandler(manager))
+	r.Delete("/{id}/members/{user_id}", RemoveMemberHandler(manager))
+	r.Post("/{id}/invites", InviteHandler(manager))
+	r.Post("/invites/{id}/accept", AcceptInviteHandler(manager))
+	r.Get("/{id}/links", ListLinksHandler(manager))
+	r.Post("/{id}/links", CreateLinkHandler(manager))
+	r.Patch("/{id}/links", UpdateLinkHandler(manager))
+	r.Delete("/{id}/links", DeleteLinksHandler(manager))
+
+	return r
+}
+
+// CreateWorkspaceHandler Creates workspace with user as its owner from JSON body {"name": "..."}
+//
+// Returns 201(StatusCreated) with workspace if processing was successful
+// Returns 400(StatusBadRequest) if body couldn't be parsed, name is invalid or user has too many workspaces
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 500(StatusInternalServerError) when storage request errors
+func CreateWorkspaceHandler(manager WorkspaceManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		var request models.CreateWorkspaceRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(writer, "wrong JSON format", http.StatusBadRequest)
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		membership, err := manager.Create(ctx, userID, request.Name)
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusCreated, workspaceToModel(membership))
+	}
+}
+
+// ListWorkspacesHandler Returns workspaces user is member of with roles of user
+//
+// Returns 200(StatusOk) if processing was successful
+// Returns 204(StatusNoContent) if user is not member of any workspace
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 500(StatusInternalServerError) when storage request errors
+func ListWorkspacesHandler(manager WorkspaceManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		memberships, err := manager.List(ctx, userID)
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		if len(memberships) == 0 {
+			writer.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		response := make([]models.Workspace, 0, len(memberships))
+		for _, membership := range memberships {
+			response = append(response, workspaceToModel(membership))
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, response)
+	}
+}
+
+// ListMembersHandler Returns members of workspace ordered by time of joining
+//
+// Returns 200(StatusOk) if processing was successful
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 404(StatusNotFound) if workspace is not found or user is not its member
+// Returns 500(StatusInternalServerError) when storage request errors
+func ListMembersHandler(manager WorkspaceManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		members, err := manager.Members(ctx, userID, chi.URLParam(req, "id"))
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		response := make([]models.WorkspaceMember, 0, len(members))
+		for _, member := range members {
+			response = append(response, memberToModel(member))
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, response)
+	}
+}
+
+// SetRoleHandler Changes role of workspace member from JSON body {"role": "owner|editor|viewer"}
+//
+// Returns 200(StatusOk) with member if processing was successful
+// Returns 400(StatusBadRequest) if body couldn't be parsed or role is invalid
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 403(StatusForbidden) if user is not owner of workspace
+// Returns 404(StatusNotFound) if workspace or member is not found
+// Returns 409(StatusConflict) if the last owner of workspace is demoted
+// Returns 500(StatusInternalServerError) when storage request errors
+func SetRoleHandler(manager WorkspaceManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		var request models.WorkspaceRoleRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(writer, "wrong JSON format", http.StatusBadRequest)
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		member, err := manager.SetRole(
+			ctx,
+			userID,
+			chi.URLParam(req, "id"),
+			entity.UserID(chi.URLParam(req, "user_id")),
+			entity.WorkspaceRole(request.Role),
+		)
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, memberToModel(member))
+	}
+}
+
+// RemoveMemberHandler Removes member from workspace, members which are not owners can remove only themselves
+//
+// Returns 204(StatusNoContent) if processing was successful
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 403(StatusForbidden) if user is not owner of workspace and removes other member
+// Returns 404(StatusNotFound) if workspace or member is not found
+// Returns 409(StatusConflict) if the last owner of workspace is removed
+// Returns 500(StatusInternalServerError) when storage request errors
+func RemoveMemberHandler(manager WorkspaceManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		err := manager.RemoveMember(ctx, userID, chi.URLParam(req, "id"), entity.UserID(chi.URLParam(req, "user_id")))
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writer.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// InviteHandler Creates single use invite to workspace from JSON body {"role": "owner|editor|viewer"}
+//
+// Returns 201(StatusCreated) with invite if processing was successful
+// Returns 400(StatusBadRequest) if body couldn't be parsed or role is invalid
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 403(StatusForbidden) if user is not owner of workspace
+// Returns 404(StatusNotFound) if workspace is not found or user is not its member
+// Returns 500(StatusInternalServerError) when storage request errors
+func InviteHandler(manager WorkspaceManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		var request models.WorkspaceRoleRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(writer, "wrong JSON format", http.StatusBadRequest)
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		invite, err := manager.Invite(ctx, userID, chi.URLParam(req, "id"), entity.WorkspaceRole(request.Role))
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusCreated, models.WorkspaceInvite{
+			ID:          invite.ID,
+			WorkspaceID: invite.WorkspaceID,
+			Role:        string(invite.Role),
+			ExpiresAt:   invite.ExpiresAt,
+		})
+	}
+}
+
+// AcceptInviteHandler Adds user to workspace of invite with its role
+//
+// Returns 200(StatusOk) with membership if processing was successful
+// Returns 400(StatusBadRequest) if user has too many workspaces
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 404(StatusNotFound) if invite is not found, already accepted or expired
+// Returns 409(StatusConflict) if user is already member of workspace
+// Returns 500(StatusInternalServerError) when storage request errors
+func AcceptInviteHandler(manager WorkspaceManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		member, err := manager.Accept(ctx, userID, chi.URLParam(req, "id"))
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, memberToModel(member))
+	}
+}
+
+// ListLinksHandler Returns page of links of workspace by query parameters page_size and page_token
+//
+// Returns 200(StatusOk) if processing was successful
+// Returns 400(StatusBadRequest) if page size or page token is invalid
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 404(StatusNotFound) if workspace is not found or user is not its member
+// Returns 500(StatusInternalServerError) when storage request errors
+func ListLinksHandler(manager WorkspaceManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		pageSize := 0
+		if value := req.URL.Query().Get("page_size"); value != "" {
+			var err error
+			pageSize, err = strconv.Atoi(value)
+			if err != nil || pageSize < 0 {
+				http.Error(writer, "page_size: must be non-negative integer", http.StatusBadRequest)
+
+				return
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		links, next, err := manager.Links(ctx, userID, chi.URLParam(req, "id"), pageSize, req.URL.Query().Get("page_token"))
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		response := models.WorkspaceLinksPage{
+			Links:         make([]models.WorkspaceLink, 0, len(links)),
+			NextPageToken: next,
+		}
+		for _, link := range links {
+			response.Links = append(response.Links, linkToModel(manager, link))
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, response)
+	}
+}
+
+// CreateLinkHandler Creates link in workspace from JSON body {"original_url": "...", "domain": "...", "metadata": {...}}
+//
+// Returns 201(StatusCreated) with link if processing was successful
+// Returns 400(StatusBadRequest) if body couldn't be parsed, URL, domain or metadata is invalid
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 403(StatusForbidden) if user is viewer of workspace or domain couldn't be used by user
+// Returns 404(StatusNotFound) if workspace is not found or user is not its member
+// Returns 403(StatusForbidden) if user exceeded quota of active links
+// Returns 409(StatusConflict) with existing link if link for original URL already exists
+// Returns 429(StatusTooManyRequests) if user exceeded quota of links per day
+// Returns 500(StatusInternalServerError) when storage request errors
+func CreateLinkHandler(manager WorkspaceManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		var request models.CreateWorkspaceLinkRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(writer, "wrong JSON format", http.StatusBadRequest)
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		created, err := manager.CreateLink(ctx, userID, chi.URLParam(req, "id"), request.Domain, request.OriginalURL, request.Metadata)
+		audit.LogUser(ctx, userID, audit.ActionLinkCreate, []string{created.ShortURL}, err)
+		if errors.Is(err, link.ErrLinkExists) {
+			writeJSON(ctx, writer, http.StatusConflict, linkToModel(manager, created))
+
+			return
+		}
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusCreated, linkToModel(manager, created))
+	}
+}
+
+// UpdateLinkHandler Replaces metadata of workspace link from JSON body {"short_url": "...", "metadata": {...}}
+//
+// Returns 200(StatusOk) with link if processing was successful
+// Returns 400(StatusBadRequest) if body couldn't be parsed or metadata is invalid
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 403(StatusForbidden) if user is viewer of workspace
+// Returns 404(StatusNotFound) if workspace or its link is not found
+// Returns 500(StatusInternalServerError) when storage request errors
+func UpdateLinkHandler(manager WorkspaceManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		var request models.UpdateWorkspaceLinkRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(writer, "wrong JSON format", http.StatusBadRequest)
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		updated, err := manager.UpdateLink(ctx, userID, chi.URLParam(req, "id"), request.ShortURL, request.Metadata)
+		audit.LogUser(ctx, userID, audit.ActionLinkUpdate, []string{updated.ShortURL}, err)
+		if err != nil {
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		writeJSON(ctx, writer, http.StatusOK, linkToModel(manager, updated))
+	}
+}
+
+// DeleteLinksHandler Deletes workspace links from JSON body {"short_urls": [...]} moving them to trash of their creators
+//
+// Links are deleted in background by deletion jobs of their creators, links which are not found in workspace are skipped.
+// Returns 200(StatusOk) with short URLs of links queued for deletion if processing was successful
+// Returns 400(StatusBadRequest) if body couldn't be parsed or contains too many links
+// Returns 401(StatusUnauthorized) if user ID couldn't be obtained
+// Returns 403(StatusForbidden) if user is viewer of workspace
+// Returns 404(StatusNotFound) if workspace is not found or user is not its member
+// Returns 500(StatusInternalServerError) when storage request errors
+// Returns 503(StatusServiceUnavailable) with Retry-After header if deletion queue is full
+func DeleteLinksHandler(manager WorkspaceManager) http.HandlerFunc {
+	return func(writer http.ResponseWriter, req *http.Request) {
+		userID, ok := userIDFromRequest(writer, req)
+		if !ok {
+			return
+		}
+
+		var request models.DeleteWorkspaceLinksRequest
+		if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+			http.Error(writer, "wrong JSON format", http.StatusBadRequest)
+
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		deleted, err := manager.DeleteLinks(ctx, userID, chi.URLParam(req, "id"), request.ShortURLs)
+		if err != nil {
+			audit.LogUser(ctx, userID, audit.ActionLinkDelete, request.ShortURLs, err)
+
+			writeError(ctx, writer, err)
+
+			return
+		}
+
+		audit.LogUser(ctx, userID, audit.ActionLinkDelete, deleted, nil)
+
+		writeJSON(ctx, writer, http.StatusOK, models.DeleteWorkspaceLinksResponse{Deleted: deleted})
+	}
+}
+
+func userIDFromRequest(writer http.ResponseWriter, req *http.Request) (entity.UserID, bool) {
+	userIDCtx, ok := req.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
+	if !ok {
+		logger.FromContext(req.Context()).Error("user id couldn't obtain from context while workspaces processing")
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return "", false
+	}
+
+	if userIDCtx.StatusCode == http.StatusUnauthorized {
+		logger.FromContext(req.Context()).Error("user id couldn't obtain from context")
+		writer.WriteHeader(http.StatusUnauthorized)
+
+		return "", false
+	}
+
+	if len(userIDCtx.UserID.String()) == 0 {
+		logger.FromContext(req.Context()).Error("empty user id from context")
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return "", false
+	}
+
+	return userIDCtx.UserID, true
+}
+
+func workspaceToModel(membership usecase.Membership) models.Workspace {
+	return models.Workspace{
+		ID:        membership.Workspace.ID,
+		Name:      membership.Workspace.Name,
+		Role:      string(membership.Role),
+		CreatedBy: membership.Workspace.CreatedBy.String(),
+		CreatedAt: membership.Workspace.CreatedAt,
+	}
+}
+
+func memberToModel(member entity.WorkspaceMember) models.WorkspaceMember {
+	return models.WorkspaceMember{
+		UserID:   member.UserID.String(),
+		Role:     string(member.Role),
+		JoinedAt: member.CreatedAt,
+	}
+}
+
+func linkToModel(manager WorkspaceManager, link entity.Link) models.WorkspaceLink {
+	return models.WorkspaceLink{
+		ShortURL:    manager.ShortURL(link),
+		Alias:       link.ShortURL,
+		OriginalURL: link.OriginalURL,
+		CreatedBy:   link.UserID.String(),
+		CreatedAt:   link.CreatedAt,
+		Deleted:     link.Deleted,
+		Disabled:    link.Disabled,
+		Metadata:    link.Metadata,
+	}
+}
+
+func writeJSON(ctx context.Context, writer http.ResponseWriter, statusCode int, value any) {
+	out, err := json.Marshal(value)
+	if err != nil {
+		logger.FromContext(ctx).Error("error while converting workspace response to output", zap.Error(err))
+
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(statusCode)
+	writer.Write(out)
+}
+
+func writeError(ctx context.Context, writer http.ResponseWriter, err error) {
+	if quota.WriteHTTPError(writer, err) {
+		return
+	}
+
+	var fieldErr *link.FieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		http.Error(writer, fieldErr.Error(), http.StatusBadRequest)
+	case errors.Is(err, usecase.ErrForbidden), errors.Is(err, domain.ErrDomainForbidden):
+		http.Error(writer, err.Error(), http.StatusForbidden)
+	case errors.Is(err, usecase.ErrWorkspaceNotFound),
+		errors.Is(err, usecase.ErrMemberNotFound),
+		errors.Is(err, usecase.ErrInviteNotFound),
+		errors.Is(err, link.ErrLinkNotFound):
+		http.Error(writer, err.Error(), http.StatusNotFound)
+	case errors.Is(err, usecase.ErrAlreadyMember), errors.Is(err, usecase.ErrLastOwner):
+		http.Error(writer, err.Error(), http.StatusConflict)
+	case errors.Is(err, delete_handlers.ErrQueueFull):
+		writer.Header().Set("Retry-After", strconv.Itoa(int(delete_handlers.RetryAfter.Seconds())))
+		http.Error(writer, delete_handlers.ErrQueueFull.Error(), http.StatusServiceUnavailable)
+	default:
+		logger.FromContext(ctx).Error("error while processing workspace request", zap.Error(err))
+
+		http.Error(writer, "internal server error", http.StatusInternalServerError)
+	}
+}
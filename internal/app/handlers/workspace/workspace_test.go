@@ -0,0 +1,279 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	delete_handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
+	"github.com/avGenie/url-shortener/internal/app/handlers/workspace/mock"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+	usecase "github.com/avGenie/url-shortener/internal/app/usecase/workspace"
+)
+
+func TestRoutes(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	memberID := entity.UserID("0c0a4811-4f10-487f-bde3-e39a14af7cd8")
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	workspace := entity.Workspace{
+		ID:        "9bb86afd-62da-43af-81f2-f37400820a2d",
+		Name:      "Team",
+		CreatedBy: userID,
+		CreatedAt: createdAt,
+	}
+	member := entity.WorkspaceMember{
+		WorkspaceID: workspace.ID,
+		UserID:      memberID,
+		Role:        entity.WorkspaceEditor,
+		CreatedAt:   createdAt,
+	}
+	workspaceLink := entity.Link{
+		ShortURL:    "EwHXdJfB",
+		OriginalURL: "https://example.com",
+		UserID:      memberID,
+		WorkspaceID: workspace.ID,
+		CreatedAt:   createdAt,
+	}
+
+	type want struct {
+		statusCode int
+		body       string
+	}
+	tests := []struct {
+		name      string
+		method    string
+		target    string
+		body      string
+		userIDCtx entity.UserIDCtx
+		prepare   func(s *mock.MockWorkspaceManager)
+		want      want
+	}{
+		{
+			name:   "create workspace",
+			method: http.MethodPost,
+			target: "/",
+			body:   `{"name":"Team"}`,
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().Create(gomock.Any(), userID, "Team").
+					Return(usecase.Membership{Workspace: workspace, Role: entity.WorkspaceOwner}, nil)
+			},
+			want: want{
+				statusCode: http.StatusCreated,
+				body:       `"role":"owner"`,
+			},
+		},
+		{
+			name:   "create workspace with invalid name",
+			method: http.MethodPost,
+			target: "/",
+			body:   `{"name":""}`,
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().Create(gomock.Any(), userID, "").
+					Return(usecase.Membership{}, &link.FieldError{Field: "name", Err: usecase.ErrInvalidName})
+			},
+			want: want{
+				statusCode: http.StatusBadRequest,
+				body:       "name: invalid workspace name",
+			},
+		},
+		{
+			name:   "list workspaces without memberships",
+			method: http.MethodGet,
+			target: "/",
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().List(gomock.Any(), userID).Return(nil, nil)
+			},
+			want: want{
+				statusCode: http.StatusNoContent,
+			},
+		},
+		{
+			name:   "list members of unknown workspace",
+			method: http.MethodGet,
+			target: "/unknown/members",
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().Members(gomock.Any(), userID, "unknown").Return(nil, usecase.ErrWorkspaceNotFound)
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name:   "set role of member",
+			method: http.MethodPut,
+			target: "/" + workspace.ID + "/members/" + memberID.String(),
+			body:   `{"role":"editor"}`,
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().SetRole(gomock.Any(), userID, workspace.ID, memberID, entity.WorkspaceEditor).Return(member, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `"role":"editor"`,
+			},
+		},
+		{
+			name:   "remove the last owner",
+			method: http.MethodDelete,
+			target: "/" + workspace.ID + "/members/" + userID.String(),
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().RemoveMember(gomock.Any(), userID, workspace.ID, userID).Return(usecase.ErrLastOwner)
+			},
+			want: want{
+				statusCode: http.StatusConflict,
+			},
+		},
+		{
+			name:   "invite by editor",
+			method: http.MethodPost,
+			target: "/" + workspace.ID + "/invites",
+			body:   `{"role":"viewer"}`,
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().Invite(gomock.Any(), userID, workspace.ID, entity.WorkspaceViewer).
+					Return(entity.WorkspaceInvite{}, usecase.ErrForbidden)
+			},
+			want: want{
+				statusCode: http.StatusForbidden,
+			},
+		},
+		{
+			name:   "accept invite",
+			method: http.MethodPost,
+			target: "/invites/secret/accept",
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().Accept(gomock.Any(), userID, "secret").Return(member, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `"user_id":"` + memberID.String() + `"`,
+			},
+		},
+		{
+			name:   "list links",
+			method: http.MethodGet,
+			target: "/" + workspace.ID + "/links?page_size=1",
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().Links(gomock.Any(), userID, workspace.ID, 1, "").Return([]entity.Link{workspaceLink}, "next", nil)
+				s.EXPECT().ShortURL(workspaceLink).Return("http://localhost:8080/EwHXdJfB")
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `"created_by":"` + memberID.String() + `"`,
+			},
+		},
+		{
+			name:    "list links with invalid page size",
+			method:  http.MethodGet,
+			target:  "/" + workspace.ID + "/links?page_size=-1",
+			prepare: func(s *mock.MockWorkspaceManager) {},
+			want: want{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:   "create existing link",
+			method: http.MethodPost,
+			target: "/" + workspace.ID + "/links",
+			body:   `{"original_url":"https://example.com"}`,
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().CreateLink(gomock.Any(), userID, workspace.ID, "", "https://example.com", nil).
+					Return(workspaceLink, link.ErrLinkExists)
+				s.EXPECT().ShortURL(workspaceLink).Return("http://localhost:8080/EwHXdJfB")
+			},
+			want: want{
+				statusCode: http.StatusConflict,
+				body:       "http://localhost:8080/EwHXdJfB",
+			},
+		},
+		{
+			name:   "update unknown link",
+			method: http.MethodPatch,
+			target: "/" + workspace.ID + "/links",
+			body:   `{"short_url":"unknown","metadata":{"team":"sales"}}`,
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().UpdateLink(gomock.Any(), userID, workspace.ID, "unknown", map[string]string{"team": "sales"}).
+					Return(entity.Link{}, link.ErrLinkNotFound)
+			},
+			want: want{
+				statusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name:   "delete links",
+			method: http.MethodDelete,
+			target: "/" + workspace.ID + "/links",
+			body:   `{"short_urls":["EwHXdJfB","unknown"]}`,
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().DeleteLinks(gomock.Any(), userID, workspace.ID, []string{"EwHXdJfB", "unknown"}).
+					Return([]string{"EwHXdJfB"}, nil)
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `{"deleted":["EwHXdJfB"]}`,
+			},
+		},
+		{
+			name:   "delete links with full deletion queue",
+			method: http.MethodDelete,
+			target: "/" + workspace.ID + "/links",
+			body:   `{"short_urls":["EwHXdJfB"]}`,
+			prepare: func(s *mock.MockWorkspaceManager) {
+				s.EXPECT().DeleteLinks(gomock.Any(), userID, workspace.ID, []string{"EwHXdJfB"}).
+					Return(nil, fmt.Errorf("error while deleting workspace links: %w", delete_handlers.ErrQueueFull))
+			},
+			want: want{
+				statusCode: http.StatusServiceUnavailable,
+				body:       delete_handlers.ErrQueueFull.Error(),
+			},
+		},
+		{
+			name:      "unauthorized user",
+			method:    http.MethodGet,
+			target:    "/",
+			userIDCtx: entity.UserIDCtx{StatusCode: http.StatusUnauthorized},
+			prepare:   func(s *mock.MockWorkspaceManager) {},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			s := mock.NewMockWorkspaceManager(ctrl)
+			test.prepare(s)
+
+			userIDCtx := test.userIDCtx
+			if userIDCtx.StatusCode == 0 {
+				userIDCtx = entity.UserIDCtx{UserID: userID, StatusCode: http.StatusOK}
+			}
+
+			request := httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+			request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, userIDCtx))
+			writer := httptest.NewRecorder()
+
+			Routes(s).ServeHTTP(writer, request)
+
+			res := writer.Result()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			assert.Contains(t, string(body), test.want.body)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Package health implements health checking of application components
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Statuses of component and whole application
+//
+// StatusUp - component is ready to serve requests
+// StatusDown - component couldn't serve requests
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Names of application components
+const (
+	ComponentStorage       = "storage"
+	ComponentDeleteFlusher = "delete_flusher"
+)
+
+const checkTimeout = 1 * time.Second
+
+// CheckFunc Checks component and returns error if component is not ready
+type CheckFunc func(ctx context.Context) error
+
+// ComponentStatus Contains status of component check
+type ComponentStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report Contains status of application and its components
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+}
+
+// IsUp Returns true if all components are up
+func (r Report) IsUp() bool {
+	return r.Status == StatusUp
+}
+
+type component struct {
+	name  string
+	check CheckFunc
+}
+
+// Checker Checks registered application components
+type Checker struct {
+	components []component
+}
+
+// NewChecker Creates checker of components
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Add Registers component check by name
+func (c *Checker) Add(name string, check CheckFunc) {
+	c.components = append(c.components, component{
+		name:  name,
+		check: check,
+	})
+}
+
+// Components Returns names of registered components in sorted order
+func (c *Checker) Components() []string {
+	names := make([]string, 0, len(c.components))
+	for _, component := range c.components {
+		names = append(names, component.name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Check Checks all components concurrently
+//
+// Each component check is limited by timeout.
+// Application is down if at least one component is down
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{
+		Status:     StatusUp,
+		Components: make(map[string]ComponentStatus, len(c.components)),
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, component := range c.components {
+		wg.Add(1)
+		go func(name string, check CheckFunc) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			status := ComponentStatus{
+				Status: StatusUp,
+			}
+			if err := check(checkCtx); err != nil {
+				status.Status = StatusDown
+				status.Error = err.Error()
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			report.Components[name] = status
+			if status.Status == StatusDown {
+				report.Status = StatusDown
+			}
+		}(component.name, component.check)
+	}
+	wg.Wait()
+
+	return report
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker(t *testing.T) {
+	errStorage := errors.New("storage is unavailable")
+
+	tests := []struct {
+		name     string
+		checks   map[string]CheckFunc
+		expected Report
+	}{
+		{
+			name:   "without components",
+			checks: map[string]CheckFunc{},
+			expected: Report{
+				Status:     StatusUp,
+				Components: map[string]ComponentStatus{},
+			},
+		},
+		{
+			name: "all components are up",
+			checks: map[string]CheckFunc{
+				"storage":        func(context.Context) error { return nil },
+				"delete_flusher": func(context.Context) error { return nil },
+			},
+			expected: Report{
+				Status: StatusUp,
+				Components: map[string]ComponentStatus{
+					"storage":        {Status: StatusUp},
+					"delete_flusher": {Status: StatusUp},
+				},
+			},
+		},
+		{
+			name: "one component is down",
+			checks: map[string]CheckFunc{
+				"storage":        func(context.Context) error { return errStorage },
+				"delete_flusher": func(context.Context) error { return nil },
+			},
+			expected: Report{
+				Status: StatusDown,
+				Components: map[string]ComponentStatus{
+					"storage":        {Status: StatusDown, Error: errStorage.Error()},
+					"delete_flusher": {Status: StatusUp},
+				},
+			},
+		},
+		{
+			name: "check exceeds timeout",
+			checks: map[string]CheckFunc{
+				"storage": func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				},
+			},
+			expected: Report{
+				Status: StatusDown,
+				Components: map[string]ComponentStatus{
+					"storage": {Status: StatusDown, Error: context.DeadlineExceeded.Error()},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checker := NewChecker()
+			for name, check := range test.checks {
+				checker.Add(name, check)
+			}
+
+			report := checker.Check(context.Background())
+			assert.Equal(t, test.expected, report)
+			assert.Equal(t, test.expected.Status == StatusUp, report.IsUp())
+		})
+	}
+}
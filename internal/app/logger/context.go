@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type (
+	loggerCtxKey    struct{}
+	requestIDCtxKey struct{}
+)
+
+// WithLogger Returns context which carries request-scoped logger
+func WithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, logger)
+}
+
+// FromContext Returns request-scoped logger from context
+//
+// Returns global logger if context doesn't contain logger
+func FromContext(ctx context.Context) *zap.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerCtxKey{}).(*zap.Logger); ok {
+			return logger
+		}
+	}
+
+	return zap.L()
+}
+
+// With Returns context which carries request-scoped logger extended by fields
+func With(ctx context.Context, fields ...zap.Field) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(fields...))
+}
+
+// WithRequestID Returns context which carries request ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, requestID)
+}
+
+// RequestIDFromContext Returns request ID from context
+//
+// Returns empty string if context doesn't contain request ID
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDCtxKey{}).(string)
+
+	return requestID
+}
@@ -1,35 +1,72 @@
-// Package logger provides logger middleware
+// Package logger provides logger middleware and request-scoped loggers
 package logger
 
 import (
+	"context"
 	"net/http"
 	"time"
 
 	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-// Initialize Initializes zap logger
+const auditLoggerName = "audit"
+
+var (
+	level = zap.NewAtomicLevel()
+	audit = zap.NewNop()
+)
+
+// Initialize Initializes zap logger and logger of audit log
 func Initialize(config config.Config) error {
-	lvl, err := zap.ParseAtomicLevel(config.LogLevel)
+	err := SetLevel(config.LogLevel)
 	if err != nil {
 		return err
 	}
 
 	cfg := zap.NewProductionConfig()
-	cfg.Level = lvl
+	cfg.Level = level
 
 	zl, err := cfg.Build()
 	if err != nil {
 		return err
 	}
 
+	// audit log is written regardless of log level
+	cfg.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+	al, err := cfg.Build()
+	if err != nil {
+		return err
+	}
+
 	zap.ReplaceGlobals(zl)
+	audit = al.Named(auditLoggerName)
 
 	return nil
 }
 
+// SetLevel Changes level of initialized logger
+func SetLevel(lvl string) error {
+	return level.UnmarshalText([]byte(lvl))
+}
+
+// Level Returns current level of logger
+func Level() string {
+	return level.String()
+}
+
+// Audit Returns logger of audit log
+//
+// Audit log is not written before logger is initialized
+func Audit() *zap.Logger {
+	return audit
+}
+
 // LoggerMiddleware logs incoming HTTP request information: URI, method, status, size
+//
+// Request is logged by request-scoped logger, so it must be used after RequestIDMiddleware
 func LoggerMiddleware(h http.Handler) http.Handler {
 	logFn := func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -46,15 +83,34 @@ func LoggerMiddleware(h http.Handler) http.Handler {
 
 		duration := time.Since(start)
 
-		zap.L().Info(
-			"got incoming HTTP request",
+		fields := []zap.Field{
 			zap.String("uri", r.RequestURI),
-			zap.String("method", r.Method),
 			zap.Duration("duration", duration),
 			zap.Int("status", respData.statusCode),
 			zap.Int("size", respData.size),
-		)
+		}
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			fields = append(fields, zap.String("route", rctx.RoutePattern()))
+		}
+
+		FromContext(r.Context()).Info("got incoming HTTP request", fields...)
 	}
 
 	return http.HandlerFunc(logFn)
 }
+
+// TraceFields Returns trace and span IDs of the span from context as log fields
+//
+// Returns nil if context doesn't contain valid span
+func TraceFields(ctx context.Context) []zap.Field {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return nil
+	}
+
+	return []zap.Field{
+		zap.String("trace_id", spanCtx.TraceID().String()),
+		zap.String("span_id", spanCtx.SpanID().String()),
+	}
+}
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// Request ID keys
+//
+// RequestIDHeader - HTTP header which contains request ID
+// RequestIDMetadataKey - GRPC metadata key which contains request ID
+const (
+	RequestIDHeader      = "X-Request-ID"
+	RequestIDMetadataKey = "x-request-id"
+)
+
+const maxRequestIDLen = 128
+
+// RequestID Returns request ID obtained from client if it is valid or generates new one
+func RequestID(clientRequestID string) string {
+	if isValidRequestID(clientRequestID) {
+		return clientRequestID
+	}
+
+	return uuid.New().String()
+}
+
+// NewRequestContext Returns context which carries request ID and request-scoped logger
+//
+// Logger is derived from global logger and contains request ID, trace IDs and given fields
+func NewRequestContext(ctx context.Context, requestID string, fields ...zap.Field) context.Context {
+	fields = append(fields, zap.String("request_id", requestID))
+	fields = append(fields, TraceFields(ctx)...)
+
+	ctx = WithRequestID(ctx, requestID)
+
+	return WithLogger(ctx, zap.L().With(fields...))
+}
+
+// RequestIDMiddleware Accepts request ID from X-Request-ID header or generates new one
+//
+// Request ID is echoed in response header and request-scoped logger is attached to request context
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := RequestID(r.Header.Get(RequestIDHeader))
+		w.Header().Set(RequestIDHeader, requestID)
+
+		ctx := NewRequestContext(r.Context(), requestID, zap.String("method", r.Method))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ContextMiddleware Extends request-scoped logger by route pattern and user ID
+//
+// Must be used for routes after authentication middleware
+func ContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields := make([]zap.Field, 0, 2)
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			fields = append(fields, zap.String("route", rctx.RoutePattern()))
+		}
+
+		if userIDCtx, ok := r.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx); ok && userIDCtx.UserID.IsValid() {
+			fields = append(fields, zap.String("user_id", userIDCtx.UserID.String()))
+		}
+
+		next.ServeHTTP(w, r.WithContext(With(r.Context(), fields...)))
+	})
+}
+
+func isValidRequestID(requestID string) bool {
+	if len(requestID) == 0 || len(requestID) > maxRequestIDLen {
+		return false
+	}
+
+	for _, c := range requestID {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name            string
+		clientRequestID string
+		keepRequestID   bool
+	}{
+		{
+			name:            "request id from client",
+			clientRequestID: "f4b0a2c8-request",
+			keepRequestID:   true,
+		},
+		{
+			name:            "empty request id",
+			clientRequestID: "",
+			keepRequestID:   false,
+		},
+		{
+			name:            "request id with spaces",
+			clientRequestID: "invalid request id",
+			keepRequestID:   false,
+		},
+		{
+			name:            "too long request id",
+			clientRequestID: strings.Repeat("a", maxRequestIDLen+1),
+			keepRequestID:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var ctxRequestID string
+			handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctxRequestID = RequestIDFromContext(r.Context())
+			}))
+
+			request := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.clientRequestID != "" {
+				request.Header.Set(RequestIDHeader, test.clientRequestID)
+			}
+			writer := httptest.NewRecorder()
+
+			handler.ServeHTTP(writer, request)
+
+			requestID := writer.Header().Get(RequestIDHeader)
+			require.NotEmpty(t, requestID)
+			assert.Equal(t, requestID, ctxRequestID)
+
+			if test.keepRequestID {
+				assert.Equal(t, test.clientRequestID, requestID)
+			} else {
+				assert.NotEqual(t, test.clientRequestID, requestID)
+			}
+		})
+	}
+}
+
+func TestContextLogger(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	defer zap.ReplaceGlobals(zap.New(core))()
+
+	r := chi.NewRouter()
+	r.Use(RequestIDMiddleware)
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userCtx := entity.UserIDCtx{
+				UserID:     entity.UserID("user"),
+				StatusCode: http.StatusOK,
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), entity.UserIDCtxKey{}, userCtx)))
+		})
+	})
+	r.With(ContextMiddleware).Get("/{url}", func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Info("handler")
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/abc", nil)
+	request.Header.Set(RequestIDHeader, "request")
+
+	r.ServeHTTP(httptest.NewRecorder(), request)
+
+	entries := logs.FilterMessage("handler").All()
+	require.Len(t, entries, 1)
+
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "request", fields["request_id"])
+	assert.Equal(t, "user", fields["user_id"])
+	assert.Equal(t, "/{url}", fields["route"])
+	assert.Equal(t, http.MethodGet, fields["method"])
+}
@@ -29,3 +29,15 @@ func (w *logResponseWriter) WriteHeader(statusCode int) {
 	w.ResponseWriter.WriteHeader(statusCode)
 	w.responseData.statusCode = statusCode
 }
+
+// Flush Implements http.Flusher interface if underlying writer supports it
+func (w *logResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap Returns underlying writer to http.ResponseController
+func (w *logResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
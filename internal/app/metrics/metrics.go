@@ -0,0 +1,383 @@
+// Package metrics provides prometheus metrics of application
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+
+	"github.com/avGenie/url-shortener/internal/app/models"
+)
+
+const (
+	namespace = "shortener"
+
+	statisticTimeout = time.Second
+)
+
+// Results of operations used as label values
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Count of HTTP requests by route pattern, method and status.",
+	}, []string{"route", "method", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of HTTP requests by route pattern, method and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "requests_total",
+		Help:      "Count of GRPC calls by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of GRPC calls by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	storageDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "storage",
+		Name:      "operation_duration_seconds",
+		Help:      "Latency of storage operations by backend, method and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"backend", "method", "result"})
+
+	deleteQueueDepth = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "delete",
+		Name:      "queue_depth",
+		Help:      "Count of deleted URLs waiting to be flushed to storage.",
+	})
+
+	deleteQueueJobs = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "delete",
+		Name:      "queue_jobs",
+		Help:      "Count of deletion jobs waiting in queue.",
+	})
+
+	deleteQueueCapacity = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "delete",
+		Name:      "queue_capacity",
+		Help:      "Max count of deletion jobs waiting in queue.",
+	})
+
+	deleteQueueRejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "delete",
+		Name:      "queue_rejected_total",
+		Help:      "Count of deletions rejected because queue was full.",
+	})
+
+	deleteWorkersBusy = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "delete",
+		Name:      "workers_busy",
+		Help:      "Count of workers flushing deleted URLs to storage.",
+	})
+
+	deleteFlushes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "delete",
+		Name:      "flushes_total",
+		Help:      "Count of deleted URLs flushes by result.",
+	}, []string{"result"})
+
+	deleteFlushedURLs = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "delete",
+		Name:      "flushed_urls_total",
+		Help:      "Count of deleted URLs successfully flushed to storage.",
+	})
+
+	deleteJobs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "delete",
+		Name:      "jobs_total",
+		Help:      "Count of deletion job attempts by resulting job status.",
+	}, []string{"status"})
+
+	trashPurges = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "trash",
+		Name:      "purges_total",
+		Help:      "Count of purges of expired links from trash by result.",
+	}, []string{"result"})
+
+	trashPurgedLinks = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "trash",
+		Name:      "purged_links_total",
+		Help:      "Count of expired links removed from trash.",
+	})
+
+	webhookEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhook",
+		Name:      "events_total",
+		Help:      "Count of link events published to webhooks by result.",
+	}, []string{"result"})
+
+	eventSubscribers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "events",
+		Name:      "subscribers",
+		Help:      "Count of subscribers of live event stream.",
+	})
+
+	eventPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "events",
+		Name:      "published_total",
+		Help:      "Count of link events published to live event stream by result.",
+	}, []string{"result"})
+
+	eventSlowSubscribers = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "events",
+		Name:      "slow_subscribers_total",
+		Help:      "Count of subscribers of live event stream disconnected because they didn't keep up with events.",
+	})
+
+	webhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhook",
+		Name:      "deliveries_total",
+		Help:      "Count of webhook delivery attempts by resulting delivery status.",
+	}, []string{"status"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		grpcRequests,
+		grpcDuration,
+		storageDuration,
+		deleteQueueDepth,
+		deleteQueueJobs,
+		deleteQueueCapacity,
+		deleteQueueRejected,
+		deleteWorkersBusy,
+		deleteFlushes,
+		deleteFlushedURLs,
+		deleteJobs,
+		trashPurges,
+		trashPurgedLinks,
+		webhookEvents,
+		webhookDeliveries,
+		eventSubscribers,
+		eventPublished,
+		eventSlowSubscribers,
+	)
+}
+
+// StatisticGetter Getter for service statistic
+type StatisticGetter interface {
+	GetStatistic(ctx context.Context) (models.CountStatistic, error)
+}
+
+// Handler Returns handler which exposes metrics in prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// RegisterStatistic Registers active links, deleted links and users gauges derived from storage statistic
+func RegisterStatistic(getter StatisticGetter) error {
+	return registry.Register(newStatisticCollector(getter))
+}
+
+// ObserveHTTPRequest Collects metrics of processed HTTP request
+func ObserveHTTPRequest(route, method string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+
+	httpRequests.WithLabelValues(route, method, statusLabel).Inc()
+	httpDuration.WithLabelValues(route, method, statusLabel).Observe(duration.Seconds())
+}
+
+// ObserveGRPCRequest Collects metrics of processed GRPC call
+func ObserveGRPCRequest(method string, code codes.Code, duration time.Duration) {
+	grpcRequests.WithLabelValues(method, code.String()).Inc()
+	grpcDuration.WithLabelValues(method, code.String()).Observe(duration.Seconds())
+}
+
+// ObserveStorageOperation Collects metrics of storage operation
+func ObserveStorageOperation(backend, method string, err error, duration time.Duration) {
+	storageDuration.WithLabelValues(backend, method, result(err)).Observe(duration.Seconds())
+}
+
+// AddDeleteQueueDepth Changes count of deleted URLs waiting to be flushed
+func AddDeleteQueueDepth(count int) {
+	deleteQueueDepth.Add(float64(count))
+}
+
+// AddDeleteQueueJobs Changes count of deletion jobs waiting in queue
+func AddDeleteQueueJobs(count int) {
+	deleteQueueJobs.Add(float64(count))
+}
+
+// SetDeleteQueueCapacity Sets max count of deletion jobs waiting in queue
+func SetDeleteQueueCapacity(capacity int) {
+	deleteQueueCapacity.Set(float64(capacity))
+}
+
+// ObserveDeleteRejected Collects deletion rejected because queue was full
+func ObserveDeleteRejected() {
+	deleteQueueRejected.Inc()
+}
+
+// AddDeleteWorkersBusy Changes count of workers flushing deleted URLs
+func AddDeleteWorkersBusy(count int) {
+	deleteWorkersBusy.Add(float64(count))
+}
+
+// ObserveDeleteFlush Collects result of deleted URLs flush
+func ObserveDeleteFlush(count int, err error) {
+	deleteFlushes.WithLabelValues(result(err)).Inc()
+
+	if err == nil {
+		deleteFlushedURLs.Add(float64(count))
+	}
+}
+
+// ObserveDeleteJob Collects status of deletion job after its attempt
+func ObserveDeleteJob(status string) {
+	deleteJobs.WithLabelValues(status).Inc()
+}
+
+// ObserveTrashPurge Collects result of purge of expired links from trash
+func ObserveTrashPurge(count int, err error) {
+	trashPurges.WithLabelValues(result(err)).Inc()
+	trashPurgedLinks.Add(float64(count))
+}
+
+// ObserveWebhookEvent Collects link event published to webhooks, dropped events are collected if queue was full
+func ObserveWebhookEvent(dropped bool) {
+	if dropped {
+		webhookEvents.WithLabelValues("dropped").Inc()
+		return
+	}
+
+	webhookEvents.WithLabelValues("queued").Inc()
+}
+
+// ObserveWebhookDelivery Collects status of webhook delivery after its attempt
+func ObserveWebhookDelivery(status string) {
+	webhookDeliveries.WithLabelValues(status).Inc()
+}
+
+// ObserveEventPublished Collects link event published to live event stream, dropped events are collected if queue was full
+func ObserveEventPublished(dropped bool) {
+	if dropped {
+		eventPublished.WithLabelValues("dropped").Inc()
+		return
+	}
+
+	eventPublished.WithLabelValues("queued").Inc()
+}
+
+// SetEventSubscribers Sets count of subscribers of live event stream
+func SetEventSubscribers(count int) {
+	eventSubscribers.Set(float64(count))
+}
+
+// ObserveSlowEventSubscriber Collects subscriber of live event stream disconnected because it was too slow
+func ObserveSlowEventSubscriber() {
+	eventSlowSubscribers.Inc()
+}
+
+func result(err error) string {
+	if err != nil {
+		return resultError
+	}
+
+	return resultSuccess
+}
+
+// statisticCollector Collects links and users gauges from storage on every scrape
+type statisticCollector struct {
+	getter StatisticGetter
+
+	urls    *prometheus.Desc
+	deleted *prometheus.Desc
+	users   *prometheus.Desc
+}
+
+func newStatisticCollector(getter StatisticGetter) *statisticCollector {
+	return &statisticCollector{
+		getter: getter,
+		urls: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "urls"),
+			"Count of active short URLs in storage.",
+			nil, nil,
+		),
+		deleted: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "deleted_urls"),
+			"Count of deleted short URLs kept in storage.",
+			nil, nil,
+		),
+		users: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "", "users"),
+			"Count of users in storage.",
+			nil, nil,
+		),
+	}
+}
+
+// Describe Implements prometheus.Collector interface
+func (c *statisticCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.urls
+	ch <- c.deleted
+	ch <- c.users
+}
+
+// Collect Implements prometheus.Collector interface
+func (c *statisticCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), statisticTimeout)
+	defer cancel()
+
+	stat, err := c.getter.GetStatistic(ctx)
+	if err != nil {
+		zap.L().Error("error while collecting statistic metrics", zap.Error(err))
+
+		ch <- prometheus.NewInvalidMetric(c.urls, err)
+		ch <- prometheus.NewInvalidMetric(c.deleted, err)
+		ch <- prometheus.NewInvalidMetric(c.users, err)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.urls, prometheus.GaugeValue, float64(stat.URLCount))
+	ch <- prometheus.MustNewConstMetric(c.deleted, prometheus.GaugeValue, float64(stat.DeletedCount))
+	ch <- prometheus.MustNewConstMetric(c.users, prometheus.GaugeValue, float64(stat.UserCount))
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/models"
+)
+
+type statisticGetter struct {
+	stat models.CountStatistic
+	err  error
+}
+
+func (g statisticGetter) GetStatistic(_ context.Context) (models.CountStatistic, error) {
+	return g.stat, g.err
+}
+
+func TestMiddleware(t *testing.T) {
+	r := chi.NewRouter()
+	r.Use(Middleware)
+	r.Get("/{url}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTemporaryRedirect)
+	})
+
+	tests := []struct {
+		name   string
+		target string
+		route  string
+		status string
+	}{
+		{
+			name:   "matched route",
+			target: "/42b3e75f",
+			route:  "/{url}",
+			status: "307",
+		},
+		{
+			name:   "unmatched route",
+			target: "/api/unknown",
+			route:  unmatchedRoute,
+			status: "404",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := testutil.ToFloat64(httpRequests.WithLabelValues(test.route, http.MethodGet, test.status))
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, test.target, nil))
+
+			after := testutil.ToFloat64(httpRequests.WithLabelValues(test.route, http.MethodGet, test.status))
+			assert.Equal(t, before+1, after)
+		})
+	}
+}
+
+func TestStatisticCollector(t *testing.T) {
+	collector := newStatisticCollector(statisticGetter{
+		stat: models.CountStatistic{URLCount: 3, UserCount: 2, DeletedCount: 1},
+	})
+
+	expected := `
+		# HELP shortener_deleted_urls Count of deleted short URLs kept in storage.
+		# TYPE shortener_deleted_urls gauge
+		shortener_deleted_urls 1
+		# HELP shortener_urls Count of active short URLs in storage.
+		# TYPE shortener_urls gauge
+		shortener_urls 3
+		# HELP shortener_users Count of users in storage.
+		# TYPE shortener_users gauge
+		shortener_users 2
+	`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+	require.NoError(t, err)
+
+	failed := newStatisticCollector(statisticGetter{err: errors.New("storage error")})
+	assert.Error(t, testutil.CollectAndCompare(failed, strings.NewReader("")))
+}
@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// UserExport Contains all data kept about user
+//
+// Links include deleted links kept in trash, activity contains records of audit trail of user
+type UserExport struct {
+	UserID            string              `json:"user_id"`
+	ExportedAt        time.Time           `json:"exported_at"`
+	Links             []ExportedLink      `json:"links"`
+	DeleteJobs        []ExportedDeleteJob `json:"delete_jobs"`
+	Webhooks          []Webhook           `json:"webhooks"`
+	WebhookDeliveries []WebhookDelivery   `json:"webhook_deliveries"`
+	Workspaces        []Workspace         `json:"workspaces"`
+	Activity          []AuditRecord       `json:"activity"`
+}
+
+// ExportedLink Contains link of user with its states
+type ExportedLink struct {
+	ShortURL    string            `json:"short_url"`
+	Alias       string            `json:"alias"`
+	OriginalURL string            `json:"original_url"`
+	WorkspaceID string            `json:"workspace_id,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Deleted     bool              `json:"deleted"`
+	DeletedAt   *time.Time        `json:"deleted_at,omitempty"`
+	Disabled    bool              `json:"disabled"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// ExportedDeleteJob Contains deletion job of user with its status
+type ExportedDeleteJob struct {
+	ID        string    `json:"id"`
+	ShortURLs []string  `json:"short_urls"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserErasure Contains counts of records of user erased or anonymized in storage
+//
+// Links created by user in workspaces with other owners are transferred to the earliest joined owner,
+// workspaces created by user and audit records of user are anonymized, other records are removed
+type UserErasure struct {
+	Links             int `json:"links"`
+	TransferredLinks  int `json:"transferred_links"`
+	DeleteJobs        int `json:"delete_jobs"`
+	Webhooks          int `json:"webhooks"`
+	WebhookDeliveries int `json:"webhook_deliveries"`
+	Memberships       int `json:"memberships"`
+	Invites           int `json:"invites"`
+	Workspaces        int `json:"workspaces"`
+	AuditRecords      int `json:"audit_records"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// AdminLink Contains short link with its owner and states for admin
+type AdminLink struct {
+	ShortURL    string            `json:"short_url"`
+	Alias       string            `json:"alias"`
+	OriginalURL string            `json:"original_url"`
+	UserID      string            `json:"user_id"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Deleted     bool              `json:"deleted"`
+	Disabled    bool              `json:"disabled"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// AdminDeletion Contains ID of deletion job and count of links queued for deletion
+type AdminDeletion struct {
+	JobID string `json:"job_id,omitempty"`
+	Count int    `json:"count"`
+}
+
+// AdminFlush Contains count of deleted URLs flushed to storage
+type AdminFlush struct {
+	Count int `json:"count"`
+}
+
+// CompactionResult Contains size of storage before and after compaction and count of kept links
+type CompactionResult struct {
+	SizeBefore int64 `json:"size_before_bytes"`
+	SizeAfter  int64 `json:"size_after_bytes"`
+	Links      int   `json:"links"`
+}
+
+// LogLevel Contains log level name
+type LogLevel struct {
+	Level string `json:"level"`
+}
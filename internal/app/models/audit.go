@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// AuditRecord Contains action changing state of service kept in audit trail
+type AuditRecord struct {
+	ID        string    `json:"id"`
+	ActorType string    `json:"actor_type"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Targets   []string  `json:"targets,omitempty"`
+	ClientIP  string    `json:"client_ip,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+	Result    string    `json:"result"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AuditPage Contains page of audit records, the newest first, and token of the next page
+//
+// Token of the next page is empty if there are no more records
+type AuditPage struct {
+	Records       []AuditRecord `json:"records"`
+	NextPageToken string        `json:"next_page_token,omitempty"`
+}
@@ -20,8 +20,9 @@ type ReqURLBatchObject struct {
 
 // BatchObjectRequest Input struct for batch POST request
 type BatchObjectRequest struct {
-	ID  string `json:"correlation_id"`
-	URL string `json:"original_url"`
+	ID     string `json:"correlation_id"`
+	URL    string `json:"original_url"`
+	Domain string `json:"domain,omitempty"`
 }
 
 // BatchObjectResponse Output struct for batch POST request
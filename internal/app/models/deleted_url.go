@@ -1,4 +1,51 @@
 package models
 
+import "time"
+
 // ReqDeletedURLBatch Contains input information about deleted URLs
 type ReqDeletedURLBatch []string
+
+// DeleteJobAccepted Contains ID of accepted deletion job
+type DeleteJobAccepted struct {
+	JobID string `json:"job_id"`
+}
+
+// DeleteJobResponse Contains state of deletion job of user
+//
+// Next attempt time is set only for pending jobs after failed attempt
+type DeleteJobResponse struct {
+	JobID         string     `json:"job_id"`
+	Status        string     `json:"status"`
+	URLsCount     int        `json:"urls_count"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// DeleteQueueStats Contains state of queue of deletion jobs and its workers
+//
+// Rejected is count of deletions rejected because queue was full since start
+type DeleteQueueStats struct {
+	QueuedJobs   int   `json:"queued_jobs"`
+	QueuedURLs   int   `json:"queued_urls"`
+	Capacity     int   `json:"capacity"`
+	UserCapacity int   `json:"user_capacity"`
+	InFlightJobs int   `json:"in_flight_jobs"`
+	Workers      int   `json:"workers"`
+	BusyWorkers  int   `json:"busy_workers"`
+	Rejected     int64 `json:"rejected"`
+}
+
+// TrashURLResponse Contains deleted URL of user which can be restored until expiration time
+type TrashURLResponse struct {
+	ShortURL    string    `json:"short_url"`
+	OriginalURL string    `json:"original_url"`
+	DeletedAt   time.Time `json:"deleted_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// RestoredURLs Contains short IDs of restored URLs
+type RestoredURLs struct {
+	Restored []string `json:"restored"`
+}
@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// LinkEventResponse Contains link event of user sent by live event stream
+type LinkEventResponse struct {
+	Type        string    `json:"type"`
+	ShortURL    string    `json:"short_url"`
+	Alias       string    `json:"alias"`
+	OriginalURL string    `json:"original_url"`
+	Time        time.Time `json:"time"`
+}
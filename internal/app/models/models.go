@@ -2,7 +2,8 @@ package models
 
 // Request Contains information about original URL in JSON representation
 type Request struct {
-	URL string `json:"url"`
+	URL    string `json:"url"`
+	Domain string `json:"domain,omitempty"`
 }
 
 // Response Contains information about short URL in JSON representation
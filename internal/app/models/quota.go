@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Usage Contains usage of user quota with limits of its plan
+type Usage struct {
+	Plan          string       `json:"plan,omitempty"`
+	ActiveLinks   QuotaCounter `json:"active_links"`
+	LinksToday    QuotaCounter `json:"links_today"`
+	TrackedClicks QuotaCounter `json:"tracked_clicks"`
+	MaxBatchSize  int          `json:"max_batch_size,omitempty"`
+}
+
+// QuotaCounter Contains usage of one limit of user quota
+//
+// Limit is omitted if usage isn't limited, reset time is omitted if usage isn't reset over time
+type QuotaCounter struct {
+	Used    int64      `json:"used"`
+	Limit   int64      `json:"limit,omitempty"`
+	ResetAt *time.Time `json:"reset_at,omitempty"`
+}
+
+// QuotaExceeded Contains error of request exceeding limit of user quota
+type QuotaExceeded struct {
+	Error   string     `json:"error"`
+	Limit   string     `json:"limit"`
+	Max     int64      `json:"max"`
+	ResetAt *time.Time `json:"reset_at,omitempty"`
+}
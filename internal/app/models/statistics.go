@@ -1,7 +1,81 @@
 package models
 
-// CountStatistic Contains URL nad users count in storage
+import "time"
+
+// Granularity of links creation time series
+//
+// GranularityHour - links are counted per hour
+// GranularityDay - links are counted per day
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+)
+
+// CountStatistic Contains active URLs, deleted URLs and users count in storage
 type CountStatistic struct {
-	URLCount  int `json:"urls"`
-	UserCount int `json:"users"`
+	URLCount     int `json:"urls"`
+	UserCount    int `json:"users"`
+	DeletedCount int `json:"deleted_urls"`
+}
+
+// StatisticQuery Parameters of service statistic request
+//
+// Links created in [From, To) range are counted per Granularity buckets in UTC,
+// Top is a max count of top domains and top clicked links
+type StatisticQuery struct {
+	From        time.Time
+	To          time.Time
+	Granularity string
+	Top         int
+}
+
+// ServiceStatistic Contains counts of storage, time series of created links, top domains and top clicked links
+type ServiceStatistic struct {
+	CountStatistic
+
+	StorageSize int64         `json:"storage_size_bytes"`
+	From        time.Time     `json:"from"`
+	To          time.Time     `json:"to"`
+	Granularity string        `json:"granularity"`
+	Created     []TimeCount   `json:"created"`
+	TopDomains  []DomainCount `json:"top_domains"`
+	TopLinks    []LinkClicks  `json:"top_links"`
+}
+
+// TimeCount Count of links created in time bucket starting at Time
+type TimeCount struct {
+	Time  time.Time `json:"time"`
+	Count int       `json:"count"`
+}
+
+// DomainCount Count of links to domain
+type DomainCount struct {
+	Domain string `json:"domain"`
+	Count  int    `json:"count"`
+}
+
+// LinkClicks Count of redirects by short link
+type LinkClicks struct {
+	ShortURL    string `json:"short_url"`
+	OriginalURL string `json:"original_url"`
+	Clicks      int64  `json:"clicks"`
+}
+
+// Truncate Returns start of time bucket of query granularity containing t
+func (q StatisticQuery) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	if q.Granularity == GranularityDay {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	return t.Truncate(time.Hour)
+}
+
+// Step Returns duration of time bucket of query granularity
+func (q StatisticQuery) Step() time.Duration {
+	if q.Granularity == GranularityDay {
+		return 24 * time.Hour
+	}
+
+	return time.Hour
 }
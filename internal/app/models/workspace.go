@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+// CreateWorkspaceRequest Contains name of new workspace
+type CreateWorkspaceRequest struct {
+	Name string `json:"name"`
+}
+
+// Workspace Contains workspace with role of user in it
+type Workspace struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkspaceMember Contains member of workspace with its role
+type WorkspaceMember struct {
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// WorkspaceRoleRequest Contains role of workspace member
+type WorkspaceRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// WorkspaceInvite Contains single use invite to workspace
+//
+// ID of invite is secret which should be passed to invited user
+type WorkspaceInvite struct {
+	ID          string    `json:"id"`
+	WorkspaceID string    `json:"workspace_id"`
+	Role        string    `json:"role"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// CreateWorkspaceLinkRequest Contains original URL of link created in workspace
+//
+// Default domain is used if domain is empty
+type CreateWorkspaceLinkRequest struct {
+	OriginalURL string            `json:"original_url"`
+	Domain      string            `json:"domain,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// UpdateWorkspaceLinkRequest Contains short URL of workspace link and its new metadata
+type UpdateWorkspaceLinkRequest struct {
+	ShortURL string            `json:"short_url"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// DeleteWorkspaceLinksRequest Contains short URLs of workspace links to delete
+type DeleteWorkspaceLinksRequest struct {
+	ShortURLs []string `json:"short_urls"`
+}
+
+// DeleteWorkspaceLinksResponse Contains short URLs of workspace links queued for deletion
+type DeleteWorkspaceLinksResponse struct {
+	Deleted []string `json:"deleted"`
+}
+
+// WorkspaceLink Contains link of workspace with its creator
+type WorkspaceLink struct {
+	ShortURL    string            `json:"short_url"`
+	Alias       string            `json:"alias"`
+	OriginalURL string            `json:"original_url"`
+	CreatedBy   string            `json:"created_by"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Deleted     bool              `json:"deleted"`
+	Disabled    bool              `json:"disabled"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// WorkspaceLinksPage Contains page of workspace links and token of the next page
+//
+// Token of the next page is empty if there are no more links
+type WorkspaceLinksPage struct {
+	Links         []WorkspaceLink `json:"links"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+}
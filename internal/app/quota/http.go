@@ -0,0 +1,49 @@
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/models"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// quotaExceededCode Code of error in response to request exceeding user quota
+const quotaExceededCode = "quota_exceeded"
+
+// WriteHTTPError Writes response with exceeded limit if error is QuotaError error
+//
+// Returns false if error isn't QuotaError error and response isn't written.
+// Returns 429(StatusTooManyRequests) with Retry-After header if usage of exceeded limit is reset over time
+// Returns 403(StatusForbidden) if usage of exceeded limit isn't reset over time
+func WriteHTTPError(writer http.ResponseWriter, err error) bool {
+	var quotaErr *api.QuotaError
+	if !errors.As(err, &quotaErr) {
+		return false
+	}
+
+	response := models.QuotaExceeded{
+		Error: quotaExceededCode,
+		Limit: quotaErr.Limit,
+		Max:   quotaErr.Max,
+	}
+
+	status := http.StatusForbidden
+	if !quotaErr.ResetAt.IsZero() {
+		status = http.StatusTooManyRequests
+		response.ResetAt = &quotaErr.ResetAt
+
+		retryAfter := math.Ceil(time.Until(quotaErr.ResetAt).Seconds())
+		writer.Header().Set("Retry-After", strconv.Itoa(max(int(retryAfter), 1)))
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	json.NewEncoder(writer).Encode(response)
+
+	return true
+}
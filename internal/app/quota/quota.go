@@ -0,0 +1,148 @@
+// Package quota implements plans of user quotas enforced by storage
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// Errors of quota configuration
+//
+// ErrInvalidPlan - returned if quota plan couldn't be parsed
+// ErrUnknownPlan - returned if user is assigned to plan which is not configured
+var (
+	ErrInvalidPlan = errors.New("invalid quota plan")
+	ErrUnknownPlan = errors.New("unknown quota plan")
+)
+
+// Policy Assigns quotas of plans to users
+//
+// Users without assigned plan get default plan, users aren't limited if no plans are configured
+type Policy struct {
+	plans       map[string]entity.Quota
+	users       map[entity.UserID]string
+	defaultPlan string
+}
+
+// NewPolicy Creates policy from plans and users of config
+func NewPolicy(config config.Config) (*Policy, error) {
+	plans, err := ParsePlans(config.QuotaPlans)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := ParseUsers(config.QuotaUsers)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(plans) == 0 {
+		return &Policy{}, nil
+	}
+
+	if _, ok := plans[config.QuotaDefaultPlan]; !ok {
+		return nil, fmt.Errorf("%w: default plan %q", ErrUnknownPlan, config.QuotaDefaultPlan)
+	}
+
+	for userID, plan := range users {
+		if _, ok := plans[plan]; !ok {
+			return nil, fmt.Errorf("%w: plan %q of user %s", ErrUnknownPlan, plan, userID)
+		}
+	}
+
+	return &Policy{
+		plans:       plans,
+		users:       users,
+		defaultPlan: config.QuotaDefaultPlan,
+	}, nil
+}
+
+// ValidateConfig Validates quota plans and plans of users from config
+func ValidateConfig(config config.Config) error {
+	_, err := NewPolicy(config)
+	if err != nil {
+		return fmt.Errorf("invalid quotas: %w", err)
+	}
+
+	return nil
+}
+
+// Quota Returns quota of plan assigned to user
+func (p *Policy) Quota(userID entity.UserID) entity.Quota {
+	if len(p.plans) == 0 {
+		return entity.Quota{}
+	}
+
+	plan, ok := p.users[userID]
+	if !ok {
+		plan = p.defaultPlan
+	}
+
+	return p.plans[plan]
+}
+
+// ParsePlans Parses plans from string in format "plan=active_links:links_per_day:batch_size:tracked_clicks,..."
+//
+// Zero limit means that it isn't limited
+func ParsePlans(raw string) (map[string]entity.Quota, error) {
+	plans := make(map[string]entity.Quota)
+	if strings.TrimSpace(raw) == "" {
+		return plans, nil
+	}
+
+	for _, item := range strings.Split(raw, ",") {
+		name, spec, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPlan, item)
+		}
+
+		parts := strings.Split(spec, ":")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPlan, item)
+		}
+
+		limits := make([]int64, 0, len(parts))
+		for _, part := range parts {
+			limit, err := strconv.ParseInt(part, 10, 32)
+			if err != nil || limit < 0 {
+				return nil, fmt.Errorf("%w: invalid limit %q in %q", ErrInvalidPlan, part, item)
+			}
+
+			limits = append(limits, limit)
+		}
+
+		plans[name] = entity.Quota{
+			Plan:             name,
+			MaxActiveLinks:   int(limits[0]),
+			MaxLinksPerDay:   int(limits[1]),
+			MaxBatchSize:     int(limits[2]),
+			MaxTrackedClicks: limits[3],
+		}
+	}
+
+	return plans, nil
+}
+
+// ParseUsers Parses plans assigned to users from string in format "user_id=plan,..."
+func ParseUsers(raw string) (map[entity.UserID]string, error) {
+	users := make(map[entity.UserID]string)
+	if strings.TrimSpace(raw) == "" {
+		return users, nil
+	}
+
+	for _, item := range strings.Split(raw, ",") {
+		userID, plan, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || userID == "" || plan == "" {
+			return nil, fmt.Errorf("%w: invalid user plan %q", ErrInvalidPlan, item)
+		}
+
+		users[entity.UserID(userID)] = plan
+	}
+
+	return users, nil
+}
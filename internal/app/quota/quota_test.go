@@ -0,0 +1,201 @@
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+func TestParsePlans(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected map[string]entity.Quota
+		isError  bool
+	}{
+		{
+			name: "correct plans",
+			raw:  "free=100:10:5:1000, pro=0:1000:100:0",
+			expected: map[string]entity.Quota{
+				"free": {Plan: "free", MaxActiveLinks: 100, MaxLinksPerDay: 10, MaxBatchSize: 5, MaxTrackedClicks: 1000},
+				"pro":  {Plan: "pro", MaxLinksPerDay: 1000, MaxBatchSize: 100},
+			},
+		},
+		{
+			name:     "empty plans",
+			raw:      "",
+			expected: map[string]entity.Quota{},
+		},
+		{
+			name:    "missing limits",
+			raw:     "free=100:10",
+			isError: true,
+		},
+		{
+			name:    "negative limit",
+			raw:     "free=100:-1:5:0",
+			isError: true,
+		},
+		{
+			name:    "missing name",
+			raw:     "=100:10:5:0",
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			plans, err := ParsePlans(test.raw)
+			if test.isError {
+				assert.ErrorIs(t, err, ErrInvalidPlan)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, plans)
+		})
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	proUserID := entity.UserID("0c0a4811-4f10-487f-bde3-e39a14af7cd8")
+
+	tests := []struct {
+		name     string
+		config   config.Config
+		expected map[entity.UserID]entity.Quota
+		err      error
+	}{
+		{
+			name: "default and assigned plans",
+			config: config.Config{
+				QuotaPlans:       "free=100:10:5:0,pro=0:0:100:0",
+				QuotaDefaultPlan: "free",
+				QuotaUsers:       proUserID.String() + "=pro",
+			},
+			expected: map[entity.UserID]entity.Quota{
+				userID:    {Plan: "free", MaxActiveLinks: 100, MaxLinksPerDay: 10, MaxBatchSize: 5},
+				proUserID: {Plan: "pro", MaxBatchSize: 100},
+			},
+		},
+		{
+			name: "no plans",
+			config: config.Config{
+				QuotaDefaultPlan: "free",
+			},
+			expected: map[entity.UserID]entity.Quota{
+				userID: {},
+			},
+		},
+		{
+			name: "unknown default plan",
+			config: config.Config{
+				QuotaPlans:       "free=100:10:5:0",
+				QuotaDefaultPlan: "basic",
+			},
+			err: ErrUnknownPlan,
+		},
+		{
+			name: "unknown plan of user",
+			config: config.Config{
+				QuotaPlans:       "free=100:10:5:0",
+				QuotaDefaultPlan: "free",
+				QuotaUsers:       proUserID.String() + "=pro",
+			},
+			err: ErrUnknownPlan,
+		},
+		{
+			name: "invalid plan of user",
+			config: config.Config{
+				QuotaPlans:       "free=100:10:5:0",
+				QuotaDefaultPlan: "free",
+				QuotaUsers:       proUserID.String(),
+			},
+			err: ErrInvalidPlan,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy, err := NewPolicy(test.config)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				assert.ErrorIs(t, ValidateConfig(test.config), test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			for userID, expected := range test.expected {
+				assert.Equal(t, expected, policy.Quota(userID), userID)
+			}
+		})
+	}
+}
+
+func TestWriteHTTPError(t *testing.T) {
+	resetAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name          string
+		err           error
+		written       bool
+		statusCode    int
+		retryAfter    bool
+		expectedLimit string
+	}{
+		{
+			name:          "active links",
+			err:           &api.QuotaError{Limit: entity.QuotaActiveLinks, Max: 100},
+			written:       true,
+			statusCode:    http.StatusForbidden,
+			expectedLimit: entity.QuotaActiveLinks,
+		},
+		{
+			name:          "links per day",
+			err:           &api.QuotaError{Limit: entity.QuotaLinksPerDay, Max: 10, ResetAt: resetAt},
+			written:       true,
+			statusCode:    http.StatusTooManyRequests,
+			retryAfter:    true,
+			expectedLimit: entity.QuotaLinksPerDay,
+		},
+		{
+			name: "other error",
+			err:  errors.New("storage error"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			writer := httptest.NewRecorder()
+
+			written := WriteHTTPError(writer, test.err)
+			require.Equal(t, test.written, written)
+			if !written {
+				return
+			}
+
+			res := writer.Result()
+			defer res.Body.Close()
+
+			assert.Equal(t, test.statusCode, res.StatusCode)
+			assert.Equal(t, test.retryAfter, res.Header.Get("Retry-After") != "")
+
+			var body models.QuotaExceeded
+			require.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+			assert.Equal(t, "quota_exceeded", body.Error)
+			assert.Equal(t, test.expectedLimit, body.Limit)
+		})
+	}
+}
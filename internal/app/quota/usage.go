@@ -0,0 +1,63 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+)
+
+// UsageStorage Interface of storage counting usage of user quotas
+type UsageStorage interface {
+	GetQuotaUsage(ctx context.Context, userID entity.UserID, now time.Time) (entity.QuotaUsage, error)
+}
+
+// Service Reports usage of user quotas
+type Service struct {
+	storage UsageStorage
+	policy  model.QuotaPolicy
+	now     func() time.Time
+}
+
+// NewService Creates service of quota usage
+func NewService(storage UsageStorage, policy model.QuotaPolicy) *Service {
+	return &Service{
+		storage: storage,
+		policy:  policy,
+		now:     time.Now,
+	}
+}
+
+// Usage Returns usage of user quota with limits of plan assigned to user
+func (s *Service) Usage(ctx context.Context, userID entity.UserID) (models.Usage, error) {
+	now := s.now().UTC()
+
+	usage, err := s.storage.GetQuotaUsage(ctx, userID, now)
+	if err != nil {
+		return models.Usage{}, fmt.Errorf("error while getting usage of user quota: %w", err)
+	}
+
+	quota := model.QuotaOf(s.policy, userID)
+	resetAt := entity.QuotaDay(now).Add(24 * time.Hour)
+
+	return models.Usage{
+		Plan: quota.Plan,
+		ActiveLinks: models.QuotaCounter{
+			Used:  int64(usage.ActiveLinks),
+			Limit: int64(quota.MaxActiveLinks),
+		},
+		LinksToday: models.QuotaCounter{
+			Used:    int64(usage.LinksToday),
+			Limit:   int64(quota.MaxLinksPerDay),
+			ResetAt: &resetAt,
+		},
+		TrackedClicks: models.QuotaCounter{
+			Used:  usage.TrackedClicks,
+			Limit: quota.MaxTrackedClicks,
+		},
+		MaxBatchSize: quota.MaxBatchSize,
+	}, nil
+}
@@ -0,0 +1,113 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+const cleanupInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time
+}
+
+// MemoryStore Token bucket store kept in process memory
+type MemoryStore struct {
+	mutex   sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+
+	done chan struct{}
+	once sync.Once
+}
+
+// NewMemoryStore Creates memory store and starts cleanup of idle buckets
+func NewMemoryStore() *MemoryStore {
+	store := &MemoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+		done:    make(chan struct{}),
+	}
+
+	go store.cleanup()
+
+	return store
+}
+
+// Take Takes token from the bucket stored by key
+func (s *MemoryStore) Take(_ context.Context, key string, policy Policy) (Result, error) {
+	now := s.now()
+	burst := float64(policy.Burst)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{
+			tokens:  burst,
+			updated: now,
+		}
+		s.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.updated).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*policy.Rate)
+		b.updated = now
+	}
+
+	if b.tokens < 1 {
+		return Result{
+			Allowed:    false,
+			RetryAfter: retryAfter(b.tokens, policy),
+		}, nil
+	}
+
+	b.tokens--
+	b.full = now.Add(time.Duration((burst - b.tokens) / policy.Rate * float64(time.Second)))
+
+	return Result{
+		Allowed:   true,
+		Remaining: int(b.tokens),
+	}, nil
+}
+
+// Close Stops cleanup of idle buckets
+func (s *MemoryStore) Close() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
+
+// cleanup Removes buckets which have been refilled completely
+func (s *MemoryStore) cleanup() {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.removeFullBuckets()
+		}
+	}
+}
+
+func (s *MemoryStore) removeFullBuckets() {
+	now := s.now()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for key, b := range s.buckets {
+		if now.After(b.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+)
+
+// Middleware Limits requests of route group by token bucket policy
+//
+// Returns 429(StatusTooManyRequests) with Retry-After header if client exceeded the policy
+func (l *Limiter) Middleware(route string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if l == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var userID entity.UserID
+			userIDCtx, ok := r.Context().Value(entity.UserIDCtxKey{}).(entity.UserIDCtx)
+			if ok && userIDCtx.StatusCode == http.StatusOK {
+				userID = userIDCtx.UserID
+			}
+
+			res := l.Allow(r.Context(), route, userID, clientIP(r))
+			if !res.Allowed {
+				zap.L().Info(
+					"too many requests",
+					zap.String("route", route),
+					zap.String("remote_addr", r.RemoteAddr),
+					zap.String("user_id", userID.String()),
+				)
+
+				w.Header().Set("Retry-After", strconv.Itoa(res.RetryAfterSeconds()))
+				w.WriteHeader(http.StatusTooManyRequests)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP Returns IP address of client resolved by access control or IP address of the request peer
+func clientIP(r *http.Request) string {
+	if ip := cidr.ClientIPFromContext(r.Context()); ip != "" {
+		return ip
+	}
+
+	return cidr.AddrIP(r.RemoteAddr)
+}
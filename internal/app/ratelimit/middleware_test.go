@@ -0,0 +1,182 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+func TestMiddleware(t *testing.T) {
+	policies := map[string]Policy{
+		RouteCreate: {Rate: 1, Burst: 1, Key: KeyUser, IPRate: 1, IPBurst: 2},
+		RouteAPI:    {Rate: 1, Burst: 1, Key: KeyIP},
+		"shared":    {Rate: 1, Burst: 1, Key: KeyUser},
+	}
+
+	type want struct {
+		statusCode int
+		retryAfter string
+	}
+	tests := []struct {
+		name       string
+		route      string
+		remoteAddr string
+		userIDCtx  entity.UserIDCtx
+		want       want
+	}{
+		{
+			name:       "first user request",
+			route:      RouteCreate,
+			remoteAddr: "192.168.1.1:1234",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:       "same user from another ip",
+			route:      RouteCreate,
+			remoteAddr: "192.168.1.2:1234",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+			want: want{
+				statusCode: http.StatusTooManyRequests,
+				retryAfter: "1",
+			},
+		},
+		{
+			name:       "another user from same ip within ip bucket",
+			route:      RouteCreate,
+			remoteAddr: "192.168.1.1:1234",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "5b1a9e6c-0b7e-4f0e-9a53-0c2e8f3d9a41",
+				StatusCode: http.StatusOK,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:       "rotated user id from same ip",
+			route:      RouteCreate,
+			remoteAddr: "192.168.1.1:1234",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "2f0c7d1e-6a4b-4c8e-9d3f-1b5e7a9c0d2f",
+				StatusCode: http.StatusOK,
+			},
+			want: want{
+				statusCode: http.StatusTooManyRequests,
+				retryAfter: "1",
+			},
+		},
+		{
+			name:       "unauthorized user is limited by ip",
+			route:      RouteCreate,
+			remoteAddr: "192.168.1.3:1234",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusUnauthorized,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:       "another user from same ip",
+			route:      RouteAPI,
+			remoteAddr: "192.168.1.1:1234",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "8c6c0dbc-22b8-4349-b33f-7204104bbd97",
+				StatusCode: http.StatusOK,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:       "api limited by ip",
+			route:      RouteAPI,
+			remoteAddr: "192.168.1.1:4321",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+			want: want{
+				statusCode: http.StatusTooManyRequests,
+				retryAfter: "1",
+			},
+		},
+		{
+			name:       "first user behind nat",
+			route:      "shared",
+			remoteAddr: "192.168.1.4:1234",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+				StatusCode: http.StatusOK,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:       "second user behind nat",
+			route:      "shared",
+			remoteAddr: "192.168.1.4:1234",
+			userIDCtx: entity.UserIDCtx{
+				UserID:     "5b1a9e6c-0b7e-4f0e-9a53-0c2e8f3d9a41",
+				StatusCode: http.StatusOK,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:       "route without policy",
+			route:      RouteRedirect,
+			remoteAddr: "192.168.1.1:1234",
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+	}
+
+	store := NewMemoryStore()
+	defer store.Close()
+
+	limiter := NewLimiter(store, policies)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/", nil)
+			request.RemoteAddr = test.remoteAddr
+			request = request.WithContext(context.WithValue(request.Context(), entity.UserIDCtxKey{}, test.userIDCtx))
+			writer := httptest.NewRecorder()
+
+			limiter.Middleware(test.route)(next).ServeHTTP(writer, request)
+
+			res := writer.Result()
+			assert.NoError(t, res.Body.Close())
+
+			assert.Equal(t, test.want.statusCode, res.StatusCode)
+			assert.Equal(t, test.want.retryAfter, res.Header.Get("Retry-After"))
+		})
+	}
+
+	var nilLimiter *Limiter
+	writer := httptest.NewRecorder()
+	nilLimiter.Middleware(RouteCreate)(next).ServeHTTP(writer, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.Equal(t, http.StatusOK, writer.Code)
+}
@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"go.uber.org/zap"
+)
+
+const (
+	bucketTTL      = time.Hour
+	cleanupTimeout = 5 * time.Second
+)
+
+// PostgresStore Token bucket store shared between instances using postgres
+//
+// Table "rate_limit" is created by postgres storage migrations
+type PostgresStore struct {
+	db *sql.DB
+
+	done chan struct{}
+	once sync.Once
+}
+
+// NewPostgresStore Creates postgres store object
+func NewPostgresStore(dbStorageConnect string) (*PostgresStore, error) {
+	if dbStorageConnect == "" {
+		return nil, fmt.Errorf("database dsn is required for postgres rate limit store")
+	}
+
+	db, err := sql.Open("pgx", dbStorageConnect)
+	if err != nil {
+		return nil, fmt.Errorf("error while postgresql connect: %w", err)
+	}
+
+	store := &PostgresStore{
+		db:   db,
+		done: make(chan struct{}),
+	}
+
+	go store.cleanup()
+
+	return store, nil
+}
+
+// Take Takes token from the bucket stored by key
+//
+// Bucket is refilled and taken in one statement, so concurrent instances don't exceed policy
+func (s *PostgresStore) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+	query := `
+		INSERT INTO rate_limit AS rl (key, tokens, allowed, updated_at)
+		VALUES (@key, @burst::double precision - 1, true, now())
+		ON CONFLICT (key) DO UPDATE SET
+			allowed = LEAST(@burst, rl.tokens + EXTRACT(EPOCH FROM now() - rl.updated_at) * @rate) >= 1,
+			tokens = LEAST(@burst, rl.tokens + EXTRACT(EPOCH FROM now() - rl.updated_at) * @rate) -
+				CASE WHEN LEAST(@burst, rl.tokens + EXTRACT(EPOCH FROM now() - rl.updated_at) * @rate) >= 1
+				THEN 1 ELSE 0 END,
+			updated_at = now()
+		RETURNING tokens, allowed`
+
+	args := pgx.NamedArgs{
+		"key":   key,
+		"burst": float64(policy.Burst),
+		"rate":  policy.Rate,
+	}
+
+	var tokens float64
+	var allowed bool
+	err := s.db.QueryRowContext(ctx, query, args).Scan(&tokens, &allowed)
+	if err != nil {
+		return Result{}, fmt.Errorf("error while taking rate limit token from postgres: %w", err)
+	}
+
+	if !allowed {
+		return Result{
+			Allowed:    false,
+			RetryAfter: retryAfter(tokens, policy),
+		}, nil
+	}
+
+	return Result{
+		Allowed:   true,
+		Remaining: int(tokens),
+	}, nil
+}
+
+// Close Closes connection to postgres DB
+func (s *PostgresStore) Close() {
+	s.once.Do(func() {
+		close(s.done)
+
+		err := s.db.Close()
+		if err != nil {
+			zap.L().Error("error while closing postgres rate limit store", zap.Error(err))
+		}
+	})
+}
+
+// cleanup Removes buckets which haven't been used for a long time
+func (s *PostgresStore) cleanup() {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), cleanupTimeout)
+			query := `DELETE FROM rate_limit WHERE updated_at < now() - make_interval(secs => $1)`
+			_, err := s.db.ExecContext(ctx, query, bucketTTL.Seconds())
+			cancel()
+			if err != nil {
+				zap.L().Error("error while removing old rate limit buckets from postgres", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,318 @@
+// Package ratelimit implements token bucket rate limiting for HTTP and GRPC requests
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// Route groups which can be limited by policies
+//
+// RouteCreate - creation of short URLs
+// RouteRedirect - redirection by short URL
+// RouteAPI - other user API requests
+const (
+	RouteCreate   = "create"
+	RouteRedirect = "redirect"
+	RouteAPI      = "api"
+)
+
+// Store types
+//
+// StoreMemory - buckets are kept in process memory
+// StorePostgres - buckets are shared between instances in postgres
+// StoreNone - rate limiting is disabled
+const (
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+	StoreNone     = "none"
+)
+
+// Keys to identify client in policy
+//
+// KeyIP - client is identified by IP address
+// KeyUser - client is identified by user ID, IP address is used for requests without user ID
+const (
+	KeyIP   = "ip"
+	KeyUser = "user"
+)
+
+// ErrInvalidPolicy Error that will be returned if rate limit policy couldn't be parsed
+var ErrInvalidPolicy = errors.New("invalid rate limit policy")
+
+var (
+	errInvalidRate  = errors.New("invalid rate")
+	errInvalidBurst = errors.New("invalid burst")
+)
+
+// Policy Describes token bucket parameters for route group
+type Policy struct {
+	// Rate Count of tokens added to bucket per second
+	Rate float64
+	// Burst Maximal count of tokens in bucket
+	Burst int
+	// Key Kind of client identifier: KeyIP or KeyUser
+	Key string
+	// IPRate Count of tokens added per second to bucket shared by all users of IP address, only for KeyUser key.
+	// Zero rate means users aren't limited by IP address
+	IPRate float64
+	// IPBurst Maximal count of tokens in bucket shared by all users of IP address
+	IPBurst int
+}
+
+// Result Contains result of taking token from bucket
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Store Interface for storing token buckets
+type Store interface {
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+	Close()
+}
+
+// Limiter Applies policies of route groups using given store
+type Limiter struct {
+	store Store
+
+	mutex    sync.RWMutex
+	policies map[string]Policy
+}
+
+// InitLimiter Creates limiter using store and policies from config
+//
+// Returns nil limiter if rate limiting is disabled
+func InitLimiter(config config.Config) (*Limiter, error) {
+	policies, err := ParsePolicies(config.RateLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	var store Store
+	switch config.RateLimitStore {
+	case StoreNone, "":
+		zap.L().Info("rate limiting is disabled")
+		return nil, nil
+	case StoreMemory:
+		zap.L().Info("init memory rate limit store")
+		store = NewMemoryStore()
+	case StorePostgres:
+		zap.L().Info("init postgres rate limit store")
+		store, err = NewPostgresStore(config.DBStorageConnect)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", config.RateLimitStore)
+	}
+
+	return NewLimiter(store, policies), nil
+}
+
+// ValidateConfig Validates rate limit store and policies from config
+func ValidateConfig(config config.Config) error {
+	var errs []error
+
+	switch config.RateLimitStore {
+	case StoreNone, StoreMemory, "":
+	case StorePostgres:
+		if config.DBStorageConnect == "" {
+			errs = append(errs, fmt.Errorf("rate_limit_store %q requires database_dsn", config.RateLimitStore))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown rate_limit_store %q", config.RateLimitStore))
+	}
+
+	_, err := ParsePolicies(config.RateLimits)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid rate_limits: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// NewLimiter Creates limiter object
+func NewLimiter(store Store, policies map[string]Policy) *Limiter {
+	return &Limiter{
+		store:    store,
+		policies: policies,
+	}
+}
+
+// SetPolicies Replaces policies of route groups
+func (l *Limiter) SetPolicies(policies map[string]Policy) {
+	if l == nil {
+		return
+	}
+
+	l.mutex.Lock()
+	l.policies = policies
+	l.mutex.Unlock()
+}
+
+// Policy Returns policy of route group
+func (l *Limiter) Policy(route string) (Policy, bool) {
+	if l == nil {
+		return Policy{}, false
+	}
+
+	l.mutex.RLock()
+	policy, ok := l.policies[route]
+	l.mutex.RUnlock()
+
+	return policy, ok
+}
+
+// Allow Takes token from the bucket of client in the route group
+//
+// Policy with KeyUser key limits each user separately, so users behind the same NAT don't share bucket.
+// Requests without user ID are limited by IP address with the same policy.
+// User IDs are issued to any client, so policy with IP rate also limits all users of IP address together
+// by larger bucket and rotation of user IDs doesn't give client unlimited fresh buckets.
+// Requests are allowed if route group has no policy or store fails
+func (l *Limiter) Allow(ctx context.Context, route string, userID entity.UserID, ip string) Result {
+	policy, ok := l.Policy(route)
+	if !ok {
+		return Result{Allowed: true}
+	}
+
+	if policy.Key != KeyUser || !userID.IsValid() {
+		return l.take(ctx, route, "ip:"+ip, policy)
+	}
+
+	res := l.take(ctx, route, "user:"+userID.String(), policy)
+	if !res.Allowed || policy.IPRate == 0 {
+		return res
+	}
+
+	ipRes := l.take(ctx, route, "users-ip:"+ip, policy.ipPolicy())
+	if !ipRes.Allowed {
+		return ipRes
+	}
+
+	res.Remaining = min(res.Remaining, ipRes.Remaining)
+
+	return res
+}
+
+// ipPolicy Returns policy of bucket shared by all users of IP address
+func (p Policy) ipPolicy() Policy {
+	return Policy{
+		Rate:  p.IPRate,
+		Burst: p.IPBurst,
+		Key:   KeyIP,
+	}
+}
+
+// take Takes token from the bucket of client in the route group, token is granted if store fails
+func (l *Limiter) take(ctx context.Context, route, client string, policy Policy) Result {
+	res, err := l.store.Take(ctx, route+":"+client, policy)
+	if err != nil {
+		zap.L().Error("error while taking rate limit token", zap.Error(err), zap.String("route", route))
+
+		return Result{Allowed: true, Remaining: policy.Burst}
+	}
+
+	return res
+}
+
+// Close Closes limiter store
+func (l *Limiter) Close() {
+	if l == nil {
+		return
+	}
+
+	l.store.Close()
+}
+
+// ParsePolicies Parses policies from string in format "route=rate:burst[:key[:ip_rate:ip_burst]],..."
+//
+// IP rate and burst are allowed only for KeyUser key
+func ParsePolicies(raw string) (map[string]Policy, error) {
+	policies := make(map[string]Policy)
+	if strings.TrimSpace(raw) == "" {
+		return policies, nil
+	}
+
+	for _, item := range strings.Split(raw, ",") {
+		route, spec, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || route == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPolicy, item)
+		}
+
+		parts := strings.Split(spec, ":")
+		if len(parts) != 2 && len(parts) != 3 && len(parts) != 5 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPolicy, item)
+		}
+
+		rate, burst, err := parseBucket(parts[0], parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w in %q", ErrInvalidPolicy, err, item)
+		}
+
+		policy := Policy{
+			Rate:  rate,
+			Burst: burst,
+			Key:   KeyIP,
+		}
+		if len(parts) >= 3 {
+			policy.Key = parts[2]
+		}
+		if policy.Key != KeyIP && policy.Key != KeyUser {
+			return nil, fmt.Errorf("%w: invalid key in %q", ErrInvalidPolicy, item)
+		}
+
+		if len(parts) == 5 {
+			if policy.Key != KeyUser {
+				return nil, fmt.Errorf("%w: ip bucket of %q key in %q", ErrInvalidPolicy, policy.Key, item)
+			}
+
+			policy.IPRate, policy.IPBurst, err = parseBucket(parts[3], parts[4])
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w of ip bucket in %q", ErrInvalidPolicy, err, item)
+			}
+		}
+
+		policies[route] = policy
+	}
+
+	return policies, nil
+}
+
+// parseBucket Parses positive finite rate and positive burst of token bucket
+func parseBucket(rawRate, rawBurst string) (float64, int, error) {
+	rate, err := strconv.ParseFloat(rawRate, 64)
+	if err != nil || rate <= 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return 0, 0, errInvalidRate
+	}
+
+	burst, err := strconv.Atoi(rawBurst)
+	if err != nil || burst < 1 {
+		return 0, 0, errInvalidBurst
+	}
+
+	return rate, burst, nil
+}
+
+// RetryAfterSeconds Returns count of seconds to wait before retry rounded up
+func (r Result) RetryAfterSeconds() int {
+	return int(math.Max(1, math.Ceil(r.RetryAfter.Seconds())))
+}
+
+// retryAfter Returns time to wait until the bucket has a token
+func retryAfter(tokens float64, policy Policy) time.Duration {
+	return time.Duration((1 - tokens) / policy.Rate * float64(time.Second))
+}
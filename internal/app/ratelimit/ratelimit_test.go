@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected map[string]Policy
+		isError  bool
+	}{
+		{
+			name: "correct policies",
+			raw:  "create=5:20, redirect=0.5:10:ip,api=10:30:user",
+			expected: map[string]Policy{
+				RouteCreate:   {Rate: 5, Burst: 20, Key: KeyIP},
+				RouteRedirect: {Rate: 0.5, Burst: 10, Key: KeyIP},
+				RouteAPI:      {Rate: 10, Burst: 30, Key: KeyUser},
+			},
+		},
+		{
+			name: "user policy with ip bucket",
+			raw:  "api=10:30:user:100:300",
+			expected: map[string]Policy{
+				RouteAPI: {Rate: 10, Burst: 30, Key: KeyUser, IPRate: 100, IPBurst: 300},
+			},
+		},
+		{
+			name:     "empty policies",
+			raw:      "",
+			expected: map[string]Policy{},
+		},
+		{
+			name:    "missing burst",
+			raw:     "create=5",
+			isError: true,
+		},
+		{
+			name:    "zero rate",
+			raw:     "create=0:5",
+			isError: true,
+		},
+		{
+			name:    "NaN rate",
+			raw:     "create=NaN:5",
+			isError: true,
+		},
+		{
+			name:    "NaN rate of ip bucket",
+			raw:     "api=10:30:user:NaN:300",
+			isError: true,
+		},
+		{
+			name:    "ip bucket of ip policy",
+			raw:     "create=5:5:ip:10:10",
+			isError: true,
+		},
+		{
+			name:    "missing burst of ip bucket",
+			raw:     "api=10:30:user:100",
+			isError: true,
+		},
+		{
+			name:    "unknown key",
+			raw:     "create=5:5:cookie",
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policies, err := ParsePolicies(test.raw)
+			if test.isError {
+				assert.ErrorIs(t, err, ErrInvalidPolicy)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, policies)
+		})
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	now := time.Now()
+
+	store := NewMemoryStore()
+	defer store.Close()
+	store.now = func() time.Time { return now }
+
+	policy := Policy{Rate: 2, Burst: 3, Key: KeyIP}
+	ctx := context.Background()
+
+	for i := 0; i < policy.Burst; i++ {
+		res, err := store.Take(ctx, "key", policy)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, policy.Burst-i-1, res.Remaining)
+	}
+
+	res, err := store.Take(ctx, "key", policy)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+	assert.Equal(t, 1, res.RetryAfterSeconds())
+
+	res, err = store.Take(ctx, "other", policy)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	now = now.Add(500 * time.Millisecond)
+	res, err = store.Take(ctx, "key", policy)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+
+	now = now.Add(time.Hour)
+	store.removeFullBuckets()
+	assert.Empty(t, store.buckets)
+}
@@ -1,6 +1,10 @@
 package api
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 // Errors which return storage
 //
@@ -8,9 +12,50 @@ import "errors"
 // ErrURLAlreadyExists - returned if short URL already exists in storage for user
 // ErrFileStorageNotOpen - returned if file storage is not opened
 // ErrAllURLsDeleted - returned if all URLs deleted for user
+// ErrURLDisabled - returned if URL is disabled by admin
+// ErrCompactionNotSupported - returned if storage doesn't support compaction
+// ErrDeleteJobNotFound - returned if deletion job is not found in storage for user
+// ErrWebhookNotFound - returned if webhook is not found in storage for user
+// ErrWebhookDeliveryNotFound - returned if webhook delivery is not found in storage for user
+// ErrWorkspaceNotFound - returned if workspace is not found in storage
+// ErrWorkspaceMemberNotFound - returned if user is not member of workspace in storage
+// ErrWorkspaceInviteNotFound - returned if workspace invite is not found in storage
+// ErrQuotaExceeded - returned if operation exceeds quota of user
 var (
 	ErrShortURLNotFound   = errors.New("short url is not found in storage for this user")
 	ErrURLAlreadyExists   = errors.New("short url already exists in storage for this user")
 	ErrFileStorageNotOpen = errors.New("file storage is not open")
 	ErrAllURLsDeleted     = errors.New("all urls have been deleted for this user")
+	ErrURLDisabled        = errors.New("short url is disabled")
+
+	ErrCompactionNotSupported = errors.New("storage doesn't support compaction")
+	ErrDeleteJobNotFound      = errors.New("delete job is not found in storage for this user")
+
+	ErrWebhookNotFound         = errors.New("webhook is not found in storage for this user")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery is not found in storage for this user")
+
+	ErrWorkspaceNotFound       = errors.New("workspace is not found in storage")
+	ErrWorkspaceMemberNotFound = errors.New("workspace member is not found in storage")
+	ErrWorkspaceInviteNotFound = errors.New("workspace invite is not found in storage")
+
+	ErrQuotaExceeded = errors.New("quota exceeded")
 )
+
+// QuotaError Error that will be returned if operation exceeds limit of user quota
+//
+// Reset time is zero if usage of limit isn't reset over time
+type QuotaError struct {
+	Limit   string
+	Max     int64
+	ResetAt time.Time
+}
+
+// Error Returns text of quota error
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s: %s limit is %d", ErrQuotaExceeded, e.Limit, e.Max)
+}
+
+// Unwrap Returns ErrQuotaExceeded error
+func (e *QuotaError) Unwrap() error {
+	return ErrQuotaExceeded
+}
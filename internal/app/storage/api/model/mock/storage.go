@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/app/storage/api/model/storage.go
+// Source: storage.go
 
 // Package mock is a generated GoMock package.
 package mock
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/avGenie/url-shortener/internal/app/entity"
 	models "github.com/avGenie/url-shortener/internal/app/models"
@@ -37,6 +38,21 @@ func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
 	return m.recorder
 }
 
+// ClaimPendingDeleteJobs mocks base method.
+func (m *MockStorage) ClaimPendingDeleteJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.DeleteJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingDeleteJobs", ctx, now, lease, limit)
+	ret0, _ := ret[0].([]entity.DeleteJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingDeleteJobs indicates an expected call of ClaimPendingDeleteJobs.
+func (mr *MockStorageMockRecorder) ClaimPendingDeleteJobs(ctx, now, lease, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingDeleteJobs", reflect.TypeOf((*MockStorage)(nil).ClaimPendingDeleteJobs), ctx, now, lease, limit)
+}
+
 // Close mocks base method.
 func (m *MockStorage) Close() {
 	m.ctrl.T.Helper()
@@ -63,6 +79,63 @@ func (mr *MockStorageMockRecorder) DeleteBatchURL(ctx, urls interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBatchURL", reflect.TypeOf((*MockStorage)(nil).DeleteBatchURL), ctx, urls)
 }
 
+// DeleteWebhook mocks base method.
+func (m *MockStorage) DeleteWebhook(ctx context.Context, userID entity.UserID, webhookID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, userID, webhookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockStorageMockRecorder) DeleteWebhook(ctx, userID, webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockStorage)(nil).DeleteWebhook), ctx, userID, webhookID)
+}
+
+// DeleteWorkspaceInvite mocks base method.
+func (m *MockStorage) DeleteWorkspaceInvite(ctx context.Context, inviteID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceInvite", ctx, inviteID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspaceInvite indicates an expected call of DeleteWorkspaceInvite.
+func (mr *MockStorageMockRecorder) DeleteWorkspaceInvite(ctx, inviteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceInvite", reflect.TypeOf((*MockStorage)(nil).DeleteWorkspaceInvite), ctx, inviteID)
+}
+
+// DeleteWorkspaceMember mocks base method.
+func (m *MockStorage) DeleteWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWorkspaceMember", ctx, workspaceID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWorkspaceMember indicates an expected call of DeleteWorkspaceMember.
+func (mr *MockStorageMockRecorder) DeleteWorkspaceMember(ctx, workspaceID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWorkspaceMember", reflect.TypeOf((*MockStorage)(nil).DeleteWorkspaceMember), ctx, workspaceID, userID)
+}
+
+// EraseUser mocks base method.
+func (m *MockStorage) EraseUser(ctx context.Context, userID entity.UserID) (models.UserErasure, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", ctx, userID)
+	ret0, _ := ret[0].(models.UserErasure)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockStorageMockRecorder) EraseUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockStorage)(nil).EraseUser), ctx, userID)
+}
+
 // GetAllURLByUserID mocks base method.
 func (m *MockStorage) GetAllURLByUserID(ctx context.Context, userID entity.UserID) (models.AllUrlsBatch, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +151,81 @@ func (mr *MockStorageMockRecorder) GetAllURLByUserID(ctx, userID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllURLByUserID", reflect.TypeOf((*MockStorage)(nil).GetAllURLByUserID), ctx, userID)
 }
 
+// GetDeleteJob mocks base method.
+func (m *MockStorage) GetDeleteJob(ctx context.Context, userID entity.UserID, jobID string) (entity.DeleteJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleteJob", ctx, userID, jobID)
+	ret0, _ := ret[0].(entity.DeleteJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleteJob indicates an expected call of GetDeleteJob.
+func (mr *MockStorageMockRecorder) GetDeleteJob(ctx, userID, jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleteJob", reflect.TypeOf((*MockStorage)(nil).GetDeleteJob), ctx, userID, jobID)
+}
+
+// GetLink mocks base method.
+func (m *MockStorage) GetLink(ctx context.Context, userID entity.UserID, shortURL string) (entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLink", ctx, userID, shortURL)
+	ret0, _ := ret[0].(entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLink indicates an expected call of GetLink.
+func (mr *MockStorageMockRecorder) GetLink(ctx, userID, shortURL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLink", reflect.TypeOf((*MockStorage)(nil).GetLink), ctx, userID, shortURL)
+}
+
+// GetQuotaUsage mocks base method.
+func (m *MockStorage) GetQuotaUsage(ctx context.Context, userID entity.UserID, now time.Time) (entity.QuotaUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotaUsage", ctx, userID, now)
+	ret0, _ := ret[0].(entity.QuotaUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuotaUsage indicates an expected call of GetQuotaUsage.
+func (mr *MockStorageMockRecorder) GetQuotaUsage(ctx, userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaUsage", reflect.TypeOf((*MockStorage)(nil).GetQuotaUsage), ctx, userID, now)
+}
+
+// GetServiceStatistic mocks base method.
+func (m *MockStorage) GetServiceStatistic(ctx context.Context, query models.StatisticQuery) (models.ServiceStatistic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceStatistic", ctx, query)
+	ret0, _ := ret[0].(models.ServiceStatistic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceStatistic indicates an expected call of GetServiceStatistic.
+func (mr *MockStorageMockRecorder) GetServiceStatistic(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceStatistic", reflect.TypeOf((*MockStorage)(nil).GetServiceStatistic), ctx, query)
+}
+
+// GetStatistic mocks base method.
+func (m *MockStorage) GetStatistic(ctx context.Context) (models.CountStatistic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatistic", ctx)
+	ret0, _ := ret[0].(models.CountStatistic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatistic indicates an expected call of GetStatistic.
+func (mr *MockStorageMockRecorder) GetStatistic(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistic", reflect.TypeOf((*MockStorage)(nil).GetStatistic), ctx)
+}
+
 // GetURL mocks base method.
 func (m *MockStorage) GetURL(ctx context.Context, userID entity.UserID, key entity.URL) (*entity.URL, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +241,246 @@ func (mr *MockStorageMockRecorder) GetURL(ctx, userID, key interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetURL", reflect.TypeOf((*MockStorage)(nil).GetURL), ctx, userID, key)
 }
 
+// GetWebhook mocks base method.
+func (m *MockStorage) GetWebhook(ctx context.Context, userID entity.UserID, webhookID string) (entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, userID, webhookID)
+	ret0, _ := ret[0].(entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockStorageMockRecorder) GetWebhook(ctx, userID, webhookID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockStorage)(nil).GetWebhook), ctx, userID, webhookID)
+}
+
+// GetWebhookDelivery mocks base method.
+func (m *MockStorage) GetWebhookDelivery(ctx context.Context, userID entity.UserID, deliveryID string) (entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDelivery", ctx, userID, deliveryID)
+	ret0, _ := ret[0].(entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDelivery indicates an expected call of GetWebhookDelivery.
+func (mr *MockStorageMockRecorder) GetWebhookDelivery(ctx, userID, deliveryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDelivery", reflect.TypeOf((*MockStorage)(nil).GetWebhookDelivery), ctx, userID, deliveryID)
+}
+
+// GetWorkspace mocks base method.
+func (m *MockStorage) GetWorkspace(ctx context.Context, workspaceID string) (entity.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspace", ctx, workspaceID)
+	ret0, _ := ret[0].(entity.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspace indicates an expected call of GetWorkspace.
+func (mr *MockStorageMockRecorder) GetWorkspace(ctx, workspaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspace", reflect.TypeOf((*MockStorage)(nil).GetWorkspace), ctx, workspaceID)
+}
+
+// GetWorkspaceInvite mocks base method.
+func (m *MockStorage) GetWorkspaceInvite(ctx context.Context, inviteID string) (entity.WorkspaceInvite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceInvite", ctx, inviteID)
+	ret0, _ := ret[0].(entity.WorkspaceInvite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceInvite indicates an expected call of GetWorkspaceInvite.
+func (mr *MockStorageMockRecorder) GetWorkspaceInvite(ctx, inviteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceInvite", reflect.TypeOf((*MockStorage)(nil).GetWorkspaceInvite), ctx, inviteID)
+}
+
+// GetWorkspaceLink mocks base method.
+func (m *MockStorage) GetWorkspaceLink(ctx context.Context, workspaceID, shortURL string) (entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceLink", ctx, workspaceID, shortURL)
+	ret0, _ := ret[0].(entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceLink indicates an expected call of GetWorkspaceLink.
+func (mr *MockStorageMockRecorder) GetWorkspaceLink(ctx, workspaceID, shortURL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceLink", reflect.TypeOf((*MockStorage)(nil).GetWorkspaceLink), ctx, workspaceID, shortURL)
+}
+
+// GetWorkspaceMember mocks base method.
+func (m *MockStorage) GetWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) (entity.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaceMember", ctx, workspaceID, userID)
+	ret0, _ := ret[0].(entity.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkspaceMember indicates an expected call of GetWorkspaceMember.
+func (mr *MockStorageMockRecorder) GetWorkspaceMember(ctx, workspaceID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaceMember", reflect.TypeOf((*MockStorage)(nil).GetWorkspaceMember), ctx, workspaceID, userID)
+}
+
+// ListAuditRecords mocks base method.
+func (m *MockStorage) ListAuditRecords(ctx context.Context, filter entity.AuditFilter, limit int) ([]entity.AuditRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditRecords", ctx, filter, limit)
+	ret0, _ := ret[0].([]entity.AuditRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditRecords indicates an expected call of ListAuditRecords.
+func (mr *MockStorageMockRecorder) ListAuditRecords(ctx, filter, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditRecords", reflect.TypeOf((*MockStorage)(nil).ListAuditRecords), ctx, filter, limit)
+}
+
+// ListDeletedLinks mocks base method.
+func (m *MockStorage) ListDeletedLinks(ctx context.Context, userID entity.UserID, deletedAfter time.Time) ([]entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeletedLinks", ctx, userID, deletedAfter)
+	ret0, _ := ret[0].([]entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeletedLinks indicates an expected call of ListDeletedLinks.
+func (mr *MockStorageMockRecorder) ListDeletedLinks(ctx, userID, deletedAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeletedLinks", reflect.TypeOf((*MockStorage)(nil).ListDeletedLinks), ctx, userID, deletedAfter)
+}
+
+// ListPendingWebhookDeliveries mocks base method.
+func (m *MockStorage) ListPendingWebhookDeliveries(ctx context.Context, before time.Time, limit int) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingWebhookDeliveries", ctx, before, limit)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingWebhookDeliveries indicates an expected call of ListPendingWebhookDeliveries.
+func (mr *MockStorageMockRecorder) ListPendingWebhookDeliveries(ctx, before, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingWebhookDeliveries", reflect.TypeOf((*MockStorage)(nil).ListPendingWebhookDeliveries), ctx, before, limit)
+}
+
+// ListUserDeleteJobs mocks base method.
+func (m *MockStorage) ListUserDeleteJobs(ctx context.Context, userID entity.UserID) ([]entity.DeleteJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserDeleteJobs", ctx, userID)
+	ret0, _ := ret[0].([]entity.DeleteJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserDeleteJobs indicates an expected call of ListUserDeleteJobs.
+func (mr *MockStorageMockRecorder) ListUserDeleteJobs(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserDeleteJobs", reflect.TypeOf((*MockStorage)(nil).ListUserDeleteJobs), ctx, userID)
+}
+
+// ListUserLinks mocks base method.
+func (m *MockStorage) ListUserLinks(ctx context.Context, userID entity.UserID, after entity.LinkCursor, limit int) ([]entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLinks", ctx, userID, after, limit)
+	ret0, _ := ret[0].([]entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLinks indicates an expected call of ListUserLinks.
+func (mr *MockStorageMockRecorder) ListUserLinks(ctx, userID, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLinks", reflect.TypeOf((*MockStorage)(nil).ListUserLinks), ctx, userID, after, limit)
+}
+
+// ListUserMemberships mocks base method.
+func (m *MockStorage) ListUserMemberships(ctx context.Context, userID entity.UserID) ([]entity.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserMemberships", ctx, userID)
+	ret0, _ := ret[0].([]entity.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserMemberships indicates an expected call of ListUserMemberships.
+func (mr *MockStorageMockRecorder) ListUserMemberships(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserMemberships", reflect.TypeOf((*MockStorage)(nil).ListUserMemberships), ctx, userID)
+}
+
+// ListWebhookDeliveries mocks base method.
+func (m *MockStorage) ListWebhookDeliveries(ctx context.Context, userID entity.UserID, webhookID string, limit int) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDeliveries", ctx, userID, webhookID, limit)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookDeliveries indicates an expected call of ListWebhookDeliveries.
+func (mr *MockStorageMockRecorder) ListWebhookDeliveries(ctx, userID, webhookID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDeliveries", reflect.TypeOf((*MockStorage)(nil).ListWebhookDeliveries), ctx, userID, webhookID, limit)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockStorage) ListWebhooks(ctx context.Context, userID entity.UserID) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx, userID)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockStorageMockRecorder) ListWebhooks(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockStorage)(nil).ListWebhooks), ctx, userID)
+}
+
+// ListWorkspaceLinks mocks base method.
+func (m *MockStorage) ListWorkspaceLinks(ctx context.Context, workspaceID string, after entity.LinkCursor, limit int) ([]entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceLinks", ctx, workspaceID, after, limit)
+	ret0, _ := ret[0].([]entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceLinks indicates an expected call of ListWorkspaceLinks.
+func (mr *MockStorageMockRecorder) ListWorkspaceLinks(ctx, workspaceID, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceLinks", reflect.TypeOf((*MockStorage)(nil).ListWorkspaceLinks), ctx, workspaceID, after, limit)
+}
+
+// ListWorkspaceMembers mocks base method.
+func (m *MockStorage) ListWorkspaceMembers(ctx context.Context, workspaceID string) ([]entity.WorkspaceMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWorkspaceMembers", ctx, workspaceID)
+	ret0, _ := ret[0].([]entity.WorkspaceMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWorkspaceMembers indicates an expected call of ListWorkspaceMembers.
+func (mr *MockStorageMockRecorder) ListWorkspaceMembers(ctx, workspaceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWorkspaceMembers", reflect.TypeOf((*MockStorage)(nil).ListWorkspaceMembers), ctx, workspaceID)
+}
+
 // PingServer mocks base method.
 func (m *MockStorage) PingServer(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -107,6 +495,64 @@ func (mr *MockStorageMockRecorder) PingServer(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingServer", reflect.TypeOf((*MockStorage)(nil).PingServer), ctx)
 }
 
+// PurgeDeletedLinks mocks base method.
+func (m *MockStorage) PurgeDeletedLinks(ctx context.Context, deletedBefore time.Time, limit int) ([]entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedLinks", ctx, deletedBefore, limit)
+	ret0, _ := ret[0].([]entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedLinks indicates an expected call of PurgeDeletedLinks.
+func (mr *MockStorageMockRecorder) PurgeDeletedLinks(ctx, deletedBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedLinks", reflect.TypeOf((*MockStorage)(nil).PurgeDeletedLinks), ctx, deletedBefore, limit)
+}
+
+// RecordClick mocks base method.
+func (m *MockStorage) RecordClick(ctx context.Context, shortURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordClick", ctx, shortURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordClick indicates an expected call of RecordClick.
+func (mr *MockStorageMockRecorder) RecordClick(ctx, shortURL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordClick", reflect.TypeOf((*MockStorage)(nil).RecordClick), ctx, shortURL)
+}
+
+// RestoreLinks mocks base method.
+func (m *MockStorage) RestoreLinks(ctx context.Context, userID entity.UserID, shortURLs []string, deletedAfter time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreLinks", ctx, userID, shortURLs, deletedAfter)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreLinks indicates an expected call of RestoreLinks.
+func (mr *MockStorageMockRecorder) RestoreLinks(ctx, userID, shortURLs, deletedAfter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreLinks", reflect.TypeOf((*MockStorage)(nil).RestoreLinks), ctx, userID, shortURLs, deletedAfter)
+}
+
+// SaveAuditRecord mocks base method.
+func (m *MockStorage) SaveAuditRecord(ctx context.Context, record entity.AuditRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAuditRecord", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAuditRecord indicates an expected call of SaveAuditRecord.
+func (mr *MockStorageMockRecorder) SaveAuditRecord(ctx, record interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAuditRecord", reflect.TypeOf((*MockStorage)(nil).SaveAuditRecord), ctx, record)
+}
+
 // SaveBatchURL mocks base method.
 func (m *MockStorage) SaveBatchURL(ctx context.Context, userID entity.UserID, batch model.Batch) (model.Batch, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +568,34 @@ func (mr *MockStorageMockRecorder) SaveBatchURL(ctx, userID, batch interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBatchURL", reflect.TypeOf((*MockStorage)(nil).SaveBatchURL), ctx, userID, batch)
 }
 
+// SaveDeleteJob mocks base method.
+func (m *MockStorage) SaveDeleteJob(ctx context.Context, job entity.DeleteJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDeleteJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveDeleteJob indicates an expected call of SaveDeleteJob.
+func (mr *MockStorageMockRecorder) SaveDeleteJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDeleteJob", reflect.TypeOf((*MockStorage)(nil).SaveDeleteJob), ctx, job)
+}
+
+// SaveLink mocks base method.
+func (m *MockStorage) SaveLink(ctx context.Context, link entity.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLink", ctx, link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLink indicates an expected call of SaveLink.
+func (mr *MockStorageMockRecorder) SaveLink(ctx, link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLink", reflect.TypeOf((*MockStorage)(nil).SaveLink), ctx, link)
+}
+
 // SaveURL mocks base method.
 func (m *MockStorage) SaveURL(ctx context.Context, userID entity.UserID, key, value entity.URL) error {
 	m.ctrl.T.Helper()
@@ -135,3 +609,167 @@ func (mr *MockStorageMockRecorder) SaveURL(ctx, userID, key, value interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveURL", reflect.TypeOf((*MockStorage)(nil).SaveURL), ctx, userID, key, value)
 }
+
+// SaveWebhook mocks base method.
+func (m *MockStorage) SaveWebhook(ctx context.Context, webhook entity.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWebhook", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWebhook indicates an expected call of SaveWebhook.
+func (mr *MockStorageMockRecorder) SaveWebhook(ctx, webhook interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWebhook", reflect.TypeOf((*MockStorage)(nil).SaveWebhook), ctx, webhook)
+}
+
+// SaveWebhookDelivery mocks base method.
+func (m *MockStorage) SaveWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWebhookDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWebhookDelivery indicates an expected call of SaveWebhookDelivery.
+func (mr *MockStorageMockRecorder) SaveWebhookDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWebhookDelivery", reflect.TypeOf((*MockStorage)(nil).SaveWebhookDelivery), ctx, delivery)
+}
+
+// SaveWorkspace mocks base method.
+func (m *MockStorage) SaveWorkspace(ctx context.Context, workspace entity.Workspace) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWorkspace", ctx, workspace)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWorkspace indicates an expected call of SaveWorkspace.
+func (mr *MockStorageMockRecorder) SaveWorkspace(ctx, workspace interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWorkspace", reflect.TypeOf((*MockStorage)(nil).SaveWorkspace), ctx, workspace)
+}
+
+// SaveWorkspaceInvite mocks base method.
+func (m *MockStorage) SaveWorkspaceInvite(ctx context.Context, invite entity.WorkspaceInvite) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWorkspaceInvite", ctx, invite)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWorkspaceInvite indicates an expected call of SaveWorkspaceInvite.
+func (mr *MockStorageMockRecorder) SaveWorkspaceInvite(ctx, invite interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWorkspaceInvite", reflect.TypeOf((*MockStorage)(nil).SaveWorkspaceInvite), ctx, invite)
+}
+
+// SaveWorkspaceMember mocks base method.
+func (m *MockStorage) SaveWorkspaceMember(ctx context.Context, member entity.WorkspaceMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveWorkspaceMember", ctx, member)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveWorkspaceMember indicates an expected call of SaveWorkspaceMember.
+func (mr *MockStorageMockRecorder) SaveWorkspaceMember(ctx, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWorkspaceMember", reflect.TypeOf((*MockStorage)(nil).SaveWorkspaceMember), ctx, member)
+}
+
+// SetLinkDisabled mocks base method.
+func (m *MockStorage) SetLinkDisabled(ctx context.Context, shortURL string, disabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLinkDisabled", ctx, shortURL, disabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLinkDisabled indicates an expected call of SetLinkDisabled.
+func (mr *MockStorageMockRecorder) SetLinkDisabled(ctx, shortURL, disabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLinkDisabled", reflect.TypeOf((*MockStorage)(nil).SetLinkDisabled), ctx, shortURL, disabled)
+}
+
+// UpdateDeleteJob mocks base method.
+func (m *MockStorage) UpdateDeleteJob(ctx context.Context, job entity.DeleteJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDeleteJob", ctx, job)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDeleteJob indicates an expected call of UpdateDeleteJob.
+func (mr *MockStorageMockRecorder) UpdateDeleteJob(ctx, job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDeleteJob", reflect.TypeOf((*MockStorage)(nil).UpdateDeleteJob), ctx, job)
+}
+
+// UpdateLinkMetadata mocks base method.
+func (m *MockStorage) UpdateLinkMetadata(ctx context.Context, userID entity.UserID, workspaceID, shortURL string, metadata map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLinkMetadata", ctx, userID, workspaceID, shortURL, metadata)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLinkMetadata indicates an expected call of UpdateLinkMetadata.
+func (mr *MockStorageMockRecorder) UpdateLinkMetadata(ctx, userID, workspaceID, shortURL, metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLinkMetadata", reflect.TypeOf((*MockStorage)(nil).UpdateLinkMetadata), ctx, userID, workspaceID, shortURL, metadata)
+}
+
+// UpdateWebhookDelivery mocks base method.
+func (m *MockStorage) UpdateWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookDelivery indicates an expected call of UpdateWebhookDelivery.
+func (mr *MockStorageMockRecorder) UpdateWebhookDelivery(ctx, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookDelivery", reflect.TypeOf((*MockStorage)(nil).UpdateWebhookDelivery), ctx, delivery)
+}
+
+// MockCompactor is a mock of Compactor interface.
+type MockCompactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompactorMockRecorder
+}
+
+// MockCompactorMockRecorder is the mock recorder for MockCompactor.
+type MockCompactorMockRecorder struct {
+	mock *MockCompactor
+}
+
+// NewMockCompactor creates a new mock instance.
+func NewMockCompactor(ctrl *gomock.Controller) *MockCompactor {
+	mock := &MockCompactor{ctrl: ctrl}
+	mock.recorder = &MockCompactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompactor) EXPECT() *MockCompactorMockRecorder {
+	return m.recorder
+}
+
+// Compact mocks base method.
+func (m *MockCompactor) Compact(ctx context.Context) (models.CompactionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compact", ctx)
+	ret0, _ := ret[0].(models.CompactionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compact indicates an expected call of Compact.
+func (mr *MockCompactorMockRecorder) Compact(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compact", reflect.TypeOf((*MockCompactor)(nil).Compact), ctx)
+}
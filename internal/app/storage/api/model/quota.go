@@ -0,0 +1,79 @@
+package model
+
+import (
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// QuotaPolicy Interface of policy which assigns quotas to users
+type QuotaPolicy interface {
+	Quota(userID entity.UserID) entity.Quota
+}
+
+// QuotaEnforcer Interface of storage which enforces quotas of users in its operations
+type QuotaEnforcer interface {
+	SetQuotaPolicy(policy QuotaPolicy)
+}
+
+// QuotaOf Returns quota of user assigned by policy
+//
+// Users aren't limited if policy is not set
+func QuotaOf(policy QuotaPolicy, userID entity.UserID) entity.Quota {
+	if policy == nil {
+		return entity.Quota{}
+	}
+
+	return policy.Quota(userID)
+}
+
+// CheckLinksQuota Returns QuotaError error if creation of count links exceeds quota with current usage
+func CheckLinksQuota(quota entity.Quota, usage entity.QuotaUsage, count int, now time.Time) error {
+	if count <= 0 {
+		return nil
+	}
+
+	if quota.MaxActiveLinks > 0 && usage.ActiveLinks+count > quota.MaxActiveLinks {
+		return &api.QuotaError{Limit: entity.QuotaActiveLinks, Max: int64(quota.MaxActiveLinks)}
+	}
+
+	if quota.MaxLinksPerDay > 0 && usage.LinksToday+count > quota.MaxLinksPerDay {
+		return &api.QuotaError{
+			Limit:   entity.QuotaLinksPerDay,
+			Max:     int64(quota.MaxLinksPerDay),
+			ResetAt: entity.QuotaDay(now).Add(24 * time.Hour),
+		}
+	}
+
+	return nil
+}
+
+// CheckRestoreQuota Returns QuotaError error if restoration of count links exceeds quota with current usage
+//
+// Restored links aren't counted as created today
+func CheckRestoreQuota(quota entity.Quota, usage entity.QuotaUsage, count int) error {
+	if quota.MaxActiveLinks > 0 && count > 0 && usage.ActiveLinks+count > quota.MaxActiveLinks {
+		return &api.QuotaError{Limit: entity.QuotaActiveLinks, Max: int64(quota.MaxActiveLinks)}
+	}
+
+	return nil
+}
+
+// CheckBatchQuota Returns QuotaError error if size of batch exceeds quota
+func CheckBatchQuota(quota entity.Quota, size int) error {
+	if quota.MaxBatchSize > 0 && size > quota.MaxBatchSize {
+		return &api.QuotaError{Limit: entity.QuotaBatchSize, Max: int64(quota.MaxBatchSize)}
+	}
+
+	return nil
+}
+
+// CheckClicksQuota Returns QuotaError error if one more click exceeds quota with current usage
+func CheckClicksQuota(quota entity.Quota, usage entity.QuotaUsage) error {
+	if quota.MaxTrackedClicks > 0 && usage.TrackedClicks >= quota.MaxTrackedClicks {
+		return &api.QuotaError{Limit: entity.QuotaTrackedClicks, Max: quota.MaxTrackedClicks}
+	}
+
+	return nil
+}
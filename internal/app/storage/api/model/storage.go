@@ -3,6 +3,7 @@ package model
 
 import (
 	"context"
+	"time"
 
 	"github.com/avGenie/url-shortener/internal/app/entity"
 	"github.com/avGenie/url-shortener/internal/app/models"
@@ -19,6 +20,60 @@ type Storage interface {
 	GetURL(ctx context.Context, userID entity.UserID, key entity.URL) (*entity.URL, error)
 	GetAllURLByUserID(ctx context.Context, userID entity.UserID) (models.AllUrlsBatch, error)
 	GetStatistic(ctx context.Context) (models.CountStatistic, error)
+	GetServiceStatistic(ctx context.Context, query models.StatisticQuery) (models.ServiceStatistic, error)
+
+	SaveLink(ctx context.Context, link entity.Link) error
+	GetLink(ctx context.Context, userID entity.UserID, shortURL string) (entity.Link, error)
+	ListUserLinks(ctx context.Context, userID entity.UserID, after entity.LinkCursor, limit int) ([]entity.Link, error)
+	RecordClick(ctx context.Context, shortURL string) error
+	GetQuotaUsage(ctx context.Context, userID entity.UserID, now time.Time) (entity.QuotaUsage, error)
+	SetLinkDisabled(ctx context.Context, shortURL string, disabled bool) error
+	UpdateLinkMetadata(ctx context.Context, userID entity.UserID, workspaceID, shortURL string, metadata map[string]string) error
 
 	DeleteBatchURL(ctx context.Context, urls entity.DeletedURLBatch) error
+	ListDeletedLinks(ctx context.Context, userID entity.UserID, deletedAfter time.Time) ([]entity.Link, error)
+	RestoreLinks(ctx context.Context, userID entity.UserID, shortURLs []string, deletedAfter time.Time) ([]string, error)
+	PurgeDeletedLinks(ctx context.Context, deletedBefore time.Time, limit int) ([]entity.Link, error)
+
+	SaveDeleteJob(ctx context.Context, job entity.DeleteJob) error
+	UpdateDeleteJob(ctx context.Context, job entity.DeleteJob) error
+	GetDeleteJob(ctx context.Context, userID entity.UserID, jobID string) (entity.DeleteJob, error)
+	ClaimPendingDeleteJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.DeleteJob, error)
+	ListUserDeleteJobs(ctx context.Context, userID entity.UserID) ([]entity.DeleteJob, error)
+
+	SaveWebhook(ctx context.Context, webhook entity.Webhook) error
+	GetWebhook(ctx context.Context, userID entity.UserID, webhookID string) (entity.Webhook, error)
+	ListWebhooks(ctx context.Context, userID entity.UserID) ([]entity.Webhook, error)
+	DeleteWebhook(ctx context.Context, userID entity.UserID, webhookID string) error
+
+	SaveWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error
+	UpdateWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error
+	GetWebhookDelivery(ctx context.Context, userID entity.UserID, deliveryID string) (entity.WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, userID entity.UserID, webhookID string, limit int) ([]entity.WebhookDelivery, error)
+	ListPendingWebhookDeliveries(ctx context.Context, before time.Time, limit int) ([]entity.WebhookDelivery, error)
+
+	SaveWorkspace(ctx context.Context, workspace entity.Workspace) error
+	GetWorkspace(ctx context.Context, workspaceID string) (entity.Workspace, error)
+	GetWorkspaceLink(ctx context.Context, workspaceID, shortURL string) (entity.Link, error)
+	ListWorkspaceLinks(ctx context.Context, workspaceID string, after entity.LinkCursor, limit int) ([]entity.Link, error)
+
+	SaveWorkspaceMember(ctx context.Context, member entity.WorkspaceMember) error
+	GetWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) (entity.WorkspaceMember, error)
+	ListWorkspaceMembers(ctx context.Context, workspaceID string) ([]entity.WorkspaceMember, error)
+	ListUserMemberships(ctx context.Context, userID entity.UserID) ([]entity.WorkspaceMember, error)
+	DeleteWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) error
+
+	SaveWorkspaceInvite(ctx context.Context, invite entity.WorkspaceInvite) error
+	GetWorkspaceInvite(ctx context.Context, inviteID string) (entity.WorkspaceInvite, error)
+	DeleteWorkspaceInvite(ctx context.Context, inviteID string) error
+
+	SaveAuditRecord(ctx context.Context, record entity.AuditRecord) error
+	ListAuditRecords(ctx context.Context, filter entity.AuditFilter, limit int) ([]entity.AuditRecord, error)
+
+	EraseUser(ctx context.Context, userID entity.UserID) (models.UserErasure, error)
+}
+
+// Compactor Interface of storage which can rewrite its data without history of changes
+type Compactor interface {
+	Compact(ctx context.Context) (models.CompactionResult, error)
 }
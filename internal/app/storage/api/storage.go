@@ -5,27 +5,49 @@ import (
 	"github.com/avGenie/url-shortener/internal/app/config"
 	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
 	"github.com/avGenie/url-shortener/internal/app/storage/file"
+	"github.com/avGenie/url-shortener/internal/app/storage/instrumented"
 	"github.com/avGenie/url-shortener/internal/app/storage/local"
 	"github.com/avGenie/url-shortener/internal/app/storage/postgres"
 	"go.uber.org/zap"
 )
 
+// Storage backend names
+const (
+	backendPostgres = "postgres"
+	backendFile     = "file"
+	backendLocal    = "local"
+)
+
 // InitStorage Creates storage object
-func InitStorage(config config.Config) (model.Storage, error) {
+//
+// Storage operations are instrumented with metrics, quotas of users are enforced by storage using given policy
+func InitStorage(config config.Config, quotas model.QuotaPolicy) (model.Storage, error) {
 	var db model.Storage
+	var backend string
 	var err error
 
 	if len(config.DBStorageConnect) > 0 {
 		zap.L().Info("init postgres storage")
+		backend = backendPostgres
 		db, err = postgres.NewPostgresStorage(config.DBStorageConnect)
 	} else if len(config.DBFileStoragePath) > 0 {
 		zap.L().Info("init file storage")
+		backend = backendFile
 		db, err = file.NewFileStorage(config.DBFileStoragePath)
 	} else {
 		zap.L().Info("init local storage")
+		backend = backendLocal
 		db = local.NewTSLocalStorage(0)
 		err = nil
 	}
 
-	return db, err
+	if err != nil {
+		return nil, err
+	}
+
+	if enforcer, ok := db.(model.QuotaEnforcer); ok {
+		enforcer.SetQuotaPolicy(quotas)
+	}
+
+	return instrumented.NewStorage(db, backend), nil
 }
@@ -0,0 +1,98 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// auditFileSuffix Suffix of file keeping audit trail next to storage file
+const auditFileSuffix = ".audit"
+
+// SaveAuditRecord Appends record to audit trail of file storage
+func (s *FileStorage) SaveAuditRecord(ctx context.Context, record entity.AuditRecord) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.auditFile == nil {
+		return fmt.Errorf("error while saving audit record to file storage: %w", api.ErrFileStorageNotOpen)
+	}
+
+	err := s.auditEncoder.Encode(&record)
+	if err != nil {
+		return fmt.Errorf("error while encoding audit record for file commit: %w", err)
+	}
+	s.auditFile.Sync()
+
+	s.audit.Append(record)
+
+	return nil
+}
+
+// ListAuditRecords Returns records satisfying filter from audit trail of file storage, the newest first
+func (s *FileStorage) ListAuditRecords(ctx context.Context, filter entity.AuditFilter, limit int) ([]entity.AuditRecord, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.audit.List(filter, limit), nil
+}
+
+// openAudit Opens audit file next to storage file and loads audit trail
+func (s *FileStorage) openAudit() error {
+	file, err := os.OpenFile(s.fileName+auditFileSuffix, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record entity.AuditRecord
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("error while decoding audit record from file: %w", err)
+		}
+
+		s.audit.Append(record)
+	}
+
+	if scanner.Err() != nil {
+		file.Close()
+		return fmt.Errorf("error while reading audit file: %w", scanner.Err())
+	}
+
+	s.auditFile = file
+	s.auditEncoder = json.NewEncoder(file)
+
+	return nil
+}
+
+// writeAudit Writes records of audit trail in order of appending
+func writeAudit(w io.Writer, records []entity.AuditRecord) error {
+	buf := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buf)
+
+	for _, record := range records {
+		err := encoder.Encode(&record)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := buf.Flush()
+	if err != nil {
+		return err
+	}
+
+	if file, ok := w.(*os.File); ok {
+		err = file.Sync()
+	}
+
+	return err
+}
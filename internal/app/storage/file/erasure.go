@@ -0,0 +1,77 @@
+package file
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/local"
+)
+
+// ListUserDeleteJobs Returns deletion jobs of user from file storage ordered by creation time
+func (s *FileStorage) ListUserDeleteJobs(ctx context.Context, userID entity.UserID) ([]entity.DeleteJob, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.jobs.UserJobs(userID), nil
+}
+
+// EraseUser Erases all data of user from file storage
+//
+// Links, deletion jobs, webhooks, memberships and invites of user are removed,
+// workspaces created by user and audit records of user are anonymized.
+// Erasure is appended to storage file as record with erased flag before all files are rewritten
+// without data of user, so erasure interrupted by restart is completed on loading
+func (s *FileStorage) EraseUser(ctx context.Context, userID entity.UserID) (models.UserErasure, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return models.UserErasure{}, fmt.Errorf("error while erasing user from file storage: %w", api.ErrFileStorageNotOpen)
+	}
+
+	err := s.encoder.Encode(&entity.URLRecord{
+		ID:     s.lastID + 1,
+		UserID: userID.String(),
+		Erased: true,
+	})
+	if err != nil {
+		return models.UserErasure{}, fmt.Errorf("error while encoding erasure of user for file commit: %w", err)
+	}
+	s.file.Sync()
+	s.lastID++
+
+	erasure := local.EraseUser(userID, &s.cache, s.jobs, s.webhooks, s.workspaces, s.audit)
+
+	_, err = s.compact()
+	if err != nil {
+		return models.UserErasure{}, fmt.Errorf("error while rewriting files after erasure of user: %w", err)
+	}
+
+	return erasure, nil
+}
+
+// completeErasures Erases data of users whose erasure records are loaded from storage file and rewrites files without it
+func (s *FileStorage) completeErasures() error {
+	if len(s.erased) == 0 {
+		return nil
+	}
+
+	for _, userID := range s.erased {
+		local.EraseUser(userID, &s.cache, s.jobs, s.webhooks, s.workspaces, s.audit)
+	}
+
+	_, err := s.compact()
+	if err != nil {
+		return fmt.Errorf("error while completing erasure of users: %w", err)
+	}
+
+	zap.L().Info("interrupted erasure of users was completed", zap.Int("users", len(s.erased)))
+	s.erased = nil
+
+	return nil
+}
@@ -6,9 +6,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -19,15 +23,44 @@ import (
 	"github.com/avGenie/url-shortener/internal/app/storage/local"
 )
 
+// jobsFileSuffix Suffix of file keeping outbox of deletion jobs next to storage file
+const jobsFileSuffix = ".jobs"
+
 // FileStorage File storage object
+//
+// Deletion jobs are kept in separate log of job states next to storage file,
+// webhooks are kept with log of their deliveries in another file next to storage file,
+// workspaces are kept with their members and invites in one more file next to storage file,
+// audit trail is appended to its own file next to storage file.
+// Erasure of user is kept in storage file until all files are rewritten without data of user
 type FileStorage struct {
 	model.Storage
 
-	cache    local.LocalStorage
-	encoder  *json.Encoder
-	file     *os.File
-	fileName string
-	mutex    sync.RWMutex
+	cache       local.LocalStorage
+	encoder     *json.Encoder
+	file        *os.File
+	fileName    string
+	jobs        *local.DeleteJobs
+	jobsEncoder *json.Encoder
+	jobsFile    *os.File
+	mutex       sync.RWMutex
+
+	webhooks        *local.Webhooks
+	webhooksEncoder *json.Encoder
+	webhooksFile    *os.File
+
+	workspaces        *local.Workspaces
+	workspacesEncoder *json.Encoder
+	workspacesFile    *os.File
+
+	audit        *local.AuditTrail
+	auditEncoder *json.Encoder
+	auditFile    *os.File
+
+	quotas model.QuotaPolicy
+
+	// erased Users whose erasure records are loaded from storage file
+	erased []entity.UserID
 
 	lastID uint
 	IsTemp bool
@@ -38,8 +71,12 @@ func NewFileStorage(fileName string) (*FileStorage, error) {
 	if fileName == "" {
 		zap.L().Info("storage was created successfully without keeping URL on disk")
 		return &FileStorage{
-			cache:  *local.NewLocalStorage(0),
-			lastID: 0,
+			cache:      *local.NewLocalStorage(0),
+			jobs:       local.NewDeleteJobs(),
+			webhooks:   local.NewWebhooks(),
+			workspaces: local.NewWorkspaces(),
+			audit:      local.NewAuditTrail(),
+			lastID:     0,
 		}, nil
 	}
 
@@ -49,12 +86,16 @@ func NewFileStorage(fileName string) (*FileStorage, error) {
 	}
 
 	storage := &FileStorage{
-		mutex:    sync.RWMutex{},
-		file:     file,
-		fileName: fileName,
-		encoder:  json.NewEncoder(file),
-		cache:    *local.NewLocalStorage(0),
-		lastID:   0,
+		mutex:      sync.RWMutex{},
+		file:       file,
+		fileName:   fileName,
+		encoder:    json.NewEncoder(file),
+		cache:      *local.NewLocalStorage(0),
+		jobs:       local.NewDeleteJobs(),
+		webhooks:   local.NewWebhooks(),
+		workspaces: local.NewWorkspaces(),
+		audit:      local.NewAuditTrail(),
+		lastID:     0,
 	}
 
 	err = storage.fillCacheFromFile()
@@ -62,6 +103,31 @@ func NewFileStorage(fileName string) (*FileStorage, error) {
 		return nil, err
 	}
 
+	err = storage.openJobs()
+	if err != nil {
+		return nil, err
+	}
+
+	err = storage.openWebhooks()
+	if err != nil {
+		return nil, err
+	}
+
+	err = storage.openWorkspaces()
+	if err != nil {
+		return nil, err
+	}
+
+	err = storage.openAudit()
+	if err != nil {
+		return nil, err
+	}
+
+	err = storage.completeErasures()
+	if err != nil {
+		return nil, err
+	}
+
 	zap.L().Info("storage was created successfully")
 
 	return storage, nil
@@ -74,12 +140,21 @@ func (s *FileStorage) GetURL(ctx context.Context, userID entity.UserID, key enti
 		return nil, fmt.Errorf("error while getting url from file: %w", api.ErrFileStorageNotOpen)
 	}
 	res, ok := s.cache.Get(key)
+	link, _ := s.cache.GetLink(key.String())
 	s.mutex.RUnlock()
 
 	if !ok {
 		return nil, fmt.Errorf("error while getting url from file: %w", api.ErrShortURLNotFound)
 	}
 
+	if link.Deleted {
+		return nil, fmt.Errorf("error while getting url from file: %w", api.ErrAllURLsDeleted)
+	}
+
+	if link.Disabled {
+		return nil, fmt.Errorf("error while getting url from file: %w", api.ErrURLDisabled)
+	}
+
 	return &res, nil
 }
 
@@ -90,10 +165,15 @@ func (s *FileStorage) GetAllURLByUserID(ctx context.Context, userID entity.UserI
 		return nil, fmt.Errorf("error while getting url from file: %w", api.ErrFileStorageNotOpen)
 	}
 	urls := s.cache.GetAllURL()
+	links := s.cache.GetAllLinks()
 	s.mutex.RUnlock()
 
 	var allURLs models.AllUrlsBatch
 	for key, value := range urls {
+		if link, ok := links[key.String()]; ok && link.Deleted {
+			continue
+		}
+
 		allURLs = append(allURLs, models.AllUrlsResponse{
 			ShortURL:    key.String(),
 			OriginalURL: value.String(),
@@ -103,7 +183,27 @@ func (s *FileStorage) GetAllURLByUserID(ctx context.Context, userID entity.UserI
 	return allURLs, nil
 }
 
+// SetQuotaPolicy Sets policy of user quotas enforced by file storage
+func (s *FileStorage) SetQuotaPolicy(policy model.QuotaPolicy) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.quotas = policy
+}
+
+// GetQuotaUsage Returns usage of user quota at given time from file storage
+//
+// Clicks aren't kept in file, so only clicks since restart are counted
+func (s *FileStorage) GetQuotaUsage(ctx context.Context, userID entity.UserID, now time.Time) (entity.QuotaUsage, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.cache.Usage(userID, now), nil
+}
+
 // SaveURL Saves user URL to file storage
+//
+// Returns QuotaError error if user exceeded quota of links
 func (s *FileStorage) SaveURL(ctx context.Context, userID entity.UserID, key, value entity.URL) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -112,68 +212,551 @@ func (s *FileStorage) SaveURL(ctx context.Context, userID entity.UserID, key, va
 		return fmt.Errorf("error while save url to file storage: %w", api.ErrFileStorageNotOpen)
 	}
 
-	storageRec := &entity.URLRecord{
-		ID:          s.lastID + 1,
-		ShortURL:    key.Path,
+	createdAt := time.Now().UTC()
+	if link, ok := s.cache.GetLink(key.String()); !ok || link.Deleted {
+		err := model.CheckLinksQuota(model.QuotaOf(s.quotas, userID), s.cache.Usage(userID, createdAt), 1, createdAt)
+		if err != nil {
+			return fmt.Errorf("error while save url to file storage: %w", err)
+		}
+	}
+
+	return s.saveLink(entity.Link{
+		ShortURL:    key.String(),
 		OriginalURL: value.String(),
+		UserID:      userID,
+		CreatedAt:   createdAt,
+	})
+}
+
+// SaveBatchURL Saves batch of user URLs to file storage
+//
+// Batch is saved entirely or isn't saved at all if user exceeded quota of batch size or links.
+// Returns QuotaError error if user exceeded quota
+func (s *FileStorage) SaveBatchURL(ctx context.Context, userID entity.UserID, batch model.Batch) (model.Batch, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return nil, api.ErrFileStorageNotOpen
 	}
 
-	err := s.encoder.Encode(&storageRec)
+	quota := model.QuotaOf(s.quotas, userID)
+	err := model.CheckBatchQuota(quota, len(batch))
 	if err != nil {
-		return fmt.Errorf("error while encoding entity for file commit: %w", err)
+		return nil, fmt.Errorf("error while save batch to file storage: %w", err)
+	}
+
+	localUrls := local.NewLocalStorage(len(batch))
+	records := make([]entity.URLRecord, 0, len(batch))
+	createdAt := time.Now().UTC()
+	for _, obj := range batch {
+		link := entity.Link{
+			ShortURL:    obj.ShortURL,
+			OriginalURL: obj.InputURL,
+			UserID:      userID,
+			CreatedAt:   createdAt,
+		}
+
+		err := localUrls.AddLink(link)
+		if err != nil {
+			return nil, fmt.Errorf("exit to add url from batch in file storage: %w", err)
+		}
+
+		s.lastID++
+		records = append(records, linkToRecord(s.lastID, link))
 	}
 
+	err = model.CheckLinksQuota(quota, s.cache.Usage(userID, createdAt), s.cache.CountNewLinks(*localUrls), createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("error while save batch to file storage: %w", err)
+	}
+
+	err = s.encoder.Encode(&records)
+	if err != nil {
+		return nil, fmt.Errorf("error while encoding entity for file commit: %w", err)
+	}
 	s.file.Sync()
 
-	s.cache.Add(key, value)
-	s.lastID = storageRec.ID
+	s.cache.Merge(*localUrls)
 
-	return nil
+	return batch, nil
 }
 
-// SaveBatchURL Saves batch of user URLs to file storage
-func (s *FileStorage) SaveBatchURL(ctx context.Context, userID entity.UserID, batch model.Batch) (model.Batch, error) {
+// SaveLink Saves user link to file storage
+//
+// Returns QuotaError error if user exceeded quota of links
+func (s *FileStorage) SaveLink(ctx context.Context, link entity.Link) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	if s.file == nil {
-		return nil, api.ErrFileStorageNotOpen
+		return fmt.Errorf("error while save link to file storage: %w", api.ErrFileStorageNotOpen)
 	}
 
-	localUrls := local.NewLocalStorage(len(batch))
-	records := make([]entity.URLRecord, 0, len(batch))
-	for _, obj := range batch {
-		key, err := entity.NewURL(obj.ShortURL)
+	_, ok := s.cache.GetLink(link.ShortURL)
+	if ok {
+		return fmt.Errorf("error while save link to file storage: %w", api.ErrURLAlreadyExists)
+	}
+
+	err := model.CheckLinksQuota(model.QuotaOf(s.quotas, link.UserID), s.cache.Usage(link.UserID, link.CreatedAt), 1, link.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error while save link to file storage: %w", err)
+	}
+
+	return s.saveLink(link)
+}
+
+// GetLink Returns link from file storage
+//
+// Link of any user is returned if user id is empty
+func (s *FileStorage) GetLink(ctx context.Context, userID entity.UserID, shortURL string) (entity.Link, error) {
+	s.mutex.RLock()
+	link, ok := s.cache.GetLink(shortURL)
+	s.mutex.RUnlock()
+
+	if !ok || (userID.IsValid() && link.UserID != userID) {
+		return entity.Link{}, fmt.Errorf("error while getting link from file: %w", api.ErrShortURLNotFound)
+	}
+
+	return link, nil
+}
+
+// ListUserLinks Returns user links placed after cursor ordered by creation time and short URL
+func (s *FileStorage) ListUserLinks(
+	ctx context.Context,
+	userID entity.UserID,
+	after entity.LinkCursor,
+	limit int,
+) ([]entity.Link, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.cache.UserLinks(userID, after, limit), nil
+}
+
+// DeleteBatchURL Marks user URLs as deleted in file storage
+//
+// Deletion is kept in file as records with deleted flag and deletion time
+func (s *FileStorage) DeleteBatchURL(ctx context.Context, urls entity.DeletedURLBatch) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return fmt.Errorf("error while deleting urls from file storage: %w", api.ErrFileStorageNotOpen)
+	}
+
+	deletedAt := time.Now().UTC()
+	for _, url := range urls {
+		if !s.cache.DeleteLink(entity.UserID(url.UserID), url.ShortURL, deletedAt) {
+			continue
+		}
+
+		s.lastID++
+		err := s.encoder.Encode(&entity.URLRecord{
+			ID:        s.lastID,
+			ShortURL:  url.ShortURL,
+			UserID:    url.UserID,
+			Deleted:   true,
+			DeletedAt: &deletedAt,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("exit to create input url from batch in file storage: %w", err)
+			return fmt.Errorf("error while encoding deleted url for file commit: %w", err)
 		}
-		value, err := entity.NewURL(obj.InputURL)
+	}
+	s.file.Sync()
+
+	return nil
+}
+
+// ListDeletedLinks Returns links of user deleted after given time from file storage
+func (s *FileStorage) ListDeletedLinks(ctx context.Context, userID entity.UserID, deletedAfter time.Time) ([]entity.Link, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.cache.DeletedLinks(userID, deletedAfter), nil
+}
+
+// RestoreLinks Restores links of user deleted after given time in file storage
+//
+// Restoration is kept in file as records with restored flag.
+// Links aren't restored if user exceeded quota of active links by them.
+// Returns short URLs of restored links
+func (s *FileStorage) RestoreLinks(
+	ctx context.Context,
+	userID entity.UserID,
+	shortURLs []string,
+	deletedAfter time.Time,
+) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return nil, fmt.Errorf("error while restoring links in file storage: %w", api.ErrFileStorageNotOpen)
+	}
+
+	count := s.cache.CountRestorable(userID, shortURLs, deletedAfter)
+	err := model.CheckRestoreQuota(model.QuotaOf(s.quotas, userID), s.cache.Usage(userID, time.Now().UTC()), count)
+	if err != nil {
+		return nil, fmt.Errorf("error while restoring links in file storage: %w", err)
+	}
+
+	restored := make([]string, 0, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		if !s.cache.RestoreLink(userID, shortURL, deletedAfter) {
+			continue
+		}
+
+		s.lastID++
+		err := s.encoder.Encode(&entity.URLRecord{
+			ID:       s.lastID,
+			ShortURL: shortURL,
+			UserID:   userID.String(),
+			Restored: true,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("exit to create short url from batch in file storage: %w", err)
+			return nil, fmt.Errorf("error while encoding restored link for file commit: %w", err)
 		}
 
-		localUrls.Add(*key, *value)
+		restored = append(restored, shortURL)
+	}
+	s.file.Sync()
 
-		storageRec := &entity.URLRecord{
-			ID:          s.lastID + 1,
-			ShortURL:    key.Path,
-			OriginalURL: value.String(),
+	return restored, nil
+}
+
+// PurgeDeletedLinks Removes up to limit links deleted before given time from file storage
+//
+// Removal is kept in file as records with purged flag, records of removed links are dropped by compaction.
+// Returns removed links
+func (s *FileStorage) PurgeDeletedLinks(ctx context.Context, deletedBefore time.Time, limit int) ([]entity.Link, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return nil, fmt.Errorf("error while purging links from file storage: %w", api.ErrFileStorageNotOpen)
+	}
+
+	expired := s.cache.ExpiredLinks(deletedBefore, limit)
+	for _, link := range expired {
+		s.lastID++
+		err := s.encoder.Encode(&entity.URLRecord{
+			ID:       s.lastID,
+			ShortURL: link.ShortURL,
+			UserID:   link.UserID.String(),
+			Purged:   true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error while encoding purged link for file commit: %w", err)
 		}
 
-		s.lastID = storageRec.ID
+		s.cache.RemoveLink(link.ShortURL)
+	}
+	s.file.Sync()
+
+	return expired, nil
+}
+
+// SaveDeleteJob Adds deletion job to outbox of file storage
+//
+// Every state of job is appended to jobs file, the last one is used on loading
+func (s *FileStorage) SaveDeleteJob(ctx context.Context, job entity.DeleteJob) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.writeJob(job)
+	if err != nil {
+		return fmt.Errorf("error while saving delete job to file storage: %w", err)
+	}
+
+	s.jobs.Save(job)
 
-		records = append(records, *storageRec)
+	return nil
+}
+
+// UpdateDeleteJob Saves the next state of deletion job kept in outbox of file storage
+//
+// State isn't appended to jobs file if job is removed, so erased job isn't restored on loading.
+// Returns ErrDeleteJobNotFound error if job is removed, e.g. by erasure of its user
+func (s *FileStorage) UpdateDeleteJob(ctx context.Context, job entity.DeleteJob) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.jobs.Get("", job.ID); !ok {
+		return fmt.Errorf("error while updating delete job in file storage: %w", api.ErrDeleteJobNotFound)
 	}
 
-	err := s.encoder.Encode(&records)
+	err := s.writeJob(job)
 	if err != nil {
-		return nil, fmt.Errorf("error while encoding entity for file commit: %w", err)
+		return fmt.Errorf("error while updating delete job in file storage: %w", err)
+	}
+
+	s.jobs.Update(job)
+
+	return nil
+}
+
+// writeJob Appends state of deletion job to jobs file, must be called under lock
+func (s *FileStorage) writeJob(job entity.DeleteJob) error {
+	if s.jobsFile == nil {
+		return api.ErrFileStorageNotOpen
+	}
+
+	err := s.jobsEncoder.Encode(&job)
+	if err != nil {
+		return fmt.Errorf("error while encoding delete job for file commit: %w", err)
+	}
+	s.jobsFile.Sync()
+
+	return nil
+}
+
+// GetDeleteJob Returns deletion job of user from file storage
+//
+// Job of any user is returned if user id is empty
+func (s *FileStorage) GetDeleteJob(ctx context.Context, userID entity.UserID, jobID string) (entity.DeleteJob, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	job, ok := s.jobs.Get(userID, jobID)
+	if !ok {
+		return entity.DeleteJob{}, fmt.Errorf("error while getting delete job from file: %w", api.ErrDeleteJobNotFound)
+	}
+
+	return job, nil
+}
+
+// ClaimPendingDeleteJobs Returns pending deletion jobs due at given time from file storage and leases them
+//
+// File storage is used by single instance, so leases are kept only in memory
+func (s *FileStorage) ClaimPendingDeleteJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.DeleteJob, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.jobs.Claim(now, now.Add(lease), limit), nil
+}
+
+// SetLinkDisabled Changes disabled state of link of any user in file storage
+//
+// Change is kept in file as record with disabled flag
+func (s *FileStorage) SetLinkDisabled(ctx context.Context, shortURL string, disabled bool) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return fmt.Errorf("error while changing disabled state in file storage: %w", api.ErrFileStorageNotOpen)
+	}
+
+	link, ok := s.cache.GetLink(shortURL)
+	if !ok {
+		return fmt.Errorf("error while changing disabled state in file storage: %w", api.ErrShortURLNotFound)
+	}
+
+	err := s.encoder.Encode(&entity.URLRecord{
+		ID:       s.lastID + 1,
+		ShortURL: shortURL,
+		UserID:   link.UserID.String(),
+		Disabled: &disabled,
+	})
+	if err != nil {
+		return fmt.Errorf("error while encoding disabled state for file commit: %w", err)
 	}
 	s.file.Sync()
 
-	s.cache.Merge(*localUrls)
+	s.lastID++
+	s.cache.SetDisabled(shortURL, disabled)
 
-	return batch, nil
+	return nil
+}
+
+// UpdateLinkMetadata Replaces metadata of link of user in workspace in file storage
+//
+// Empty workspace ID means personal link of user.
+// Change is kept in file as record with metadata updated flag
+func (s *FileStorage) UpdateLinkMetadata(
+	ctx context.Context,
+	userID entity.UserID,
+	workspaceID, shortURL string,
+	metadata map[string]string,
+) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return fmt.Errorf("error while updating link metadata in file storage: %w", api.ErrFileStorageNotOpen)
+	}
+
+	link, ok := s.cache.GetLink(shortURL)
+	if !ok || link.UserID != userID || link.WorkspaceID != workspaceID {
+		return fmt.Errorf("error while updating link metadata in file storage: %w", api.ErrShortURLNotFound)
+	}
+
+	err := s.encoder.Encode(&entity.URLRecord{
+		ID:          s.lastID + 1,
+		ShortURL:    shortURL,
+		UserID:      link.UserID.String(),
+		Metadata:    metadata,
+		MetaUpdated: true,
+	})
+	if err != nil {
+		return fmt.Errorf("error while encoding link metadata for file commit: %w", err)
+	}
+	s.file.Sync()
+
+	s.lastID++
+	s.cache.SetMetadata(shortURL, metadata)
+
+	return nil
+}
+
+// Compact Rewrites storage file by records of current links without history of changes
+//
+// Deleted links are kept with their deletion records, purged links are dropped,
+// jobs and webhooks files keep only the last states of jobs and deliveries,
+// workspaces file keeps only current members and invites, audit file keeps all records
+// with erased users anonymized. Files are replaced by renaming of compacted files, so they are never left partially written
+func (s *FileStorage) Compact(ctx context.Context) (models.CompactionResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.compact()
+}
+
+// compact Rewrites files of storage by current state of cache
+//
+// Storage file is replaced the last, so its erasure records are dropped only after data of erased users
+// is removed from all other files
+func (s *FileStorage) compact() (models.CompactionResult, error) {
+	if s.file == nil {
+		return models.CompactionResult{}, fmt.Errorf("error while compacting file storage: %w", api.ErrFileStorageNotOpen)
+	}
+
+	before, err := s.file.Stat()
+	if err != nil {
+		return models.CompactionResult{}, fmt.Errorf("error while getting size of storage file: %w", err)
+	}
+
+	jobsFile, err := replaceFile(s.jobsFile.Name(), before.Mode(), func(w io.Writer) error {
+		return writeJobs(w, s.jobs.All())
+	})
+	if err != nil {
+		return models.CompactionResult{}, fmt.Errorf("error while compacting jobs file: %w", err)
+	}
+
+	s.jobsFile.Close()
+	s.jobsFile = jobsFile
+	s.jobsEncoder = json.NewEncoder(jobsFile)
+
+	webhooksFile, err := replaceFile(s.webhooksFile.Name(), before.Mode(), func(w io.Writer) error {
+		webhooks, deliveries := s.webhooks.All()
+		return writeWebhooks(w, webhooks, deliveries)
+	})
+	if err != nil {
+		return models.CompactionResult{}, fmt.Errorf("error while compacting webhooks file: %w", err)
+	}
+
+	s.webhooksFile.Close()
+	s.webhooksFile = webhooksFile
+	s.webhooksEncoder = json.NewEncoder(webhooksFile)
+
+	workspacesFile, err := replaceFile(s.workspacesFile.Name(), before.Mode(), func(w io.Writer) error {
+		workspaces, members, invites := s.workspaces.All()
+		return writeWorkspaces(w, workspaces, members, invites)
+	})
+	if err != nil {
+		return models.CompactionResult{}, fmt.Errorf("error while compacting workspaces file: %w", err)
+	}
+
+	s.workspacesFile.Close()
+	s.workspacesFile = workspacesFile
+	s.workspacesEncoder = json.NewEncoder(workspacesFile)
+
+	auditFile, err := replaceFile(s.auditFile.Name(), before.Mode(), func(w io.Writer) error {
+		return writeAudit(w, s.audit.All())
+	})
+	if err != nil {
+		return models.CompactionResult{}, fmt.Errorf("error while compacting audit file: %w", err)
+	}
+
+	s.auditFile.Close()
+	s.auditFile = auditFile
+	s.auditEncoder = json.NewEncoder(auditFile)
+
+	var links int
+	var lastID uint
+	file, err := replaceFile(s.fileName, before.Mode(), func(w io.Writer) error {
+		links, lastID, err = writeCompacted(w, s.cache.GetAllLinks())
+		return err
+	})
+	if err != nil {
+		return models.CompactionResult{}, fmt.Errorf("error while compacting storage file: %w", err)
+	}
+
+	after, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return models.CompactionResult{}, fmt.Errorf("error while getting size of compacted storage file: %w", err)
+	}
+
+	s.file.Close()
+	s.file = file
+	s.encoder = json.NewEncoder(file)
+	s.lastID = lastID
+
+	return models.CompactionResult{
+		SizeBefore: before.Size(),
+		SizeAfter:  after.Size(),
+		Links:      links,
+	}, nil
+}
+
+// GetStatistic Returns count of users and URLs in file storage
+func (s *FileStorage) GetStatistic(ctx context.Context) (models.CountStatistic, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.cache.Statistic(), nil
+}
+
+// GetServiceStatistic Returns statistic of file storage built from incremental counters of cache
+//
+// Storage size is size of storage file
+func (s *FileStorage) GetServiceStatistic(ctx context.Context, query models.StatisticQuery) (models.ServiceStatistic, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	stat := s.cache.ServiceStatistic(query)
+	if s.file != nil {
+		info, err := s.file.Stat()
+		if err != nil {
+			return models.ServiceStatistic{}, fmt.Errorf("error while getting size of storage file: %w", err)
+		}
+
+		stat.StorageSize = info.Size()
+	}
+
+	return stat, nil
+}
+
+// RecordClick Increments count of redirects by link in file storage
+//
+// Clicks are counted in cache only and are not kept in file, so they are reset on restart.
+// Returns QuotaError error if owner of link exceeded quota of tracked clicks
+func (s *FileStorage) RecordClick(ctx context.Context, shortURL string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if link, ok := s.cache.GetLink(shortURL); ok && !link.Deleted {
+		err := model.CheckClicksQuota(model.QuotaOf(s.quotas, link.UserID), s.cache.Usage(link.UserID, time.Now().UTC()))
+		if err != nil {
+			return fmt.Errorf("error while recording click in file storage: %w", err)
+		}
+	}
+
+	if !s.cache.AddClick(shortURL) {
+		return fmt.Errorf("error while recording click in file storage: %w", api.ErrShortURLNotFound)
+	}
+
+	return nil
 }
 
 // Close Closes connection to file storage
@@ -184,6 +767,26 @@ func (s *FileStorage) Close() {
 		if err != nil {
 			zap.L().Error("error while closing file storage", zap.Error(err))
 		}
+
+		err = os.Remove(s.fileName + jobsFileSuffix)
+		if err != nil && !os.IsNotExist(err) {
+			zap.L().Error("error while closing jobs file of file storage", zap.Error(err))
+		}
+
+		err = os.Remove(s.fileName + webhooksFileSuffix)
+		if err != nil && !os.IsNotExist(err) {
+			zap.L().Error("error while closing webhooks file of file storage", zap.Error(err))
+		}
+
+		err = os.Remove(s.fileName + workspacesFileSuffix)
+		if err != nil && !os.IsNotExist(err) {
+			zap.L().Error("error while closing workspaces file of file storage", zap.Error(err))
+		}
+
+		err = os.Remove(s.fileName + auditFileSuffix)
+		if err != nil && !os.IsNotExist(err) {
+			zap.L().Error("error while closing audit file of file storage", zap.Error(err))
+		}
 	}
 }
 
@@ -197,30 +800,258 @@ func (s *FileStorage) PingServer(ctx context.Context) error {
 }
 
 // Fills cache from the DB storage file
+//
+// Line of file contains one record or array of batch records
 func (s *FileStorage) fillCacheFromFile() error {
 	s.file.Seek(0, 0)
 	scanner := bufio.NewScanner(s.file)
-	record := entity.URLRecord{}
 
 	for scanner.Scan() {
-		err := json.Unmarshal(scanner.Bytes(), &record)
+		var records []entity.URLRecord
+
+		line := scanner.Bytes()
+		if strings.HasPrefix(string(line), "[") {
+			err := json.Unmarshal(line, &records)
+			if err != nil {
+				return err
+			}
+		} else {
+			var record entity.URLRecord
+			err := json.Unmarshal(line, &record)
+			if err != nil {
+				return err
+			}
+			records = append(records, record)
+		}
+
+		for _, record := range records {
+			err := s.applyRecord(record)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// openJobs Opens jobs file next to storage file and loads the last states of jobs
+func (s *FileStorage) openJobs() error {
+	file, err := os.OpenFile(s.fileName+jobsFileSuffix, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var job entity.DeleteJob
+		err := json.Unmarshal(scanner.Bytes(), &job)
 		if err != nil {
-			return err
+			file.Close()
+			return fmt.Errorf("error while decoding delete job from file: %w", err)
 		}
 
-		key, err := entity.NewURL(record.ShortURL)
+		s.jobs.Save(job)
+	}
+
+	if scanner.Err() != nil {
+		file.Close()
+		return fmt.Errorf("error while reading jobs file: %w", scanner.Err())
+	}
+
+	s.jobsFile = file
+	s.jobsEncoder = json.NewEncoder(file)
+
+	return nil
+}
+
+func (s *FileStorage) applyRecord(record entity.URLRecord) error {
+	s.lastID = record.ID
+
+	switch {
+	case record.Erased:
+		s.erased = append(s.erased, entity.UserID(record.UserID))
+		return nil
+	case record.Purged:
+		s.cache.RemoveLink(record.ShortURL)
+		return nil
+	case record.Restored:
+		s.cache.RestoreLink(entity.UserID(record.UserID), record.ShortURL, time.Time{})
+		return nil
+	case record.Deleted:
+		// deletion time isn't kept by old records, so their links stay in trash for the whole retention since loading
+		deletedAt := time.Now().UTC()
+		if record.DeletedAt != nil {
+			deletedAt = *record.DeletedAt
+		}
+
+		s.cache.DeleteLink(entity.UserID(record.UserID), record.ShortURL, deletedAt)
+		return nil
+	}
+
+	if record.MetaUpdated {
+		s.cache.SetMetadata(record.ShortURL, record.Metadata)
+		return nil
+	}
+
+	disabled := record.Disabled != nil && *record.Disabled
+	if record.OriginalURL == "" && record.Disabled != nil {
+		s.cache.SetDisabled(record.ShortURL, disabled)
+		return nil
+	}
+
+	return s.cache.AddLink(entity.Link{
+		ShortURL:    record.ShortURL,
+		OriginalURL: record.OriginalURL,
+		UserID:      entity.UserID(record.UserID),
+		WorkspaceID: record.WorkspaceID,
+		CreatedAt:   record.CreatedAt,
+		Disabled:    disabled,
+		Metadata:    record.Metadata,
+	})
+}
+
+// saveLink Writes link record to file and adds link to cache
+func (s *FileStorage) saveLink(link entity.Link) error {
+	record := linkToRecord(s.lastID+1, link)
+
+	err := s.encoder.Encode(&record)
+	if err != nil {
+		return fmt.Errorf("error while encoding entity for file commit: %w", err)
+	}
+
+	s.file.Sync()
+
+	err = s.cache.AddLink(link)
+	if err != nil {
+		return fmt.Errorf("error while adding link to file storage cache: %w", err)
+	}
+	s.lastID = record.ID
+
+	return nil
+}
+
+// replaceFile Replaces file by content written to temporary file and returns replaced file opened for appending
+func replaceFile(name string, mode os.FileMode, write func(w io.Writer) error) (*os.File, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".compact-*")
+	if err != nil {
+		return nil, fmt.Errorf("error while creating compacted file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	err = write(tmp)
+	if err == nil {
+		err = tmp.Chmod(mode)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error while writing compacted file: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), name)
+	if err != nil {
+		return nil, fmt.Errorf("error while replacing file by compacted one: %w", err)
+	}
+
+	file, err := os.OpenFile(name, os.O_RDWR|os.O_APPEND, 0666)
+	if err != nil {
+		return nil, fmt.Errorf("error while opening compacted file: %w", err)
+	}
+
+	return file, nil
+}
+
+// writeJobs Writes the last states of jobs
+func writeJobs(w io.Writer, jobs []entity.DeleteJob) error {
+	buf := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buf)
+
+	for _, job := range jobs {
+		err := encoder.Encode(&job)
 		if err != nil {
 			return err
 		}
+	}
+
+	err := buf.Flush()
+	if err != nil {
+		return err
+	}
+
+	if file, ok := w.(*os.File); ok {
+		err = file.Sync()
+	}
 
-		value, err := entity.NewURL(record.OriginalURL)
+	return err
+}
+
+// writeCompacted Writes records of links ordered by creation time and returns count of links and ID of the last record
+func writeCompacted(w io.Writer, links map[string]entity.Link) (int, uint, error) {
+	sorted := make([]entity.Link, 0, len(links))
+	for _, link := range links {
+		sorted = append(sorted, link)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[j].IsAfter(sorted[i].Cursor())
+	})
+
+	buf := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buf)
+
+	var id uint
+	for _, link := range sorted {
+		id++
+		record := linkToRecord(id, link)
+		err := encoder.Encode(&record)
 		if err != nil {
-			return err
+			return 0, 0, err
 		}
 
-		s.cache.Add(*key, *value)
-		s.lastID = record.ID
+		if !link.Deleted {
+			continue
+		}
+
+		id++
+		err = encoder.Encode(&entity.URLRecord{
+			ID:        id,
+			ShortURL:  link.ShortURL,
+			UserID:    link.UserID.String(),
+			Deleted:   true,
+			DeletedAt: &link.DeletedAt,
+		})
+		if err != nil {
+			return 0, 0, err
+		}
 	}
 
-	return nil
+	err := buf.Flush()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if file, ok := w.(*os.File); ok {
+		err = file.Sync()
+	}
+
+	return len(sorted), id, err
+}
+
+func linkToRecord(id uint, link entity.Link) entity.URLRecord {
+	record := entity.URLRecord{
+		ID:          id,
+		ShortURL:    link.ShortURL,
+		OriginalURL: link.OriginalURL,
+		UserID:      link.UserID.String(),
+		WorkspaceID: link.WorkspaceID,
+		CreatedAt:   link.CreatedAt,
+		Metadata:    link.Metadata,
+	}
+
+	if link.Disabled {
+		record.Disabled = &link.Disabled
+	}
+
+	return record
 }
@@ -0,0 +1,611 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+)
+
+func TestCompact(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "short-url-db.json")
+	ctx := context.Background()
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	storage, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	for i, shortURL := range []string{"42b3e75f", "77fca595", "ac6bb669"} {
+		err = storage.SaveLink(ctx, entity.Link{
+			ShortURL:    shortURL,
+			OriginalURL: "https://practicum.yandex.ru/" + shortURL,
+			UserID:      userID,
+			CreatedAt:   createdAt.Add(time.Duration(i) * time.Second),
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, storage.SetLinkDisabled(ctx, "42b3e75f", true))
+	require.NoError(t, storage.SetLinkDisabled(ctx, "42b3e75f", false))
+	require.NoError(t, storage.SetLinkDisabled(ctx, "77fca595", true))
+	require.NoError(t, storage.DeleteBatchURL(ctx, entity.DeletedURLBatch{{UserID: userID.String(), ShortURL: "ac6bb669"}}))
+
+	res, err := storage.Compact(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Links)
+	assert.Less(t, res.SizeAfter, res.SizeBefore)
+
+	// storage keeps writing to compacted file
+	require.NoError(t, storage.SetLinkDisabled(ctx, "42b3e75f", true))
+
+	reopened, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	for _, shortURL := range []string{"42b3e75f", "77fca595"} {
+		link, err := reopened.GetLink(ctx, "", shortURL)
+		require.NoError(t, err)
+		assert.True(t, link.Disabled)
+		assert.Equal(t, userID, link.UserID)
+
+		key, err := entity.ParseURL(shortURL)
+		require.NoError(t, err)
+
+		_, err = reopened.GetURL(ctx, "", *key)
+		assert.ErrorIs(t, err, api.ErrURLDisabled)
+	}
+
+	link, err := reopened.GetLink(ctx, "", "ac6bb669")
+	require.NoError(t, err)
+	assert.True(t, link.Deleted)
+	assert.Equal(t, createdAt.Add(2*time.Second), link.CreatedAt)
+}
+
+func TestLinkMetadata(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "short-url-db.json")
+	ctx := context.Background()
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	otherID := entity.UserID("0c0a4811-4f10-487f-bde3-e39a14af7cd8")
+
+	storage, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	require.NoError(t, storage.SaveLink(ctx, entity.Link{
+		ShortURL:    "42b3e75f",
+		OriginalURL: "https://practicum.yandex.ru/",
+		UserID:      userID,
+		WorkspaceID: "team",
+	}))
+
+	metadata := map[string]string{"team": "sales"}
+
+	err = storage.UpdateLinkMetadata(ctx, otherID, "team", "42b3e75f", metadata)
+	assert.ErrorIs(t, err, api.ErrShortURLNotFound)
+
+	err = storage.UpdateLinkMetadata(ctx, userID, "", "42b3e75f", metadata)
+	assert.ErrorIs(t, err, api.ErrShortURLNotFound)
+
+	require.NoError(t, storage.UpdateLinkMetadata(ctx, userID, "team", "42b3e75f", metadata))
+
+	reopened, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	link, err := reopened.GetLink(ctx, userID, "42b3e75f")
+	require.NoError(t, err)
+	assert.Equal(t, metadata, link.Metadata)
+}
+
+func TestDeleteJobs(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "short-url-db.json")
+	ctx := context.Background()
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	storage, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	pending := entity.DeleteJob{
+		ID:            "9bb86afd-62da-43af-81f2-f37400820a2d",
+		UserID:        userID,
+		ShortURLs:     []string{"42b3e75f"},
+		Status:        entity.DeleteJobPending,
+		NextAttemptAt: createdAt,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+	require.NoError(t, storage.SaveDeleteJob(ctx, pending))
+
+	done := pending
+	done.ID = "0f5a4e42-5d9b-4c4e-9f3c-4d6e2fb3c7aa"
+	require.NoError(t, storage.SaveDeleteJob(ctx, done))
+	done.Status = entity.DeleteJobDone
+	done.Attempts = 1
+	require.NoError(t, storage.UpdateDeleteJob(ctx, done))
+
+	_, err = storage.Compact(ctx)
+	require.NoError(t, err)
+
+	reopened, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	jobs, err := reopened.ClaimPendingDeleteJobs(ctx, createdAt.Add(-time.Second), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	claimed := pending
+	claimed.NextAttemptAt = createdAt.Add(time.Minute)
+
+	jobs, err = reopened.ClaimPendingDeleteJobs(ctx, createdAt, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.DeleteJob{claimed}, jobs)
+
+	// claimed job isn't returned until its lease expires
+	jobs, err = reopened.ClaimPendingDeleteJobs(ctx, createdAt, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	jobs, err = reopened.ClaimPendingDeleteJobs(ctx, claimed.NextAttemptAt, time.Minute, 10)
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+
+	job, err := reopened.GetDeleteJob(ctx, userID, done.ID)
+	require.NoError(t, err)
+	assert.Equal(t, done, job)
+
+	_, err = reopened.GetDeleteJob(ctx, "0c0a4811-4f10-487f-bde3-e39a14af7cd8", done.ID)
+	assert.ErrorIs(t, err, api.ErrDeleteJobNotFound)
+
+	// state of job saved after erasure of its user isn't kept and doesn't restore job on loading
+	_, err = reopened.EraseUser(ctx, userID)
+	require.NoError(t, err)
+
+	claimed.Attempts = 1
+	assert.ErrorIs(t, reopened.UpdateDeleteJob(ctx, claimed), api.ErrDeleteJobNotFound)
+
+	reopened, err = NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	_, err = reopened.GetDeleteJob(ctx, "", pending.ID)
+	assert.ErrorIs(t, err, api.ErrDeleteJobNotFound)
+}
+
+func TestTrash(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "short-url-db.json")
+	ctx := context.Background()
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	storage, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	for i, shortURL := range []string{"42b3e75f", "77fca595", "ac6bb669"} {
+		err = storage.SaveLink(ctx, entity.Link{
+			ShortURL:    shortURL,
+			OriginalURL: "https://practicum.yandex.ru/" + shortURL,
+			UserID:      userID,
+			CreatedAt:   createdAt.Add(time.Duration(i) * time.Second),
+		})
+		require.NoError(t, err)
+	}
+
+	before := time.Now().UTC().Add(-time.Second)
+	require.NoError(t, storage.DeleteBatchURL(ctx, entity.DeletedURLBatch{
+		{UserID: userID.String(), ShortURL: "42b3e75f"},
+		{UserID: userID.String(), ShortURL: "77fca595"},
+	}))
+
+	links, err := storage.ListDeletedLinks(ctx, userID, before)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.False(t, links[0].DeletedAt.Before(before))
+
+	links, err = storage.ListDeletedLinks(ctx, "0c0a4811-4f10-487f-bde3-e39a14af7cd8", before)
+	require.NoError(t, err)
+	assert.Empty(t, links)
+
+	restored, err := storage.RestoreLinks(ctx, userID, []string{"42b3e75f", "ac6bb669", "unknown"}, before)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"42b3e75f"}, restored)
+
+	// retention of deleted link is expired
+	restored, err = storage.RestoreLinks(ctx, userID, []string{"77fca595"}, time.Now().UTC().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+
+	purged, err := storage.PurgeDeletedLinks(ctx, time.Now().UTC().Add(time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, purged, 1)
+	assert.Equal(t, "77fca595", purged[0].ShortURL)
+
+	reopened, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	link, err := reopened.GetLink(ctx, "", "42b3e75f")
+	require.NoError(t, err)
+	assert.False(t, link.Deleted)
+	assert.True(t, link.DeletedAt.IsZero())
+
+	_, err = reopened.GetLink(ctx, "", "77fca595")
+	assert.ErrorIs(t, err, api.ErrShortURLNotFound)
+
+	stat, err := reopened.GetStatistic(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, models.CountStatistic{URLCount: 2, UserCount: 1}, stat)
+}
+
+func TestWebhooks(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "short-url-db.json")
+	ctx := context.Background()
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	storage, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	webhook := entity.Webhook{
+		ID:        "9bb86afd-62da-43af-81f2-f37400820a2d",
+		UserID:    userID,
+		URL:       "https://example.com/hook",
+		Secret:    "secret",
+		Events:    []string{entity.EventLinkCreated},
+		CreatedAt: createdAt,
+	}
+	require.NoError(t, storage.SaveWebhook(ctx, webhook))
+
+	deleted := webhook
+	deleted.ID = "0f5a4e42-5d9b-4c4e-9f3c-4d6e2fb3c7aa"
+	require.NoError(t, storage.SaveWebhook(ctx, deleted))
+
+	delivery := entity.WebhookDelivery{
+		ID:            "5d1b7a0e-3b8f-4c55-9d0e-7b7c1c2f6d11",
+		WebhookID:     webhook.ID,
+		UserID:        userID,
+		Event:         entity.EventLinkCreated,
+		Payload:       []byte(`{"type":"link.created"}`),
+		Status:        entity.WebhookDeliveryPending,
+		NextAttemptAt: createdAt,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+	require.NoError(t, storage.SaveWebhookDelivery(ctx, delivery))
+
+	orphan := delivery
+	orphan.ID = "a3c2f1d4-6e5b-4a7c-8d9e-0f1a2b3c4d5e"
+	orphan.WebhookID = deleted.ID
+	require.NoError(t, storage.SaveWebhookDelivery(ctx, orphan))
+	require.NoError(t, storage.DeleteWebhook(ctx, userID, deleted.ID))
+
+	delivery.Status = entity.WebhookDeliveryDelivered
+	delivery.Attempts = 1
+	delivery.ResponseCode = 200
+	require.NoError(t, storage.SaveWebhookDelivery(ctx, delivery))
+
+	_, err = storage.Compact(ctx)
+	require.NoError(t, err)
+
+	reopened, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	webhooks, err := reopened.ListWebhooks(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.Webhook{webhook}, webhooks)
+
+	found, err := reopened.GetWebhookDelivery(ctx, userID, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, delivery, found)
+
+	_, err = reopened.GetWebhookDelivery(ctx, userID, orphan.ID)
+	assert.ErrorIs(t, err, api.ErrWebhookDeliveryNotFound)
+
+	pending, err := reopened.ListPendingWebhookDeliveries(ctx, createdAt, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	err = reopened.DeleteWebhook(ctx, "0c0a4811-4f10-487f-bde3-e39a14af7cd8", webhook.ID)
+	assert.ErrorIs(t, err, api.ErrWebhookNotFound)
+}
+
+func TestAuditTrail(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "short-url-db.json")
+	ctx := context.Background()
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	storage, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	created := entity.AuditRecord{
+		ID:        "1",
+		ActorType: entity.AuditActorUser,
+		Actor:     "ac2a4811-4f10-487f-bde3-e39a14af7cd8",
+		Action:    "link.create",
+		Targets:   []string{"aHR0cHM6"},
+		ClientIP:  "192.168.1.14",
+		Result:    "success",
+		CreatedAt: createdAt,
+	}
+	deleted := created
+	deleted.ID = "2"
+	deleted.Action = "link.delete"
+	deleted.Targets = []string{"aHR0cHM6", "bG9jYWxo"}
+	deleted.CreatedAt = createdAt.Add(time.Minute)
+	disabled := entity.AuditRecord{
+		ID:        "3",
+		ActorType: entity.AuditActorAdmin,
+		Actor:     "alice",
+		Action:    "admin.link.disable",
+		Targets:   []string{"bG9jYWxo"},
+		Result:    "success",
+		CreatedAt: createdAt.Add(time.Minute),
+	}
+
+	for _, record := range []entity.AuditRecord{created, deleted, disabled} {
+		require.NoError(t, storage.SaveAuditRecord(ctx, record))
+	}
+
+	_, err = storage.Compact(ctx)
+	require.NoError(t, err)
+
+	reopened, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		filter   entity.AuditFilter
+		limit    int
+		expected []entity.AuditRecord
+	}{
+		{
+			name:     "all records",
+			expected: []entity.AuditRecord{disabled, deleted, created},
+		},
+		{
+			name:     "records of user",
+			filter:   entity.AuditFilter{ActorType: entity.AuditActorUser, Actor: created.Actor},
+			expected: []entity.AuditRecord{deleted, created},
+		},
+		{
+			name:     "records by target",
+			filter:   entity.AuditFilter{Target: "bG9jYWxo"},
+			expected: []entity.AuditRecord{disabled, deleted},
+		},
+		{
+			name:     "records of period",
+			filter:   entity.AuditFilter{From: createdAt, To: createdAt.Add(time.Minute)},
+			expected: []entity.AuditRecord{created},
+		},
+		{
+			name:     "records after cursor",
+			filter:   entity.AuditFilter{After: disabled.Cursor()},
+			limit:    1,
+			expected: []entity.AuditRecord{deleted},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			records, err := reopened.ListAuditRecords(ctx, test.filter, test.limit)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, records)
+		})
+	}
+}
+
+type testQuotaPolicy entity.Quota
+
+func (p testQuotaPolicy) Quota(_ entity.UserID) entity.Quota {
+	return entity.Quota(p)
+}
+
+func TestQuotas(t *testing.T) {
+	ctx := context.Background()
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+
+	storage, err := NewFileStorage(filepath.Join(t.TempDir(), "short-url-db.json"))
+	require.NoError(t, err)
+	storage.SetQuotaPolicy(testQuotaPolicy{
+		MaxActiveLinks:   2,
+		MaxLinksPerDay:   3,
+		MaxBatchSize:     2,
+		MaxTrackedClicks: 1,
+	})
+
+	saveLink := func(shortURL string) error {
+		return storage.SaveLink(ctx, entity.Link{
+			ShortURL:    shortURL,
+			OriginalURL: "https://practicum.yandex.ru/" + shortURL,
+			UserID:      userID,
+			CreatedAt:   time.Now().UTC(),
+		})
+	}
+	requireQuotaError := func(err error, limit string) *api.QuotaError {
+		var quotaErr *api.QuotaError
+		require.ErrorAs(t, err, &quotaErr)
+		assert.Equal(t, limit, quotaErr.Limit)
+
+		return quotaErr
+	}
+
+	_, err = storage.SaveBatchURL(ctx, userID, model.Batch{
+		{ID: "1", InputURL: "https://practicum.yandex.ru/1", ShortURL: "42b3e75f"},
+		{ID: "2", InputURL: "https://practicum.yandex.ru/2", ShortURL: "77fca595"},
+		{ID: "3", InputURL: "https://practicum.yandex.ru/3", ShortURL: "ac6bb669"},
+	})
+	requireQuotaError(err, entity.QuotaBatchSize)
+
+	require.NoError(t, saveLink("42b3e75f"))
+	require.NoError(t, saveLink("77fca595"))
+	requireQuotaError(saveLink("ac6bb669"), entity.QuotaActiveLinks)
+
+	require.NoError(t, storage.RecordClick(ctx, "42b3e75f"))
+	requireQuotaError(storage.RecordClick(ctx, "77fca595"), entity.QuotaTrackedClicks)
+
+	require.NoError(t, storage.DeleteBatchURL(ctx, entity.DeletedURLBatch{{UserID: userID.String(), ShortURL: "77fca595"}}))
+	require.NoError(t, saveLink("ac6bb669"))
+
+	require.NoError(t, storage.DeleteBatchURL(ctx, entity.DeletedURLBatch{{UserID: userID.String(), ShortURL: "ac6bb669"}}))
+	quotaErr := requireQuotaError(saveLink("f1c2a9d4"), entity.QuotaLinksPerDay)
+	assert.Equal(t, entity.QuotaDay(time.Now()).Add(24*time.Hour), quotaErr.ResetAt)
+
+	_, err = storage.RestoreLinks(ctx, userID, []string{"77fca595", "ac6bb669"}, time.Time{})
+	requireQuotaError(err, entity.QuotaActiveLinks)
+
+	restored, err := storage.RestoreLinks(ctx, userID, []string{"77fca595"}, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"77fca595"}, restored)
+
+	usage, err := storage.GetQuotaUsage(ctx, userID, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, entity.QuotaUsage{ActiveLinks: 2, LinksToday: 3, TrackedClicks: 1}, usage)
+}
+
+func TestEraseUser(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "short-url-db.json")
+	ctx := context.Background()
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	otherID := entity.UserID("0c0a4811-4f10-487f-bde3-e39a14af7cd8")
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	storage, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	for _, link := range []entity.Link{
+		{ShortURL: "42b3e75f", OriginalURL: "https://practicum.yandex.ru/", UserID: userID, CreatedAt: createdAt},
+		{ShortURL: "77fca595", OriginalURL: "https://yandex.ru/", UserID: otherID, CreatedAt: createdAt},
+	} {
+		require.NoError(t, storage.SaveLink(ctx, link))
+	}
+
+	job := entity.DeleteJob{
+		ID:        "5d1b7a0e-3b8f-4c55-9d0e-7b7c1c2f6d11",
+		UserID:    userID,
+		ShortURLs: []string{"42b3e75f"},
+		Status:    entity.DeleteJobPending,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+	require.NoError(t, storage.SaveDeleteJob(ctx, job))
+
+	require.NoError(t, storage.SaveWebhook(ctx, entity.Webhook{
+		ID:        "9bb86afd-62da-43af-81f2-f37400820a2d",
+		UserID:    userID,
+		URL:       "https://example.com/hook",
+		CreatedAt: createdAt,
+	}))
+
+	workspace := entity.Workspace{
+		ID:        "0f5a4e42-5d9b-4c4e-9f3c-4d6e2fb3c7aa",
+		Name:      "team",
+		CreatedBy: userID,
+		CreatedAt: createdAt,
+	}
+	require.NoError(t, storage.SaveWorkspace(ctx, workspace))
+	for _, member := range []entity.WorkspaceMember{
+		{WorkspaceID: workspace.ID, UserID: userID, Role: entity.WorkspaceOwner, CreatedAt: createdAt},
+		{WorkspaceID: workspace.ID, UserID: otherID, Role: entity.WorkspaceOwner, CreatedAt: createdAt},
+	} {
+		require.NoError(t, storage.SaveWorkspaceMember(ctx, member))
+	}
+
+	require.NoError(t, storage.SaveAuditRecord(ctx, entity.AuditRecord{
+		ID:        "1",
+		ActorType: entity.AuditActorUser,
+		Actor:     userID.String(),
+		Action:    "link.create",
+		Targets:   []string{"42b3e75f"},
+		ClientIP:  "192.168.1.14",
+		Result:    "success",
+		CreatedAt: createdAt,
+	}))
+
+	erasure, err := storage.EraseUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, models.UserErasure{
+		Links:        1,
+		DeleteJobs:   1,
+		Webhooks:     1,
+		Memberships:  1,
+		Workspaces:   1,
+		AuditRecords: 1,
+	}, erasure)
+
+	erasure, err = storage.EraseUser(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, models.UserErasure{}, erasure)
+
+	reopened, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	_, err = reopened.GetLink(ctx, "", "42b3e75f")
+	assert.ErrorIs(t, err, api.ErrShortURLNotFound)
+
+	link, err := reopened.GetLink(ctx, "", "77fca595")
+	require.NoError(t, err)
+	assert.Equal(t, otherID, link.UserID)
+
+	jobs, err := reopened.ListUserDeleteJobs(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	webhooks, err := reopened.ListWebhooks(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, webhooks)
+
+	found, err := reopened.GetWorkspace(ctx, workspace.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ErasedUserID, found.CreatedBy)
+
+	memberships, err := reopened.ListUserMemberships(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, memberships)
+
+	records, err := reopened.ListAuditRecords(ctx, entity.AuditFilter{}, 0)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, entity.ErasedUserID.String(), records[0].Actor)
+	assert.Empty(t, records[0].ClientIP)
+
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), userID.String())
+}
+
+func TestEraseUserInterrupted(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "short-url-db.json")
+	ctx := context.Background()
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+
+	storage, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	require.NoError(t, storage.SaveLink(ctx, entity.Link{
+		ShortURL:    "42b3e75f",
+		OriginalURL: "https://practicum.yandex.ru/",
+		UserID:      userID,
+	}))
+
+	// erasure record is written, but files aren't rewritten before restart
+	file, err := os.OpenFile(fileName, os.O_WRONLY|os.O_APPEND, 0o666)
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(file).Encode(entity.URLRecord{ID: 2, UserID: userID.String(), Erased: true}))
+	require.NoError(t, file.Close())
+
+	reopened, err := NewFileStorage(fileName)
+	require.NoError(t, err)
+
+	_, err = reopened.GetLink(ctx, "", "42b3e75f")
+	assert.ErrorIs(t, err, api.ErrShortURLNotFound)
+
+	content, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), userID.String())
+}
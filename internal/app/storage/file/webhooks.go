@@ -0,0 +1,235 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// webhooksFileSuffix Suffix of file keeping webhooks and log of their deliveries next to storage file
+const webhooksFileSuffix = ".webhooks"
+
+// SaveWebhook Adds webhook of user to file storage or replaces it
+func (s *FileStorage) SaveWebhook(ctx context.Context, webhook entity.Webhook) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.writeWebhookRecord(entity.WebhookRecord{Webhook: &webhook})
+	if err != nil {
+		return fmt.Errorf("error while saving webhook to file storage: %w", err)
+	}
+
+	s.webhooks.Save(webhook)
+
+	return nil
+}
+
+// GetWebhook Returns webhook of user from file storage
+func (s *FileStorage) GetWebhook(ctx context.Context, userID entity.UserID, webhookID string) (entity.Webhook, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	webhook, ok := s.webhooks.Get(userID, webhookID)
+	if !ok {
+		return entity.Webhook{}, fmt.Errorf("error while getting webhook from file: %w", api.ErrWebhookNotFound)
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks Returns webhooks of user from file storage ordered by creation time
+func (s *FileStorage) ListWebhooks(ctx context.Context, userID entity.UserID) ([]entity.Webhook, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.webhooks.List(userID), nil
+}
+
+// DeleteWebhook Removes webhook of user with its deliveries from file storage
+//
+// Removal is kept in webhooks file as record with deleted flag
+func (s *FileStorage) DeleteWebhook(ctx context.Context, userID entity.UserID, webhookID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	webhook, ok := s.webhooks.Get(userID, webhookID)
+	if !ok {
+		return fmt.Errorf("error while deleting webhook from file: %w", api.ErrWebhookNotFound)
+	}
+
+	err := s.writeWebhookRecord(entity.WebhookRecord{Webhook: &webhook, Deleted: true})
+	if err != nil {
+		return fmt.Errorf("error while deleting webhook from file storage: %w", err)
+	}
+
+	s.webhooks.Delete(userID, webhookID)
+
+	return nil
+}
+
+// SaveWebhookDelivery Adds webhook delivery to log of file storage
+//
+// Every state of delivery is appended to webhooks file, the last one is used on loading.
+// Returns ErrWebhookNotFound error if webhook of delivery is removed
+func (s *FileStorage) SaveWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.webhooks.Get(delivery.UserID, delivery.WebhookID); !ok {
+		return fmt.Errorf("error while saving webhook delivery to file storage: %w", api.ErrWebhookNotFound)
+	}
+
+	err := s.writeWebhookRecord(entity.WebhookRecord{Delivery: &delivery})
+	if err != nil {
+		return fmt.Errorf("error while saving webhook delivery to file storage: %w", err)
+	}
+
+	s.webhooks.SaveDelivery(delivery)
+
+	return nil
+}
+
+// UpdateWebhookDelivery Saves the next state of webhook delivery kept in log of file storage
+//
+// State isn't appended to webhooks file if delivery is removed, so it isn't restored on loading.
+// Returns ErrWebhookDeliveryNotFound error if delivery is removed with its webhook or by erasure of its user
+func (s *FileStorage) UpdateWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.webhooks.GetDelivery(delivery.UserID, delivery.ID); !ok {
+		return fmt.Errorf("error while updating webhook delivery in file storage: %w", api.ErrWebhookDeliveryNotFound)
+	}
+
+	err := s.writeWebhookRecord(entity.WebhookRecord{Delivery: &delivery})
+	if err != nil {
+		return fmt.Errorf("error while updating webhook delivery in file storage: %w", err)
+	}
+
+	s.webhooks.UpdateDelivery(delivery)
+
+	return nil
+}
+
+// GetWebhookDelivery Returns webhook delivery of user from file storage
+func (s *FileStorage) GetWebhookDelivery(ctx context.Context, userID entity.UserID, deliveryID string) (entity.WebhookDelivery, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	delivery, ok := s.webhooks.GetDelivery(userID, deliveryID)
+	if !ok {
+		return entity.WebhookDelivery{}, fmt.Errorf("error while getting webhook delivery from file: %w", api.ErrWebhookDeliveryNotFound)
+	}
+
+	return delivery, nil
+}
+
+// ListWebhookDeliveries Returns deliveries of webhook of user from file storage, the most recently created first
+func (s *FileStorage) ListWebhookDeliveries(
+	ctx context.Context,
+	userID entity.UserID,
+	webhookID string,
+	limit int,
+) ([]entity.WebhookDelivery, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.webhooks.Deliveries(userID, webhookID, limit), nil
+}
+
+// ListPendingWebhookDeliveries Returns pending webhook deliveries due before given time from file storage
+func (s *FileStorage) ListPendingWebhookDeliveries(ctx context.Context, before time.Time, limit int) ([]entity.WebhookDelivery, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.webhooks.PendingDeliveries(before, limit), nil
+}
+
+func (s *FileStorage) writeWebhookRecord(record entity.WebhookRecord) error {
+	if s.webhooksFile == nil {
+		return api.ErrFileStorageNotOpen
+	}
+
+	err := s.webhooksEncoder.Encode(&record)
+	if err != nil {
+		return fmt.Errorf("error while encoding webhook record for file commit: %w", err)
+	}
+	s.webhooksFile.Sync()
+
+	return nil
+}
+
+// openWebhooks Opens webhooks file next to storage file and loads webhooks with the last states of deliveries
+func (s *FileStorage) openWebhooks() error {
+	file, err := os.OpenFile(s.fileName+webhooksFileSuffix, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record entity.WebhookRecord
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("error while decoding webhook record from file: %w", err)
+		}
+
+		switch {
+		case record.Webhook != nil && record.Deleted:
+			s.webhooks.Delete(record.Webhook.UserID, record.Webhook.ID)
+		case record.Webhook != nil:
+			s.webhooks.Save(*record.Webhook)
+		case record.Delivery != nil:
+			s.webhooks.SaveDelivery(*record.Delivery)
+		}
+	}
+
+	if scanner.Err() != nil {
+		file.Close()
+		return fmt.Errorf("error while reading webhooks file: %w", scanner.Err())
+	}
+
+	s.webhooksFile = file
+	s.webhooksEncoder = json.NewEncoder(file)
+
+	return nil
+}
+
+// writeWebhooks Writes webhooks and the last states of their deliveries
+func writeWebhooks(w io.Writer, webhooks []entity.Webhook, deliveries []entity.WebhookDelivery) error {
+	buf := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buf)
+
+	for _, webhook := range webhooks {
+		err := encoder.Encode(&entity.WebhookRecord{Webhook: &webhook})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, delivery := range deliveries {
+		err := encoder.Encode(&entity.WebhookRecord{Delivery: &delivery})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := buf.Flush()
+	if err != nil {
+		return err
+	}
+
+	if file, ok := w.(*os.File); ok {
+		err = file.Sync()
+	}
+
+	return err
+}
@@ -0,0 +1,279 @@
+package file
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// workspacesFileSuffix Suffix of file keeping workspaces with their members and invites next to storage file
+const workspacesFileSuffix = ".workspaces"
+
+// SaveWorkspace Adds workspace to file storage or replaces it
+func (s *FileStorage) SaveWorkspace(ctx context.Context, workspace entity.Workspace) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.writeWorkspaceRecord(entity.WorkspaceRecord{Workspace: &workspace})
+	if err != nil {
+		return fmt.Errorf("error while saving workspace to file storage: %w", err)
+	}
+
+	s.workspaces.Save(workspace)
+
+	return nil
+}
+
+// GetWorkspace Returns workspace from file storage
+func (s *FileStorage) GetWorkspace(ctx context.Context, workspaceID string) (entity.Workspace, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	workspace, ok := s.workspaces.Get(workspaceID)
+	if !ok {
+		return entity.Workspace{}, fmt.Errorf("error while getting workspace from file: %w", api.ErrWorkspaceNotFound)
+	}
+
+	return workspace, nil
+}
+
+// GetWorkspaceLink Returns link of workspace from file storage
+func (s *FileStorage) GetWorkspaceLink(ctx context.Context, workspaceID, shortURL string) (entity.Link, error) {
+	s.mutex.RLock()
+	link, ok := s.cache.GetLink(shortURL)
+	s.mutex.RUnlock()
+
+	if !ok || link.WorkspaceID != workspaceID {
+		return entity.Link{}, fmt.Errorf("error while getting workspace link from file: %w", api.ErrShortURLNotFound)
+	}
+
+	return link, nil
+}
+
+// ListWorkspaceLinks Returns links of workspace placed after cursor ordered by creation time and short URL
+func (s *FileStorage) ListWorkspaceLinks(
+	ctx context.Context,
+	workspaceID string,
+	after entity.LinkCursor,
+	limit int,
+) ([]entity.Link, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.cache.WorkspaceLinks(workspaceID, after, limit), nil
+}
+
+// SaveWorkspaceMember Adds member of workspace to file storage or replaces its role
+func (s *FileStorage) SaveWorkspaceMember(ctx context.Context, member entity.WorkspaceMember) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.writeWorkspaceRecord(entity.WorkspaceRecord{Member: &member})
+	if err != nil {
+		return fmt.Errorf("error while saving workspace member to file storage: %w", err)
+	}
+
+	s.workspaces.SaveMember(member)
+
+	return nil
+}
+
+// GetWorkspaceMember Returns membership of user in workspace from file storage
+func (s *FileStorage) GetWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) (entity.WorkspaceMember, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	member, ok := s.workspaces.GetMember(workspaceID, userID)
+	if !ok {
+		return entity.WorkspaceMember{}, fmt.Errorf("error while getting workspace member from file: %w", api.ErrWorkspaceMemberNotFound)
+	}
+
+	return member, nil
+}
+
+// ListWorkspaceMembers Returns members of workspace from file storage ordered by time of joining
+func (s *FileStorage) ListWorkspaceMembers(ctx context.Context, workspaceID string) ([]entity.WorkspaceMember, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.workspaces.Members(workspaceID), nil
+}
+
+// ListUserMemberships Returns memberships of user in workspaces from file storage ordered by time of joining
+func (s *FileStorage) ListUserMemberships(ctx context.Context, userID entity.UserID) ([]entity.WorkspaceMember, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.workspaces.Memberships(userID), nil
+}
+
+// DeleteWorkspaceMember Removes user from workspace in file storage
+//
+// Removal is kept in workspaces file as record with deleted flag
+func (s *FileStorage) DeleteWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	member, ok := s.workspaces.GetMember(workspaceID, userID)
+	if !ok {
+		return fmt.Errorf("error while deleting workspace member from file: %w", api.ErrWorkspaceMemberNotFound)
+	}
+
+	err := s.writeWorkspaceRecord(entity.WorkspaceRecord{Member: &member, Deleted: true})
+	if err != nil {
+		return fmt.Errorf("error while deleting workspace member from file storage: %w", err)
+	}
+
+	s.workspaces.DeleteMember(workspaceID, userID)
+
+	return nil
+}
+
+// SaveWorkspaceInvite Adds workspace invite to file storage
+func (s *FileStorage) SaveWorkspaceInvite(ctx context.Context, invite entity.WorkspaceInvite) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err := s.writeWorkspaceRecord(entity.WorkspaceRecord{Invite: &invite})
+	if err != nil {
+		return fmt.Errorf("error while saving workspace invite to file storage: %w", err)
+	}
+
+	s.workspaces.SaveInvite(invite)
+
+	return nil
+}
+
+// GetWorkspaceInvite Returns workspace invite from file storage
+func (s *FileStorage) GetWorkspaceInvite(ctx context.Context, inviteID string) (entity.WorkspaceInvite, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	invite, ok := s.workspaces.GetInvite(inviteID)
+	if !ok {
+		return entity.WorkspaceInvite{}, fmt.Errorf("error while getting workspace invite from file: %w", api.ErrWorkspaceInviteNotFound)
+	}
+
+	return invite, nil
+}
+
+// DeleteWorkspaceInvite Removes workspace invite from file storage
+//
+// Removal is kept in workspaces file as record with deleted flag
+func (s *FileStorage) DeleteWorkspaceInvite(ctx context.Context, inviteID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	invite, ok := s.workspaces.GetInvite(inviteID)
+	if !ok {
+		return fmt.Errorf("error while deleting workspace invite from file: %w", api.ErrWorkspaceInviteNotFound)
+	}
+
+	err := s.writeWorkspaceRecord(entity.WorkspaceRecord{Invite: &invite, Deleted: true})
+	if err != nil {
+		return fmt.Errorf("error while deleting workspace invite from file storage: %w", err)
+	}
+
+	s.workspaces.DeleteInvite(inviteID)
+
+	return nil
+}
+
+func (s *FileStorage) writeWorkspaceRecord(record entity.WorkspaceRecord) error {
+	if s.workspacesFile == nil {
+		return api.ErrFileStorageNotOpen
+	}
+
+	err := s.workspacesEncoder.Encode(&record)
+	if err != nil {
+		return fmt.Errorf("error while encoding workspace record for file commit: %w", err)
+	}
+	s.workspacesFile.Sync()
+
+	return nil
+}
+
+// openWorkspaces Opens workspaces file next to storage file and loads workspaces with their members and invites
+func (s *FileStorage) openWorkspaces() error {
+	file, err := os.OpenFile(s.fileName+workspacesFileSuffix, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record entity.WorkspaceRecord
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("error while decoding workspace record from file: %w", err)
+		}
+
+		switch {
+		case record.Workspace != nil:
+			s.workspaces.Save(*record.Workspace)
+		case record.Member != nil && record.Deleted:
+			s.workspaces.DeleteMember(record.Member.WorkspaceID, record.Member.UserID)
+		case record.Member != nil:
+			s.workspaces.SaveMember(*record.Member)
+		case record.Invite != nil && record.Deleted:
+			s.workspaces.DeleteInvite(record.Invite.ID)
+		case record.Invite != nil:
+			s.workspaces.SaveInvite(*record.Invite)
+		}
+	}
+
+	if scanner.Err() != nil {
+		file.Close()
+		return fmt.Errorf("error while reading workspaces file: %w", scanner.Err())
+	}
+
+	s.workspacesFile = file
+	s.workspacesEncoder = json.NewEncoder(file)
+
+	return nil
+}
+
+// writeWorkspaces Writes workspaces with their current members and invites
+func writeWorkspaces(w io.Writer, workspaces []entity.Workspace, members []entity.WorkspaceMember, invites []entity.WorkspaceInvite) error {
+	buf := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buf)
+
+	for _, workspace := range workspaces {
+		err := encoder.Encode(&entity.WorkspaceRecord{Workspace: &workspace})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, member := range members {
+		err := encoder.Encode(&entity.WorkspaceRecord{Member: &member})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, invite := range invites {
+		err := encoder.Encode(&entity.WorkspaceRecord{Invite: &invite})
+		if err != nil {
+			return err
+		}
+	}
+
+	err := buf.Flush()
+	if err != nil {
+		return err
+	}
+
+	if file, ok := w.(*os.File); ok {
+		err = file.Sync()
+	}
+
+	return err
+}
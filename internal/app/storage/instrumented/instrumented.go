@@ -0,0 +1,487 @@
+// Package instrumented contains storage decorator which collects metrics and traces of storage operations
+package instrumented
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/metrics"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	"github.com/avGenie/url-shortener/internal/app/tracing"
+)
+
+// Storage Storage decorator collecting latency and child span of every operation
+type Storage struct {
+	storage model.Storage
+	backend string
+}
+
+// NewStorage Creates instrumented storage object
+func NewStorage(storage model.Storage, backend string) *Storage {
+	return &Storage{
+		storage: storage,
+		backend: backend,
+	}
+}
+
+// Close Closes decorated storage
+func (s *Storage) Close() {
+	s.storage.Close()
+}
+
+// PingServer Pings decorated storage
+func (s *Storage) PingServer(ctx context.Context) (err error) {
+	ctx, op := s.start(ctx, "PingServer")
+	defer op.end(&err)
+
+	return s.storage.PingServer(ctx)
+}
+
+// SaveURL Saves user URL to decorated storage
+func (s *Storage) SaveURL(ctx context.Context, userID entity.UserID, key, value entity.URL) (err error) {
+	ctx, op := s.start(ctx, "SaveURL")
+	defer op.end(&err)
+
+	return s.storage.SaveURL(ctx, userID, key, value)
+}
+
+// SaveBatchURL Saves batch of user URLs to decorated storage
+func (s *Storage) SaveBatchURL(ctx context.Context, userID entity.UserID, batch model.Batch) (_ model.Batch, err error) {
+	ctx, op := s.start(ctx, "SaveBatchURL")
+	defer op.end(&err)
+
+	return s.storage.SaveBatchURL(ctx, userID, batch)
+}
+
+// GetURL Returns user URL from decorated storage
+func (s *Storage) GetURL(ctx context.Context, userID entity.UserID, key entity.URL) (_ *entity.URL, err error) {
+	ctx, op := s.start(ctx, "GetURL")
+	defer op.end(&err)
+
+	return s.storage.GetURL(ctx, userID, key)
+}
+
+// GetAllURLByUserID Returns all user URLs from decorated storage
+func (s *Storage) GetAllURLByUserID(ctx context.Context, userID entity.UserID) (_ models.AllUrlsBatch, err error) {
+	ctx, op := s.start(ctx, "GetAllURLByUserID")
+	defer op.end(&err)
+
+	return s.storage.GetAllURLByUserID(ctx, userID)
+}
+
+// GetStatistic Returns count of users and URLs from decorated storage
+func (s *Storage) GetStatistic(ctx context.Context) (_ models.CountStatistic, err error) {
+	ctx, op := s.start(ctx, "GetStatistic")
+	defer op.end(&err)
+
+	return s.storage.GetStatistic(ctx)
+}
+
+// GetServiceStatistic Returns service statistic from decorated storage
+func (s *Storage) GetServiceStatistic(ctx context.Context, query models.StatisticQuery) (_ models.ServiceStatistic, err error) {
+	ctx, op := s.start(ctx, "GetServiceStatistic")
+	defer op.end(&err)
+
+	return s.storage.GetServiceStatistic(ctx, query)
+}
+
+// SaveLink Saves user link to decorated storage
+func (s *Storage) SaveLink(ctx context.Context, link entity.Link) (err error) {
+	ctx, op := s.start(ctx, "SaveLink")
+	defer op.end(&err)
+
+	return s.storage.SaveLink(ctx, link)
+}
+
+// GetLink Returns link from decorated storage
+func (s *Storage) GetLink(ctx context.Context, userID entity.UserID, shortURL string) (_ entity.Link, err error) {
+	ctx, op := s.start(ctx, "GetLink")
+	defer op.end(&err)
+
+	return s.storage.GetLink(ctx, userID, shortURL)
+}
+
+// ListUserLinks Returns page of user links from decorated storage
+func (s *Storage) ListUserLinks(
+	ctx context.Context,
+	userID entity.UserID,
+	after entity.LinkCursor,
+	limit int,
+) (_ []entity.Link, err error) {
+	ctx, op := s.start(ctx, "ListUserLinks")
+	defer op.end(&err)
+
+	return s.storage.ListUserLinks(ctx, userID, after, limit)
+}
+
+// RecordClick Records redirect by link in decorated storage
+func (s *Storage) RecordClick(ctx context.Context, shortURL string) (err error) {
+	ctx, op := s.start(ctx, "RecordClick")
+	defer op.end(&err)
+
+	return s.storage.RecordClick(ctx, shortURL)
+}
+
+// GetQuotaUsage Returns usage of user quota from decorated storage
+func (s *Storage) GetQuotaUsage(ctx context.Context, userID entity.UserID, now time.Time) (_ entity.QuotaUsage, err error) {
+	ctx, op := s.start(ctx, "GetQuotaUsage")
+	defer op.end(&err)
+
+	return s.storage.GetQuotaUsage(ctx, userID, now)
+}
+
+// SetLinkDisabled Changes disabled state of link in decorated storage
+func (s *Storage) SetLinkDisabled(ctx context.Context, shortURL string, disabled bool) (err error) {
+	ctx, op := s.start(ctx, "SetLinkDisabled")
+	defer op.end(&err)
+
+	return s.storage.SetLinkDisabled(ctx, shortURL, disabled)
+}
+
+// UpdateLinkMetadata Replaces metadata of link of user in workspace in decorated storage
+func (s *Storage) UpdateLinkMetadata(
+	ctx context.Context,
+	userID entity.UserID,
+	workspaceID, shortURL string,
+	metadata map[string]string,
+) (err error) {
+	ctx, op := s.start(ctx, "UpdateLinkMetadata")
+	defer op.end(&err)
+
+	return s.storage.UpdateLinkMetadata(ctx, userID, workspaceID, shortURL, metadata)
+}
+
+// Compact Compacts decorated storage
+//
+// Returns ErrCompactionNotSupported error if decorated storage doesn't support compaction
+func (s *Storage) Compact(ctx context.Context) (_ models.CompactionResult, err error) {
+	compactor, ok := s.storage.(model.Compactor)
+	if !ok {
+		return models.CompactionResult{}, api.ErrCompactionNotSupported
+	}
+
+	ctx, op := s.start(ctx, "Compact")
+	defer op.end(&err)
+
+	return compactor.Compact(ctx)
+}
+
+// DeleteBatchURL Deletes user URLs from decorated storage
+func (s *Storage) DeleteBatchURL(ctx context.Context, urls entity.DeletedURLBatch) (err error) {
+	ctx, op := s.start(ctx, "DeleteBatchURL")
+	defer op.end(&err)
+
+	return s.storage.DeleteBatchURL(ctx, urls)
+}
+
+// ListDeletedLinks Returns links of user deleted after given time from decorated storage
+func (s *Storage) ListDeletedLinks(ctx context.Context, userID entity.UserID, deletedAfter time.Time) (_ []entity.Link, err error) {
+	ctx, op := s.start(ctx, "ListDeletedLinks")
+	defer op.end(&err)
+
+	return s.storage.ListDeletedLinks(ctx, userID, deletedAfter)
+}
+
+// RestoreLinks Restores links of user deleted after given time in decorated storage
+func (s *Storage) RestoreLinks(
+	ctx context.Context,
+	userID entity.UserID,
+	shortURLs []string,
+	deletedAfter time.Time,
+) (_ []string, err error) {
+	ctx, op := s.start(ctx, "RestoreLinks")
+	defer op.end(&err)
+
+	return s.storage.RestoreLinks(ctx, userID, shortURLs, deletedAfter)
+}
+
+// PurgeDeletedLinks Removes links deleted before given time from decorated storage
+func (s *Storage) PurgeDeletedLinks(ctx context.Context, deletedBefore time.Time, limit int) (_ []entity.Link, err error) {
+	ctx, op := s.start(ctx, "PurgeDeletedLinks")
+	defer op.end(&err)
+
+	return s.storage.PurgeDeletedLinks(ctx, deletedBefore, limit)
+}
+
+// SaveDeleteJob Saves deletion job to outbox of decorated storage
+func (s *Storage) SaveDeleteJob(ctx context.Context, job entity.DeleteJob) (err error) {
+	ctx, op := s.start(ctx, "SaveDeleteJob")
+	defer op.end(&err)
+
+	return s.storage.SaveDeleteJob(ctx, job)
+}
+
+// UpdateDeleteJob Saves the next state of deletion job in outbox of decorated storage
+func (s *Storage) UpdateDeleteJob(ctx context.Context, job entity.DeleteJob) (err error) {
+	ctx, op := s.start(ctx, "UpdateDeleteJob")
+	defer op.end(&err)
+
+	return s.storage.UpdateDeleteJob(ctx, job)
+}
+
+// GetDeleteJob Returns deletion job of user from decorated storage
+func (s *Storage) GetDeleteJob(ctx context.Context, userID entity.UserID, jobID string) (_ entity.DeleteJob, err error) {
+	ctx, op := s.start(ctx, "GetDeleteJob")
+	defer op.end(&err)
+
+	return s.storage.GetDeleteJob(ctx, userID, jobID)
+}
+
+// ClaimPendingDeleteJobs Claims pending deletion jobs in decorated storage
+func (s *Storage) ClaimPendingDeleteJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) (_ []entity.DeleteJob, err error) {
+	ctx, op := s.start(ctx, "ClaimPendingDeleteJobs")
+	defer op.end(&err)
+
+	return s.storage.ClaimPendingDeleteJobs(ctx, now, lease, limit)
+}
+
+// ListUserDeleteJobs Returns deletion jobs of user from decorated storage
+func (s *Storage) ListUserDeleteJobs(ctx context.Context, userID entity.UserID) (_ []entity.DeleteJob, err error) {
+	ctx, op := s.start(ctx, "ListUserDeleteJobs")
+	defer op.end(&err)
+
+	return s.storage.ListUserDeleteJobs(ctx, userID)
+}
+
+// SaveWebhook Saves webhook of user to decorated storage
+func (s *Storage) SaveWebhook(ctx context.Context, webhook entity.Webhook) (err error) {
+	ctx, op := s.start(ctx, "SaveWebhook")
+	defer op.end(&err)
+
+	return s.storage.SaveWebhook(ctx, webhook)
+}
+
+// GetWebhook Returns webhook of user from decorated storage
+func (s *Storage) GetWebhook(ctx context.Context, userID entity.UserID, webhookID string) (_ entity.Webhook, err error) {
+	ctx, op := s.start(ctx, "GetWebhook")
+	defer op.end(&err)
+
+	return s.storage.GetWebhook(ctx, userID, webhookID)
+}
+
+// ListWebhooks Returns webhooks of user from decorated storage
+func (s *Storage) ListWebhooks(ctx context.Context, userID entity.UserID) (_ []entity.Webhook, err error) {
+	ctx, op := s.start(ctx, "ListWebhooks")
+	defer op.end(&err)
+
+	return s.storage.ListWebhooks(ctx, userID)
+}
+
+// DeleteWebhook Removes webhook of user from decorated storage
+func (s *Storage) DeleteWebhook(ctx context.Context, userID entity.UserID, webhookID string) (err error) {
+	ctx, op := s.start(ctx, "DeleteWebhook")
+	defer op.end(&err)
+
+	return s.storage.DeleteWebhook(ctx, userID, webhookID)
+}
+
+// SaveWebhookDelivery Saves webhook delivery to log of decorated storage
+func (s *Storage) SaveWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) (err error) {
+	ctx, op := s.start(ctx, "SaveWebhookDelivery")
+	defer op.end(&err)
+
+	return s.storage.SaveWebhookDelivery(ctx, delivery)
+}
+
+// UpdateWebhookDelivery Saves the next state of webhook delivery in log of decorated storage
+func (s *Storage) UpdateWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) (err error) {
+	ctx, op := s.start(ctx, "UpdateWebhookDelivery")
+	defer op.end(&err)
+
+	return s.storage.UpdateWebhookDelivery(ctx, delivery)
+}
+
+// GetWebhookDelivery Returns webhook delivery of user from decorated storage
+func (s *Storage) GetWebhookDelivery(ctx context.Context, userID entity.UserID, deliveryID string) (_ entity.WebhookDelivery, err error) {
+	ctx, op := s.start(ctx, "GetWebhookDelivery")
+	defer op.end(&err)
+
+	return s.storage.GetWebhookDelivery(ctx, userID, deliveryID)
+}
+
+// ListWebhookDeliveries Returns deliveries of webhook of user from decorated storage
+func (s *Storage) ListWebhookDeliveries(
+	ctx context.Context,
+	userID entity.UserID,
+	webhookID string,
+	limit int,
+) (_ []entity.WebhookDelivery, err error) {
+	ctx, op := s.start(ctx, "ListWebhookDeliveries")
+	defer op.end(&err)
+
+	return s.storage.ListWebhookDeliveries(ctx, userID, webhookID, limit)
+}
+
+// ListPendingWebhookDeliveries Returns pending webhook deliveries from decorated storage
+func (s *Storage) ListPendingWebhookDeliveries(ctx context.Context, before time.Time, limit int) (_ []entity.WebhookDelivery, err error) {
+	ctx, op := s.start(ctx, "ListPendingWebhookDeliveries")
+	defer op.end(&err)
+
+	return s.storage.ListPendingWebhookDeliveries(ctx, before, limit)
+}
+
+// SaveWorkspace Saves workspace to decorated storage
+func (s *Storage) SaveWorkspace(ctx context.Context, workspace entity.Workspace) (err error) {
+	ctx, op := s.start(ctx, "SaveWorkspace")
+	defer op.end(&err)
+
+	return s.storage.SaveWorkspace(ctx, workspace)
+}
+
+// GetWorkspace Returns workspace from decorated storage
+func (s *Storage) GetWorkspace(ctx context.Context, workspaceID string) (_ entity.Workspace, err error) {
+	ctx, op := s.start(ctx, "GetWorkspace")
+	defer op.end(&err)
+
+	return s.storage.GetWorkspace(ctx, workspaceID)
+}
+
+// GetWorkspaceLink Returns link of workspace from decorated storage
+func (s *Storage) GetWorkspaceLink(ctx context.Context, workspaceID, shortURL string) (_ entity.Link, err error) {
+	ctx, op := s.start(ctx, "GetWorkspaceLink")
+	defer op.end(&err)
+
+	return s.storage.GetWorkspaceLink(ctx, workspaceID, shortURL)
+}
+
+// ListWorkspaceLinks Returns page of links of workspace from decorated storage
+func (s *Storage) ListWorkspaceLinks(
+	ctx context.Context,
+	workspaceID string,
+	after entity.LinkCursor,
+	limit int,
+) (_ []entity.Link, err error) {
+	ctx, op := s.start(ctx, "ListWorkspaceLinks")
+	defer op.end(&err)
+
+	return s.storage.ListWorkspaceLinks(ctx, workspaceID, after, limit)
+}
+
+// SaveWorkspaceMember Saves member of workspace to decorated storage
+func (s *Storage) SaveWorkspaceMember(ctx context.Context, member entity.WorkspaceMember) (err error) {
+	ctx, op := s.start(ctx, "SaveWorkspaceMember")
+	defer op.end(&err)
+
+	return s.storage.SaveWorkspaceMember(ctx, member)
+}
+
+// GetWorkspaceMember Returns membership of user in workspace from decorated storage
+func (s *Storage) GetWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) (_ entity.WorkspaceMember, err error) {
+	ctx, op := s.start(ctx, "GetWorkspaceMember")
+	defer op.end(&err)
+
+	return s.storage.GetWorkspaceMember(ctx, workspaceID, userID)
+}
+
+// ListWorkspaceMembers Returns members of workspace from decorated storage
+func (s *Storage) ListWorkspaceMembers(ctx context.Context, workspaceID string) (_ []entity.WorkspaceMember, err error) {
+	ctx, op := s.start(ctx, "ListWorkspaceMembers")
+	defer op.end(&err)
+
+	return s.storage.ListWorkspaceMembers(ctx, workspaceID)
+}
+
+// ListUserMemberships Returns memberships of user in workspaces from decorated storage
+func (s *Storage) ListUserMemberships(ctx context.Context, userID entity.UserID) (_ []entity.WorkspaceMember, err error) {
+	ctx, op := s.start(ctx, "ListUserMemberships")
+	defer op.end(&err)
+
+	return s.storage.ListUserMemberships(ctx, userID)
+}
+
+// DeleteWorkspaceMember Removes user from workspace in decorated storage
+func (s *Storage) DeleteWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) (err error) {
+	ctx, op := s.start(ctx, "DeleteWorkspaceMember")
+	defer op.end(&err)
+
+	return s.storage.DeleteWorkspaceMember(ctx, workspaceID, userID)
+}
+
+// SaveWorkspaceInvite Saves workspace invite to decorated storage
+func (s *Storage) SaveWorkspaceInvite(ctx context.Context, invite entity.WorkspaceInvite) (err error) {
+	ctx, op := s.start(ctx, "SaveWorkspaceInvite")
+	defer op.end(&err)
+
+	return s.storage.SaveWorkspaceInvite(ctx, invite)
+}
+
+// GetWorkspaceInvite Returns workspace invite from decorated storage
+func (s *Storage) GetWorkspaceInvite(ctx context.Context, inviteID string) (_ entity.WorkspaceInvite, err error) {
+	ctx, op := s.start(ctx, "GetWorkspaceInvite")
+	defer op.end(&err)
+
+	return s.storage.GetWorkspaceInvite(ctx, inviteID)
+}
+
+// DeleteWorkspaceInvite Removes workspace invite from decorated storage
+func (s *Storage) DeleteWorkspaceInvite(ctx context.Context, inviteID string) (err error) {
+	ctx, op := s.start(ctx, "DeleteWorkspaceInvite")
+	defer op.end(&err)
+
+	return s.storage.DeleteWorkspaceInvite(ctx, inviteID)
+}
+
+// SaveAuditRecord Appends record to audit trail of decorated storage
+func (s *Storage) SaveAuditRecord(ctx context.Context, record entity.AuditRecord) (err error) {
+	ctx, op := s.start(ctx, "SaveAuditRecord")
+	defer op.end(&err)
+
+	return s.storage.SaveAuditRecord(ctx, record)
+}
+
+// ListAuditRecords Returns records satisfying filter from audit trail of decorated storage
+func (s *Storage) ListAuditRecords(ctx context.Context, filter entity.AuditFilter, limit int) (_ []entity.AuditRecord, err error) {
+	ctx, op := s.start(ctx, "ListAuditRecords")
+	defer op.end(&err)
+
+	return s.storage.ListAuditRecords(ctx, filter, limit)
+}
+
+// EraseUser Erases all data of user from decorated storage
+func (s *Storage) EraseUser(ctx context.Context, userID entity.UserID) (_ models.UserErasure, err error) {
+	ctx, op := s.start(ctx, "EraseUser")
+	defer op.end(&err)
+
+	return s.storage.EraseUser(ctx, userID)
+}
+
+// operation Contains state of instrumented storage operation
+type operation struct {
+	backend string
+	method  string
+	start   time.Time
+	span    trace.Span
+}
+
+func (s *Storage) start(ctx context.Context, method string) (context.Context, *operation) {
+	ctx, span := tracing.Tracer().Start(
+		ctx,
+		"storage."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", s.backend)),
+	)
+
+	return ctx, &operation{
+		backend: s.backend,
+		method:  method,
+		start:   time.Now(),
+		span:    span,
+	}
+}
+
+func (o *operation) end(err *error) {
+	metrics.ObserveStorageOperation(o.backend, o.method, *err, time.Since(o.start))
+
+	if *err != nil {
+		o.span.RecordError(*err)
+		o.span.SetStatus(codes.Error, (*err).Error())
+	}
+	o.span.End()
+}
@@ -0,0 +1,95 @@
+package local
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// AuditTrail Append-only trail of audit records
+type AuditTrail struct {
+	records []entity.AuditRecord
+}
+
+// NewAuditTrail Creates empty audit trail
+func NewAuditTrail() *AuditTrail {
+	return &AuditTrail{}
+}
+
+// Append Adds record to the end of audit trail
+func (a *AuditTrail) Append(record entity.AuditRecord) {
+	record.Targets = append([]string(nil), record.Targets...)
+	a.records = append(a.records, record)
+}
+
+// Anonymize Replaces ID of user in actors and targets of records by ErasedUserID
+//
+// Client IP is removed from records of actions of user. Returns count of changed records
+func (a *AuditTrail) Anonymize(userID entity.UserID) int {
+	count := 0
+	for i, record := range a.records {
+		anonymized, ok := anonymizeRecord(record, userID)
+		if ok {
+			a.records[i] = anonymized
+			count++
+		}
+	}
+
+	return count
+}
+
+// All Returns all records in order of appending
+func (a *AuditTrail) All() []entity.AuditRecord {
+	return append([]entity.AuditRecord(nil), a.records...)
+}
+
+// List Returns records satisfying filter, the newest first
+func (a *AuditTrail) List(filter entity.AuditFilter, limit int) []entity.AuditRecord {
+	records := make([]entity.AuditRecord, 0)
+	for _, record := range a.records {
+		if filter.Match(record) {
+			records = append(records, record)
+		}
+	}
+
+	sort.Slice(records, func(i, k int) bool {
+		if records[i].CreatedAt.Equal(records[k].CreatedAt) {
+			return records[i].ID > records[k].ID
+		}
+
+		return records[i].CreatedAt.After(records[k].CreatedAt)
+	})
+
+	if limit > 0 && len(records) > limit {
+		records = records[:limit]
+	}
+
+	return records
+}
+
+// anonymizeRecord Returns record with ID of user replaced by ErasedUserID and false if record doesn't contain ID of user
+func anonymizeRecord(record entity.AuditRecord, userID entity.UserID) (entity.AuditRecord, bool) {
+	changed := false
+	if record.ActorType == entity.AuditActorUser && record.Actor == userID.String() {
+		record.Actor = entity.ErasedUserID.String()
+		record.ClientIP = ""
+		changed = true
+	}
+
+	if slices.Contains(record.Targets, userID.String()) {
+		targets := make([]string, 0, len(record.Targets))
+		for _, target := range record.Targets {
+			if target == userID.String() {
+				target = entity.ErasedUserID.String()
+			}
+
+			targets = append(targets, target)
+		}
+
+		record.Targets = targets
+		changed = true
+	}
+
+	return record, changed
+}
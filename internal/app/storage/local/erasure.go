@@ -0,0 +1,49 @@
+package local
+
+import (
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+)
+
+// EraseUser Erases data of user from links, deletion jobs, webhooks and workspaces and anonymizes user in audit trail
+//
+// Links created by user in workspaces with other owners are transferred to the earliest joined owner
+// before links of user are removed. Erasure is idempotent, erasing of user without data returns zero counts
+func EraseUser(
+	userID entity.UserID,
+	urls *LocalStorage,
+	jobs *DeleteJobs,
+	webhooks *Webhooks,
+	workspaces *Workspaces,
+	audit *AuditTrail,
+) models.UserErasure {
+	var erasure models.UserErasure
+
+	erasure.TransferredLinks = transferWorkspaceLinks(userID, urls, workspaces)
+	erasure.Links = urls.EraseUser(userID)
+	erasure.DeleteJobs = jobs.EraseUser(userID)
+	erasure.Webhooks, erasure.WebhookDeliveries = webhooks.EraseUser(userID)
+	erasure.Memberships, erasure.Invites, erasure.Workspaces = workspaces.EraseUser(userID)
+	erasure.AuditRecords = audit.Anonymize(userID)
+
+	return erasure
+}
+
+// transferWorkspaceLinks Transfers links created by user in workspaces to their other owners
+//
+// Returns count of transferred links
+func transferWorkspaceLinks(userID entity.UserID, urls *LocalStorage, workspaces *Workspaces) int {
+	count := 0
+	for shortURL, link := range urls.GetAllLinks() {
+		if link.UserID != userID || link.WorkspaceID == "" {
+			continue
+		}
+
+		owner, ok := workspaces.Successor(link.WorkspaceID, userID)
+		if ok && urls.TransferLink(shortURL, owner) {
+			count++
+		}
+	}
+
+	return count
+}
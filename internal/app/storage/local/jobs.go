@@ -0,0 +1,132 @@
+package local
+
+import (
+	"sort"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// DeleteJobs Outbox of deletion jobs by job ID
+type DeleteJobs struct {
+	jobs map[string]entity.DeleteJob
+}
+
+// NewDeleteJobs Creates outbox of deletion jobs
+func NewDeleteJobs() *DeleteJobs {
+	return &DeleteJobs{
+		jobs: make(map[string]entity.DeleteJob),
+	}
+}
+
+// Save Adds deletion job or replaces its previous state
+func (j *DeleteJobs) Save(job entity.DeleteJob) {
+	job.ShortURLs = append([]string(nil), job.ShortURLs...)
+	j.jobs[job.ID] = job
+}
+
+// Update Replaces state of deletion job kept in outbox
+//
+// Returns false if job is not found, e.g. it is removed by erasure of its user
+func (j *DeleteJobs) Update(job entity.DeleteJob) bool {
+	if _, ok := j.jobs[job.ID]; !ok {
+		return false
+	}
+
+	j.Save(job)
+
+	return true
+}
+
+// Get Returns deletion job of user
+//
+// Job of any user is returned if user id is empty
+func (j *DeleteJobs) Get(userID entity.UserID, jobID string) (entity.DeleteJob, bool) {
+	job, ok := j.jobs[jobID]
+	if !ok || (userID.IsValid() && job.UserID != userID) {
+		return entity.DeleteJob{}, false
+	}
+
+	return job, true
+}
+
+// Claim Returns pending jobs due at given time ordered by time of the next attempt
+// and postpones their next attempt until end of lease
+func (j *DeleteJobs) Claim(now, leaseUntil time.Time, limit int) []entity.DeleteJob {
+	jobs := make([]entity.DeleteJob, 0)
+	for _, job := range j.jobs {
+		if job.IsDue(now) {
+			jobs = append(jobs, job)
+		}
+	}
+
+	sort.Slice(jobs, func(i, k int) bool {
+		if jobs[i].NextAttemptAt.Equal(jobs[k].NextAttemptAt) {
+			return jobs[i].CreatedAt.Before(jobs[k].CreatedAt)
+		}
+
+		return jobs[i].NextAttemptAt.Before(jobs[k].NextAttemptAt)
+	})
+
+	if limit > 0 && len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+
+	for i := range jobs {
+		jobs[i].NextAttemptAt = leaseUntil
+		j.jobs[jobs[i].ID] = jobs[i]
+	}
+
+	return jobs
+}
+
+// UserJobs Returns deletion jobs of user ordered by creation time
+func (j *DeleteJobs) UserJobs(userID entity.UserID) []entity.DeleteJob {
+	jobs := make([]entity.DeleteJob, 0)
+	for _, job := range j.jobs {
+		if job.UserID == userID {
+			jobs = append(jobs, job)
+		}
+	}
+
+	sortJobs(jobs)
+
+	return jobs
+}
+
+// EraseUser Removes deletion jobs of user
+//
+// Returns count of removed jobs
+func (j *DeleteJobs) EraseUser(userID entity.UserID) int {
+	count := 0
+	for id, job := range j.jobs {
+		if job.UserID == userID {
+			delete(j.jobs, id)
+			count++
+		}
+	}
+
+	return count
+}
+
+// All Returns all deletion jobs ordered by creation time
+func (j *DeleteJobs) All() []entity.DeleteJob {
+	jobs := make([]entity.DeleteJob, 0, len(j.jobs))
+	for _, job := range j.jobs {
+		jobs = append(jobs, job)
+	}
+
+	sortJobs(jobs)
+
+	return jobs
+}
+
+func sortJobs(jobs []entity.DeleteJob) {
+	sort.Slice(jobs, func(i, k int) bool {
+		if jobs[i].CreatedAt.Equal(jobs[k].CreatedAt) {
+			return jobs[i].ID < jobs[k].ID
+		}
+
+		return jobs[i].CreatedAt.Before(jobs[k].CreatedAt)
+	})
+}
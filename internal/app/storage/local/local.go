@@ -1,16 +1,28 @@
 package local
 
-import "github.com/avGenie/url-shortener/internal/app/entity"
+import (
+	"sort"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+)
 
 // LocalStorage Local storage object
 type LocalStorage struct {
-	urls map[entity.URL]entity.URL
+	urls  map[entity.URL]entity.URL
+	users map[entity.UserID]struct{}
+	links map[string]entity.Link
+	stats linkStats
 }
 
 // NewLocalStorage Creates local storage object
 func NewLocalStorage(size int) *LocalStorage {
 	return &LocalStorage{
-		urls: make(map[entity.URL]entity.URL, size),
+		urls:  make(map[entity.URL]entity.URL, size),
+		users: make(map[entity.UserID]struct{}),
+		links: make(map[string]entity.Link, size),
+		stats: newLinkStats(),
 	}
 }
 
@@ -26,14 +38,383 @@ func (s *LocalStorage) GetAllURL() map[entity.URL]entity.URL {
 	return s.urls
 }
 
+// GetAllLinks Returns all links by short URL
+func (s *LocalStorage) GetAllLinks() map[string]entity.Link {
+	return s.links
+}
+
 // Add Adds the given value under the specified key to local storage
 func (s *LocalStorage) Add(key, value entity.URL) {
 	s.urls[key] = value
 }
 
+// AddLink Adds link to local storage
+func (s *LocalStorage) AddLink(link entity.Link) error {
+	key, err := entity.NewURL(link.ShortURL)
+	if err != nil {
+		return err
+	}
+
+	value, err := entity.NewURL(link.OriginalURL)
+	if err != nil {
+		return err
+	}
+
+	s.urls[*key] = *value
+	s.putLink(link)
+	s.AddUser(link.UserID)
+
+	return nil
+}
+
+// GetLink Returns link from local storage by short URL
+func (s *LocalStorage) GetLink(shortURL string) (entity.Link, bool) {
+	link, ok := s.links[shortURL]
+
+	return link, ok
+}
+
+// DeleteLink Marks link of user as deleted at deletion time
+//
+// Returns false if link is not found for user or it is already deleted
+func (s *LocalStorage) DeleteLink(userID entity.UserID, shortURL string, deletedAt time.Time) bool {
+	link, ok := s.links[shortURL]
+	if !ok || link.UserID != userID || link.Deleted {
+		return false
+	}
+
+	link.Deleted = true
+	link.DeletedAt = deletedAt
+	s.putLink(link)
+
+	return true
+}
+
+// RestoreLink Restores link of user deleted after given time
+//
+// Returns false if link is not found for user, it is not deleted or it is deleted before given time
+func (s *LocalStorage) RestoreLink(userID entity.UserID, shortURL string, deletedAfter time.Time) bool {
+	link, ok := s.links[shortURL]
+	if !ok || !isRestorable(link, userID, deletedAfter) {
+		return false
+	}
+
+	link.Deleted = false
+	link.DeletedAt = time.Time{}
+	s.putLink(link)
+
+	return true
+}
+
+// CountRestorable Returns count of distinct links of user which are restored by RestoreLink
+func (s *LocalStorage) CountRestorable(userID entity.UserID, shortURLs []string, deletedAfter time.Time) int {
+	restorable := make(map[string]struct{}, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		link, ok := s.links[shortURL]
+		if ok && isRestorable(link, userID, deletedAfter) {
+			restorable[shortURL] = struct{}{}
+		}
+	}
+
+	return len(restorable)
+}
+
+// RemoveLink Removes link with its counters from local storage
+//
+// Returns false if link is not found
+func (s *LocalStorage) RemoveLink(shortURL string) bool {
+	link, ok := s.links[shortURL]
+	if !ok {
+		return false
+	}
+
+	s.stats.account(link, -1)
+	s.stats.addClicks(link, -s.stats.clicks[shortURL])
+	delete(s.links, shortURL)
+	delete(s.stats.clicks, shortURL)
+
+	if key, err := entity.NewURL(shortURL); err == nil {
+		delete(s.urls, *key)
+	}
+
+	return true
+}
+
+// TransferLink Changes owner of link keeping its clicks
+//
+// Returns false if link is not found
+func (s *LocalStorage) TransferLink(shortURL string, userID entity.UserID) bool {
+	link, ok := s.links[shortURL]
+	if !ok {
+		return false
+	}
+
+	clicks := s.stats.clicks[shortURL]
+	s.stats.addClicks(link, -clicks)
+
+	link.UserID = userID
+	s.putLink(link)
+	s.stats.addClicks(link, clicks)
+	s.AddUser(userID)
+
+	return true
+}
+
+// EraseUser Removes all links of user with their counters and forgets user
+//
+// Returns count of removed links
+func (s *LocalStorage) EraseUser(userID entity.UserID) int {
+	count := 0
+	for shortURL, link := range s.links {
+		if link.UserID == userID && s.RemoveLink(shortURL) {
+			count++
+		}
+	}
+
+	delete(s.users, userID)
+
+	return count
+}
+
+// DeletedLinks Returns links of user deleted after given time ordered from the most recently deleted
+func (s *LocalStorage) DeletedLinks(userID entity.UserID, deletedAfter time.Time) []entity.Link {
+	var links []entity.Link
+	for _, link := range s.links {
+		if link.UserID == userID && link.Deleted && !link.DeletedAt.Before(deletedAfter) {
+			links = append(links, link)
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].DeletedAt.Equal(links[j].DeletedAt) {
+			return links[i].ShortURL < links[j].ShortURL
+		}
+
+		return links[i].DeletedAt.After(links[j].DeletedAt)
+	})
+
+	return links
+}
+
+// ExpiredLinks Returns links deleted before given time ordered from the earliest deleted
+func (s *LocalStorage) ExpiredLinks(deletedBefore time.Time, limit int) []entity.Link {
+	var links []entity.Link
+	for _, link := range s.links {
+		if link.Deleted && link.DeletedAt.Before(deletedBefore) {
+			links = append(links, link)
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].DeletedAt.Equal(links[j].DeletedAt) {
+			return links[i].ShortURL < links[j].ShortURL
+		}
+
+		return links[i].DeletedAt.Before(links[j].DeletedAt)
+	})
+
+	if len(links) > limit {
+		links = links[:limit]
+	}
+
+	return links
+}
+
+// SetDisabled Changes disabled state of link
+//
+// Returns false if link is not found
+func (s *LocalStorage) SetDisabled(shortURL string, disabled bool) bool {
+	link, ok := s.links[shortURL]
+	if !ok {
+		return false
+	}
+
+	link.Disabled = disabled
+	s.putLink(link)
+
+	return true
+}
+
+// SetMetadata Replaces metadata of link
+//
+// Returns false if link is not found
+func (s *LocalStorage) SetMetadata(shortURL string, metadata map[string]string) bool {
+	link, ok := s.links[shortURL]
+	if !ok {
+		return false
+	}
+
+	link.Metadata = metadata
+	s.putLink(link)
+
+	return true
+}
+
+// AddClick Increments count of redirects by link
+//
+// Returns false if link is not found or deleted
+func (s *LocalStorage) AddClick(shortURL string) bool {
+	link, ok := s.links[shortURL]
+	if !ok || link.Deleted {
+		return false
+	}
+
+	s.stats.addClicks(link, 1)
+
+	return true
+}
+
+// TopLinks Returns active links with the largest count of redirects
+func (s *LocalStorage) TopLinks(limit int) []models.LinkClicks {
+	res := make([]models.LinkClicks, 0, len(s.stats.clicks))
+	for shortURL, clicks := range s.stats.clicks {
+		link, ok := s.links[shortURL]
+		if !ok || link.Deleted {
+			continue
+		}
+
+		res = append(res, models.LinkClicks{
+			ShortURL:    shortURL,
+			OriginalURL: link.OriginalURL,
+			Clicks:      clicks,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Clicks == res[j].Clicks {
+			return res[i].ShortURL < res[j].ShortURL
+		}
+
+		return res[i].Clicks > res[j].Clicks
+	})
+
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res
+}
+
+// UserLinks Returns user links placed after cursor ordered by creation time and short URL
+func (s *LocalStorage) UserLinks(userID entity.UserID, after entity.LinkCursor, limit int) []entity.Link {
+	var links []entity.Link
+	for _, link := range s.links {
+		if link.UserID == userID && link.IsAfter(after) {
+			links = append(links, link)
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		return links[j].IsAfter(links[i].Cursor())
+	})
+
+	if len(links) > limit {
+		links = links[:limit]
+	}
+
+	return links
+}
+
+// WorkspaceLinks Returns links of workspace placed after cursor ordered by creation time and short URL
+func (s *LocalStorage) WorkspaceLinks(workspaceID string, after entity.LinkCursor, limit int) []entity.Link {
+	var links []entity.Link
+	for _, link := range s.links {
+		if link.WorkspaceID == workspaceID && link.IsAfter(after) {
+			links = append(links, link)
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		return links[j].IsAfter(links[i].Cursor())
+	})
+
+	if len(links) > limit {
+		links = links[:limit]
+	}
+
+	return links
+}
+
+// Usage Returns usage of quota by user at given time
+func (s *LocalStorage) Usage(userID entity.UserID, now time.Time) entity.QuotaUsage {
+	return s.stats.usage(userID, now)
+}
+
+// CountNewLinks Returns count of links of input storage which become active links in local storage after merge
+func (s *LocalStorage) CountNewLinks(inputStorage LocalStorage) int {
+	count := 0
+	for shortURL := range inputStorage.links {
+		if link, ok := s.links[shortURL]; !ok || link.Deleted {
+			count++
+		}
+	}
+
+	return count
+}
+
+// AddUser Adds the user who saved URLs to local storage
+func (s *LocalStorage) AddUser(userID entity.UserID) {
+	if !userID.IsValid() {
+		return
+	}
+
+	s.users[userID] = struct{}{}
+}
+
+// Statistic Returns count of active URLs, deleted URLs and users in local storage
+func (s *LocalStorage) Statistic() models.CountStatistic {
+	return models.CountStatistic{
+		URLCount:     s.stats.active,
+		UserCount:    len(s.users),
+		DeletedCount: s.stats.deleted,
+	}
+}
+
+// ServiceStatistic Returns statistic of local storage built from counters
+//
+// Storage size is approximate size of links data in memory
+func (s *LocalStorage) ServiceStatistic(query models.StatisticQuery) models.ServiceStatistic {
+	return models.ServiceStatistic{
+		CountStatistic: s.Statistic(),
+		StorageSize:    s.stats.size,
+		Created:        s.stats.createdSeries(query),
+		TopDomains:     s.stats.topDomains(query.Top),
+		TopLinks:       s.TopLinks(query.Top),
+	}
+}
+
 // Merge Adds the given value under the specified key to local storage
 func (s *LocalStorage) Merge(inputStorage LocalStorage) {
 	for key, value := range inputStorage.urls {
 		s.urls[key] = value
 	}
+
+	for _, link := range inputStorage.links {
+		s.putLink(link)
+	}
+
+	for shortURL, clicks := range inputStorage.stats.clicks {
+		if link, ok := s.links[shortURL]; ok {
+			s.stats.addClicks(link, clicks)
+		}
+	}
+
+	for userID := range inputStorage.users {
+		s.users[userID] = struct{}{}
+	}
+}
+
+// isRestorable Returns true if link is deleted by user after given time
+func isRestorable(link entity.Link, userID entity.UserID, deletedAfter time.Time) bool {
+	return link.UserID == userID && link.Deleted && !link.DeletedAt.Before(deletedAfter)
+}
+
+// putLink Adds or replaces link and updates counters
+func (s *LocalStorage) putLink(link entity.Link) {
+	if old, ok := s.links[link.ShortURL]; ok {
+		s.stats.account(old, -1)
+	}
+
+	s.links[link.ShortURL] = link
+	s.stats.account(link, 1)
 }
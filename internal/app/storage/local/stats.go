@@ -0,0 +1,139 @@
+package local
+
+import (
+	"sort"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+)
+
+// linkStats Counters of links updated on every change of local storage
+//
+// Links creation is counted per hour, so statistic of any range is built without scanning all links.
+// Usage of user quotas is counted per user, links created by user are counted per UTC day
+type linkStats struct {
+	active  int
+	deleted int
+	size    int64
+	created map[int64]int
+	domains map[string]int
+	clicks  map[string]int64
+
+	userActive  map[entity.UserID]int
+	userCreated map[userDay]int
+	userClicks  map[entity.UserID]int64
+}
+
+// userDay Key of count of links created by user during UTC day
+type userDay struct {
+	userID entity.UserID
+	day    int64
+}
+
+func newLinkStats() linkStats {
+	return linkStats{
+		created:     make(map[int64]int),
+		domains:     make(map[string]int),
+		clicks:      make(map[string]int64),
+		userActive:  make(map[entity.UserID]int),
+		userCreated: make(map[userDay]int),
+		userClicks:  make(map[entity.UserID]int64),
+	}
+}
+
+// account Adds link to counters if sign is positive or removes it if sign is negative
+func (s *linkStats) account(link entity.Link, sign int) {
+	if link.Deleted {
+		s.deleted += sign
+	} else {
+		s.active += sign
+		addCount(s.domains, link.Domain(), sign)
+		addCount(s.userActive, link.UserID, sign)
+	}
+
+	addCount(s.created, link.CreatedAt.UTC().Truncate(time.Hour).Unix(), sign)
+	addCount(s.userCreated, userDay{userID: link.UserID, day: entity.QuotaDay(link.CreatedAt).Unix()}, sign)
+	s.size += int64(sign) * linkSize(link)
+}
+
+// addClicks Adds count of redirects by link to counters of link and its user
+func (s *linkStats) addClicks(link entity.Link, clicks int64) {
+	s.clicks[link.ShortURL] += clicks
+	s.userClicks[link.UserID] += clicks
+	if s.userClicks[link.UserID] == 0 {
+		delete(s.userClicks, link.UserID)
+	}
+}
+
+// usage Returns usage of quota by user at given time
+func (s *linkStats) usage(userID entity.UserID, now time.Time) entity.QuotaUsage {
+	return entity.QuotaUsage{
+		ActiveLinks:   s.userActive[userID],
+		LinksToday:    s.userCreated[userDay{userID: userID, day: entity.QuotaDay(now).Unix()}],
+		TrackedClicks: s.userClicks[userID],
+	}
+}
+
+// createdSeries Returns counts of links created in query range per buckets of query granularity
+func (s *linkStats) createdSeries(query models.StatisticQuery) []models.TimeCount {
+	buckets := make(map[time.Time]int)
+	for hour, count := range s.created {
+		createdAt := time.Unix(hour, 0).UTC()
+		if createdAt.Before(query.Truncate(query.From)) || !createdAt.Before(query.To) {
+			continue
+		}
+
+		buckets[query.Truncate(createdAt)] += count
+	}
+
+	res := make([]models.TimeCount, 0, len(buckets))
+	for bucket, count := range buckets {
+		res = append(res, models.TimeCount{Time: bucket, Count: count})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Time.Before(res[j].Time)
+	})
+
+	return res
+}
+
+// topDomains Returns domains with the largest count of active links
+func (s *linkStats) topDomains(limit int) []models.DomainCount {
+	res := make([]models.DomainCount, 0, len(s.domains))
+	for domain, count := range s.domains {
+		res = append(res, models.DomainCount{Domain: domain, Count: count})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Count == res[j].Count {
+			return res[i].Domain < res[j].Domain
+		}
+
+		return res[i].Count > res[j].Count
+	})
+
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res
+}
+
+func addCount[K comparable](counts map[K]int, key K, delta int) {
+	counts[key] += delta
+	if counts[key] == 0 {
+		delete(counts, key)
+	}
+}
+
+// linkSize Returns approximate size of link data in bytes
+func linkSize(link entity.Link) int64 {
+	size := len(link.ShortURL) + len(link.OriginalURL) + len(link.UserID)
+	for key, value := range link.Metadata {
+		size += len(key) + len(value)
+	}
+
+	return int64(size)
+}
@@ -0,0 +1,25 @@
+package local
+
+import (
+	"context"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// SaveAuditRecord Appends record to audit trail of local storage
+func (s *TSLocalStorage) SaveAuditRecord(ctx context.Context, record entity.AuditRecord) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.audit.Append(record)
+
+	return nil
+}
+
+// ListAuditRecords Returns records satisfying filter from audit trail of local storage, the newest first
+func (s *TSLocalStorage) ListAuditRecords(ctx context.Context, filter entity.AuditFilter, limit int) ([]entity.AuditRecord, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.audit.List(filter, limit), nil
+}
@@ -0,0 +1,27 @@
+package local
+
+import (
+	"context"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+)
+
+// ListUserDeleteJobs Returns deletion jobs of user from local storage ordered by creation time
+func (s *TSLocalStorage) ListUserDeleteJobs(ctx context.Context, userID entity.UserID) ([]entity.DeleteJob, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.jobs.UserJobs(userID), nil
+}
+
+// EraseUser Erases all data of user from local storage
+//
+// Links, deletion jobs, webhooks, memberships and invites of user are removed,
+// workspaces created by user and audit records of user are anonymized
+func (s *TSLocalStorage) EraseUser(ctx context.Context, userID entity.UserID) (models.UserErasure, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return EraseUser(userID, &s.urls, s.jobs, s.webhooks, s.workspaces, s.audit), nil
+}
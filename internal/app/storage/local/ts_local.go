@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/avGenie/url-shortener/internal/app/entity"
 	"github.com/avGenie/url-shortener/internal/app/models"
@@ -16,14 +17,23 @@ import (
 type TSLocalStorage struct {
 	model.Storage
 
-	urls  LocalStorage
-	mutex sync.RWMutex
+	urls       LocalStorage
+	jobs       *DeleteJobs
+	webhooks   *Webhooks
+	workspaces *Workspaces
+	audit      *AuditTrail
+	quotas     model.QuotaPolicy
+	mutex      sync.RWMutex
 }
 
 // NewTSLocalStorage Creates thread save local storage object
 func NewTSLocalStorage(size int) *TSLocalStorage {
 	return &TSLocalStorage{
-		urls: *NewLocalStorage(size),
+		urls:       *NewLocalStorage(size),
+		jobs:       NewDeleteJobs(),
+		webhooks:   NewWebhooks(),
+		workspaces: NewWorkspaces(),
+		audit:      NewAuditTrail(),
 	}
 }
 
@@ -31,12 +41,21 @@ func NewTSLocalStorage(size int) *TSLocalStorage {
 func (s *TSLocalStorage) GetURL(ctx context.Context, userID entity.UserID, key entity.URL) (*entity.URL, error) {
 	s.mutex.RLock()
 	res, ok := s.urls.Get(key)
+	link, _ := s.urls.GetLink(key.String())
 	s.mutex.RUnlock()
 
 	if !ok {
 		return nil, fmt.Errorf("error while getting url from ts local storage: %w", api.ErrShortURLNotFound)
 	}
 
+	if link.Deleted {
+		return nil, fmt.Errorf("error while getting url from ts local storage: %w", api.ErrAllURLsDeleted)
+	}
+
+	if link.Disabled {
+		return nil, fmt.Errorf("error while getting url from ts local storage: %w", api.ErrURLDisabled)
+	}
+
 	return &res, nil
 }
 
@@ -44,10 +63,15 @@ func (s *TSLocalStorage) GetURL(ctx context.Context, userID entity.UserID, key e
 func (s *TSLocalStorage) GetAllURLByUserID(ctx context.Context, userID entity.UserID) (models.AllUrlsBatch, error) {
 	s.mutex.RLock()
 	urls := s.urls.GetAllURL()
+	links := s.urls.GetAllLinks()
 	s.mutex.RUnlock()
 
 	var allURLs models.AllUrlsBatch
 	for key, value := range urls {
+		if link, ok := links[key.String()]; ok && link.Deleted {
+			continue
+		}
+
 		allURLs = append(allURLs, models.AllUrlsResponse{
 			ShortURL:    key.String(),
 			OriginalURL: value.String(),
@@ -57,7 +81,25 @@ func (s *TSLocalStorage) GetAllURLByUserID(ctx context.Context, userID entity.Us
 	return allURLs, nil
 }
 
+// SetQuotaPolicy Sets policy of user quotas enforced by local storage
+func (s *TSLocalStorage) SetQuotaPolicy(policy model.QuotaPolicy) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.quotas = policy
+}
+
+// GetQuotaUsage Returns usage of user quota at given time from local storage
+func (s *TSLocalStorage) GetQuotaUsage(ctx context.Context, userID entity.UserID, now time.Time) (entity.QuotaUsage, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.urls.Usage(userID, now), nil
+}
+
 // SaveURL Saves user URL to local storage
+//
+// Returns QuotaError error if user exceeded quota of links
 func (s *TSLocalStorage) SaveURL(ctx context.Context, userID entity.UserID, key, value entity.URL) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -67,32 +109,294 @@ func (s *TSLocalStorage) SaveURL(ctx context.Context, userID entity.UserID, key,
 		return fmt.Errorf("error while save url to ts local storage: %w", api.ErrURLAlreadyExists)
 	}
 
-	s.urls.Add(key, value)
+	createdAt := time.Now().UTC()
+	err := model.CheckLinksQuota(model.QuotaOf(s.quotas, userID), s.urls.Usage(userID, createdAt), 1, createdAt)
+	if err != nil {
+		return fmt.Errorf("error while save url to ts local storage: %w", err)
+	}
 
-	return nil
+	return s.urls.AddLink(entity.Link{
+		ShortURL:    key.String(),
+		OriginalURL: value.String(),
+		UserID:      userID,
+		CreatedAt:   createdAt,
+	})
 }
 
 // SaveBatchURL Saves batch of user URLs to local storage
+//
+// Batch is saved entirely or isn't saved at all if user exceeded quota of batch size or links.
+// Returns QuotaError error if user exceeded quota
 func (s *TSLocalStorage) SaveBatchURL(ctx context.Context, userID entity.UserID, batch model.Batch) (model.Batch, error) {
+	s.mutex.RLock()
+	quota := model.QuotaOf(s.quotas, userID)
+	s.mutex.RUnlock()
+
+	err := model.CheckBatchQuota(quota, len(batch))
+	if err != nil {
+		return nil, fmt.Errorf("error while save batch to ts local storage: %w", err)
+	}
+
 	localUrls := NewLocalStorage(len(batch))
+	createdAt := time.Now().UTC()
 	for _, obj := range batch {
-		key, err := entity.NewURL(obj.ShortURL)
+		err := localUrls.AddLink(entity.Link{
+			ShortURL:    obj.ShortURL,
+			OriginalURL: obj.InputURL,
+			UserID:      userID,
+			CreatedAt:   createdAt,
+		})
 		if err != nil {
-			return nil, fmt.Errorf("exit to create input url from batch in local storage: %w", err)
+			return nil, fmt.Errorf("exit to add url from batch to local storage: %w", err)
 		}
-		value, err := entity.NewURL(obj.InputURL)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	err = model.CheckLinksQuota(quota, s.urls.Usage(userID, createdAt), s.urls.CountNewLinks(*localUrls), createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("error while save batch to ts local storage: %w", err)
+	}
+
+	s.urls.Merge(*localUrls)
+
+	return batch, nil
+}
+
+// SaveLink Saves user link to local storage
+//
+// Returns QuotaError error if user exceeded quota of links
+func (s *TSLocalStorage) SaveLink(ctx context.Context, link entity.Link) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, ok := s.urls.GetLink(link.ShortURL)
+	if ok {
+		return fmt.Errorf("error while save link to ts local storage: %w", api.ErrURLAlreadyExists)
+	}
+
+	err := model.CheckLinksQuota(model.QuotaOf(s.quotas, link.UserID), s.urls.Usage(link.UserID, link.CreatedAt), 1, link.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error while save link to ts local storage: %w", err)
+	}
+
+	return s.urls.AddLink(link)
+}
+
+// GetLink Returns link from local storage
+//
+// Link of any user is returned if user id is empty
+func (s *TSLocalStorage) GetLink(ctx context.Context, userID entity.UserID, shortURL string) (entity.Link, error) {
+	s.mutex.RLock()
+	link, ok := s.urls.GetLink(shortURL)
+	s.mutex.RUnlock()
+
+	if !ok || (userID.IsValid() && link.UserID != userID) {
+		return entity.Link{}, fmt.Errorf("error while getting link from ts local storage: %w", api.ErrShortURLNotFound)
+	}
+
+	return link, nil
+}
+
+// ListUserLinks Returns user links placed after cursor ordered by creation time and short URL
+func (s *TSLocalStorage) ListUserLinks(
+	ctx context.Context,
+	userID entity.UserID,
+	after entity.LinkCursor,
+	limit int,
+) ([]entity.Link, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.urls.UserLinks(userID, after, limit), nil
+}
+
+// DeleteBatchURL Marks user URLs as deleted in local storage
+//
+// Deleted URLs are moved to trash of user
+func (s *TSLocalStorage) DeleteBatchURL(ctx context.Context, urls entity.DeletedURLBatch) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	deletedAt := time.Now().UTC()
+	for _, url := range urls {
+		s.urls.DeleteLink(entity.UserID(url.UserID), url.ShortURL, deletedAt)
+	}
+
+	return nil
+}
+
+// ListDeletedLinks Returns links of user deleted after given time from local storage
+func (s *TSLocalStorage) ListDeletedLinks(ctx context.Context, userID entity.UserID, deletedAfter time.Time) ([]entity.Link, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.urls.DeletedLinks(userID, deletedAfter), nil
+}
+
+// RestoreLinks Restores links of user deleted after given time in local storage
+//
+// Links aren't restored if user exceeded quota of active links by them.
+// Returns short URLs of restored links
+func (s *TSLocalStorage) RestoreLinks(
+	ctx context.Context,
+	userID entity.UserID,
+	shortURLs []string,
+	deletedAfter time.Time,
+) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	count := s.urls.CountRestorable(userID, shortURLs, deletedAfter)
+	err := model.CheckRestoreQuota(model.QuotaOf(s.quotas, userID), s.urls.Usage(userID, time.Now().UTC()), count)
+	if err != nil {
+		return nil, fmt.Errorf("error while restoring links in ts local storage: %w", err)
+	}
+
+	restored := make([]string, 0, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		if s.urls.RestoreLink(userID, shortURL, deletedAfter) {
+			restored = append(restored, shortURL)
+		}
+	}
+
+	return restored, nil
+}
+
+// PurgeDeletedLinks Removes up to limit links deleted before given time from local storage
+//
+// Returns removed links
+func (s *TSLocalStorage) PurgeDeletedLinks(ctx context.Context, deletedBefore time.Time, limit int) ([]entity.Link, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	expired := s.urls.ExpiredLinks(deletedBefore, limit)
+	for _, link := range expired {
+		s.urls.RemoveLink(link.ShortURL)
+	}
+
+	return expired, nil
+}
+
+// SaveDeleteJob Adds deletion job to outbox of local storage
+func (s *TSLocalStorage) SaveDeleteJob(ctx context.Context, job entity.DeleteJob) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.jobs.Save(job)
+
+	return nil
+}
+
+// UpdateDeleteJob Saves the next state of deletion job kept in outbox of local storage
+//
+// Returns ErrDeleteJobNotFound error if job is removed, e.g. by erasure of its user
+func (s *TSLocalStorage) UpdateDeleteJob(ctx context.Context, job entity.DeleteJob) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.jobs.Update(job) {
+		return fmt.Errorf("error while updating delete job in ts local storage: %w", api.ErrDeleteJobNotFound)
+	}
+
+	return nil
+}
+
+// GetDeleteJob Returns deletion job of user from local storage
+//
+// Job of any user is returned if user id is empty
+func (s *TSLocalStorage) GetDeleteJob(ctx context.Context, userID entity.UserID, jobID string) (entity.DeleteJob, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	job, ok := s.jobs.Get(userID, jobID)
+	if !ok {
+		return entity.DeleteJob{}, fmt.Errorf("error while getting delete job from ts local storage: %w", api.ErrDeleteJobNotFound)
+	}
+
+	return job, nil
+}
+
+// ClaimPendingDeleteJobs Returns pending deletion jobs due at given time from local storage and leases them
+func (s *TSLocalStorage) ClaimPendingDeleteJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.DeleteJob, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.jobs.Claim(now, now.Add(lease), limit), nil
+}
+
+// GetStatistic Returns count of users and URLs in local storage
+func (s *TSLocalStorage) GetStatistic(ctx context.Context) (models.CountStatistic, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.urls.Statistic(), nil
+}
+
+// GetServiceStatistic Returns statistic of local storage built from incremental counters
+func (s *TSLocalStorage) GetServiceStatistic(ctx context.Context, query models.StatisticQuery) (models.ServiceStatistic, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.urls.ServiceStatistic(query), nil
+}
+
+// RecordClick Increments count of redirects by link in local storage
+//
+// Returns QuotaError error if owner of link exceeded quota of tracked clicks
+func (s *TSLocalStorage) RecordClick(ctx context.Context, shortURL string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if link, ok := s.urls.GetLink(shortURL); ok && !link.Deleted {
+		err := model.CheckClicksQuota(model.QuotaOf(s.quotas, link.UserID), s.urls.Usage(link.UserID, time.Now().UTC()))
 		if err != nil {
-			return nil, fmt.Errorf("exit to create short url from batch in local storage: %w", err)
+			return fmt.Errorf("error while recording click in ts local storage: %w", err)
 		}
+	}
 
-		localUrls.Add(*key, *value)
+	if !s.urls.AddClick(shortURL) {
+		return fmt.Errorf("error while recording click in ts local storage: %w", api.ErrShortURLNotFound)
 	}
 
+	return nil
+}
+
+// SetLinkDisabled Changes disabled state of link of any user in local storage
+func (s *TSLocalStorage) SetLinkDisabled(ctx context.Context, shortURL string, disabled bool) error {
 	s.mutex.Lock()
-	s.urls.Merge(*localUrls)
-	s.mutex.Unlock()
+	defer s.mutex.Unlock()
 
-	return batch, nil
+	if !s.urls.SetDisabled(shortURL, disabled) {
+		return fmt.Errorf("error while changing disabled state in ts local storage: %w", api.ErrShortURLNotFound)
+	}
+
+	return nil
+}
+
+// UpdateLinkMetadata Replaces metadata of link of user in workspace in local storage
+//
+// Empty workspace ID means personal link of user
+func (s *TSLocalStorage) UpdateLinkMetadata(
+	ctx context.Context,
+	userID entity.UserID,
+	workspaceID, shortURL string,
+	metadata map[string]string,
+) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	link, ok := s.urls.GetLink(shortURL)
+	if !ok || link.UserID != userID || link.WorkspaceID != workspaceID {
+		return fmt.Errorf("error while updating link metadata in ts local storage: %w", api.ErrShortURLNotFound)
+	}
+
+	if !s.urls.SetMetadata(shortURL, metadata) {
+		return fmt.Errorf("error while updating link metadata in ts local storage: %w", api.ErrShortURLNotFound)
+	}
+
+	return nil
 }
 
 // PingServer Pings to local storage
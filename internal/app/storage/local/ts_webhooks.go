@@ -0,0 +1,117 @@
+package local
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// SaveWebhook Adds webhook of user to local storage or replaces it
+func (s *TSLocalStorage) SaveWebhook(ctx context.Context, webhook entity.Webhook) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.webhooks.Save(webhook)
+
+	return nil
+}
+
+// GetWebhook Returns webhook of user from local storage
+func (s *TSLocalStorage) GetWebhook(ctx context.Context, userID entity.UserID, webhookID string) (entity.Webhook, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	webhook, ok := s.webhooks.Get(userID, webhookID)
+	if !ok {
+		return entity.Webhook{}, fmt.Errorf("error while getting webhook from ts local storage: %w", api.ErrWebhookNotFound)
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks Returns webhooks of user from local storage ordered by creation time
+func (s *TSLocalStorage) ListWebhooks(ctx context.Context, userID entity.UserID) ([]entity.Webhook, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.webhooks.List(userID), nil
+}
+
+// DeleteWebhook Removes webhook of user with its deliveries from local storage
+func (s *TSLocalStorage) DeleteWebhook(ctx context.Context, userID entity.UserID, webhookID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.webhooks.Delete(userID, webhookID) {
+		return fmt.Errorf("error while deleting webhook from ts local storage: %w", api.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+// SaveWebhookDelivery Adds webhook delivery to log of local storage
+//
+// Returns ErrWebhookNotFound error if webhook of delivery is removed
+func (s *TSLocalStorage) SaveWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.webhooks.Get(delivery.UserID, delivery.WebhookID); !ok {
+		return fmt.Errorf("error while saving webhook delivery to ts local storage: %w", api.ErrWebhookNotFound)
+	}
+
+	s.webhooks.SaveDelivery(delivery)
+
+	return nil
+}
+
+// UpdateWebhookDelivery Saves the next state of webhook delivery kept in log of local storage
+//
+// Returns ErrWebhookDeliveryNotFound error if delivery is removed with its webhook or by erasure of its user
+func (s *TSLocalStorage) UpdateWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.webhooks.UpdateDelivery(delivery) {
+		return fmt.Errorf("error while updating webhook delivery in ts local storage: %w", api.ErrWebhookDeliveryNotFound)
+	}
+
+	return nil
+}
+
+// GetWebhookDelivery Returns webhook delivery of user from local storage
+func (s *TSLocalStorage) GetWebhookDelivery(ctx context.Context, userID entity.UserID, deliveryID string) (entity.WebhookDelivery, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	delivery, ok := s.webhooks.GetDelivery(userID, deliveryID)
+	if !ok {
+		return entity.WebhookDelivery{}, fmt.Errorf("error while getting webhook delivery from ts local storage: %w", api.ErrWebhookDeliveryNotFound)
+	}
+
+	return delivery, nil
+}
+
+// ListWebhookDeliveries Returns deliveries of webhook of user from local storage, the most recently created first
+func (s *TSLocalStorage) ListWebhookDeliveries(
+	ctx context.Context,
+	userID entity.UserID,
+	webhookID string,
+	limit int,
+) ([]entity.WebhookDelivery, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.webhooks.Deliveries(userID, webhookID, limit), nil
+}
+
+// ListPendingWebhookDeliveries Returns pending webhook deliveries due before given time from local storage
+func (s *TSLocalStorage) ListPendingWebhookDeliveries(ctx context.Context, before time.Time, limit int) ([]entity.WebhookDelivery, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.webhooks.PendingDeliveries(before, limit), nil
+}
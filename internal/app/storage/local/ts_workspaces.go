@@ -0,0 +1,144 @@
+package local
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// SaveWorkspace Adds workspace to local storage or replaces it
+func (s *TSLocalStorage) SaveWorkspace(ctx context.Context, workspace entity.Workspace) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.workspaces.Save(workspace)
+
+	return nil
+}
+
+// GetWorkspace Returns workspace from local storage
+func (s *TSLocalStorage) GetWorkspace(ctx context.Context, workspaceID string) (entity.Workspace, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	workspace, ok := s.workspaces.Get(workspaceID)
+	if !ok {
+		return entity.Workspace{}, fmt.Errorf("error while getting workspace from ts local storage: %w", api.ErrWorkspaceNotFound)
+	}
+
+	return workspace, nil
+}
+
+// GetWorkspaceLink Returns link of workspace from local storage
+func (s *TSLocalStorage) GetWorkspaceLink(ctx context.Context, workspaceID, shortURL string) (entity.Link, error) {
+	s.mutex.RLock()
+	link, ok := s.urls.GetLink(shortURL)
+	s.mutex.RUnlock()
+
+	if !ok || link.WorkspaceID != workspaceID {
+		return entity.Link{}, fmt.Errorf("error while getting workspace link from ts local storage: %w", api.ErrShortURLNotFound)
+	}
+
+	return link, nil
+}
+
+// ListWorkspaceLinks Returns links of workspace placed after cursor ordered by creation time and short URL
+func (s *TSLocalStorage) ListWorkspaceLinks(
+	ctx context.Context,
+	workspaceID string,
+	after entity.LinkCursor,
+	limit int,
+) ([]entity.Link, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.urls.WorkspaceLinks(workspaceID, after, limit), nil
+}
+
+// SaveWorkspaceMember Adds member of workspace to local storage or replaces its role
+func (s *TSLocalStorage) SaveWorkspaceMember(ctx context.Context, member entity.WorkspaceMember) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.workspaces.SaveMember(member)
+
+	return nil
+}
+
+// GetWorkspaceMember Returns membership of user in workspace from local storage
+func (s *TSLocalStorage) GetWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) (entity.WorkspaceMember, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	member, ok := s.workspaces.GetMember(workspaceID, userID)
+	if !ok {
+		return entity.WorkspaceMember{}, fmt.Errorf("error while getting workspace member from ts local storage: %w", api.ErrWorkspaceMemberNotFound)
+	}
+
+	return member, nil
+}
+
+// ListWorkspaceMembers Returns members of workspace from local storage ordered by time of joining
+func (s *TSLocalStorage) ListWorkspaceMembers(ctx context.Context, workspaceID string) ([]entity.WorkspaceMember, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.workspaces.Members(workspaceID), nil
+}
+
+// ListUserMemberships Returns memberships of user in workspaces from local storage ordered by time of joining
+func (s *TSLocalStorage) ListUserMemberships(ctx context.Context, userID entity.UserID) ([]entity.WorkspaceMember, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.workspaces.Memberships(userID), nil
+}
+
+// DeleteWorkspaceMember Removes user from workspace in local storage
+func (s *TSLocalStorage) DeleteWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.workspaces.DeleteMember(workspaceID, userID) {
+		return fmt.Errorf("error while deleting workspace member from ts local storage: %w", api.ErrWorkspaceMemberNotFound)
+	}
+
+	return nil
+}
+
+// SaveWorkspaceInvite Adds workspace invite to local storage
+func (s *TSLocalStorage) SaveWorkspaceInvite(ctx context.Context, invite entity.WorkspaceInvite) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.workspaces.SaveInvite(invite)
+
+	return nil
+}
+
+// GetWorkspaceInvite Returns workspace invite from local storage
+func (s *TSLocalStorage) GetWorkspaceInvite(ctx context.Context, inviteID string) (entity.WorkspaceInvite, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	invite, ok := s.workspaces.GetInvite(inviteID)
+	if !ok {
+		return entity.WorkspaceInvite{}, fmt.Errorf("error while getting workspace invite from ts local storage: %w", api.ErrWorkspaceInviteNotFound)
+	}
+
+	return invite, nil
+}
+
+// DeleteWorkspaceInvite Removes workspace invite from local storage
+func (s *TSLocalStorage) DeleteWorkspaceInvite(ctx context.Context, inviteID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.workspaces.DeleteInvite(inviteID) {
+		return fmt.Errorf("error while deleting workspace invite from ts local storage: %w", api.ErrWorkspaceInviteNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package local
+
+import (
+	"sort"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// Webhooks Webhooks of users by webhook ID and log of their deliveries by delivery ID
+type Webhooks struct {
+	webhooks   map[string]entity.Webhook
+	deliveries map[string]entity.WebhookDelivery
+}
+
+// NewWebhooks Creates webhooks with empty log of deliveries
+func NewWebhooks() *Webhooks {
+	return &Webhooks{
+		webhooks:   make(map[string]entity.Webhook),
+		deliveries: make(map[string]entity.WebhookDelivery),
+	}
+}
+
+// Save Adds webhook or replaces it
+func (w *Webhooks) Save(webhook entity.Webhook) {
+	webhook.Events = append([]string(nil), webhook.Events...)
+	w.webhooks[webhook.ID] = webhook
+}
+
+// Get Returns webhook of user
+func (w *Webhooks) Get(userID entity.UserID, webhookID string) (entity.Webhook, bool) {
+	webhook, ok := w.webhooks[webhookID]
+	if !ok || webhook.UserID != userID {
+		return entity.Webhook{}, false
+	}
+
+	return webhook, true
+}
+
+// List Returns webhooks of user ordered by creation time
+func (w *Webhooks) List(userID entity.UserID) []entity.Webhook {
+	webhooks := make([]entity.Webhook, 0)
+	for _, webhook := range w.webhooks {
+		if webhook.UserID == userID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	sortWebhooks(webhooks)
+
+	return webhooks
+}
+
+// Delete Removes webhook of user with its deliveries
+//
+// Returns false if webhook is not found
+func (w *Webhooks) Delete(userID entity.UserID, webhookID string) bool {
+	if _, ok := w.Get(userID, webhookID); !ok {
+		return false
+	}
+
+	delete(w.webhooks, webhookID)
+	for id, delivery := range w.deliveries {
+		if delivery.WebhookID == webhookID {
+			delete(w.deliveries, id)
+		}
+	}
+
+	return true
+}
+
+// EraseUser Removes webhooks of user with deliveries of user
+//
+// Returns counts of removed webhooks and deliveries
+func (w *Webhooks) EraseUser(userID entity.UserID) (int, int) {
+	webhooks := 0
+	for id, webhook := range w.webhooks {
+		if webhook.UserID == userID {
+			delete(w.webhooks, id)
+			webhooks++
+		}
+	}
+
+	deliveries := 0
+	for id, delivery := range w.deliveries {
+		if delivery.UserID == userID {
+			delete(w.deliveries, id)
+			deliveries++
+		}
+	}
+
+	return webhooks, deliveries
+}
+
+// SaveDelivery Adds delivery or replaces its previous state
+func (w *Webhooks) SaveDelivery(delivery entity.WebhookDelivery) {
+	w.deliveries[delivery.ID] = delivery
+}
+
+// UpdateDelivery Replaces state of delivery kept in log
+//
+// Returns false if delivery is not found, e.g. it is removed with its webhook
+func (w *Webhooks) UpdateDelivery(delivery entity.WebhookDelivery) bool {
+	if _, ok := w.deliveries[delivery.ID]; !ok {
+		return false
+	}
+
+	w.SaveDelivery(delivery)
+
+	return true
+}
+
+// GetDelivery Returns delivery of user
+func (w *Webhooks) GetDelivery(userID entity.UserID, deliveryID string) (entity.WebhookDelivery, bool) {
+	delivery, ok := w.deliveries[deliveryID]
+	if !ok || delivery.UserID != userID {
+		return entity.WebhookDelivery{}, false
+	}
+
+	return delivery, true
+}
+
+// Deliveries Returns up to limit deliveries of webhook of user, the most recently created first
+func (w *Webhooks) Deliveries(userID entity.UserID, webhookID string, limit int) []entity.WebhookDelivery {
+	deliveries := make([]entity.WebhookDelivery, 0)
+	for _, delivery := range w.deliveries {
+		if delivery.UserID == userID && delivery.WebhookID == webhookID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, k int) bool {
+		if deliveries[i].CreatedAt.Equal(deliveries[k].CreatedAt) {
+			return deliveries[i].ID > deliveries[k].ID
+		}
+
+		return deliveries[i].CreatedAt.After(deliveries[k].CreatedAt)
+	})
+
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries
+}
+
+// PendingDeliveries Returns pending deliveries due before given time ordered by time of the next attempt
+func (w *Webhooks) PendingDeliveries(before time.Time, limit int) []entity.WebhookDelivery {
+	deliveries := make([]entity.WebhookDelivery, 0)
+	for _, delivery := range w.deliveries {
+		if delivery.IsDue(before) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	sort.Slice(deliveries, func(i, k int) bool {
+		if deliveries[i].NextAttemptAt.Equal(deliveries[k].NextAttemptAt) {
+			return deliveries[i].CreatedAt.Before(deliveries[k].CreatedAt)
+		}
+
+		return deliveries[i].NextAttemptAt.Before(deliveries[k].NextAttemptAt)
+	})
+
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+
+	return deliveries
+}
+
+// All Returns all webhooks ordered by creation time and all deliveries ordered by creation time
+func (w *Webhooks) All() ([]entity.Webhook, []entity.WebhookDelivery) {
+	webhooks := make([]entity.Webhook, 0, len(w.webhooks))
+	for _, webhook := range w.webhooks {
+		webhooks = append(webhooks, webhook)
+	}
+	sortWebhooks(webhooks)
+
+	deliveries := make([]entity.WebhookDelivery, 0, len(w.deliveries))
+	for _, delivery := range w.deliveries {
+		deliveries = append(deliveries, delivery)
+	}
+
+	sort.Slice(deliveries, func(i, k int) bool {
+		return deliveries[i].CreatedAt.Before(deliveries[k].CreatedAt)
+	})
+
+	return webhooks, deliveries
+}
+
+func sortWebhooks(webhooks []entity.Webhook) {
+	sort.Slice(webhooks, func(i, k int) bool {
+		if webhooks[i].CreatedAt.Equal(webhooks[k].CreatedAt) {
+			return webhooks[i].ID < webhooks[k].ID
+		}
+
+		return webhooks[i].CreatedAt.Before(webhooks[k].CreatedAt)
+	})
+}
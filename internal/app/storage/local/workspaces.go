@@ -0,0 +1,215 @@
+package local
+
+import (
+	"sort"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// Workspaces Workspaces by workspace ID with their members and invites
+type Workspaces struct {
+	workspaces map[string]entity.Workspace
+	members    map[string]map[entity.UserID]entity.WorkspaceMember
+	invites    map[string]entity.WorkspaceInvite
+}
+
+// NewWorkspaces Creates workspaces without members and invites
+func NewWorkspaces() *Workspaces {
+	return &Workspaces{
+		workspaces: make(map[string]entity.Workspace),
+		members:    make(map[string]map[entity.UserID]entity.WorkspaceMember),
+		invites:    make(map[string]entity.WorkspaceInvite),
+	}
+}
+
+// Save Adds workspace or replaces it
+func (w *Workspaces) Save(workspace entity.Workspace) {
+	w.workspaces[workspace.ID] = workspace
+}
+
+// Get Returns workspace by ID
+func (w *Workspaces) Get(workspaceID string) (entity.Workspace, bool) {
+	workspace, ok := w.workspaces[workspaceID]
+
+	return workspace, ok
+}
+
+// SaveMember Adds member of workspace or replaces its role
+func (w *Workspaces) SaveMember(member entity.WorkspaceMember) {
+	members, ok := w.members[member.WorkspaceID]
+	if !ok {
+		members = make(map[entity.UserID]entity.WorkspaceMember)
+		w.members[member.WorkspaceID] = members
+	}
+
+	members[member.UserID] = member
+}
+
+// GetMember Returns membership of user in workspace
+func (w *Workspaces) GetMember(workspaceID string, userID entity.UserID) (entity.WorkspaceMember, bool) {
+	member, ok := w.members[workspaceID][userID]
+
+	return member, ok
+}
+
+// Members Returns members of workspace ordered by time of joining
+func (w *Workspaces) Members(workspaceID string) []entity.WorkspaceMember {
+	members := make([]entity.WorkspaceMember, 0, len(w.members[workspaceID]))
+	for _, member := range w.members[workspaceID] {
+		members = append(members, member)
+	}
+
+	sortMembers(members)
+
+	return members
+}
+
+// Successor Returns the earliest joined owner of workspace except user
+//
+// Returns false if workspace has no other owners
+func (w *Workspaces) Successor(workspaceID string, userID entity.UserID) (entity.UserID, bool) {
+	for _, member := range w.Members(workspaceID) {
+		if member.UserID != userID && member.Role == entity.WorkspaceOwner {
+			return member.UserID, true
+		}
+	}
+
+	return "", false
+}
+
+// Memberships Returns memberships of user in workspaces ordered by time of joining
+func (w *Workspaces) Memberships(userID entity.UserID) []entity.WorkspaceMember {
+	memberships := make([]entity.WorkspaceMember, 0)
+	for _, members := range w.members {
+		if member, ok := members[userID]; ok {
+			memberships = append(memberships, member)
+		}
+	}
+
+	sort.Slice(memberships, func(i, k int) bool {
+		if memberships[i].CreatedAt.Equal(memberships[k].CreatedAt) {
+			return memberships[i].WorkspaceID < memberships[k].WorkspaceID
+		}
+
+		return memberships[i].CreatedAt.Before(memberships[k].CreatedAt)
+	})
+
+	return memberships
+}
+
+// DeleteMember Removes user from workspace
+//
+// Returns false if user is not member of workspace
+func (w *Workspaces) DeleteMember(workspaceID string, userID entity.UserID) bool {
+	if _, ok := w.GetMember(workspaceID, userID); !ok {
+		return false
+	}
+
+	delete(w.members[workspaceID], userID)
+
+	return true
+}
+
+// SaveInvite Adds invite to workspace
+func (w *Workspaces) SaveInvite(invite entity.WorkspaceInvite) {
+	w.invites[invite.ID] = invite
+}
+
+// GetInvite Returns invite by ID
+func (w *Workspaces) GetInvite(inviteID string) (entity.WorkspaceInvite, bool) {
+	invite, ok := w.invites[inviteID]
+
+	return invite, ok
+}
+
+// DeleteInvite Removes invite
+//
+// Returns false if invite is not found
+func (w *Workspaces) DeleteInvite(inviteID string) bool {
+	if _, ok := w.invites[inviteID]; !ok {
+		return false
+	}
+
+	delete(w.invites, inviteID)
+
+	return true
+}
+
+// EraseUser Removes memberships of user and invites created by user
+//
+// Workspaces created by user are kept for their members with creator replaced by ErasedUserID.
+// Returns counts of removed memberships, removed invites and anonymized workspaces
+func (w *Workspaces) EraseUser(userID entity.UserID) (int, int, int) {
+	memberships := 0
+	for _, members := range w.members {
+		if _, ok := members[userID]; ok {
+			delete(members, userID)
+			memberships++
+		}
+	}
+
+	invites := 0
+	for id, invite := range w.invites {
+		if invite.CreatedBy == userID {
+			delete(w.invites, id)
+			invites++
+		}
+	}
+
+	workspaces := 0
+	for id, workspace := range w.workspaces {
+		if workspace.CreatedBy == userID {
+			workspace.CreatedBy = entity.ErasedUserID
+			w.workspaces[id] = workspace
+			workspaces++
+		}
+	}
+
+	return memberships, invites, workspaces
+}
+
+// All Returns all workspaces, members and invites ordered by creation time
+func (w *Workspaces) All() ([]entity.Workspace, []entity.WorkspaceMember, []entity.WorkspaceInvite) {
+	workspaces := make([]entity.Workspace, 0, len(w.workspaces))
+	for _, workspace := range w.workspaces {
+		workspaces = append(workspaces, workspace)
+	}
+
+	sort.Slice(workspaces, func(i, k int) bool {
+		if workspaces[i].CreatedAt.Equal(workspaces[k].CreatedAt) {
+			return workspaces[i].ID < workspaces[k].ID
+		}
+
+		return workspaces[i].CreatedAt.Before(workspaces[k].CreatedAt)
+	})
+
+	var members []entity.WorkspaceMember
+	for _, workspace := range workspaces {
+		members = append(members, w.Members(workspace.ID)...)
+	}
+
+	invites := make([]entity.WorkspaceInvite, 0, len(w.invites))
+	for _, invite := range w.invites {
+		invites = append(invites, invite)
+	}
+
+	sort.Slice(invites, func(i, k int) bool {
+		if invites[i].CreatedAt.Equal(invites[k].CreatedAt) {
+			return invites[i].ID < invites[k].ID
+		}
+
+		return invites[i].CreatedAt.Before(invites[k].CreatedAt)
+	})
+
+	return workspaces, members, invites
+}
+
+func sortMembers(members []entity.WorkspaceMember) {
+	sort.Slice(members, func(i, k int) bool {
+		if members[i].CreatedAt.Equal(members[k].CreatedAt) {
+			return members[i].UserID < members[k].UserID
+		}
+
+		return members[i].CreatedAt.Before(members[k].CreatedAt)
+	})
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// auditColumns Columns of audit_log table scanned by scanAuditRecord
+const auditColumns = `id, actor_type, actor, action, targets, client_ip, request_id, result, error, created_at`
+
+// SaveAuditRecord Appends record to audit_log table of postgres DB
+func (s *PostgresStorage) SaveAuditRecord(ctx context.Context, record entity.AuditRecord) error {
+	if record.Targets == nil {
+		record.Targets = []string{}
+	}
+
+	targets, err := json.Marshal(record.Targets)
+	if err != nil {
+		return fmt.Errorf("error while encoding targets of audit record: %w", err)
+	}
+
+	query := `INSERT INTO audit_log(` + auditColumns + `)
+		VALUES(@id, @actorType, @actor, @action, @targets, @clientIP, @requestID, @result, @error, @createdAt)`
+	args := pgx.NamedArgs{
+		"id":        record.ID,
+		"actorType": record.ActorType,
+		"actor":     record.Actor,
+		"action":    record.Action,
+		"targets":   targets,
+		"clientIP":  record.ClientIP,
+		"requestID": record.RequestID,
+		"result":    record.Result,
+		"error":     record.Error,
+		"createdAt": record.CreatedAt,
+	}
+
+	_, err = s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to save audit record to postgres: %w", err)
+	}
+
+	return nil
+}
+
+// ListAuditRecords Returns records satisfying filter from audit_log table of postgres DB, the newest first
+func (s *PostgresStorage) ListAuditRecords(ctx context.Context, filter entity.AuditFilter, limit int) ([]entity.AuditRecord, error) {
+	query := `SELECT ` + auditColumns + ` FROM audit_log
+		WHERE (@actorType = '' OR actor_type = @actorType)
+			AND (@actor = '' OR actor = @actor)
+			AND (@action = '' OR action = @action)
+			AND (@target = '' OR targets @> jsonb_build_array(@target::text))
+			AND (NOT @hasFrom OR created_at >= @from)
+			AND (NOT @hasTo OR created_at < @to)
+			AND (@first OR (created_at, id) < (@createdAt, @id))
+		ORDER BY created_at DESC, id DESC LIMIT @limit`
+	args := pgx.NamedArgs{
+		"actorType": filter.ActorType,
+		"actor":     filter.Actor,
+		"action":    filter.Action,
+		"target":    filter.Target,
+		"hasFrom":   !filter.From.IsZero(),
+		"from":      filter.From,
+		"hasTo":     !filter.To.IsZero(),
+		"to":        filter.To,
+		"first":     filter.After.IsEmpty(),
+		"createdAt": filter.After.CreatedAt,
+		"id":        filter.After.ID,
+		"limit":     limit,
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error in postgres request execution while listing audit records: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]entity.AuditRecord, 0, limit)
+	for rows.Next() {
+		record, err := scanAuditRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing audit record row in postgres: %w", err)
+		}
+
+		records = append(records, record)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while listing audit records: %w", rows.Err())
+	}
+
+	return records, nil
+}
+
+func scanAuditRecord(row rowScanner) (entity.AuditRecord, error) {
+	var record entity.AuditRecord
+	var targets []byte
+
+	err := row.Scan(
+		&record.ID,
+		&record.ActorType,
+		&record.Actor,
+		&record.Action,
+		&targets,
+		&record.ClientIP,
+		&record.RequestID,
+		&record.Result,
+		&record.Error,
+		&record.CreatedAt,
+	)
+	if err != nil {
+		return entity.AuditRecord{}, err
+	}
+
+	err = json.Unmarshal(targets, &record.Targets)
+	if err != nil {
+		return entity.AuditRecord{}, fmt.Errorf("error while decoding targets of audit record: %w", err)
+	}
+
+	return record, nil
+}
@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+)
+
+// erasureStep Statement of user erasure with counter of affected rows
+type erasureStep struct {
+	name  string
+	query string
+	count *int
+}
+
+// EraseUser Erases all data of user from postgres DB in one transaction
+//
+// Links created by user in workspaces with other owners are transferred to the earliest joined owner,
+// links which owner already has with the same short URL are removed.
+// Links, deletion jobs, webhooks with deliveries, memberships and invites of user are removed,
+// workspaces created by user and audit records of user are anonymized
+func (s *PostgresStorage) EraseUser(ctx context.Context, userID entity.UserID) (models.UserErasure, error) {
+	var erasure models.UserErasure
+	var anonymizedTargets int
+
+	steps := []erasureStep{
+		{
+			name: "workspace links",
+			query: `WITH successor AS (
+					SELECT DISTINCT ON (workspace_id) workspace_id, user_id FROM workspace_member
+					WHERE role = @ownerRole AND user_id <> @userID
+					ORDER BY workspace_id, created_at, user_id
+				)
+				UPDATE url SET user_id = successor.user_id
+				FROM successor
+				WHERE url.user_id = @userID AND url.workspace_id = successor.workspace_id
+					AND NOT EXISTS (
+						SELECT 1 FROM url owned WHERE owned.user_id = successor.user_id AND owned.short_url = url.short_url
+					)`,
+			count: &erasure.TransferredLinks,
+		},
+		{
+			name:  "links",
+			query: `DELETE FROM url WHERE user_id = @userID`,
+			count: &erasure.Links,
+		},
+		{
+			name:  "delete jobs",
+			query: `DELETE FROM delete_job WHERE user_id = @userID`,
+			count: &erasure.DeleteJobs,
+		},
+		{
+			name:  "webhook deliveries",
+			query: `DELETE FROM webhook_delivery WHERE user_id = @userID`,
+			count: &erasure.WebhookDeliveries,
+		},
+		{
+			name:  "webhooks",
+			query: `DELETE FROM webhook WHERE user_id = @userID`,
+			count: &erasure.Webhooks,
+		},
+		{
+			name:  "workspace memberships",
+			query: `DELETE FROM workspace_member WHERE user_id = @userID`,
+			count: &erasure.Memberships,
+		},
+		{
+			name:  "workspace invites",
+			query: `DELETE FROM workspace_invite WHERE created_by = @userID`,
+			count: &erasure.Invites,
+		},
+		{
+			name:  "workspaces",
+			query: `UPDATE workspace SET created_by = @erasedUserID WHERE created_by = @userID`,
+			count: &erasure.Workspaces,
+		},
+		{
+			name: "audit records",
+			query: `UPDATE audit_log SET actor = @erasedUserID, client_ip = ''
+				WHERE actor_type = @actorType AND actor = @userID`,
+			count: &erasure.AuditRecords,
+		},
+		{
+			name: "audit targets",
+			query: `UPDATE audit_log SET targets = (
+					SELECT jsonb_agg(CASE WHEN t.target = @userID THEN @erasedUserID ELSE t.target END ORDER BY t.position)
+					FROM jsonb_array_elements_text(targets) WITH ORDINALITY AS t(target, position)
+				)
+				WHERE targets @> jsonb_build_array(@userID::text)`,
+			count: &anonymizedTargets,
+		},
+	}
+	args := pgx.NamedArgs{
+		"userID":       userID.String(),
+		"erasedUserID": entity.ErasedUserID.String(),
+		"actorType":    entity.AuditActorUser,
+		"ownerRole":    string(entity.WorkspaceOwner),
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return models.UserErasure{}, fmt.Errorf("exit to create erase user transaction in postgres: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, step := range steps {
+		res, err := tx.ExecContext(ctx, step.query, args)
+		if err != nil {
+			return models.UserErasure{}, fmt.Errorf("unable to erase %s of user in postgres: %w", step.name, err)
+		}
+
+		count, err := res.RowsAffected()
+		if err != nil {
+			return models.UserErasure{}, fmt.Errorf("error while getting count of erased %s of user in postgres: %w", step.name, err)
+		}
+		*step.count = int(count)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return models.UserErasure{}, fmt.Errorf("unable to commit erase user transaction in postgres: %w", err)
+	}
+
+	erasure.AuditRecords += anonymizedTargets
+
+	return erasure, nil
+}
@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// SaveDeleteJob Adds deletion job to outbox table of postgres DB
+func (s *PostgresStorage) SaveDeleteJob(ctx context.Context, job entity.DeleteJob) error {
+	shortURLs, err := json.Marshal(job.ShortURLs)
+	if err != nil {
+		return fmt.Errorf("error while encoding short urls of delete job: %w", err)
+	}
+
+	query := `INSERT INTO delete_job(id, user_id, short_urls, status, attempts, last_error, next_attempt_at, created_at, updated_at)
+		VALUES(@id, @userID, @shortUrls, @status, @attempts, @lastError, @nextAttemptAt, @createdAt, @updatedAt)`
+	args := pgx.NamedArgs{
+		"id":            job.ID,
+		"userID":        job.UserID.String(),
+		"shortUrls":     shortURLs,
+		"status":        string(job.Status),
+		"attempts":      job.Attempts,
+		"lastError":     job.LastError,
+		"nextAttemptAt": job.NextAttemptAt,
+		"createdAt":     job.CreatedAt,
+		"updatedAt":     job.UpdatedAt,
+	}
+
+	_, err = s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to save delete job to postgres: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateDeleteJob Saves the next state of deletion job kept in outbox table of postgres DB
+//
+// Returns ErrDeleteJobNotFound error if job is removed, e.g. by erasure of its user
+func (s *PostgresStorage) UpdateDeleteJob(ctx context.Context, job entity.DeleteJob) error {
+	query := `UPDATE delete_job SET
+			status = @status,
+			attempts = @attempts,
+			last_error = @lastError,
+			next_attempt_at = @nextAttemptAt,
+			updated_at = @updatedAt
+		WHERE id = @id`
+	args := pgx.NamedArgs{
+		"id":            job.ID,
+		"status":        string(job.Status),
+		"attempts":      job.Attempts,
+		"lastError":     job.LastError,
+		"nextAttemptAt": job.NextAttemptAt,
+		"updatedAt":     job.UpdatedAt,
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to update delete job in postgres: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to get count of updated delete jobs in postgres: %w", err)
+	}
+
+	if count == 0 {
+		return api.ErrDeleteJobNotFound
+	}
+
+	return nil
+}
+
+// GetDeleteJob Returns deletion job of user from postgres DB
+//
+// Job of any user is returned if user id is empty
+func (s *PostgresStorage) GetDeleteJob(ctx context.Context, userID entity.UserID, jobID string) (entity.DeleteJob, error) {
+	query := `SELECT id, user_id, short_urls, status, attempts, last_error, next_attempt_at, created_at, updated_at
+		FROM delete_job WHERE id = @id AND (@userID = '' OR user_id::text = @userID)`
+	args := pgx.NamedArgs{
+		"id":     jobID,
+		"userID": userID.String(),
+	}
+
+	job, err := scanDeleteJob(s.db.QueryRowContext(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.DeleteJob{}, api.ErrDeleteJobNotFound
+		}
+
+		return entity.DeleteJob{}, fmt.Errorf("error in postgres while getting delete job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ClaimPendingDeleteJobs Returns pending deletion jobs due at given time ordered by creation time and leases them
+//
+// Next attempt of claimed jobs is postponed until end of lease in the same statement,
+// so every due job is claimed by single instance. Rows locked by concurrent claims are skipped
+func (s *PostgresStorage) ClaimPendingDeleteJobs(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]entity.DeleteJob, error) {
+	query := `UPDATE delete_job SET next_attempt_at = @leaseUntil
+		WHERE id IN (
+			SELECT id FROM delete_job WHERE status = @status AND next_attempt_at <= @now
+			ORDER BY next_attempt_at, created_at LIMIT @limit
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, short_urls, status, attempts, last_error, next_attempt_at, created_at, updated_at`
+	args := pgx.NamedArgs{
+		"status":     string(entity.DeleteJobPending),
+		"now":        now,
+		"leaseUntil": now.Add(lease),
+		"limit":      limit,
+	}
+
+	jobs, err := s.queryDeleteJobs(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error while claiming pending delete jobs: %w", err)
+	}
+
+	// rows returned by update aren't ordered
+	slices.SortFunc(jobs, func(a, b entity.DeleteJob) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return jobs, nil
+}
+
+// ListUserDeleteJobs Returns deletion jobs of user ordered by creation time
+func (s *PostgresStorage) ListUserDeleteJobs(ctx context.Context, userID entity.UserID) ([]entity.DeleteJob, error) {
+	query := `SELECT id, user_id, short_urls, status, attempts, last_error, next_attempt_at, created_at, updated_at
+		FROM delete_job WHERE user_id = @userID
+		ORDER BY created_at, id`
+	args := pgx.NamedArgs{
+		"userID": userID.String(),
+	}
+
+	jobs, err := s.queryDeleteJobs(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing delete jobs of user: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func (s *PostgresStorage) queryDeleteJobs(ctx context.Context, query string, args pgx.NamedArgs) ([]entity.DeleteJob, error) {
+	rows, err := s.db.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error in postgres request execution while listing delete jobs: %w", err)
+	}
+	defer rows.Close()
+
+	jobs := make([]entity.DeleteJob, 0)
+	for rows.Next() {
+		job, err := scanDeleteJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing delete job row in postgres: %w", err)
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while listing delete jobs: %w", rows.Err())
+	}
+
+	return jobs, nil
+}
+
+func scanDeleteJob(row rowScanner) (entity.DeleteJob, error) {
+	var job entity.DeleteJob
+	var userID, status string
+	var shortURLs []byte
+
+	err := row.Scan(
+		&job.ID,
+		&userID,
+		&shortURLs,
+		&status,
+		&job.Attempts,
+		&job.LastError,
+		&job.NextAttemptAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return entity.DeleteJob{}, err
+	}
+	job.UserID = entity.UserID(userID)
+	job.Status = entity.DeleteJobStatus(status)
+
+	err = json.Unmarshal(shortURLs, &job.ShortURLs)
+	if err != nil {
+		return entity.DeleteJob{}, fmt.Errorf("error while decoding short urls of delete job: %w", err)
+	}
+
+	return job, nil
+}
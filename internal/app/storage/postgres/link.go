@@ -0,0 +1,314 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// SaveLink Saves user link with metadata and workspace to postgres DB
+//
+// Returns QuotaError error if user exceeded quota of links
+func (s *PostgresStorage) SaveLink(ctx context.Context, link entity.Link) error {
+	metadata, err := marshalMetadata(link.Metadata)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("exit to create save link transaction in postgres: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO url(short_url, url, user_id, workspace_id, created_at, metadata)
+		VALUES(@shortUrl, @url, @userID, NULLIF(@workspaceID, ''), @createdAt, @metadata)`
+	args := pgx.NamedArgs{
+		"shortUrl":    link.ShortURL,
+		"url":         link.OriginalURL,
+		"userID":      link.UserID.String(),
+		"workspaceID": link.WorkspaceID,
+		"createdAt":   link.CreatedAt,
+		"metadata":    metadata,
+	}
+
+	_, err = tx.ExecContext(ctx, query, args)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
+			return fmt.Errorf("error while save link to postgres: %w", api.ErrURLAlreadyExists)
+		}
+
+		return fmt.Errorf("unable to insert link to postgres: %w", err)
+	}
+
+	err = s.checkLinksQuota(ctx, tx, link.UserID, 1, link.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("error while save link to postgres: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("unable to commit save link transaction in postgres: %w", err)
+	}
+
+	return nil
+}
+
+// GetLink Returns link from postgres DB
+//
+// Link of any user is returned if user id is empty.
+// Deleted and disabled links are returned with their states
+func (s *PostgresStorage) GetLink(ctx context.Context, userID entity.UserID, shortURL string) (entity.Link, error) {
+	query := `SELECT ` + linkColumns + ` FROM url
+		WHERE short_url = @shortUrl AND (@userID = '' OR user_id::text = @userID)
+		ORDER BY created_at LIMIT 1`
+	args := pgx.NamedArgs{
+		"shortUrl": shortURL,
+		"userID":   userID.String(),
+	}
+
+	link, err := scanLink(s.db.QueryRowContext(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Link{}, api.ErrShortURLNotFound
+		}
+
+		return entity.Link{}, fmt.Errorf("error in postgres while getting link: %w", err)
+	}
+
+	return link, nil
+}
+
+// ListUserLinks Returns user links placed after cursor ordered by creation time and short URL
+func (s *PostgresStorage) ListUserLinks(
+	ctx context.Context,
+	userID entity.UserID,
+	after entity.LinkCursor,
+	limit int,
+) ([]entity.Link, error) {
+	query := `SELECT ` + linkColumns + ` FROM url
+		WHERE user_id = @userID AND (@first OR (created_at, short_url) > (@createdAt, @shortUrl))
+		ORDER BY created_at, short_url LIMIT @limit`
+	args := pgx.NamedArgs{
+		"userID":    userID.String(),
+		"first":     after.IsEmpty(),
+		"createdAt": after.CreatedAt,
+		"shortUrl":  after.ShortURL,
+		"limit":     limit,
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error in postgres request execution while listing user links: %w", err)
+	}
+	defer rows.Close()
+
+	links := make([]entity.Link, 0, limit)
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing link row in postgres: %w", err)
+		}
+
+		links = append(links, link)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while listing user links: %w", rows.Err())
+	}
+
+	return links, nil
+}
+
+// GetWorkspaceLink Returns link of workspace from postgres DB
+//
+// Live link is preferred if several members created links with the same short ID.
+// Deleted and disabled links are returned with their states
+func (s *PostgresStorage) GetWorkspaceLink(ctx context.Context, workspaceID, shortURL string) (entity.Link, error) {
+	query := `SELECT ` + linkColumns + ` FROM url
+		WHERE workspace_id = @workspaceID AND short_url = @shortUrl
+		ORDER BY deleted, created_at LIMIT 1`
+	args := pgx.NamedArgs{
+		"workspaceID": workspaceID,
+		"shortUrl":    shortURL,
+	}
+
+	link, err := scanLink(s.db.QueryRowContext(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Link{}, api.ErrShortURLNotFound
+		}
+
+		return entity.Link{}, fmt.Errorf("error in postgres while getting workspace link: %w", err)
+	}
+
+	return link, nil
+}
+
+// ListWorkspaceLinks Returns links of workspace placed after cursor ordered by creation time and short URL
+func (s *PostgresStorage) ListWorkspaceLinks(
+	ctx context.Context,
+	workspaceID string,
+	after entity.LinkCursor,
+	limit int,
+) ([]entity.Link, error) {
+	query := `SELECT ` + linkColumns + ` FROM url
+		WHERE workspace_id = @workspaceID AND (@first OR (created_at, short_url) > (@createdAt, @shortUrl))
+		ORDER BY created_at, short_url LIMIT @limit`
+	args := pgx.NamedArgs{
+		"workspaceID": workspaceID,
+		"first":       after.IsEmpty(),
+		"createdAt":   after.CreatedAt,
+		"shortUrl":    after.ShortURL,
+		"limit":       limit,
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error in postgres request execution while listing workspace links: %w", err)
+	}
+	defer rows.Close()
+
+	links := make([]entity.Link, 0, limit)
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing link row in postgres: %w", err)
+		}
+
+		links = append(links, link)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while listing workspace links: %w", rows.Err())
+	}
+
+	return links, nil
+}
+
+// UpdateLinkMetadata Replaces metadata of link of user in workspace in postgres DB
+//
+// Empty workspace ID means personal link of user
+func (s *PostgresStorage) UpdateLinkMetadata(
+	ctx context.Context,
+	userID entity.UserID,
+	workspaceID, shortURL string,
+	metadata map[string]string,
+) error {
+	encoded, err := marshalMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE url SET metadata = @metadata
+		WHERE user_id = @userID AND short_url = @shortUrl AND COALESCE(workspace_id, '') = @workspaceID`
+	args := pgx.NamedArgs{
+		"userID":      userID.String(),
+		"workspaceID": workspaceID,
+		"shortUrl":    shortURL,
+		"metadata":    encoded,
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to update metadata of link in postgres: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to get count of updated links in postgres: %w", err)
+	}
+
+	if count == 0 {
+		return fmt.Errorf("error while updating link metadata in postgres: %w", api.ErrShortURLNotFound)
+	}
+
+	return nil
+}
+
+// SetLinkDisabled Changes disabled state of link of any user in postgres DB
+func (s *PostgresStorage) SetLinkDisabled(ctx context.Context, shortURL string, disabled bool) error {
+	query := `UPDATE url SET disabled = @disabled WHERE short_url = @shortUrl`
+	args := pgx.NamedArgs{
+		"shortUrl": shortURL,
+		"disabled": disabled,
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to change disabled state of link in postgres: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to get count of changed links in postgres: %w", err)
+	}
+
+	if count == 0 {
+		return fmt.Errorf("error while changing disabled state in postgres: %w", api.ErrShortURLNotFound)
+	}
+
+	return nil
+}
+
+// linkColumns Columns of url table scanned by scanLink
+const linkColumns = `short_url, url, user_id, COALESCE(workspace_id, ''), created_at, deleted, deleted_at, disabled, metadata`
+
+// rowScanner Row or rows of query result
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanLink(row rowScanner) (entity.Link, error) {
+	var link entity.Link
+	var userID string
+	var deletedAt sql.NullTime
+	var metadata []byte
+
+	err := row.Scan(
+		&link.ShortURL,
+		&link.OriginalURL,
+		&userID,
+		&link.WorkspaceID,
+		&link.CreatedAt,
+		&link.Deleted,
+		&deletedAt,
+		&link.Disabled,
+		&metadata,
+	)
+	if err != nil {
+		return entity.Link{}, err
+	}
+	link.UserID = entity.UserID(userID)
+	link.DeletedAt = deletedAt.Time
+
+	err = json.Unmarshal(metadata, &link.Metadata)
+	if err != nil {
+		return entity.Link{}, fmt.Errorf("error while decoding link metadata: %w", err)
+	}
+
+	return link, nil
+}
+
+func marshalMetadata(metadata map[string]string) ([]byte, error) {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	res, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("error while encoding link metadata: %w", err)
+	}
+
+	return res, nil
+}
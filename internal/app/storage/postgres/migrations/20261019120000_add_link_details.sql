@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE url ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+ALTER TABLE url ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS idx_url_user_created ON url(user_id, created_at, short_url);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_url_user_created;
+ALTER TABLE url DROP COLUMN metadata;
+ALTER TABLE url DROP COLUMN created_at;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE url ADD COLUMN clicks BIGINT NOT NULL DEFAULT 0;
+CREATE INDEX IF NOT EXISTS idx_url_created ON url(created_at);
+CREATE INDEX IF NOT EXISTS idx_url_clicks ON url(clicks DESC) WHERE NOT deleted AND clicks > 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_url_clicks;
+DROP INDEX IF EXISTS idx_url_created;
+ALTER TABLE url DROP COLUMN clicks;
+-- +goose StatementEnd
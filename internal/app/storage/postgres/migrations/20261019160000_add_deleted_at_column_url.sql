@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE url ADD COLUMN deleted_at TIMESTAMPTZ;
+UPDATE url SET deleted_at = now() WHERE deleted;
+CREATE INDEX IF NOT EXISTS idx_url_deleted_at ON url(deleted_at) WHERE deleted;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_url_deleted_at;
+ALTER TABLE url DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS workspace(
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_by uuid NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS workspace_member(
+    workspace_id TEXT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+    user_id uuid NOT NULL,
+    role TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (workspace_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS idx_workspace_member_user_id ON workspace_member(user_id);
+
+CREATE TABLE IF NOT EXISTS workspace_invite(
+    id TEXT PRIMARY KEY,
+    workspace_id TEXT NOT NULL REFERENCES workspace(id) ON DELETE CASCADE,
+    role TEXT NOT NULL,
+    created_by uuid NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE url ADD COLUMN workspace_id TEXT;
+CREATE INDEX IF NOT EXISTS idx_url_workspace_created ON url(workspace_id, created_at, short_url) WHERE workspace_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_url_workspace_created;
+ALTER TABLE url DROP COLUMN workspace_id;
+DROP TABLE IF EXISTS workspace_invite;
+DROP TABLE IF EXISTS workspace_member;
+DROP TABLE IF EXISTS workspace;
+-- +goose StatementEnd
@@ -7,6 +7,7 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
@@ -33,7 +34,8 @@ var migrationFs embed.FS
 type PostgresStorage struct {
 	model.Storage
 
-	db *sql.DB
+	db     *sql.DB
+	quotas model.QuotaPolicy
 }
 
 // NewPostgresStorage Creates postgres storage object
@@ -72,15 +74,25 @@ func (s *PostgresStorage) PingServer(ctx context.Context) error {
 }
 
 // SaveURL Saves user URL to postgres DB
+//
+// Returns QuotaError error if user exceeded quota of links
 func (s *PostgresStorage) SaveURL(ctx context.Context, userID entity.UserID, key, value entity.URL) error {
-	query := `INSERT INTO url(short_url, url, user_id) VALUES(@shortUrl, @url, @userID)`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("exit to create save url transaction in postgres: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO url(short_url, url, user_id, created_at) VALUES(@shortUrl, @url, @userID, @createdAt)`
+	createdAt := time.Now().UTC()
 	args := pgx.NamedArgs{
-		"shortUrl": key.String(),
-		"url":      value.String(),
-		"userID":   userID.String(),
+		"shortUrl":  key.String(),
+		"url":       value.String(),
+		"userID":    userID.String(),
+		"createdAt": createdAt,
 	}
 
-	_, err := s.db.ExecContext(ctx, query, args)
+	_, err = tx.ExecContext(ctx, query, args)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
@@ -90,12 +102,31 @@ func (s *PostgresStorage) SaveURL(ctx context.Context, userID entity.UserID, key
 		return fmt.Errorf("unable to insert row to postgres: %w", err)
 	}
 
+	err = s.checkLinksQuota(ctx, tx, userID, 1, createdAt)
+	if err != nil {
+		return fmt.Errorf("error while save url to postgres: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("unable to commit save url transaction in postgres: %w", err)
+	}
+
 	return nil
 }
 
 // SaveBatchURL Saves batch of user URLs to postgres DB
+//
+// Batch is saved entirely or isn't saved at all if user exceeded quota of batch size or links.
+// Returns QuotaError error if user exceeded quota
 func (s *PostgresStorage) SaveBatchURL(ctx context.Context, userID entity.UserID, batch model.Batch) (model.Batch, error) {
-	query := `INSERT INTO url(short_url, url, user_id) VALUES($1, $2, $3)`
+	err := model.CheckBatchQuota(model.QuotaOf(s.quotas, userID), len(batch))
+	if err != nil {
+		return nil, fmt.Errorf("error while save batch to postgres: %w", err)
+	}
+
+	query := `INSERT INTO url(short_url, url, user_id, created_at) VALUES($1, $2, $3, $4)`
+	createdAt := time.Now().UTC()
 	tx, err := s.db.Begin()
 	if err != nil {
 		return nil, fmt.Errorf("exit to create transaction in postgres: %w", err)
@@ -109,11 +140,16 @@ func (s *PostgresStorage) SaveBatchURL(ctx context.Context, userID entity.UserID
 	defer stmt.Close()
 
 	for _, obj := range batch {
-		_, err = stmt.ExecContext(ctx, obj.ShortURL, obj.InputURL, userID.String())
+		_, err = stmt.ExecContext(ctx, obj.ShortURL, obj.InputURL, userID.String(), createdAt)
 		if err != nil {
 			return nil, fmt.Errorf("exit to write batch object to postgres: %w", err)
 		}
 	}
+
+	err = s.checkLinksQuota(ctx, tx, userID, len(batch), createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("error while save batch to postgres: %w", err)
+	}
 	tx.Commit()
 
 	return batch, nil
@@ -129,13 +165,10 @@ func (s *PostgresStorage) GetURL(ctx context.Context, userID entity.UserID, key
 }
 
 // GetAllURLByUserID Returns all user URLs from postgres DB
+//
+// Deleted URLs are kept in trash of user and aren't returned.
+// Returns ErrAllURLsDeleted error if all URLs of user are deleted
 func (s *PostgresStorage) GetAllURLByUserID(ctx context.Context, userID entity.UserID) (models.AllUrlsBatch, error) {
-	tx, err := s.db.BeginTx(ctx, nil)
-	if err != nil {
-		return nil, fmt.Errorf("exit to create get all urls transaction in postgres: %w", err)
-	}
-	defer tx.Rollback()
-
 	query := `SELECT url, short_url, deleted FROM url WHERE user_id = @userID`
 	args := pgx.NamedArgs{
 		"userID": userID.String(),
@@ -145,10 +178,7 @@ func (s *PostgresStorage) GetAllURLByUserID(ctx context.Context, userID entity.U
 	if err != nil {
 		return nil, fmt.Errorf("error in postgres request execution while getting all urls by user id: %w", err)
 	}
-
-	if rows.Err() != nil {
-		return nil, fmt.Errorf("error in postgres requested rows while getting all urls by user id: %w", rows.Err())
-	}
+	defer rows.Close()
 
 	isDeleted := false
 	var urlsBatch models.AllUrlsBatch
@@ -157,31 +187,19 @@ func (s *PostgresStorage) GetAllURLByUserID(ctx context.Context, userID entity.U
 		var deleted bool
 		err = rows.Scan(&url.OriginalURL, &url.ShortURL, &deleted)
 		if err != nil {
-			if err == sql.ErrNoRows {
-				return nil, api.ErrShortURLNotFound
-			}
 			return nil, fmt.Errorf("error while processing response row in postgres: %w", err)
 		}
 
 		if deleted {
 			isDeleted = true
-			err = deleteURL(s.db, ctx, userID.String(), url.ShortURL)
-			if err != nil {
-				zap.L().Error(
-					"unable to delete url while getting all urls from postgres",
-					zap.Error(err),
-					zap.String("short_url", url.ShortURL))
-			}
-
 			continue
 		}
 
 		urlsBatch = append(urlsBatch, url)
 	}
 
-	err = tx.Commit()
-	if err != nil {
-		return nil, fmt.Errorf("exit to commit transaction while getting all urls in postgres: %w", err)
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while getting all urls by user id: %w", rows.Err())
 	}
 
 	if len(urlsBatch) == 0 && isDeleted {
@@ -191,9 +209,9 @@ func (s *PostgresStorage) GetAllURLByUserID(ctx context.Context, userID entity.U
 	return urlsBatch, nil
 }
 
-// GetStatistic Returns count of users and URls in storage
+// GetStatistic Returns count of users, active and deleted URLs in storage
 func (s *PostgresStorage) GetStatistic(ctx context.Context) (models.CountStatistic, error) {
-	query := `SELECT COUNT(DISTINCT user_id), COUNT(short_url) FROM url`
+	query := `SELECT COUNT(DISTINCT user_id), COUNT(*) FILTER (WHERE NOT deleted), COUNT(*) FILTER (WHERE deleted) FROM url`
 
 	row := s.db.QueryRowContext(ctx, query)
 	if row == nil {
@@ -205,7 +223,7 @@ func (s *PostgresStorage) GetStatistic(ctx context.Context) (models.CountStatist
 	}
 
 	var stat models.CountStatistic
-	err := row.Scan(&stat.UserCount, &stat.URLCount)
+	err := row.Scan(&stat.UserCount, &stat.URLCount, &stat.DeletedCount)
 	if err != nil {
 		return models.CountStatistic{}, fmt.Errorf("error while processing response row in postgres get statistic: %w", err)
 	}
@@ -214,6 +232,8 @@ func (s *PostgresStorage) GetStatistic(ctx context.Context) (models.CountStatist
 }
 
 // DeleteBatchURL Delete user URL from postgres DB
+//
+// Deleted URLs are moved to trash of user with deletion time
 func (s *PostgresStorage) DeleteBatchURL(ctx context.Context, urls entity.DeletedURLBatch) error {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -221,7 +241,7 @@ func (s *PostgresStorage) DeleteBatchURL(ctx context.Context, urls entity.Delete
 	}
 	defer tx.Rollback()
 
-	query := `UPDATE url SET deleted = true WHERE user_id=$1 AND short_url=$2`
+	query := `UPDATE url SET deleted = true, deleted_at = now() WHERE user_id=$1 AND short_url=$2 AND NOT deleted`
 	stmt, err := tx.PrepareContext(ctx, query)
 	if err != nil {
 		return fmt.Errorf("exit to prepare query while deleting urls in postgres: %w", err)
@@ -243,85 +263,41 @@ func (s *PostgresStorage) DeleteBatchURL(ctx context.Context, urls entity.Delete
 	return nil
 }
 
+// getURL Returns original URL of short code of any user
+//
+// Several users can own links with the same short code, the earliest live link is preferred
+// like owner of clicks, so link in trash of one user doesn't hide live links of others
 func (s *PostgresStorage) getURL(ctx context.Context, key entity.URL) (*entity.URL, error) {
-	tx, err := s.db.Begin()
-	if err != nil {
-		return nil, fmt.Errorf("exit to create get user url transaction in postgres: %w", err)
-	}
-	query := `SELECT id, url, deleted FROM url WHERE short_url=@shortUrl`
+	query := `SELECT url, deleted, disabled FROM url WHERE short_url = @shortUrl
+		ORDER BY deleted, created_at, user_id LIMIT 1`
 	args := pgx.NamedArgs{
 		"shortUrl": key.String(),
 	}
 
-	row := s.db.QueryRowContext(ctx, query, args)
-	if row == nil {
-		return nil, fmt.Errorf("error while postgres request execution")
-	}
-
-	if row.Err() != nil {
-		return nil, fmt.Errorf("error while postgres request execution: %w", row.Err())
-	}
-
-	var id int
-	var dbURL string
-	var deleted bool
-	err = row.Scan(&id, &dbURL, &deleted)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, api.ErrShortURLNotFound
-		}
-		return nil, fmt.Errorf("error while processing response row in postgres: %w", err)
-	}
-
-	if deleted {
-		query = `DELETE FROM url WHERE id=$1`
-		_, err = s.db.ExecContext(ctx, query, id)
-		if err != nil {
-			zap.L().Error(
-				"unable to delete url while getting from postgres",
-				zap.Error(err),
-				zap.String("short_url", key.String()))
-		}
-
-		return nil, api.ErrAllURLsDeleted
-	}
-
-	err = tx.Commit()
-	if err != nil {
-		return nil, fmt.Errorf("exit to commit transaction while getting user url in postgres: %w", err)
-	}
-
-	url, err := entity.NewURL(dbURL)
-	if err != nil {
-		return nil, fmt.Errorf("error while creating url in postgres: %w", err)
-	}
-
-	return url, nil
+	return scanURL(s.db.QueryRowContext(ctx, query, args))
 }
 
 func (s *PostgresStorage) getUserURL(ctx context.Context, userID entity.UserID, key entity.URL) (*entity.URL, error) {
-	tx, err := s.db.Begin()
-	if err != nil {
-		return nil, fmt.Errorf("exit to create get user url transaction in postgres: %w", err)
-	}
-	query := `SELECT url, deleted FROM url WHERE user_id = @userID AND short_url = @shortUrl`
+	query := `SELECT url, deleted, disabled FROM url WHERE user_id = @userID AND short_url = @shortUrl`
 	args := pgx.NamedArgs{
 		"userID":   userID.String(),
 		"shortUrl": key.String(),
 	}
 
-	row := s.db.QueryRowContext(ctx, query, args)
-	if row == nil {
-		return nil, fmt.Errorf("error in postgres request execution while getting url")
-	}
+	return scanURL(s.db.QueryRowContext(ctx, query, args))
+}
 
+// scanURL Returns original URL from row with deletion and disabled states
+//
+// Deleted URL is kept in trash of user until it is purged, so reading doesn't change it
+func scanURL(row *sql.Row) (*entity.URL, error) {
 	if row.Err() != nil {
 		return nil, fmt.Errorf("error in postgres request execution while getting url: %w", row.Err())
 	}
 
 	var dbURL string
-	var deleted bool
-	err = row.Scan(&dbURL, &deleted)
+	var deleted, disabled bool
+	err := row.Scan(&dbURL, &deleted, &disabled)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, api.ErrShortURLNotFound
@@ -330,19 +306,11 @@ func (s *PostgresStorage) getUserURL(ctx context.Context, userID entity.UserID,
 	}
 
 	if deleted {
-		err = deleteURL(s.db, ctx, userID.String(), key.String())
-		if err != nil {
-			zap.L().Error(
-				"unable to delete url while getting from postgres",
-				zap.Error(err),
-				zap.String("short_url", key.String()))
-		}
-
 		return nil, api.ErrAllURLsDeleted
 	}
-	err = tx.Commit()
-	if err != nil {
-		return nil, fmt.Errorf("exit to commit transaction while getting user url in postgres: %w", err)
+
+	if disabled {
+		return nil, api.ErrURLDisabled
 	}
 
 	url, err := entity.NewURL(dbURL)
@@ -366,13 +334,3 @@ func migration(db *sql.DB) error {
 
 	return nil
 }
-
-func deleteURL(db *sql.DB, ctx context.Context, userID string, shortURL string) error {
-	query := `DELETE FROM url WHERE user_id=$1 AND short_url=$2`
-	_, err := db.ExecContext(ctx, query, userID, shortURL)
-	if err != nil {
-		return fmt.Errorf("unable to delete row from postgres: %w", err)
-	}
-
-	return nil
-}
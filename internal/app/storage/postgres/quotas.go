@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+)
+
+// rowQuerier Connection or transaction querying one row
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// SetQuotaPolicy Sets policy of user quotas enforced by postgres storage
+func (s *PostgresStorage) SetQuotaPolicy(policy model.QuotaPolicy) {
+	s.quotas = policy
+}
+
+// GetQuotaUsage Returns usage of user quota at given time from postgres DB
+func (s *PostgresStorage) GetQuotaUsage(ctx context.Context, userID entity.UserID, now time.Time) (entity.QuotaUsage, error) {
+	return quotaUsage(ctx, s.db, userID, now)
+}
+
+// checkLinksQuota Returns QuotaError error if count links saved in transaction exceeded quota of user
+//
+// Transaction takes lock of user quota, so concurrent transactions of user are checked one after another
+func (s *PostgresStorage) checkLinksQuota(ctx context.Context, tx *sql.Tx, userID entity.UserID, count int, now time.Time) error {
+	quota := model.QuotaOf(s.quotas, userID)
+	if quota.MaxActiveLinks == 0 && quota.MaxLinksPerDay == 0 {
+		return nil
+	}
+
+	usage, err := lockQuotaUsage(ctx, tx, userID, now)
+	if err != nil {
+		return err
+	}
+	usage.ActiveLinks -= count
+	usage.LinksToday -= count
+
+	return model.CheckLinksQuota(quota, usage, count, now)
+}
+
+// checkRestoreQuota Returns QuotaError error if count links restored in transaction exceeded quota of user
+func (s *PostgresStorage) checkRestoreQuota(ctx context.Context, tx *sql.Tx, userID entity.UserID, count int) error {
+	quota := model.QuotaOf(s.quotas, userID)
+	if quota.MaxActiveLinks == 0 {
+		return nil
+	}
+
+	usage, err := lockQuotaUsage(ctx, tx, userID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	usage.ActiveLinks -= count
+
+	return model.CheckRestoreQuota(quota, usage, count)
+}
+
+// checkClicksQuota Returns QuotaError error if owner of clicked link exceeded quota of tracked clicks
+func (s *PostgresStorage) checkClicksQuota(ctx context.Context, tx *sql.Tx, userID entity.UserID) error {
+	if s.quotas == nil {
+		return nil
+	}
+
+	quota := s.quotas.Quota(userID)
+	if quota.MaxTrackedClicks == 0 {
+		return nil
+	}
+
+	usage, err := lockQuotaUsage(ctx, tx, userID, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	return model.CheckClicksQuota(quota, usage)
+}
+
+// lockQuotaUsage Takes lock of user quota until the end of transaction and returns usage of quota
+func lockQuotaUsage(ctx context.Context, tx *sql.Tx, userID entity.UserID, now time.Time) (entity.QuotaUsage, error) {
+	query := `SELECT pg_advisory_xact_lock(hashtext(@userID))`
+	args := pgx.NamedArgs{
+		"userID": userID.String(),
+	}
+
+	_, err := tx.ExecContext(ctx, query, args)
+	if err != nil {
+		return entity.QuotaUsage{}, fmt.Errorf("unable to lock user quota in postgres: %w", err)
+	}
+
+	return quotaUsage(ctx, tx, userID, now)
+}
+
+func quotaUsage(ctx context.Context, querier rowQuerier, userID entity.UserID, now time.Time) (entity.QuotaUsage, error) {
+	query := `SELECT COUNT(*) FILTER (WHERE NOT deleted), COUNT(*) FILTER (WHERE created_at >= @day), COALESCE(SUM(clicks), 0)
+		FROM url WHERE user_id = @userID`
+	args := pgx.NamedArgs{
+		"userID": userID.String(),
+		"day":    entity.QuotaDay(now),
+	}
+
+	var usage entity.QuotaUsage
+	err := querier.QueryRowContext(ctx, query, args).Scan(&usage.ActiveLinks, &usage.LinksToday, &usage.TrackedClicks)
+	if err != nil {
+		return entity.QuotaUsage{}, fmt.Errorf("error in postgres while getting usage of user quota: %w", err)
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// domainPattern Extracts host of original URL in postgres regular expression syntax
+const domainPattern = `^[a-zA-Z][a-zA-Z0-9+.-]*://(?:[^/?#@]*@)?([^/?#:]+)`
+
+// GetServiceStatistic Returns statistic of storage aggregated by postgres
+//
+// Created links are counted in query range, top domains and top clicked links are counted among active links
+func (s *PostgresStorage) GetServiceStatistic(ctx context.Context, query models.StatisticQuery) (models.ServiceStatistic, error) {
+	count, err := s.GetStatistic(ctx)
+	if err != nil {
+		return models.ServiceStatistic{}, err
+	}
+
+	stat := models.ServiceStatistic{CountStatistic: count}
+
+	err = s.db.QueryRowContext(ctx, `SELECT pg_total_relation_size('url')`).Scan(&stat.StorageSize)
+	if err != nil {
+		return models.ServiceStatistic{}, fmt.Errorf("error in postgres while getting storage size: %w", err)
+	}
+
+	stat.Created, err = s.createdSeries(ctx, query)
+	if err != nil {
+		return models.ServiceStatistic{}, err
+	}
+
+	stat.TopDomains, err = s.topDomains(ctx, query.Top)
+	if err != nil {
+		return models.ServiceStatistic{}, err
+	}
+
+	stat.TopLinks, err = s.topLinks(ctx, query.Top)
+	if err != nil {
+		return models.ServiceStatistic{}, err
+	}
+
+	return stat, nil
+}
+
+// RecordClick Increments count of redirects by link in postgres DB
+//
+// The same short code may be kept by several users, click is counted for the owner
+// of the earliest active link and quota of tracked clicks of this owner is checked.
+// Returns QuotaError error if owner of link exceeded quota of tracked clicks
+func (s *PostgresStorage) RecordClick(ctx context.Context, shortURL string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("exit to create record click transaction in postgres: %w", err)
+	}
+	defer tx.Rollback()
+
+	userID, err := clickedLinkOwner(ctx, tx, shortURL)
+	if err != nil {
+		return fmt.Errorf("error while recording click in postgres: %w", err)
+	}
+
+	err = s.checkClicksQuota(ctx, tx, userID)
+	if err != nil {
+		return fmt.Errorf("error while recording click in postgres: %w", err)
+	}
+
+	query := `UPDATE url SET clicks = clicks + 1 WHERE user_id = @userID AND short_url = @shortUrl`
+	args := pgx.NamedArgs{
+		"userID":   userID.String(),
+		"shortUrl": shortURL,
+	}
+
+	_, err = tx.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to record click in postgres: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("unable to commit record click transaction in postgres: %w", err)
+	}
+
+	return nil
+}
+
+// clickedLinkOwner Returns owner of the earliest active link with short code
+//
+// Returns ErrShortURLNotFound error if short code has no active links
+func clickedLinkOwner(ctx context.Context, tx *sql.Tx, shortURL string) (entity.UserID, error) {
+	query := `SELECT user_id FROM url WHERE short_url = @shortUrl AND NOT deleted
+		ORDER BY created_at, user_id LIMIT 1`
+	args := pgx.NamedArgs{
+		"shortUrl": shortURL,
+	}
+
+	var userID string
+	err := tx.QueryRowContext(ctx, query, args).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", api.ErrShortURLNotFound
+		}
+
+		return "", fmt.Errorf("error in postgres while getting owner of link: %w", err)
+	}
+
+	return entity.UserID(userID), nil
+}
+
+func (s *PostgresStorage) createdSeries(ctx context.Context, query models.StatisticQuery) ([]models.TimeCount, error) {
+	sqlQuery := `SELECT date_trunc(@granularity, created_at AT TIME ZONE 'UTC') AS bucket, COUNT(*) FROM url
+		WHERE created_at >= @from AND created_at < @to
+		GROUP BY bucket ORDER BY bucket`
+	args := pgx.NamedArgs{
+		"granularity": query.Granularity,
+		"from":        query.Truncate(query.From),
+		"to":          query.To,
+	}
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery, args)
+	if err != nil {
+		return nil, fmt.Errorf("error in postgres request execution while getting created links: %w", err)
+	}
+	defer rows.Close()
+
+	var res []models.TimeCount
+	for rows.Next() {
+		var bucket time.Time
+		var count int
+		err = rows.Scan(&bucket, &count)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing created links row in postgres: %w", err)
+		}
+
+		res = append(res, models.TimeCount{Time: bucket.UTC(), Count: count})
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while getting created links: %w", rows.Err())
+	}
+
+	return res, nil
+}
+
+func (s *PostgresStorage) topDomains(ctx context.Context, limit int) ([]models.DomainCount, error) {
+	query := `SELECT lower(substring(url from @pattern)) AS domain, COUNT(*) AS count FROM url
+		WHERE NOT deleted AND url ~ @pattern
+		GROUP BY domain ORDER BY count DESC, domain LIMIT @limit`
+	args := pgx.NamedArgs{
+		"pattern": domainPattern,
+		"limit":   limit,
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error in postgres request execution while getting top domains: %w", err)
+	}
+	defer rows.Close()
+
+	var res []models.DomainCount
+	for rows.Next() {
+		var domain models.DomainCount
+		err = rows.Scan(&domain.Domain, &domain.Count)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing top domain row in postgres: %w", err)
+		}
+
+		res = append(res, domain)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while getting top domains: %w", rows.Err())
+	}
+
+	return res, nil
+}
+
+func (s *PostgresStorage) topLinks(ctx context.Context, limit int) ([]models.LinkClicks, error) {
+	query := `SELECT short_url, url, clicks FROM url
+		WHERE NOT deleted AND clicks > 0
+		ORDER BY clicks DESC, short_url LIMIT @limit`
+	args := pgx.NamedArgs{
+		"limit": limit,
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error in postgres request execution while getting top links: %w", err)
+	}
+	defer rows.Close()
+
+	var res []models.LinkClicks
+	for rows.Next() {
+		var link models.LinkClicks
+		err = rows.Scan(&link.ShortURL, &link.OriginalURL, &link.Clicks)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing top link row in postgres: %w", err)
+		}
+
+		res = append(res, link)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while getting top links: %w", rows.Err())
+	}
+
+	return res, nil
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// ListDeletedLinks Returns links of user deleted after given time from postgres DB ordered from the most recently deleted
+func (s *PostgresStorage) ListDeletedLinks(ctx context.Context, userID entity.UserID, deletedAfter time.Time) ([]entity.Link, error) {
+	query := `SELECT ` + linkColumns + ` FROM url
+		WHERE user_id = @userID AND deleted AND deleted_at >= @deletedAfter
+		ORDER BY deleted_at DESC, short_url`
+	args := pgx.NamedArgs{
+		"userID":       userID.String(),
+		"deletedAfter": deletedAfter,
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error in postgres request execution while listing deleted links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []entity.Link
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing deleted link row in postgres: %w", err)
+		}
+
+		links = append(links, link)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while listing deleted links: %w", rows.Err())
+	}
+
+	return links, nil
+}
+
+// RestoreLinks Restores links of user deleted after given time in postgres DB
+//
+// Links aren't restored if user exceeded quota of active links by them.
+// Returns short URLs of restored links
+func (s *PostgresStorage) RestoreLinks(
+	ctx context.Context,
+	userID entity.UserID,
+	shortURLs []string,
+	deletedAfter time.Time,
+) ([]string, error) {
+	query := `UPDATE url SET deleted = false, deleted_at = NULL
+		WHERE user_id = @userID AND short_url = ANY(@shortUrls) AND deleted AND deleted_at >= @deletedAfter
+		RETURNING short_url`
+	args := pgx.NamedArgs{
+		"userID":       userID.String(),
+		"shortUrls":    shortURLs,
+		"deletedAfter": deletedAfter,
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("exit to create restore links transaction in postgres: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to restore links in postgres: %w", err)
+	}
+	defer rows.Close()
+
+	restored := make([]string, 0, len(shortURLs))
+	for rows.Next() {
+		var shortURL string
+		err = rows.Scan(&shortURL)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing restored link row in postgres: %w", err)
+		}
+
+		restored = append(restored, shortURL)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while restoring links: %w", rows.Err())
+	}
+	rows.Close()
+
+	err = s.checkRestoreQuota(ctx, tx, userID, len(restored))
+	if err != nil {
+		return nil, fmt.Errorf("error while restoring links in postgres: %w", err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, fmt.Errorf("unable to commit restore links transaction in postgres: %w", err)
+	}
+
+	return restored, nil
+}
+
+// PurgeDeletedLinks Removes up to limit links deleted before given time from postgres DB
+//
+// Returns removed links
+func (s *PostgresStorage) PurgeDeletedLinks(ctx context.Context, deletedBefore time.Time, limit int) ([]entity.Link, error) {
+	query := `DELETE FROM url WHERE id IN (
+			SELECT id FROM url WHERE deleted AND deleted_at < @deletedBefore
+			ORDER BY deleted_at LIMIT @limit
+		)
+		RETURNING ` + linkColumns
+	args := pgx.NamedArgs{
+		"deletedBefore": deletedBefore,
+		"limit":         limit,
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to purge deleted links from postgres: %w", err)
+	}
+	defer rows.Close()
+
+	links := make([]entity.Link, 0, limit)
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing purged link row in postgres: %w", err)
+		}
+
+		links = append(links, link)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while purging deleted links: %w", rows.Err())
+	}
+
+	return links, nil
+}
@@ -0,0 +1,340 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// webhookDeliveryColumns Columns of webhook_delivery table scanned by scanWebhookDelivery
+const webhookDeliveryColumns = `id, webhook_id, user_id, event, payload, status, attempts, response_code, last_error,
+	next_attempt_at, created_at, updated_at`
+
+// SaveWebhook Adds webhook of user to postgres DB or replaces it
+func (s *PostgresStorage) SaveWebhook(ctx context.Context, webhook entity.Webhook) error {
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return fmt.Errorf("error while encoding events of webhook: %w", err)
+	}
+
+	query := `INSERT INTO webhook(id, user_id, url, secret, events, created_at)
+		VALUES(@id, @userID, @url, @secret, @events, @createdAt)
+		ON CONFLICT (id) DO UPDATE SET
+			url = EXCLUDED.url,
+			secret = EXCLUDED.secret,
+			events = EXCLUDED.events`
+	args := pgx.NamedArgs{
+		"id":        webhook.ID,
+		"userID":    webhook.UserID.String(),
+		"url":       webhook.URL,
+		"secret":    webhook.Secret,
+		"events":    events,
+		"createdAt": webhook.CreatedAt,
+	}
+
+	_, err = s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to save webhook to postgres: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhook Returns webhook of user from postgres DB
+func (s *PostgresStorage) GetWebhook(ctx context.Context, userID entity.UserID, webhookID string) (entity.Webhook, error) {
+	query := `SELECT id, user_id, url, secret, events, created_at FROM webhook
+		WHERE id = @id AND user_id = @userID`
+	args := pgx.NamedArgs{
+		"id":     webhookID,
+		"userID": userID.String(),
+	}
+
+	webhook, err := scanWebhook(s.db.QueryRowContext(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Webhook{}, api.ErrWebhookNotFound
+		}
+
+		return entity.Webhook{}, fmt.Errorf("error in postgres while getting webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks Returns webhooks of user from postgres DB ordered by creation time
+func (s *PostgresStorage) ListWebhooks(ctx context.Context, userID entity.UserID) ([]entity.Webhook, error) {
+	query := `SELECT id, user_id, url, secret, events, created_at FROM webhook
+		WHERE user_id = @userID ORDER BY created_at, id`
+	args := pgx.NamedArgs{
+		"userID": userID.String(),
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error in postgres request execution while listing webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := make([]entity.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing webhook row in postgres: %w", err)
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while listing webhooks: %w", rows.Err())
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook Removes webhook of user with its deliveries from postgres DB
+func (s *PostgresStorage) DeleteWebhook(ctx context.Context, userID entity.UserID, webhookID string) error {
+	query := `DELETE FROM webhook WHERE id = @id AND user_id = @userID`
+	args := pgx.NamedArgs{
+		"id":     webhookID,
+		"userID": userID.String(),
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to delete webhook from postgres: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to get count of deleted webhooks in postgres: %w", err)
+	}
+
+	if count == 0 {
+		return api.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// SaveWebhookDelivery Adds webhook delivery to log table of postgres DB
+//
+// Returns ErrWebhookNotFound error if webhook of delivery is removed
+func (s *PostgresStorage) SaveWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	query := `INSERT INTO webhook_delivery(` + webhookDeliveryColumns + `)
+		SELECT @id::text, @webhookID::text, @userID::uuid, @event::text, @payload::jsonb, @status::text,
+			@attempts::integer, @responseCode::integer, @lastError::text,
+			@nextAttemptAt::timestamptz, @createdAt::timestamptz, @updatedAt::timestamptz
+		WHERE EXISTS (SELECT 1 FROM webhook WHERE id = @webhookID::text AND user_id = @userID::uuid)`
+	args := pgx.NamedArgs{
+		"id":            delivery.ID,
+		"webhookID":     delivery.WebhookID,
+		"userID":        delivery.UserID.String(),
+		"event":         delivery.Event,
+		"payload":       []byte(delivery.Payload),
+		"status":        string(delivery.Status),
+		"attempts":      delivery.Attempts,
+		"responseCode":  delivery.ResponseCode,
+		"lastError":     delivery.LastError,
+		"nextAttemptAt": delivery.NextAttemptAt,
+		"createdAt":     delivery.CreatedAt,
+		"updatedAt":     delivery.UpdatedAt,
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		// webhook is removed concurrently after check of its existence
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
+			return api.ErrWebhookNotFound
+		}
+
+		return fmt.Errorf("unable to save webhook delivery to postgres: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to get count of saved webhook deliveries in postgres: %w", err)
+	}
+
+	if count == 0 {
+		return api.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// UpdateWebhookDelivery Saves the next state of webhook delivery kept in log table of postgres DB
+//
+// Returns ErrWebhookDeliveryNotFound error if delivery is removed with its webhook or by erasure of its user
+func (s *PostgresStorage) UpdateWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	query := `UPDATE webhook_delivery SET
+			status = @status,
+			attempts = @attempts,
+			response_code = @responseCode,
+			last_error = @lastError,
+			next_attempt_at = @nextAttemptAt,
+			updated_at = @updatedAt
+		WHERE id = @id`
+	args := pgx.NamedArgs{
+		"id":            delivery.ID,
+		"status":        string(delivery.Status),
+		"attempts":      delivery.Attempts,
+		"responseCode":  delivery.ResponseCode,
+		"lastError":     delivery.LastError,
+		"nextAttemptAt": delivery.NextAttemptAt,
+		"updatedAt":     delivery.UpdatedAt,
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to update webhook delivery in postgres: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to get count of updated webhook deliveries in postgres: %w", err)
+	}
+
+	if count == 0 {
+		return api.ErrWebhookDeliveryNotFound
+	}
+
+	return nil
+}
+
+// GetWebhookDelivery Returns webhook delivery of user from postgres DB
+func (s *PostgresStorage) GetWebhookDelivery(ctx context.Context, userID entity.UserID, deliveryID string) (entity.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_delivery
+		WHERE id = @id AND user_id = @userID`
+	args := pgx.NamedArgs{
+		"id":     deliveryID,
+		"userID": userID.String(),
+	}
+
+	delivery, err := scanWebhookDelivery(s.db.QueryRowContext(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.WebhookDelivery{}, api.ErrWebhookDeliveryNotFound
+		}
+
+		return entity.WebhookDelivery{}, fmt.Errorf("error in postgres while getting webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// ListWebhookDeliveries Returns deliveries of webhook of user from postgres DB, the most recently created first
+func (s *PostgresStorage) ListWebhookDeliveries(
+	ctx context.Context,
+	userID entity.UserID,
+	webhookID string,
+	limit int,
+) ([]entity.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_delivery
+		WHERE webhook_id = @webhookID AND user_id = @userID
+		ORDER BY created_at DESC, id DESC LIMIT @limit`
+	args := pgx.NamedArgs{
+		"webhookID": webhookID,
+		"userID":    userID.String(),
+		"limit":     limit,
+	}
+
+	return s.queryWebhookDeliveries(ctx, query, args)
+}
+
+// ListPendingWebhookDeliveries Returns pending webhook deliveries due before given time ordered by time of the next attempt
+func (s *PostgresStorage) ListPendingWebhookDeliveries(ctx context.Context, before time.Time, limit int) ([]entity.WebhookDelivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_delivery
+		WHERE status = @status AND next_attempt_at <= @before
+		ORDER BY next_attempt_at, created_at LIMIT @limit`
+	args := pgx.NamedArgs{
+		"status": string(entity.WebhookDeliveryPending),
+		"before": before,
+		"limit":  limit,
+	}
+
+	return s.queryWebhookDeliveries(ctx, query, args)
+}
+
+func (s *PostgresStorage) queryWebhookDeliveries(ctx context.Context, query string, args pgx.NamedArgs) ([]entity.WebhookDelivery, error) {
+	rows, err := s.db.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error in postgres request execution while listing webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]entity.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing webhook delivery row in postgres: %w", err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while listing webhook deliveries: %w", rows.Err())
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhook(row rowScanner) (entity.Webhook, error) {
+	var webhook entity.Webhook
+	var userID string
+	var events []byte
+
+	err := row.Scan(&webhook.ID, &userID, &webhook.URL, &webhook.Secret, &events, &webhook.CreatedAt)
+	if err != nil {
+		return entity.Webhook{}, err
+	}
+	webhook.UserID = entity.UserID(userID)
+
+	err = json.Unmarshal(events, &webhook.Events)
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("error while decoding events of webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func scanWebhookDelivery(row rowScanner) (entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	var userID, status string
+	var payload []byte
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&userID,
+		&delivery.Event,
+		&payload,
+		&status,
+		&delivery.Attempts,
+		&delivery.ResponseCode,
+		&delivery.LastError,
+		&delivery.NextAttemptAt,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+	if err != nil {
+		return entity.WebhookDelivery{}, err
+	}
+	delivery.UserID = entity.UserID(userID)
+	delivery.Status = entity.WebhookDeliveryStatus(status)
+	delivery.Payload = payload
+
+	return delivery, nil
+}
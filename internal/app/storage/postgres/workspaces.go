@@ -0,0 +1,259 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// workspaceMemberColumns Columns of workspace_member table scanned by scanWorkspaceMember
+const workspaceMemberColumns = `workspace_id, user_id, role, created_at`
+
+// SaveWorkspace Adds workspace to postgres DB or replaces its name
+func (s *PostgresStorage) SaveWorkspace(ctx context.Context, workspace entity.Workspace) error {
+	query := `INSERT INTO workspace(id, name, created_by, created_at)
+		VALUES(@id, @name, @createdBy, @createdAt)
+		ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name`
+	args := pgx.NamedArgs{
+		"id":        workspace.ID,
+		"name":      workspace.Name,
+		"createdBy": workspace.CreatedBy.String(),
+		"createdAt": workspace.CreatedAt,
+	}
+
+	_, err := s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to save workspace to postgres: %w", err)
+	}
+
+	return nil
+}
+
+// GetWorkspace Returns workspace from postgres DB
+func (s *PostgresStorage) GetWorkspace(ctx context.Context, workspaceID string) (entity.Workspace, error) {
+	query := `SELECT id, name, created_by, created_at FROM workspace WHERE id = @id`
+	args := pgx.NamedArgs{
+		"id": workspaceID,
+	}
+
+	var workspace entity.Workspace
+	var createdBy string
+	err := s.db.QueryRowContext(ctx, query, args).Scan(&workspace.ID, &workspace.Name, &createdBy, &workspace.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.Workspace{}, api.ErrWorkspaceNotFound
+		}
+
+		return entity.Workspace{}, fmt.Errorf("error in postgres while getting workspace: %w", err)
+	}
+	workspace.CreatedBy = entity.UserID(createdBy)
+
+	return workspace, nil
+}
+
+// SaveWorkspaceMember Adds member of workspace to postgres DB or replaces its role
+func (s *PostgresStorage) SaveWorkspaceMember(ctx context.Context, member entity.WorkspaceMember) error {
+	query := `INSERT INTO workspace_member(` + workspaceMemberColumns + `)
+		VALUES(@workspaceID, @userID, @role, @createdAt)
+		ON CONFLICT (workspace_id, user_id) DO UPDATE SET role = EXCLUDED.role`
+	args := pgx.NamedArgs{
+		"workspaceID": member.WorkspaceID,
+		"userID":      member.UserID.String(),
+		"role":        string(member.Role),
+		"createdAt":   member.CreatedAt,
+	}
+
+	_, err := s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to save workspace member to postgres: %w", err)
+	}
+
+	return nil
+}
+
+// GetWorkspaceMember Returns membership of user in workspace from postgres DB
+func (s *PostgresStorage) GetWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) (entity.WorkspaceMember, error) {
+	query := `SELECT ` + workspaceMemberColumns + ` FROM workspace_member
+		WHERE workspace_id = @workspaceID AND user_id = @userID`
+	args := pgx.NamedArgs{
+		"workspaceID": workspaceID,
+		"userID":      userID.String(),
+	}
+
+	member, err := scanWorkspaceMember(s.db.QueryRowContext(ctx, query, args))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.WorkspaceMember{}, api.ErrWorkspaceMemberNotFound
+		}
+
+		return entity.WorkspaceMember{}, fmt.Errorf("error in postgres while getting workspace member: %w", err)
+	}
+
+	return member, nil
+}
+
+// ListWorkspaceMembers Returns members of workspace from postgres DB ordered by time of joining
+func (s *PostgresStorage) ListWorkspaceMembers(ctx context.Context, workspaceID string) ([]entity.WorkspaceMember, error) {
+	query := `SELECT ` + workspaceMemberColumns + ` FROM workspace_member
+		WHERE workspace_id = @workspaceID ORDER BY created_at, user_id`
+	args := pgx.NamedArgs{
+		"workspaceID": workspaceID,
+	}
+
+	return s.queryWorkspaceMembers(ctx, query, args)
+}
+
+// ListUserMemberships Returns memberships of user in workspaces from postgres DB ordered by time of joining
+func (s *PostgresStorage) ListUserMemberships(ctx context.Context, userID entity.UserID) ([]entity.WorkspaceMember, error) {
+	query := `SELECT ` + workspaceMemberColumns + ` FROM workspace_member
+		WHERE user_id = @userID ORDER BY created_at, workspace_id`
+	args := pgx.NamedArgs{
+		"userID": userID.String(),
+	}
+
+	return s.queryWorkspaceMembers(ctx, query, args)
+}
+
+// DeleteWorkspaceMember Removes user from workspace in postgres DB
+func (s *PostgresStorage) DeleteWorkspaceMember(ctx context.Context, workspaceID string, userID entity.UserID) error {
+	query := `DELETE FROM workspace_member WHERE workspace_id = @workspaceID AND user_id = @userID`
+	args := pgx.NamedArgs{
+		"workspaceID": workspaceID,
+		"userID":      userID.String(),
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to delete workspace member from postgres: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to get count of deleted workspace members in postgres: %w", err)
+	}
+
+	if count == 0 {
+		return api.ErrWorkspaceMemberNotFound
+	}
+
+	return nil
+}
+
+// SaveWorkspaceInvite Adds workspace invite to postgres DB
+func (s *PostgresStorage) SaveWorkspaceInvite(ctx context.Context, invite entity.WorkspaceInvite) error {
+	query := `INSERT INTO workspace_invite(id, workspace_id, role, created_by, created_at, expires_at)
+		VALUES(@id, @workspaceID, @role, @createdBy, @createdAt, @expiresAt)`
+	args := pgx.NamedArgs{
+		"id":          invite.ID,
+		"workspaceID": invite.WorkspaceID,
+		"role":        string(invite.Role),
+		"createdBy":   invite.CreatedBy.String(),
+		"createdAt":   invite.CreatedAt,
+		"expiresAt":   invite.ExpiresAt,
+	}
+
+	_, err := s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to save workspace invite to postgres: %w", err)
+	}
+
+	return nil
+}
+
+// GetWorkspaceInvite Returns workspace invite from postgres DB
+func (s *PostgresStorage) GetWorkspaceInvite(ctx context.Context, inviteID string) (entity.WorkspaceInvite, error) {
+	query := `SELECT id, workspace_id, role, created_by, created_at, expires_at FROM workspace_invite WHERE id = @id`
+	args := pgx.NamedArgs{
+		"id": inviteID,
+	}
+
+	var invite entity.WorkspaceInvite
+	var role, createdBy string
+	err := s.db.QueryRowContext(ctx, query, args).Scan(
+		&invite.ID,
+		&invite.WorkspaceID,
+		&role,
+		&createdBy,
+		&invite.CreatedAt,
+		&invite.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.WorkspaceInvite{}, api.ErrWorkspaceInviteNotFound
+		}
+
+		return entity.WorkspaceInvite{}, fmt.Errorf("error in postgres while getting workspace invite: %w", err)
+	}
+	invite.Role = entity.WorkspaceRole(role)
+	invite.CreatedBy = entity.UserID(createdBy)
+
+	return invite, nil
+}
+
+// DeleteWorkspaceInvite Removes workspace invite from postgres DB
+func (s *PostgresStorage) DeleteWorkspaceInvite(ctx context.Context, inviteID string) error {
+	query := `DELETE FROM workspace_invite WHERE id = @id`
+	args := pgx.NamedArgs{
+		"id": inviteID,
+	}
+
+	res, err := s.db.ExecContext(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("unable to delete workspace invite from postgres: %w", err)
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to get count of deleted workspace invites in postgres: %w", err)
+	}
+
+	if count == 0 {
+		return api.ErrWorkspaceInviteNotFound
+	}
+
+	return nil
+}
+
+func (s *PostgresStorage) queryWorkspaceMembers(ctx context.Context, query string, args pgx.NamedArgs) ([]entity.WorkspaceMember, error) {
+	rows, err := s.db.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("error in postgres request execution while listing workspace members: %w", err)
+	}
+	defer rows.Close()
+
+	members := make([]entity.WorkspaceMember, 0)
+	for rows.Next() {
+		member, err := scanWorkspaceMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error while processing workspace member row in postgres: %w", err)
+		}
+
+		members = append(members, member)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf("error in postgres requested rows while listing workspace members: %w", rows.Err())
+	}
+
+	return members, nil
+}
+
+func scanWorkspaceMember(row rowScanner) (entity.WorkspaceMember, error) {
+	var member entity.WorkspaceMember
+	var userID, role string
+
+	err := row.Scan(&member.WorkspaceID, &userID, &role, &member.CreatedAt)
+	if err != nil {
+		return entity.WorkspaceMember{}, err
+	}
+	member.UserID = entity.UserID(userID)
+	member.Role = entity.WorkspaceRole(role)
+
+	return member, nil
+}
@@ -0,0 +1,130 @@
+// Package tracing provides OpenTelemetry tracing of application
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+)
+
+// Exporters of spans
+//
+// ExporterNone - spans are not exported
+// ExporterStdout - spans are written to stdout
+// ExporterOTLP - spans are sent to OpenTelemetry collector using OTLP over GRPC
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+const (
+	serviceName = "url-shortener"
+	tracerName  = "github.com/avGenie/url-shortener"
+)
+
+// ShutdownFunc Flushes exported spans and stops tracer provider
+type ShutdownFunc func(ctx context.Context) error
+
+// Init Initializes global tracer provider and W3C trace context propagator
+func Init(config config.Config) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, err := newExporter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if exporter == nil {
+		zap.L().Info("tracing is disabled")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", serviceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error while creating tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.TracingSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	zap.L().Info("tracing is enabled", zap.String("exporter", config.TracingExporter))
+
+	return provider.Shutdown, nil
+}
+
+// ValidateConfig Validates tracing exporter, endpoint and sample ratio from config
+func ValidateConfig(config config.Config) error {
+	var errs []error
+
+	switch config.TracingExporter {
+	case ExporterNone, ExporterStdout, "":
+	case ExporterOTLP:
+		if config.TracingEndpoint == "" {
+			errs = append(errs, fmt.Errorf("tracing_exporter %q requires tracing_endpoint", config.TracingExporter))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown tracing_exporter %q", config.TracingExporter))
+	}
+
+	if config.TracingSampleRatio < 0 || config.TracingSampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing_sample_ratio %v is out of range [0, 1]", config.TracingSampleRatio))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Tracer Returns application tracer
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+func newExporter(config config.Config) (sdktrace.SpanExporter, error) {
+	switch config.TracingExporter {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("error while creating stdout span exporter: %w", err)
+		}
+
+		return exporter, nil
+	case ExporterOTLP:
+		exporter, err := otlptracegrpc.New(
+			context.Background(),
+			otlptracegrpc.WithEndpoint(config.TracingEndpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error while creating otlp span exporter: %w", err)
+		}
+
+		return exporter, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", config.TracingExporter)
+	}
+}
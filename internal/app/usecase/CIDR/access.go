@@ -0,0 +1,215 @@
+package cidr
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+)
+
+// Headers of proxies containing IP addresses of client and intermediate proxies
+const (
+	ForwardedHeader    = "Forwarded"
+	ForwardedForHeader = "X-Forwarded-For"
+)
+
+type clientIPCtxKey struct{}
+
+// HeaderValues Returns all values of header by its name
+type HeaderValues func(name string) []string
+
+// AccessControl Access control of clients by trusted subnets
+//
+// IP address of client is resolved from Forwarded, X-Forwarded-For or X-Real-IP
+// headers only if peer is a trusted proxy, otherwise address of peer is used.
+// Subnets and proxies can be replaced while AccessControl is used
+type AccessControl struct {
+	subnets *CIDR
+	proxies *CIDR
+}
+
+// NewAccessControl Creates access control from trusted subnets and trusted proxies separated by comma
+func NewAccessControl(subnets, proxies string) (*AccessControl, error) {
+	access := &AccessControl{
+		subnets: &CIDR{},
+		proxies: &CIDR{},
+	}
+
+	err := access.Set(subnets, proxies)
+	if err != nil {
+		return nil, err
+	}
+
+	return access, nil
+}
+
+// Set Replaces trusted subnets and trusted proxies
+//
+// Nothing is replaced if one of them is invalid
+func (a *AccessControl) Set(subnets, proxies string) error {
+	if _, err := config.ParseSubnets(proxies); err != nil {
+		return fmt.Errorf("error while parsing trusted proxies: %w", err)
+	}
+
+	err := a.subnets.Set(subnets)
+	if err != nil {
+		return err
+	}
+
+	return a.proxies.Set(proxies)
+}
+
+// Allowed Returns true if ip is in one of trusted subnets
+func (a *AccessControl) Allowed(ip string) bool {
+	if a == nil {
+		return false
+	}
+
+	return a.subnets.Contains(ip)
+}
+
+// ClientIP Resolves IP address of client from address of peer and forwarding headers
+//
+// Addresses of forwarding chain are checked from the nearest one, and the first address
+// which is not a trusted proxy is returned. Chain is not used if peer is not a trusted proxy
+func (a *AccessControl) ClientIP(peerAddr string, header HeaderValues) string {
+	ip := AddrIP(peerAddr)
+	if a == nil || !a.proxies.Contains(ip) {
+		return ip
+	}
+
+	chain := forwardingChain(header)
+	for i := len(chain) - 1; i >= 0; i-- {
+		hop := AddrIP(chain[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+
+		ip = hop
+		if !a.proxies.Contains(ip) {
+			break
+		}
+	}
+
+	return ip
+}
+
+// RequestIP Returns IP address of HTTP client
+//
+// IP address resolved by ClientIPMiddleware is used if it is present in request context
+func (a *AccessControl) RequestIP(r *http.Request) string {
+	if ip := ClientIPFromContext(r.Context()); ip != "" {
+		return ip
+	}
+
+	return a.ClientIP(r.RemoteAddr, r.Header.Values)
+}
+
+// ClientIPMiddleware Resolves IP address of client and stores it in request context
+func (a *AccessControl) ClientIPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := a.ClientIP(r.RemoteAddr, r.Header.Values)
+
+		next.ServeHTTP(w, r.WithContext(WithClientIP(r.Context(), ip)))
+	})
+}
+
+// Middleware Allows requests only from IP addresses of trusted subnets
+//
+// Returns 403(StatusForbidden) if subnets are unknown or IP address is not in subnets
+func (a *AccessControl) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := a.RequestIP(r)
+		if !a.Allowed(ip) {
+			zap.L().Info("forbidden request from untrusted subnet", zap.String("uri", r.RequestURI), zap.String("ip", ip))
+			w.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// WithClientIP Returns context with IP address of client
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPCtxKey{}, ip)
+}
+
+// ClientIPFromContext Returns IP address of client from context or empty string if it is absent
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPCtxKey{}).(string)
+
+	return ip
+}
+
+// AddrIP Returns IP address from network address with optional port
+func AddrIP(addr string) string {
+	addr = strings.Trim(strings.TrimSpace(addr), `"`)
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]")
+	}
+
+	return host
+}
+
+// forwardingChain Returns addresses of forwarding chain from the farthest to the nearest one
+//
+// Forwarded header has priority over X-Forwarded-For one, X-Real-IP header is used if there are none
+func forwardingChain(header HeaderValues) []string {
+	if chain := forwardedFor(header(ForwardedHeader)); len(chain) != 0 {
+		return chain
+	}
+
+	var chain []string
+	for _, value := range header(ForwardedForHeader) {
+		for _, addr := range strings.Split(value, ",") {
+			if addr = strings.TrimSpace(addr); addr != "" {
+				chain = append(chain, addr)
+			}
+		}
+	}
+
+	if len(chain) != 0 {
+		return chain
+	}
+
+	if values := header(RealIPHeader); len(values) != 0 {
+		return values[:1]
+	}
+
+	return nil
+}
+
+// forwardedFor Returns "for" parameters of Forwarded header elements
+//
+// Element without "for" parameter is kept as empty address to break the chain
+func forwardedFor(values []string) []string {
+	var res []string
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			if strings.TrimSpace(element) == "" {
+				continue
+			}
+
+			var addr string
+			for _, pair := range strings.Split(element, ";") {
+				key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if ok && strings.EqualFold(key, "for") {
+					addr = val
+				}
+			}
+
+			res = append(res, addr)
+		}
+	}
+
+	return res
+}
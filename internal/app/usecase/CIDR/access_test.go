@@ -0,0 +1,159 @@
+package cidr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessControlClientIP(t *testing.T) {
+	access, err := NewAccessControl("192.168.1.0/24", "10.0.0.0/8, fd00::/8")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		peerAddr string
+		header   map[string]string
+		expected string
+	}{
+		{
+			name:     "peer without headers",
+			peerAddr: "192.168.1.14:5000",
+			expected: "192.168.1.14",
+		},
+		{
+			name:     "headers of untrusted peer are ignored",
+			peerAddr: "203.0.113.5:5000",
+			header: map[string]string{
+				RealIPHeader:       "192.168.1.14",
+				ForwardedForHeader: "192.168.1.14",
+				ForwardedHeader:    "for=192.168.1.14",
+			},
+			expected: "203.0.113.5",
+		},
+		{
+			name:     "X-Real-IP of trusted proxy",
+			peerAddr: "10.0.0.1:5000",
+			header:   map[string]string{RealIPHeader: "192.168.1.14"},
+			expected: "192.168.1.14",
+		},
+		{
+			name:     "X-Forwarded-For chain of trusted proxies",
+			peerAddr: "10.0.0.1:5000",
+			header:   map[string]string{ForwardedForHeader: "192.168.1.14, 10.0.0.2"},
+			expected: "192.168.1.14",
+		},
+		{
+			name:     "address spoofed by client is skipped",
+			peerAddr: "10.0.0.1:5000",
+			header:   map[string]string{ForwardedForHeader: "192.168.1.14, 203.0.113.5"},
+			expected: "203.0.113.5",
+		},
+		{
+			name:     "Forwarded header has priority",
+			peerAddr: "10.0.0.1:5000",
+			header: map[string]string{
+				ForwardedHeader:    `for="[2001:db8::1]:4711";proto=https, for=10.0.0.2`,
+				ForwardedForHeader: "203.0.113.5",
+			},
+			expected: "2001:db8::1",
+		},
+		{
+			name:     "obfuscated address stops chain",
+			peerAddr: "[fd00::1]:5000",
+			header:   map[string]string{ForwardedHeader: "for=192.168.1.14, for=_hidden"},
+			expected: "fd00::1",
+		},
+		{
+			name:     "all addresses are trusted proxies",
+			peerAddr: "10.0.0.1:5000",
+			header:   map[string]string{ForwardedForHeader: "10.0.0.3, 10.0.0.2"},
+			expected: "10.0.0.3",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			header := http.Header{}
+			for key, value := range test.header {
+				header.Set(key, value)
+			}
+
+			assert.Equal(t, test.expected, access.ClientIP(test.peerAddr, header.Values))
+		})
+	}
+}
+
+func TestAccessControlMiddleware(t *testing.T) {
+	access, err := NewAccessControl("192.168.1.0/24, 2001:db8::/32", "10.0.0.0/8")
+	require.NoError(t, err)
+
+	handler := access.ClientIPMiddleware(access.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		expectedCode int
+	}{
+		{
+			name:         "IP in subnet",
+			remoteAddr:   "192.168.1.14:5000",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "IPv6 in subnet",
+			remoteAddr:   "[2001:db8::1]:5000",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "client behind trusted proxy",
+			remoteAddr:   "10.0.0.1:5000",
+			forwardedFor: "192.168.1.14",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "forwarded header from untrusted peer",
+			remoteAddr:   "203.0.113.5:5000",
+			forwardedFor: "192.168.1.14",
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil)
+			request.RemoteAddr = test.remoteAddr
+			if test.forwardedFor != "" {
+				request.Header.Set(ForwardedForHeader, test.forwardedFor)
+			}
+
+			writer := httptest.NewRecorder()
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.expectedCode, writer.Code)
+		})
+	}
+}
+
+func TestAccessControlSet(t *testing.T) {
+	access, err := NewAccessControl("192.168.1.0/24", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "10.0.0.1", access.ClientIP("10.0.0.1:5000", http.Header{ForwardedForHeader: {"192.168.1.14"}}.Values))
+
+	err = access.Set("192.168.1.0/24", "10.0.0.0/8")
+	require.NoError(t, err)
+
+	assert.Equal(t, "192.168.1.14", access.ClientIP("10.0.0.1:5000", http.Header{ForwardedForHeader: {"192.168.1.14"}}.Values))
+
+	err = access.Set("10.0.0.0/8", "invalid")
+	require.Error(t, err)
+
+	assert.True(t, access.Allowed("192.168.1.14"))
+}
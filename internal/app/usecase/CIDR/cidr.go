@@ -3,33 +3,67 @@ package cidr
 import (
 	"fmt"
 	"net"
+	"sync"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
 )
 
-// CIDR Struct describing Classless Inter-Domain Routing
+// RealIPHeader Header containing IP address of client set by proxy
+const RealIPHeader = "X-Real-IP"
+
+// CIDR Struct describing trusted subnets in Classless Inter-Domain Routing notation
+//
+// Subnets can be replaced while CIDR is used
 type CIDR struct {
-	ipNet *net.IPNet
-	ip    net.IP
+	mutex   sync.RWMutex
+	subnets []*net.IPNet
 }
 
-// NewCIDR Creates CIDR object
-func NewCIDR(subnet string) (*CIDR, error) {
-	ip, ipNet, err := net.ParseCIDR(subnet)
+// NewCIDR Creates CIDR object from subnets separated by comma
+func NewCIDR(subnets string) (*CIDR, error) {
+	cidr := &CIDR{}
+
+	err := cidr.Set(subnets)
 	if err != nil {
-		return nil, fmt.Errorf("error while parsing subnet: %w", err)
+		return nil, err
 	}
 
-	return &CIDR{
-		ip:    ip,
-		ipNet: ipNet,
-	}, nil
+	return cidr, nil
 }
 
-// Contains Returns true if ip is in CIDR subnet
-func (c CIDR) Contains(ip string) bool {
+// Set Replaces subnets by subnets separated by comma
+func (c *CIDR) Set(subnets string) error {
+	ipNets, err := config.ParseSubnets(subnets)
+	if err != nil {
+		return fmt.Errorf("error while parsing subnet: %w", err)
+	}
+
+	c.mutex.Lock()
+	c.subnets = ipNets
+	c.mutex.Unlock()
+
+	return nil
+}
+
+// Contains Returns true if ip is in one of CIDR subnets
+func (c *CIDR) Contains(ip string) bool {
+	if c == nil {
+		return false
+	}
+
 	netIP := net.ParseIP(ip)
 	if netIP == nil {
 		return false
 	}
 
-	return c.ipNet.Contains(netIP)
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	for _, subnet := range c.subnets {
+		if subnet.Contains(netIP) {
+			return true
+		}
+	}
+
+	return false
 }
@@ -26,6 +26,18 @@ func TestCIDR(t *testing.T) {
 			inputIP:        "192.168.2.14",
 			expectedOutput: false,
 		},
+		{
+			name:           "IP in second subnet",
+			subnet:         "192.168.1.0/24, 10.0.0.0/8",
+			inputIP:        "10.1.2.3",
+			expectedOutput: true,
+		},
+		{
+			name:           "empty subnet",
+			subnet:         "",
+			inputIP:        "192.168.1.14",
+			expectedOutput: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -37,3 +49,19 @@ func TestCIDR(t *testing.T) {
 		})
 	}
 }
+
+func TestCIDRSet(t *testing.T) {
+	cidr, err := NewCIDR("192.168.1.0/24")
+	require.NoError(t, err)
+
+	err = cidr.Set("10.0.0.0/8")
+	require.NoError(t, err)
+
+	assert.False(t, cidr.Contains("192.168.1.14"))
+	assert.True(t, cidr.Contains("10.1.2.3"))
+
+	err = cidr.Set("invalid")
+	require.Error(t, err)
+
+	assert.True(t, cidr.Contains("10.1.2.3"))
+}
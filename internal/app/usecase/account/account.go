@@ -0,0 +1,380 @@
+// Package account implements use cases of data subject requests of user
+//
+// User can export all data kept about it and erase it from storage
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/audit"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	cidr "github.com/avGenie/url-shortener/internal/app/usecase/CIDR"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+)
+
+// ActionUserErase Action of user erasing all its data
+const ActionUserErase = "user.erase"
+
+// ErrSoleOwner Error that will be returned if user is the only owner of workspace with other members
+//
+// Erasure would leave members without owner, so ownership should be passed to other member first
+var ErrSoleOwner = errors.New("user is the only owner of workspace with other members")
+
+// Limits of export of user data
+//
+// exportPageSize - count of links and audit records read from storage at once
+// exportDeliveriesLimit - max count of the latest deliveries exported for each webhook
+const (
+	exportPageSize        = 1000
+	exportDeliveriesLimit = 1000
+)
+
+// UserQueue In-process queue of work of users, e.g. deletion jobs or live events
+type UserQueue interface {
+	DropUser(userID entity.UserID) int
+}
+
+// Service Use cases of export and erasure of user data
+type Service struct {
+	storage model.Storage
+	domains *domain.Registry
+	queues  []UserQueue
+	now     func() time.Time
+}
+
+// NewService Creates service of user data
+//
+// Short URLs of exported links are built on their domains.
+// Work of erased user is dropped from queues, so it doesn't restore erased data
+func NewService(storage model.Storage, domains *domain.Registry, queues ...UserQueue) *Service {
+	return &Service{
+		storage: storage,
+		domains: domains,
+		queues:  queues,
+		now:     time.Now,
+	}
+}
+
+// Export Returns all data kept about user
+//
+// Links include deleted links kept in trash, secrets of webhooks are not exported.
+// Activity contains actions of user and actions of other actors targeting user without their client IP,
+// actions of other actors on links aren't exported
+func (s *Service) Export(ctx context.Context, userID entity.UserID) (models.UserExport, error) {
+	export := models.UserExport{
+		UserID:     userID.String(),
+		ExportedAt: s.now().UTC(),
+	}
+
+	links, err := s.links(ctx, userID)
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("error while exporting links of user: %w", err)
+	}
+	export.Links = links
+
+	jobs, err := s.storage.ListUserDeleteJobs(ctx, userID)
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("error while exporting delete jobs of user: %w", err)
+	}
+	export.DeleteJobs = jobsToModel(jobs)
+
+	export.Webhooks, export.WebhookDeliveries, err = s.webhooks(ctx, userID)
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("error while exporting webhooks of user: %w", err)
+	}
+
+	export.Workspaces, err = s.workspaces(ctx, userID)
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("error while exporting workspaces of user: %w", err)
+	}
+
+	export.Activity, err = s.activity(ctx, userID)
+	if err != nil {
+		return models.UserExport{}, fmt.Errorf("error while exporting activity of user: %w", err)
+	}
+
+	return export, nil
+}
+
+// Erase Erases all data of user from storage
+//
+// Links created by user in workspaces with other owners are transferred to the earliest joined owner.
+// Links, deletion jobs, webhooks, memberships and invites of user are removed,
+// workspaces created by user and audit records of user are anonymized.
+// Queued work of user is dropped after storage is erased, work in flight doesn't save its result.
+// Erasure is idempotent, repeated erasure returns zero counts.
+// Returns ErrSoleOwner error if user is the only owner of workspace with other members
+func (s *Service) Erase(ctx context.Context, userID entity.UserID) (models.UserErasure, error) {
+	var erasure models.UserErasure
+
+	err := s.checkOwnership(ctx, userID)
+	if err == nil {
+		erasure, err = s.storage.EraseUser(ctx, userID)
+	}
+	if err == nil {
+		for _, queue := range s.queues {
+			queue.DropUser(userID)
+		}
+	}
+
+	// erasure is audited without ID and client IP of erased user
+	audit.Log(cidr.WithClientIP(ctx, ""), audit.Event{
+		ActorType: entity.AuditActorUser,
+		Actor:     entity.ErasedUserID.String(),
+		Action:    ActionUserErase,
+		Err:       err,
+	})
+
+	if err != nil {
+		return models.UserErasure{}, fmt.Errorf("error while erasing user: %w", err)
+	}
+
+	return erasure, nil
+}
+
+// checkOwnership Returns ErrSoleOwner error with ID of workspace if user is the only owner of workspace with other members
+func (s *Service) checkOwnership(ctx context.Context, userID entity.UserID) error {
+	memberships, err := s.storage.ListUserMemberships(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error while listing workspaces of user: %w", err)
+	}
+
+	for _, membership := range memberships {
+		if membership.Role != entity.WorkspaceOwner {
+			continue
+		}
+
+		members, err := s.storage.ListWorkspaceMembers(ctx, membership.WorkspaceID)
+		if err != nil {
+			return fmt.Errorf("error while listing workspace members: %w", err)
+		}
+
+		if isSoleOwner(members, userID) {
+			return fmt.Errorf("%w %q", ErrSoleOwner, membership.WorkspaceID)
+		}
+	}
+
+	return nil
+}
+
+// isSoleOwner Returns true if workspace has other members, but none of them is owner
+func isSoleOwner(members []entity.WorkspaceMember, userID entity.UserID) bool {
+	others := false
+	for _, member := range members {
+		if member.UserID == userID {
+			continue
+		}
+
+		if member.Role == entity.WorkspaceOwner {
+			return false
+		}
+		others = true
+	}
+
+	return others
+}
+
+func (s *Service) links(ctx context.Context, userID entity.UserID) ([]models.ExportedLink, error) {
+	res := make([]models.ExportedLink, 0)
+
+	var after entity.LinkCursor
+	for {
+		links, err := s.storage.ListUserLinks(ctx, userID, after, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, link := range links {
+			res = append(res, s.linkToModel(link))
+		}
+
+		if len(links) < exportPageSize {
+			return res, nil
+		}
+
+		after = links[len(links)-1].Cursor()
+	}
+}
+
+func (s *Service) webhooks(ctx context.Context, userID entity.UserID) ([]models.Webhook, []models.WebhookDelivery, error) {
+	webhooks, err := s.storage.ListWebhooks(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resWebhooks := make([]models.Webhook, 0, len(webhooks))
+	resDeliveries := make([]models.WebhookDelivery, 0)
+	for _, webhook := range webhooks {
+		resWebhooks = append(resWebhooks, webhookToModel(webhook))
+
+		deliveries, err := s.storage.ListWebhookDeliveries(ctx, userID, webhook.ID, exportDeliveriesLimit)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, delivery := range deliveries {
+			resDeliveries = append(resDeliveries, deliveryToModel(delivery))
+		}
+	}
+
+	return resWebhooks, resDeliveries, nil
+}
+
+func (s *Service) workspaces(ctx context.Context, userID entity.UserID) ([]models.Workspace, error) {
+	memberships, err := s.storage.ListUserMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]models.Workspace, 0, len(memberships))
+	for _, member := range memberships {
+		workspace, err := s.storage.GetWorkspace(ctx, member.WorkspaceID)
+		if errors.Is(err, api.ErrWorkspaceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, models.Workspace{
+			ID:        workspace.ID,
+			Name:      workspace.Name,
+			Role:      string(member.Role),
+			CreatedBy: workspace.CreatedBy.String(),
+			CreatedAt: workspace.CreatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+// activity Returns audit records of actions of user and of actions of other actors targeting user, the newest first
+//
+// Client IP of other actors is removed. Records targeting links are included only if user is their actor,
+// short IDs are shared by all owners of the same original URL and can't be attributed to one user
+func (s *Service) activity(ctx context.Context, userID entity.UserID) ([]models.AuditRecord, error) {
+	own, err := s.auditRecords(ctx, entity.AuditFilter{
+		ActorType: entity.AuditActorUser,
+		Actor:     userID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	targeting, err := s.auditRecords(ctx, entity.AuditFilter{
+		Target: userID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	records := own
+	for _, record := range targeting {
+		if record.ActorType == entity.AuditActorUser && record.Actor == userID.String() {
+			continue
+		}
+
+		record.ClientIP = ""
+		records = append(records, record)
+	}
+
+	slices.SortFunc(records, func(a, b entity.AuditRecord) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+
+		return strings.Compare(b.ID, a.ID)
+	})
+
+	return audit.PageToModel(records, "").Records, nil
+}
+
+func (s *Service) auditRecords(ctx context.Context, filter entity.AuditFilter) ([]entity.AuditRecord, error) {
+	res := make([]entity.AuditRecord, 0)
+	for {
+		records, err := s.storage.ListAuditRecords(ctx, filter, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, records...)
+
+		if len(records) < exportPageSize {
+			return res, nil
+		}
+
+		filter.After = records[len(records)-1].Cursor()
+	}
+}
+
+func (s *Service) linkToModel(link entity.Link) models.ExportedLink {
+	res := models.ExportedLink{
+		ShortURL:    s.domains.ShortURL(link.ShortURL),
+		Alias:       link.ShortURL,
+		OriginalURL: link.OriginalURL,
+		WorkspaceID: link.WorkspaceID,
+		CreatedAt:   link.CreatedAt,
+		Deleted:     link.Deleted,
+		Disabled:    link.Disabled,
+		Metadata:    link.Metadata,
+	}
+
+	if link.Deleted && !link.DeletedAt.IsZero() {
+		res.DeletedAt = &link.DeletedAt
+	}
+
+	return res
+}
+
+func jobsToModel(jobs []entity.DeleteJob) []models.ExportedDeleteJob {
+	res := make([]models.ExportedDeleteJob, 0, len(jobs))
+	for _, job := range jobs {
+		res = append(res, models.ExportedDeleteJob{
+			ID:        job.ID,
+			ShortURLs: job.ShortURLs,
+			Status:    string(job.Status),
+			CreatedAt: job.CreatedAt,
+			UpdatedAt: job.UpdatedAt,
+		})
+	}
+
+	return res
+}
+
+func webhookToModel(webhook entity.Webhook) models.Webhook {
+	return models.Webhook{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+func deliveryToModel(delivery entity.WebhookDelivery) models.WebhookDelivery {
+	res := models.WebhookDelivery{
+		ID:           delivery.ID,
+		WebhookID:    delivery.WebhookID,
+		Event:        delivery.Event,
+		Payload:      delivery.Payload,
+		Status:       string(delivery.Status),
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode,
+		LastError:    delivery.LastError,
+		CreatedAt:    delivery.CreatedAt,
+		UpdatedAt:    delivery.UpdatedAt,
+	}
+
+	if delivery.Status == entity.WebhookDeliveryPending {
+		res.NextAttemptAt = &delivery.NextAttemptAt
+	}
+
+	return res
+}
@@ -0,0 +1,225 @@
+package account
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	handlers "github.com/avGenie/url-shortener/internal/app/handlers/delete"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/local"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+)
+
+func TestErasePendingDeleteJob(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	ctx := context.Background()
+
+	domains, err := domain.NewRegistry(config.Config{BaseURIPrefix: "http://localhost:8080"})
+	require.NoError(t, err)
+
+	storage := local.NewTSLocalStorage(0)
+	require.NoError(t, storage.SaveLink(ctx, entity.Link{
+		ShortURL:    "42b3e75f",
+		OriginalURL: "https://practicum.yandex.ru/",
+		UserID:      userID,
+	}))
+
+	deleteHandler := handlers.NewDeleteHandler(storage, config.Config{
+		DeleteQueueSize: 10,
+		DeleteWorkers:   1,
+	})
+
+	jobID, err := deleteHandler.ProcessDeletedURLs(ctx, userID, []string{"42b3e75f"})
+	require.NoError(t, err)
+
+	erasure, err := NewService(storage, domains, deleteHandler).Erase(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, erasure.Links)
+	assert.Equal(t, 1, erasure.DeleteJobs)
+
+	count, err := deleteHandler.Flush(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	deleteHandler.Stop()
+
+	_, err = storage.GetDeleteJob(ctx, "", jobID)
+	assert.ErrorIs(t, err, api.ErrDeleteJobNotFound)
+
+	jobs, err := storage.ClaimPendingDeleteJobs(ctx, time.Now().Add(time.Hour), time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestEraseWorkspaces(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	memberID := entity.UserID("0c0a4811-4f10-487f-bde3-e39a14af7cd8")
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		memberRole    entity.WorkspaceRole
+		err           error
+		wantOwner     entity.UserID
+		wantErasure   models.UserErasure
+		wantLinkFound bool
+	}{
+		{
+			name:       "sole owner with other members",
+			memberRole: entity.WorkspaceEditor,
+			err:        ErrSoleOwner,
+			// nothing is erased
+			wantOwner:     userID,
+			wantLinkFound: true,
+		},
+		{
+			name:       "links transferred to other owner",
+			memberRole: entity.WorkspaceOwner,
+			wantOwner:  memberID,
+			wantErasure: models.UserErasure{
+				Links:            1,
+				TransferredLinks: 1,
+				Memberships:      1,
+				Workspaces:       1,
+			},
+			wantLinkFound: true,
+		},
+		{
+			name: "the only member of workspace",
+			wantErasure: models.UserErasure{
+				Links:       2,
+				Memberships: 1,
+				Workspaces:  1,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			domains, err := domain.NewRegistry(config.Config{BaseURIPrefix: "http://localhost:8080"})
+			require.NoError(t, err)
+
+			storage := local.NewTSLocalStorage(0)
+			require.NoError(t, storage.SaveWorkspace(ctx, entity.Workspace{
+				ID:        "team",
+				Name:      "Team",
+				CreatedBy: userID,
+				CreatedAt: createdAt,
+			}))
+			require.NoError(t, storage.SaveWorkspaceMember(ctx, entity.WorkspaceMember{
+				WorkspaceID: "team",
+				UserID:      userID,
+				Role:        entity.WorkspaceOwner,
+				CreatedAt:   createdAt,
+			}))
+			if test.memberRole != "" {
+				require.NoError(t, storage.SaveWorkspaceMember(ctx, entity.WorkspaceMember{
+					WorkspaceID: "team",
+					UserID:      memberID,
+					Role:        test.memberRole,
+					CreatedAt:   createdAt.Add(time.Minute),
+				}))
+			}
+
+			require.NoError(t, storage.SaveLink(ctx, entity.Link{
+				ShortURL:    "42b3e75f",
+				OriginalURL: "https://practicum.yandex.ru/",
+				UserID:      userID,
+			}))
+			require.NoError(t, storage.SaveLink(ctx, entity.Link{
+				ShortURL:    "77fca595",
+				OriginalURL: "https://practicum.yandex.ru/team",
+				UserID:      userID,
+				WorkspaceID: "team",
+			}))
+
+			erasure, err := NewService(storage, domains).Erase(ctx, userID)
+			require.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.wantErasure, erasure)
+
+			link, err := storage.GetLink(ctx, "", "77fca595")
+			if !test.wantLinkFound {
+				assert.ErrorIs(t, err, api.ErrShortURLNotFound)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.wantOwner, link.UserID)
+			assert.Equal(t, "team", link.WorkspaceID)
+		})
+	}
+}
+
+func TestExportActivity(t *testing.T) {
+	userID := entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	otherID := entity.UserID("0c0a4811-4f10-487f-bde3-e39a14af7cd8")
+	createdAt := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	domains, err := domain.NewRegistry(config.Config{BaseURIPrefix: "http://localhost:8080"})
+	require.NoError(t, err)
+
+	storage := local.NewTSLocalStorage(0)
+	records := []entity.AuditRecord{
+		{
+			ID:        "1",
+			ActorType: entity.AuditActorUser,
+			Actor:     userID.String(),
+			Action:    "link.create",
+			Targets:   []string{"42b3e75f"},
+			ClientIP:  "192.168.0.1",
+			CreatedAt: createdAt,
+		},
+		{
+			ID:        "2",
+			ActorType: entity.AuditActorAdmin,
+			Actor:     "admin",
+			Action:    "admin.user.delete_links",
+			Targets:   []string{userID.String()},
+			ClientIP:  "10.0.0.1",
+			CreatedAt: createdAt.Add(time.Minute),
+		},
+		{
+			ID:        "3",
+			ActorType: entity.AuditActorUser,
+			Actor:     otherID.String(),
+			Action:    "link.create",
+			Targets:   []string{"42b3e75f"},
+			ClientIP:  "192.168.0.2",
+			CreatedAt: createdAt.Add(2 * time.Minute),
+		},
+	}
+	for _, record := range records {
+		require.NoError(t, storage.SaveAuditRecord(ctx, record))
+	}
+
+	export, err := NewService(storage, domains).Export(ctx, userID)
+	require.NoError(t, err)
+
+	assert.Equal(t, []models.AuditRecord{
+		{
+			ID:        "2",
+			ActorType: entity.AuditActorAdmin,
+			Actor:     "admin",
+			Action:    "admin.user.delete_links",
+			Targets:   []string{userID.String()},
+			CreatedAt: createdAt.Add(time.Minute),
+		},
+		{
+			ID:        "1",
+			ActorType: entity.AuditActorUser,
+			Actor:     userID.String(),
+			Action:    "link.create",
+			Targets:   []string{"42b3e75f"},
+			ClientIP:  "192.168.0.1",
+			CreatedAt: createdAt,
+		},
+	}, export.Activity)
+}
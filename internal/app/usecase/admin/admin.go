@@ -0,0 +1,233 @@
+// Package admin implements use cases of service administration
+//
+// Every admin action is written to audit log with name of admin from context
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/avGenie/url-shortener/internal/app/audit"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+// Actions of admins written to audit log
+const (
+	ActionGetLink          = "admin.link.get"
+	ActionDisableLink      = "admin.link.disable"
+	ActionEnableLink       = "admin.link.enable"
+	ActionDeleteUserLinks  = "admin.user.delete_links"
+	ActionFlushDeleteQueue = "admin.delete_queue.flush"
+	ActionGetDeleteQueue   = "admin.delete_queue.get"
+	ActionCompactStorage   = "admin.storage.compact"
+	ActionGetLogLevel      = "admin.log_level.get"
+	ActionSetLogLevel      = "admin.log_level.set"
+	ActionListAudit        = "admin.audit.list"
+)
+
+// Errors returning by admin use cases
+//
+// ErrInvalidLogLevel - log level is unknown
+// ErrInvalidUserID - user id is empty
+// ErrCompactionNotSupported - storage doesn't support compaction
+var (
+	ErrInvalidLogLevel        = errors.New("invalid log level")
+	ErrInvalidUserID          = errors.New("invalid user id")
+	ErrCompactionNotSupported = errors.New("storage doesn't support compaction")
+)
+
+// DeleteQueue Deletes user URLs in background, flushes them on demand and reports its state
+type DeleteQueue interface {
+	link.Deleter
+	Flush(ctx context.Context) (int, error)
+	Stats() models.DeleteQueueStats
+}
+
+// Service Use cases of service administration
+type Service struct {
+	storage model.Storage
+	queue   DeleteQueue
+	links   *link.Service
+	trail   *audit.Trail
+}
+
+// NewService Creates service of administration
+func NewService(storage model.Storage, queue DeleteQueue, domains *domain.Registry) *Service {
+	return &Service{
+		storage: storage,
+		queue:   queue,
+		links:   link.NewService(storage, queue, domains),
+		trail:   audit.NewTrail(storage),
+	}
+}
+
+// ShortURL Returns full short URL of link
+func (s *Service) ShortURL(link entity.Link) string {
+	return s.links.ShortURL(link)
+}
+
+// GetLink Returns link of any user by short ID or full short URL
+//
+// Deleted and disabled links are returned with their states
+func (s *Service) GetLink(ctx context.Context, shortURL string) (_ entity.Link, err error) {
+	defer s.audit(ctx, ActionGetLink, shortURL, &err)
+
+	return s.links.Get(ctx, "", shortURL)
+}
+
+// SetLinkDisabled Disables link of any user globally or enables it again
+//
+// Disabled link is not redirected until it is enabled
+func (s *Service) SetLinkDisabled(ctx context.Context, shortURL string, disabled bool) (_ entity.Link, err error) {
+	action := ActionEnableLink
+	if disabled {
+		action = ActionDisableLink
+	}
+	defer s.audit(ctx, action, shortURL, &err)
+
+	found, err := s.links.Get(ctx, "", shortURL)
+	if err != nil {
+		return entity.Link{}, err
+	}
+
+	err = s.storage.SetLinkDisabled(ctx, found.ShortURL, disabled)
+	if err != nil {
+		if errors.Is(err, storage_err.ErrShortURLNotFound) {
+			return entity.Link{}, link.ErrLinkNotFound
+		}
+
+		return entity.Link{}, fmt.Errorf("error while changing disabled state of link: %w", err)
+	}
+	found.Disabled = disabled
+
+	return found, nil
+}
+
+// DeleteUserLinks Deletes all not deleted links of user in background
+//
+// Returns empty job ID if user doesn't have links
+func (s *Service) DeleteUserLinks(ctx context.Context, userID entity.UserID) (_ models.AdminDeletion, err error) {
+	defer s.audit(ctx, ActionDeleteUserLinks, userID.String(), &err)
+
+	if !userID.IsValid() {
+		return models.AdminDeletion{}, &link.FieldError{Field: "user_id", Err: ErrInvalidUserID}
+	}
+
+	links, err := s.links.All(ctx, userID)
+	if err != nil {
+		return models.AdminDeletion{}, fmt.Errorf("error while getting links of user: %w", err)
+	}
+
+	if len(links) == 0 {
+		return models.AdminDeletion{}, nil
+	}
+
+	shortURLs := make([]string, 0, len(links))
+	for _, val := range links {
+		shortURLs = append(shortURLs, val.ShortURL)
+	}
+
+	jobID, err := s.links.Delete(ctx, userID, shortURLs)
+	if err != nil {
+		return models.AdminDeletion{}, err
+	}
+
+	return models.AdminDeletion{
+		JobID: jobID,
+		Count: len(shortURLs),
+	}, nil
+}
+
+// FlushDeleteQueue Flushes queued deleted URLs to the storage
+func (s *Service) FlushDeleteQueue(ctx context.Context) (_ models.AdminFlush, err error) {
+	defer s.audit(ctx, ActionFlushDeleteQueue, "", &err)
+
+	count, err := s.queue.Flush(ctx)
+	if err != nil {
+		return models.AdminFlush{}, fmt.Errorf("error while flushing delete queue: %w", err)
+	}
+
+	return models.AdminFlush{Count: count}, nil
+}
+
+// DeleteQueueStats Returns state of queue of deletion jobs and its workers
+func (s *Service) DeleteQueueStats(ctx context.Context) models.DeleteQueueStats {
+	s.audit(ctx, ActionGetDeleteQueue, "", new(error))
+
+	return s.queue.Stats()
+}
+
+// CompactStorage Rewrites storage data without history of changes
+//
+// Returns ErrCompactionNotSupported error if storage doesn't support compaction
+func (s *Service) CompactStorage(ctx context.Context) (_ models.CompactionResult, err error) {
+	defer s.audit(ctx, ActionCompactStorage, "", &err)
+
+	compactor, ok := s.storage.(model.Compactor)
+	if !ok {
+		return models.CompactionResult{}, ErrCompactionNotSupported
+	}
+
+	res, err := compactor.Compact(ctx)
+	if err != nil {
+		if errors.Is(err, storage_err.ErrCompactionNotSupported) {
+			return models.CompactionResult{}, ErrCompactionNotSupported
+		}
+
+		return models.CompactionResult{}, fmt.Errorf("error while compacting storage: %w", err)
+	}
+
+	return res, nil
+}
+
+// LogLevel Returns current log level
+func (s *Service) LogLevel(ctx context.Context) models.LogLevel {
+	s.audit(ctx, ActionGetLogLevel, "", new(error))
+
+	return models.LogLevel{Level: logger.Level()}
+}
+
+// SetLogLevel Changes log level at runtime
+//
+// Level is reset to configured one on config reload
+func (s *Service) SetLogLevel(ctx context.Context, level string) (_ models.LogLevel, err error) {
+	defer s.audit(ctx, ActionSetLogLevel, level, &err)
+
+	err = logger.SetLevel(level)
+	if err != nil {
+		return models.LogLevel{}, &link.FieldError{Field: "level", Err: fmt.Errorf("%w: %q", ErrInvalidLogLevel, level)}
+	}
+
+	return models.LogLevel{Level: logger.Level()}, nil
+}
+
+// ListAuditRecords Returns page of records of audit trail satisfying filter, the newest first, and token of the next page
+//
+// Token of the next page is empty if there are no more records
+func (s *Service) ListAuditRecords(
+	ctx context.Context,
+	filter entity.AuditFilter,
+	pageSize int,
+	pageToken string,
+) (_ []entity.AuditRecord, _ string, err error) {
+	defer s.audit(ctx, ActionListAudit, "", &err)
+
+	return s.trail.List(ctx, filter, pageSize, pageToken)
+}
+
+func (s *Service) audit(ctx context.Context, action, target string, err *error) {
+	audit.Log(ctx, audit.Event{
+		ActorType: entity.AuditActorAdmin,
+		Actor:     ActorFromContext(ctx),
+		Action:    action,
+		Target:    target,
+		Err:       *err,
+	})
+}
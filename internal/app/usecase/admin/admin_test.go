@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/local"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+const (
+	baseURIPrefix = "http://localhost:8080"
+
+	userID = entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+)
+
+type testQueue struct {
+	userID  entity.UserID
+	batch   models.ReqDeletedURLBatch
+	flushed int
+}
+
+func (q *testQueue) ProcessDeletedURLs(_ context.Context, userID entity.UserID, batch models.ReqDeletedURLBatch) (string, error) {
+	q.userID = userID
+	q.batch = batch
+
+	return "job", nil
+}
+
+func (q *testQueue) Flush(_ context.Context) (int, error) {
+	return q.flushed, nil
+}
+
+func (q *testQueue) Stats() models.DeleteQueueStats {
+	return models.DeleteQueueStats{QueuedJobs: q.flushed, Capacity: 10}
+}
+
+func newTestService(t *testing.T, queue *testQueue) (*Service, *local.TSLocalStorage, entity.Link) {
+	storage := local.NewTSLocalStorage(0)
+
+	domains, err := domain.NewRegistry(config.Config{BaseURIPrefix: baseURIPrefix})
+	require.NoError(t, err)
+
+	service := NewService(storage, queue, domains)
+
+	created, err := service.links.Create(context.Background(), userID, "", "https://practicum.yandex.ru/", nil)
+	require.NoError(t, err)
+
+	return service, storage, created
+}
+
+func TestGetLink(t *testing.T) {
+	service, _, created := newTestService(t, &testQueue{})
+	ctx := WithActor(context.Background(), "alice")
+
+	found, err := service.GetLink(ctx, service.ShortURL(created))
+	require.NoError(t, err)
+	assert.Equal(t, userID, found.UserID)
+	assert.Equal(t, created.OriginalURL, found.OriginalURL)
+
+	_, err = service.GetLink(ctx, "unknown")
+	assert.ErrorIs(t, err, link.ErrLinkNotFound)
+}
+
+func TestSetLinkDisabled(t *testing.T) {
+	service, storage, created := newTestService(t, &testQueue{})
+	ctx := WithActor(context.Background(), "alice")
+
+	shortURL, err := entity.ParseURL(created.ShortURL)
+	require.NoError(t, err)
+
+	disabled, err := service.SetLinkDisabled(ctx, created.ShortURL, true)
+	require.NoError(t, err)
+	assert.True(t, disabled.Disabled)
+
+	_, err = storage.GetURL(ctx, "", *shortURL)
+	assert.ErrorIs(t, err, storage_err.ErrURLDisabled)
+
+	found, err := service.GetLink(ctx, created.ShortURL)
+	require.NoError(t, err)
+	assert.True(t, found.Disabled)
+
+	enabled, err := service.SetLinkDisabled(ctx, service.ShortURL(created), false)
+	require.NoError(t, err)
+	assert.False(t, enabled.Disabled)
+
+	_, err = storage.GetURL(ctx, "", *shortURL)
+	assert.NoError(t, err)
+
+	_, err = service.SetLinkDisabled(ctx, "unknown", true)
+	assert.ErrorIs(t, err, link.ErrLinkNotFound)
+}
+
+func TestDeleteUserLinks(t *testing.T) {
+	queue := &testQueue{}
+	service, _, created := newTestService(t, queue)
+	ctx := WithActor(context.Background(), "alice")
+
+	deletion, err := service.DeleteUserLinks(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, models.AdminDeletion{JobID: "job", Count: 1}, deletion)
+	assert.Equal(t, userID, queue.userID)
+	assert.Equal(t, models.ReqDeletedURLBatch{created.ShortURL}, queue.batch)
+
+	deletion, err = service.DeleteUserLinks(ctx, "a0a2b3b2-7c1c-4c7a-b7f5-5b1f6a9e0c11")
+	require.NoError(t, err)
+	assert.Equal(t, models.AdminDeletion{}, deletion)
+
+	_, err = service.DeleteUserLinks(ctx, "")
+	require.ErrorIs(t, err, ErrInvalidUserID)
+
+	var fieldErr *link.FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "user_id", fieldErr.Field)
+}
+
+func TestFlushDeleteQueue(t *testing.T) {
+	service, _, _ := newTestService(t, &testQueue{flushed: 3})
+
+	flush, err := service.FlushDeleteQueue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, models.AdminFlush{Count: 3}, flush)
+}
+
+func TestDeleteQueueStats(t *testing.T) {
+	service, _, _ := newTestService(t, &testQueue{flushed: 3})
+
+	assert.Equal(t, models.DeleteQueueStats{QueuedJobs: 3, Capacity: 10}, service.DeleteQueueStats(context.Background()))
+}
+
+func TestCompactStorageNotSupported(t *testing.T) {
+	service, _, _ := newTestService(t, &testQueue{})
+
+	_, err := service.CompactStorage(context.Background())
+	assert.ErrorIs(t, err, ErrCompactionNotSupported)
+}
+
+func TestSetLogLevel(t *testing.T) {
+	service, _, _ := newTestService(t, &testQueue{})
+	ctx := context.Background()
+
+	current := service.LogLevel(ctx).Level
+	defer logger.SetLevel(current)
+
+	level, err := service.SetLogLevel(ctx, "debug")
+	require.NoError(t, err)
+	assert.Equal(t, models.LogLevel{Level: "debug"}, level)
+	assert.Equal(t, level, service.LogLevel(ctx))
+
+	_, err = service.SetLogLevel(ctx, "verbose")
+	require.ErrorIs(t, err, ErrInvalidLogLevel)
+	assert.Equal(t, level, service.LogLevel(ctx))
+}
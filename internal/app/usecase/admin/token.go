@@ -0,0 +1,162 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/logger"
+)
+
+// AuthorizationHeader Header and GRPC metadata key containing admin token in format "Bearer <token>"
+const AuthorizationHeader = "Authorization"
+
+const (
+	bearerScheme = "Bearer"
+
+	minTokenLen = 16
+)
+
+// ErrInvalidToken Error that will be returned if admin token couldn't be parsed
+var ErrInvalidToken = errors.New("invalid admin token")
+
+type actorCtxKey struct{}
+
+// Authenticator Identifies admins by tokens
+type Authenticator struct {
+	tokens map[string]string
+}
+
+// NewAuthenticator Creates authenticator using admin tokens from config
+//
+// Admin API is disabled if there are no tokens in config
+func NewAuthenticator(config config.Config) (*Authenticator, error) {
+	tokens, err := ParseTokens(config.AdminTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Authenticator{
+		tokens: tokens,
+	}, nil
+}
+
+// ValidateConfig Validates admin tokens from config
+func ValidateConfig(config config.Config) error {
+	_, err := ParseTokens(config.AdminTokens)
+	if err != nil {
+		return fmt.Errorf("invalid admin_tokens: %w", err)
+	}
+
+	return nil
+}
+
+// ParseTokens Parses admin tokens in format "name:token,..." and returns admin names by tokens
+//
+// Name is written to audit log as actor of admin actions
+func ParseTokens(raw string) (map[string]string, error) {
+	tokens := make(map[string]string)
+	if strings.TrimSpace(raw) == "" {
+		return tokens, nil
+	}
+
+	names := make(map[string]bool)
+	// tokens are secret, so they are never written to errors
+	for i, item := range strings.Split(raw, ",") {
+		name, token, ok := strings.Cut(strings.TrimSpace(item), ":")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%w: name is required in item %d", ErrInvalidToken, i+1)
+		}
+
+		if len(token) < minTokenLen {
+			return nil, fmt.Errorf("%w: token of %q must contain at least %d characters", ErrInvalidToken, name, minTokenLen)
+		}
+
+		if names[name] {
+			return nil, fmt.Errorf("%w: duplicated name %q", ErrInvalidToken, name)
+		}
+
+		if _, ok := tokens[token]; ok {
+			return nil, fmt.Errorf("%w: token of %q is used by another admin", ErrInvalidToken, name)
+		}
+
+		names[name] = true
+		tokens[token] = name
+	}
+
+	return tokens, nil
+}
+
+// Enabled Returns true if there is at least one admin token
+func (a *Authenticator) Enabled() bool {
+	return a != nil && len(a.tokens) != 0
+}
+
+// Authenticate Returns name of admin by authorization value in format "Bearer <token>"
+//
+// Returns false if token is missing or unknown
+func (a *Authenticator) Authenticate(authorization string) (string, bool) {
+	if !a.Enabled() {
+		return "", false
+	}
+
+	scheme, token, ok := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !ok || !strings.EqualFold(scheme, bearerScheme) {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+
+	// all tokens are compared to not reveal position of matched one
+	var name string
+	for known, admin := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(known), []byte(token)) == 1 {
+			name = admin
+		}
+	}
+
+	return name, name != ""
+}
+
+// Middleware Allows requests only with admin token in Authorization header
+//
+// Name of admin is stored in request context.
+// Returns 403(StatusForbidden) if admin API is disabled
+// Returns 401(StatusUnauthorized) if token is missing or unknown
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.Enabled() {
+			http.Error(w, "admin API is disabled", http.StatusForbidden)
+			return
+		}
+
+		actor, ok := a.Authenticate(r.Header.Get(AuthorizationHeader))
+		if !ok {
+			logger.FromContext(r.Context()).Info("unauthenticated admin request", zap.String("uri", r.RequestURI))
+			w.Header().Set("WWW-Authenticate", bearerScheme)
+			http.Error(w, "invalid admin token", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := logger.With(WithActor(r.Context(), actor), zap.String("admin", actor))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WithActor Returns context with name of admin
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorCtxKey{}, actor)
+}
+
+// ActorFromContext Returns name of admin from context or empty string if it is absent
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorCtxKey{}).(string)
+
+	return actor
+}
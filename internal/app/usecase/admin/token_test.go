@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+)
+
+const (
+	aliceToken = "alice-secret-token-1"
+	bobToken   = "bob-secret-token-22"
+)
+
+func TestParseTokens(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected map[string]string
+		isError  bool
+	}{
+		{
+			name:     "empty tokens",
+			raw:      "",
+			expected: map[string]string{},
+		},
+		{
+			name: "several admins",
+			raw:  "alice:" + aliceToken + ", bob:" + bobToken,
+			expected: map[string]string{
+				aliceToken: "alice",
+				bobToken:   "bob",
+			},
+		},
+		{
+			name:    "missing name",
+			raw:     ":" + aliceToken,
+			isError: true,
+		},
+		{
+			name:    "short token",
+			raw:     "alice:secret",
+			isError: true,
+		},
+		{
+			name:    "duplicated name",
+			raw:     "alice:" + aliceToken + ",alice:" + bobToken,
+			isError: true,
+		},
+		{
+			name:    "duplicated token",
+			raw:     "alice:" + aliceToken + ",bob:" + aliceToken,
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens, err := ParseTokens(test.raw)
+			if test.isError {
+				require.ErrorIs(t, err, ErrInvalidToken)
+				assert.NotContains(t, err.Error(), aliceToken)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, tokens)
+		})
+	}
+}
+
+func TestAuthenticatorMiddleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		tokens        string
+		authorization string
+		expectedCode  int
+		expectedActor string
+	}{
+		{
+			name:          "admin API is disabled",
+			authorization: "Bearer " + aliceToken,
+			expectedCode:  http.StatusForbidden,
+		},
+		{
+			name:         "missing token",
+			tokens:       "alice:" + aliceToken,
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "unknown token",
+			tokens:        "alice:" + aliceToken,
+			authorization: "Bearer " + bobToken,
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "token without scheme",
+			tokens:        "alice:" + aliceToken,
+			authorization: aliceToken,
+			expectedCode:  http.StatusUnauthorized,
+		},
+		{
+			name:          "known token",
+			tokens:        "alice:" + aliceToken + ",bob:" + bobToken,
+			authorization: "bearer " + bobToken,
+			expectedCode:  http.StatusOK,
+			expectedActor: "bob",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authenticator, err := NewAuthenticator(config.Config{AdminTokens: test.tokens})
+			require.NoError(t, err)
+
+			var actor string
+			handler := authenticator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor = ActorFromContext(r.Context())
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			request := httptest.NewRequest(http.MethodGet, "/api/admin/log-level", nil)
+			if test.authorization != "" {
+				request.Header.Set(AuthorizationHeader, test.authorization)
+			}
+
+			writer := httptest.NewRecorder()
+			handler.ServeHTTP(writer, request)
+
+			assert.Equal(t, test.expectedCode, writer.Code)
+			assert.Equal(t, test.expectedActor, actor)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+// Package domain implements branded short domains with their own namespaces of short codes
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+const (
+	optionNotFound = "not_found"
+	optionUsers    = "users"
+
+	namespaceSeparator = "/"
+)
+
+// Errors of domains
+//
+// ErrInvalidDomain - domain settings couldn't be parsed
+// ErrUnknownDomain - requested domain is not configured
+// ErrDomainForbidden - user is not allowed to create links on domain
+var (
+	ErrInvalidDomain   = errors.New("invalid domain")
+	ErrUnknownDomain   = errors.New("unknown domain")
+	ErrDomainForbidden = errors.New("domain is not allowed for user")
+)
+
+// Domain Short domain with its own namespace of short codes
+//
+// Codes of default domain are kept in storage as is, codes of other domains are prefixed with host name of domain.
+// NotFoundURL is the page unknown codes of domain are redirected to, 404 is returned if it is empty.
+// Links on domain can be created only by allowed users, any user is allowed if list of users is empty
+type Domain struct {
+	Host        string
+	BaseURL     string
+	NotFoundURL string
+	Users       map[entity.UserID]struct{}
+	Default     bool
+}
+
+// Registry Configured short domains by host name
+type Registry struct {
+	defaultDomain Domain
+	domains       map[string]Domain
+}
+
+// ValidateConfig Validates settings of domains from config
+func ValidateConfig(config config.Config) error {
+	_, err := NewRegistry(config)
+
+	return err
+}
+
+// NewRegistry Creates registry of default domain of base URL and domains from config
+//
+// Domain with host of base URL configures not found page and allowed users of default domain
+func NewRegistry(config config.Config) (*Registry, error) {
+	defaultDomain, err := parseBaseURL(config.BaseURIPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base_url: %w", err)
+	}
+	defaultDomain.Default = true
+
+	domains, err := ParseDomains(config.Domains)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domains: %w", err)
+	}
+
+	if domain, ok := domains[defaultDomain.Host]; ok {
+		domain.BaseURL = defaultDomain.BaseURL
+		domain.Default = true
+		defaultDomain = domain
+	}
+	domains[defaultDomain.Host] = defaultDomain
+
+	return &Registry{
+		defaultDomain: defaultDomain,
+		domains:       domains,
+	}, nil
+}
+
+// ParseDomains Parses domains in format "base_url[;not_found=url][;users=id|id...],..."
+func ParseDomains(raw string) (map[string]Domain, error) {
+	domains := make(map[string]Domain)
+	if strings.TrimSpace(raw) == "" {
+		return domains, nil
+	}
+
+	for _, item := range strings.Split(raw, ",") {
+		options := strings.Split(strings.TrimSpace(item), ";")
+
+		domain, err := parseBaseURL(options[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidDomain, item, err)
+		}
+
+		for _, option := range options[1:] {
+			name, value, _ := strings.Cut(option, "=")
+			switch name {
+			case optionNotFound:
+				if !entity.IsValidURL(value) {
+					return nil, fmt.Errorf("%w %q: invalid not found page", ErrInvalidDomain, item)
+				}
+				domain.NotFoundURL = value
+			case optionUsers:
+				domain.Users = make(map[entity.UserID]struct{})
+				for _, userID := range strings.Split(value, "|") {
+					if !entity.UserID(userID).IsValid() {
+						return nil, fmt.Errorf("%w %q: invalid user id %q", ErrInvalidDomain, item, userID)
+					}
+					domain.Users[entity.UserID(userID)] = struct{}{}
+				}
+			default:
+				return nil, fmt.Errorf("%w %q: unknown option %q", ErrInvalidDomain, item, name)
+			}
+		}
+
+		if _, ok := domains[domain.Host]; ok {
+			return nil, fmt.Errorf("%w %q: duplicated host", ErrInvalidDomain, item)
+		}
+		domains[domain.Host] = domain
+	}
+
+	return domains, nil
+}
+
+// Default Returns default domain of base URL
+func (r *Registry) Default() Domain {
+	return r.defaultDomain
+}
+
+// Get Returns domain by host name or base URL
+//
+// Returns ErrUnknownDomain error if domain is not configured
+func (r *Registry) Get(name string) (Domain, error) {
+	if u, err := url.Parse(name); err == nil && u.Host != "" {
+		name = u.Host
+	}
+
+	domain, ok := r.domains[hostname(name)]
+	if !ok {
+		return Domain{}, fmt.Errorf("%w: %q", ErrUnknownDomain, name)
+	}
+
+	return domain, nil
+}
+
+// Resolve Returns domain which received request for host
+//
+// Default domain is returned for unknown hosts
+func (r *Registry) Resolve(host string) Domain {
+	domain, ok := r.domains[hostname(host)]
+	if !ok {
+		return r.defaultDomain
+	}
+
+	return domain
+}
+
+// Select Returns domain of new links of user
+//
+// Domain is taken by name if it is set, otherwise by host which received request.
+// Returns ErrUnknownDomain error if domain with name is not configured
+// and ErrDomainForbidden error if user is not allowed to create links on domain
+func (r *Registry) Select(userID entity.UserID, name, host string) (Domain, error) {
+	domain := r.Resolve(host)
+	if name != "" {
+		var err error
+		domain, err = r.Get(name)
+		if err != nil {
+			return Domain{}, err
+		}
+	}
+
+	if !domain.Allows(userID) {
+		return Domain{}, fmt.Errorf("%w: %q", ErrDomainForbidden, domain.Host)
+	}
+
+	return domain, nil
+}
+
+// ShortURL Returns full short URL of link by its short code in storage
+func (r *Registry) ShortURL(key string) string {
+	host, code, ok := strings.Cut(key, namespaceSeparator)
+	if !ok {
+		return r.defaultDomain.ShortURL(key)
+	}
+
+	domain, ok := r.domains[host]
+	if !ok {
+		// domain of link is removed from config
+		return fmt.Sprintf("%s://%s", defaultScheme(r.defaultDomain), key)
+	}
+
+	return domain.ShortURL(code)
+}
+
+// Key Returns short code of link in storage by its full short URL
+//
+// Short code in storage is returned as is
+func (r *Registry) Key(shortURL string) string {
+	for _, domain := range r.domains {
+		if code, ok := strings.CutPrefix(shortURL, domain.BaseURL+"/"); ok {
+			return domain.Key(code)
+		}
+	}
+
+	return shortURL
+}
+
+// HostKey Returns short code of link in storage by its full short URL or by short code on domain which received request for host
+//
+// Short code in storage is returned as is
+func (r *Registry) HostKey(host, shortURL string) string {
+	if key := r.Key(shortURL); key != shortURL || strings.Contains(shortURL, namespaceSeparator) {
+		return key
+	}
+
+	return r.Resolve(host).Key(shortURL)
+}
+
+// Key Returns short code of link in storage by short code on domain
+func (d Domain) Key(code string) string {
+	if d.Default {
+		return code
+	}
+
+	return d.Host + namespaceSeparator + code
+}
+
+// ShortURL Returns full short URL on domain by short code on domain
+func (d Domain) ShortURL(code string) string {
+	return fmt.Sprintf("%s/%s", d.BaseURL, code)
+}
+
+// Allows Returns true if user is allowed to create links on domain
+func (d Domain) Allows(userID entity.UserID) bool {
+	if len(d.Users) == 0 {
+		return true
+	}
+
+	_, ok := d.Users[userID]
+
+	return ok
+}
+
+func parseBaseURL(baseURL string) (Domain, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return Domain{}, err
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return Domain{}, fmt.Errorf("absolute http or https URL is expected in %q", baseURL)
+	}
+
+	return Domain{
+		Host:    hostname(u.Host),
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// hostname Returns lowercased host name without port
+func hostname(host string) string {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+
+	return strings.ToLower(strings.Trim(host, "[]"))
+}
+
+func defaultScheme(domain Domain) string {
+	scheme, _, _ := strings.Cut(domain.BaseURL, "://")
+
+	return scheme
+}
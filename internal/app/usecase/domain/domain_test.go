@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+const (
+	userID      = entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	otherUserID = entity.UserID("0c0a4811-4f10-487f-bde3-e39a14af7cd8")
+)
+
+func TestParseDomains(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    map[string]Domain
+		wantErr string
+	}{
+		{
+			name: "empty",
+			raw:  " ",
+			want: map[string]Domain{},
+		},
+		{
+			name: "domains with options",
+			raw:  "https://go.brand.io/;not_found=https://brand.io/404, http://VIP.brand.io:8443;users=" + userID.String(),
+			want: map[string]Domain{
+				"go.brand.io": {
+					Host:        "go.brand.io",
+					BaseURL:     "https://go.brand.io",
+					NotFoundURL: "https://brand.io/404",
+				},
+				"vip.brand.io": {
+					Host:    "vip.brand.io",
+					BaseURL: "http://VIP.brand.io:8443",
+					Users:   map[entity.UserID]struct{}{userID: {}},
+				},
+			},
+		},
+		{
+			name:    "relative base url",
+			raw:     "go.brand.io",
+			wantErr: "absolute http or https URL is expected",
+		},
+		{
+			name:    "invalid not found page",
+			raw:     "https://go.brand.io;not_found=brand",
+			wantErr: "invalid not found page",
+		},
+		{
+			name:    "invalid user id",
+			raw:     "https://go.brand.io;users=" + userID.String() + "|",
+			wantErr: `invalid user id ""`,
+		},
+		{
+			name:    "unknown option",
+			raw:     "https://go.brand.io;ttl=1h",
+			wantErr: `unknown option "ttl"`,
+		},
+		{
+			name:    "duplicated host",
+			raw:     "https://go.brand.io,http://go.brand.io:8080",
+			wantErr: "duplicated host",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			domains, err := ParseDomains(test.raw)
+			if test.wantErr != "" {
+				require.ErrorIs(t, err, ErrInvalidDomain)
+				assert.Contains(t, err.Error(), test.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, domains)
+		})
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	_, err := NewRegistry(config.Config{BaseURIPrefix: "localhost:8080"})
+	assert.ErrorContains(t, err, "invalid base_url")
+
+	err = ValidateConfig(config.Config{BaseURIPrefix: "http://localhost:8080", Domains: "https://go.brand.io;ttl=1h"})
+	assert.ErrorContains(t, err, "invalid domains")
+
+	// domain of base URL configures default domain
+	registry, err := NewRegistry(config.Config{
+		BaseURIPrefix: "http://localhost:8080/",
+		Domains:       "https://localhost;not_found=https://brand.io/404,https://go.brand.io",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, Domain{
+		Host:        "localhost",
+		BaseURL:     "http://localhost:8080",
+		NotFoundURL: "https://brand.io/404",
+		Default:     true,
+	}, registry.Default())
+}
+
+func TestRegistry(t *testing.T) {
+	registry, err := NewRegistry(config.Config{
+		BaseURIPrefix: "http://localhost:8080",
+		Domains:       "https://go.brand.io,https://vip.brand.io;users=" + userID.String(),
+	})
+	require.NoError(t, err)
+
+	t.Run("resolve", func(t *testing.T) {
+		assert.Equal(t, "go.brand.io", registry.Resolve("GO.brand.io:443").Host)
+		assert.True(t, registry.Resolve("unknown.io").Default)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		domain, err := registry.Get("https://go.brand.io/")
+		require.NoError(t, err)
+		assert.Equal(t, "go.brand.io", domain.Host)
+
+		_, err = registry.Get("unknown.io")
+		assert.ErrorIs(t, err, ErrUnknownDomain)
+	})
+
+	t.Run("select", func(t *testing.T) {
+		domain, err := registry.Select(otherUserID, "", "go.brand.io")
+		require.NoError(t, err)
+		assert.Equal(t, "go.brand.io", domain.Host)
+
+		domain, err = registry.Select(userID, "vip.brand.io", "go.brand.io")
+		require.NoError(t, err)
+		assert.Equal(t, "vip.brand.io", domain.Host)
+
+		_, err = registry.Select(otherUserID, "vip.brand.io", "")
+		assert.ErrorIs(t, err, ErrDomainForbidden)
+
+		_, err = registry.Select(userID, "unknown.io", "")
+		assert.ErrorIs(t, err, ErrUnknownDomain)
+	})
+
+	t.Run("keys and short urls", func(t *testing.T) {
+		brand, err := registry.Get("go.brand.io")
+		require.NoError(t, err)
+
+		assert.Equal(t, "42b3e75f", registry.Default().Key("42b3e75f"))
+		assert.Equal(t, "go.brand.io/42b3e75f", brand.Key("42b3e75f"))
+
+		assert.Equal(t, "http://localhost:8080/42b3e75f", registry.ShortURL("42b3e75f"))
+		assert.Equal(t, "https://go.brand.io/42b3e75f", registry.ShortURL("go.brand.io/42b3e75f"))
+		assert.Equal(t, "http://removed.io/42b3e75f", registry.ShortURL("removed.io/42b3e75f"))
+
+		assert.Equal(t, "42b3e75f", registry.Key("http://localhost:8080/42b3e75f"))
+		assert.Equal(t, "go.brand.io/42b3e75f", registry.Key("https://go.brand.io/42b3e75f"))
+		assert.Equal(t, "42b3e75f", registry.Key("42b3e75f"))
+
+		assert.Equal(t, "go.brand.io/42b3e75f", registry.HostKey("go.brand.io", "42b3e75f"))
+		assert.Equal(t, "42b3e75f", registry.HostKey("go.brand.io", "http://localhost:8080/42b3e75f"))
+		assert.Equal(t, "vip.brand.io/42b3e75f", registry.HostKey("go.brand.io", "vip.brand.io/42b3e75f"))
+		assert.Equal(t, "42b3e75f", registry.HostKey("unknown.io", "42b3e75f"))
+	})
+}
@@ -0,0 +1,335 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/metrics"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+)
+
+const resolveTimeout = 3 * time.Second
+
+// Errors of subscription to bus
+//
+// ErrBusClosed - bus is closed on shutdown
+// ErrSlowSubscriber - subscriber didn't keep up with events and is disconnected
+// ErrUserErased - user of subscription is erased
+var (
+	ErrBusClosed      = errors.New("event bus is closed")
+	ErrSlowSubscriber = errors.New("subscriber is too slow")
+	ErrUserErased     = errors.New("user of subscription is erased")
+)
+
+// Event Link event of bus with its sequence number
+//
+// Sequence numbers grow monotonically and start from start time of process in microseconds,
+// so they are not reused by the next process and can be used as Last-Event-ID
+type Event struct {
+	ID uint64
+	entity.LinkEvent
+}
+
+// Bus In-process bus of link events streamed live to subscribers of their owners
+//
+// The latest events are kept in bounded ring buffer to resume streams after reconnection.
+// Publishers are never blocked: events are dropped if queue is full and slow subscribers are disconnected
+type Bus struct {
+	storage    model.Storage
+	input      chan entity.LinkEvent
+	bufferSize int
+	streamSize int
+	published  atomic.Uint64
+
+	mutex       sync.Mutex
+	ring        []Event
+	head        int
+	lastID      uint64
+	subscribers map[*Subscription]struct{}
+	closed      bool
+	dispatched  uint64
+	erased      map[entity.UserID]uint64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// Subscription Stream of events of user
+type Subscription struct {
+	bus    *Bus
+	userID entity.UserID
+	events chan Event
+	err    error
+}
+
+// ValidateConfig Validates settings of event bus from config
+func ValidateConfig(config config.Config) error {
+	_, err := NewBus(nil, config)
+
+	return err
+}
+
+// NewBus Creates bus of link events
+//
+// Size of ring buffer and of queue of every subscriber are taken from config
+func NewBus(storage model.Storage, config config.Config) (*Bus, error) {
+	var errs []error
+
+	if config.EventBufferSize <= 0 {
+		errs = append(errs, fmt.Errorf("event_buffer_size must be positive, got %d", config.EventBufferSize))
+	}
+
+	if config.EventStreamBuffer <= 0 {
+		errs = append(errs, fmt.Errorf("event_stream_buffer must be positive, got %d", config.EventStreamBuffer))
+	}
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return &Bus{
+		storage:     storage,
+		input:       make(chan entity.LinkEvent, config.EventBufferSize),
+		bufferSize:  config.EventBufferSize,
+		streamSize:  config.EventStreamBuffer,
+		ring:        make([]Event, 0, config.EventBufferSize),
+		lastID:      uint64(time.Now().UnixMicro()),
+		subscribers: make(map[*Subscription]struct{}),
+		erased:      make(map[entity.UserID]uint64),
+		done:        make(chan struct{}),
+	}, nil
+}
+
+// Publish Queues link event for subscribers of its owner
+//
+// Doesn't block caller: event is dropped with warning if queue is full
+func (b *Bus) Publish(event entity.LinkEvent) {
+	b.published.Add(1)
+
+	select {
+	case b.input <- event:
+		metrics.ObserveEventPublished(false)
+	default:
+		b.published.Add(^uint64(0))
+		metrics.ObserveEventPublished(true)
+		zap.L().Warn("live event is dropped because queue is full", zap.String("event", event.Type), zap.String("short_url", event.ShortURL))
+	}
+}
+
+// Start Starts dispatching of queued events to subscribers in background
+func (b *Bus) Start() {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.run()
+	}()
+}
+
+// Close Stops dispatching and closes all subscriptions
+//
+// Streams of subscribers should be finished before servers are shut down gracefully
+func (b *Bus) Close() {
+	b.once.Do(func() {
+		close(b.done)
+	})
+
+	b.wg.Wait()
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.closed = true
+	for sub := range b.subscribers {
+		b.unsubscribe(sub, ErrBusClosed)
+	}
+}
+
+// DropUser Removes buffered events of user and closes its subscriptions after erasure of user
+//
+// Events of user queued before erasure are skipped when they are dispatched.
+// Returns count of removed events
+func (b *Bus) DropUser(userID entity.UserID) int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if published := b.published.Load(); published > b.dispatched {
+		b.erased[userID] = published
+	}
+
+	ring := make([]Event, 0, b.bufferSize)
+	for i := 0; i < len(b.ring); i++ {
+		event := b.ring[(b.head+i)%len(b.ring)]
+		if event.UserID != userID {
+			ring = append(ring, event)
+		}
+	}
+	dropped := len(b.ring) - len(ring)
+	b.ring = ring
+	b.head = 0
+
+	for sub := range b.subscribers {
+		if sub.userID == userID {
+			b.unsubscribe(sub, ErrUserErased)
+		}
+	}
+
+	return dropped
+}
+
+// Subscribe Subscribes to events of user
+//
+// Buffered events of user published after event with lastEventID are returned to be sent first,
+// zero lastEventID means stream without resumption.
+// Returns ErrBusClosed error if bus is closed
+func (b *Bus) Subscribe(userID entity.UserID, lastEventID uint64) (*Subscription, []Event, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.closed {
+		return nil, nil, ErrBusClosed
+	}
+
+	var backlog []Event
+	if lastEventID != 0 {
+		for i := 0; i < len(b.ring); i++ {
+			event := b.ring[(b.head+i)%len(b.ring)]
+			if event.ID > lastEventID && event.UserID == userID {
+				backlog = append(backlog, event)
+			}
+		}
+	}
+
+	sub := &Subscription{
+		bus:    b,
+		userID: userID,
+		events: make(chan Event, b.streamSize),
+	}
+	b.subscribers[sub] = struct{}{}
+	metrics.SetEventSubscribers(len(b.subscribers))
+
+	return sub, backlog, nil
+}
+
+// Events Returns channel of events of subscription
+//
+// Channel is closed if subscription is closed, Err returns the reason
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err Returns reason of closing of channel of events
+//
+// Returns ErrSlowSubscriber if subscriber didn't keep up with events
+// or ErrBusClosed if bus is closed. Returns nil if subscription is open or closed by subscriber
+func (s *Subscription) Err() error {
+	s.bus.mutex.Lock()
+	defer s.bus.mutex.Unlock()
+
+	return s.err
+}
+
+// Close Unsubscribes from bus
+func (s *Subscription) Close() {
+	s.bus.mutex.Lock()
+	defer s.bus.mutex.Unlock()
+
+	s.bus.unsubscribe(s, nil)
+}
+
+func (b *Bus) run() {
+	for {
+		select {
+		case <-b.done:
+			return
+		case event := <-b.input:
+			b.dispatch(event)
+		}
+	}
+}
+
+// dispatch Resolves owner of event, buffers event and sends it to subscribers of owner
+//
+// Events of user queued before erasure of user are skipped
+func (b *Bus) dispatch(event entity.LinkEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), resolveTimeout)
+	defer cancel()
+
+	event, err := Resolve(ctx, b.storage, event)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.dispatched++
+	if err != nil {
+		zap.L().Debug("live event is skipped", zap.Error(err))
+		return
+	}
+
+	if b.isErased(event.UserID) {
+		zap.L().Debug("live event of erased user is skipped", zap.String("event", event.Type))
+		return
+	}
+
+	b.lastID++
+	buffered := Event{
+		ID:        b.lastID,
+		LinkEvent: event,
+	}
+
+	if len(b.ring) < b.bufferSize {
+		b.ring = append(b.ring, buffered)
+	} else {
+		b.ring[b.head] = buffered
+		b.head = (b.head + 1) % b.bufferSize
+	}
+
+	for sub := range b.subscribers {
+		if sub.userID != event.UserID {
+			continue
+		}
+
+		select {
+		case sub.events <- buffered:
+		default:
+			metrics.ObserveSlowEventSubscriber()
+			b.unsubscribe(sub, ErrSlowSubscriber)
+		}
+	}
+}
+
+// isErased Returns true if dispatched event of user is queued before erasure of user, must be called under lock
+//
+// Marks of erasure are forgotten after all events queued before erasure are dispatched
+func (b *Bus) isErased(userID entity.UserID) bool {
+	mark, ok := b.erased[userID]
+	erased := ok && b.dispatched <= mark
+
+	for id, mark := range b.erased {
+		if mark <= b.dispatched {
+			delete(b.erased, id)
+		}
+	}
+
+	return erased
+}
+
+// unsubscribe Removes subscription and closes its channel, must be called under lock
+func (b *Bus) unsubscribe(sub *Subscription, err error) {
+	if _, ok := b.subscribers[sub]; !ok {
+		return
+	}
+
+	delete(b.subscribers, sub)
+	sub.err = err
+	close(sub.events)
+	metrics.SetEventSubscribers(len(b.subscribers))
+}
@@ -0,0 +1,262 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/storage/local"
+)
+
+const (
+	userID      = entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	otherUserID = entity.UserID("0c0a4811-4f10-487f-bde3-e39a14af7cd8")
+)
+
+func newTestBus(t *testing.T, bufferSize, streamSize int) (*Bus, *local.TSLocalStorage) {
+	storage := local.NewTSLocalStorage(0)
+
+	bus, err := NewBus(storage, config.Config{
+		EventBufferSize:   bufferSize,
+		EventStreamBuffer: streamSize,
+	})
+	require.NoError(t, err)
+
+	return bus, storage
+}
+
+func linkEvent(user entity.UserID, shortURL string) entity.LinkEvent {
+	return entity.LinkEvent{
+		Type:        entity.EventLinkCreated,
+		UserID:      user,
+		ShortURL:    shortURL,
+		OriginalURL: "https://practicum.yandex.ru/" + shortURL,
+	}
+}
+
+// dispatchAll Dispatches queued events synchronously instead of background goroutine
+func dispatchAll(bus *Bus) {
+	for {
+		select {
+		case event := <-bus.input:
+			bus.dispatch(event)
+		default:
+			return
+		}
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.NoError(t, ValidateConfig(config.Config{EventBufferSize: 1, EventStreamBuffer: 1}))
+
+	err := ValidateConfig(config.Config{EventBufferSize: 0, EventStreamBuffer: -1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "event_buffer_size must be positive, got 0")
+	assert.Contains(t, err.Error(), "event_stream_buffer must be positive, got -1")
+}
+
+func TestSubscribe(t *testing.T) {
+	bus, _ := newTestBus(t, 3, 10)
+
+	sub, backlog, err := bus.Subscribe(userID, 0)
+	require.NoError(t, err)
+	assert.Empty(t, backlog)
+
+	other, _, err := bus.Subscribe(otherUserID, 0)
+	require.NoError(t, err)
+
+	// queue of bus is as small as ring, so events are dispatched one by one
+	for _, shortURL := range []string{"42b3e75f", "77fca595", "ac6bb669", "b5c1a2d3"} {
+		bus.Publish(linkEvent(userID, shortURL))
+		dispatchAll(bus)
+	}
+	bus.Publish(linkEvent(otherUserID, "c9d8e7f6"))
+	dispatchAll(bus)
+
+	var received []Event
+	for i := 0; i < 4; i++ {
+		received = append(received, <-sub.Events())
+	}
+	assert.Equal(t, "42b3e75f", received[0].ShortURL)
+	assert.Equal(t, received[0].ID+3, received[3].ID)
+
+	event := <-other.Events()
+	assert.Equal(t, "c9d8e7f6", event.ShortURL)
+	assert.Empty(t, sub.Events())
+
+	// ring keeps only the latest events of all users
+	_, backlog, err = bus.Subscribe(userID, received[0].ID)
+	require.NoError(t, err)
+	require.Len(t, backlog, 2)
+	assert.Equal(t, received[2], backlog[0])
+	assert.Equal(t, received[3], backlog[1])
+
+	_, backlog, err = bus.Subscribe(userID, received[3].ID)
+	require.NoError(t, err)
+	assert.Empty(t, backlog)
+
+	sub.Close()
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.NoError(t, sub.Err())
+}
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+	bus, storage := newTestBus(t, 10, 10)
+
+	require.NoError(t, storage.SaveLink(ctx, entity.Link{
+		ShortURL:    "42b3e75f",
+		OriginalURL: "https://practicum.yandex.ru/",
+		UserID:      userID,
+		CreatedAt:   time.Now().UTC(),
+	}))
+
+	sub, _, err := bus.Subscribe(userID, 0)
+	require.NoError(t, err)
+
+	bus.Publish(entity.LinkEvent{Type: entity.EventLinkDeleted, UserID: otherUserID, ShortURL: "42b3e75f"})
+	bus.Publish(entity.LinkEvent{Type: entity.EventLinkClicked, ShortURL: "unknown"})
+	bus.Publish(entity.LinkEvent{Type: entity.EventLinkClicked, ShortURL: "42b3e75f"})
+	dispatchAll(bus)
+
+	require.Len(t, sub.Events(), 1)
+	event := <-sub.Events()
+	assert.Equal(t, entity.EventLinkClicked, event.Type)
+	assert.Equal(t, userID, event.UserID)
+	assert.Equal(t, "https://practicum.yandex.ru/", event.OriginalURL)
+}
+
+func TestSlowSubscriber(t *testing.T) {
+	bus, _ := newTestBus(t, 10, 2)
+
+	slow, _, err := bus.Subscribe(userID, 0)
+	require.NoError(t, err)
+
+	for _, shortURL := range []string{"42b3e75f", "77fca595", "ac6bb669"} {
+		bus.Publish(linkEvent(userID, shortURL))
+	}
+	dispatchAll(bus)
+
+	// buffered events are received before channel is closed
+	assert.Equal(t, "42b3e75f", (<-slow.Events()).ShortURL)
+	last := <-slow.Events()
+	assert.Equal(t, "77fca595", last.ShortURL)
+
+	_, ok := <-slow.Events()
+	assert.False(t, ok)
+	assert.ErrorIs(t, slow.Err(), ErrSlowSubscriber)
+
+	// stream is resumed from the last received event
+	_, backlog, err := bus.Subscribe(userID, last.ID)
+	require.NoError(t, err)
+	require.Len(t, backlog, 1)
+	assert.Equal(t, "ac6bb669", backlog[0].ShortURL)
+}
+
+func TestClose(t *testing.T) {
+	bus, _ := newTestBus(t, 10, 10)
+	bus.Start()
+
+	sub, _, err := bus.Subscribe(userID, 0)
+	require.NoError(t, err)
+
+	bus.Publish(linkEvent(userID, "42b3e75f"))
+
+	event := <-sub.Events()
+	assert.Equal(t, "42b3e75f", event.ShortURL)
+
+	bus.Close()
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.ErrorIs(t, sub.Err(), ErrBusClosed)
+
+	_, _, err = bus.Subscribe(userID, 0)
+	assert.ErrorIs(t, err, ErrBusClosed)
+}
+
+func TestDropUser(t *testing.T) {
+	bus, _ := newTestBus(t, 10, 10)
+
+	sub, _, err := bus.Subscribe(userID, 0)
+	require.NoError(t, err)
+	otherSub, _, err := bus.Subscribe(otherUserID, 0)
+	require.NoError(t, err)
+
+	bus.Publish(linkEvent(userID, "42b3e75f"))
+	bus.Publish(linkEvent(otherUserID, "77fca595"))
+	dispatchAll(bus)
+
+	first := <-sub.Events()
+	<-otherSub.Events()
+
+	// event queued before erasure isn't dispatched yet
+	bus.Publish(linkEvent(userID, "ac6bb669"))
+
+	assert.Equal(t, 1, bus.DropUser(userID))
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.ErrorIs(t, sub.Err(), ErrUserErased)
+
+	dispatchAll(bus)
+	assert.Empty(t, otherSub.Events())
+
+	_, backlog, err := bus.Subscribe(userID, first.ID-1)
+	require.NoError(t, err)
+	assert.Empty(t, backlog)
+
+	_, backlog, err = bus.Subscribe(otherUserID, first.ID-1)
+	require.NoError(t, err)
+	require.Len(t, backlog, 1)
+	assert.Equal(t, "77fca595", backlog[0].ShortURL)
+
+	// events published after erasure are streamed again
+	sub, _, err = bus.Subscribe(userID, 0)
+	require.NoError(t, err)
+
+	bus.Publish(linkEvent(userID, "d4c2a1f0"))
+	dispatchAll(bus)
+
+	assert.Equal(t, "d4c2a1f0", (<-sub.Events()).ShortURL)
+	assert.Empty(t, bus.erased)
+}
+
+func TestStorage(t *testing.T) {
+	ctx := context.Background()
+	bus, storage := newTestBus(t, 10, 10)
+	decorated := NewStorage(storage, bus)
+
+	sub, _, err := bus.Subscribe(userID, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, decorated.SaveLink(ctx, entity.Link{
+		ShortURL:    "42b3e75f",
+		OriginalURL: "https://practicum.yandex.ru/",
+		UserID:      userID,
+		CreatedAt:   time.Now().UTC(),
+	}))
+	require.Error(t, decorated.SaveLink(ctx, entity.Link{
+		ShortURL:    "42b3e75f",
+		OriginalURL: "https://practicum.yandex.ru/",
+		UserID:      userID,
+	}))
+	require.NoError(t, decorated.RecordClick(ctx, "42b3e75f"))
+	require.NoError(t, decorated.DeleteBatchURL(ctx, entity.DeletedURLBatch{{UserID: userID.String(), ShortURL: "42b3e75f"}}))
+	dispatchAll(bus)
+
+	var types []string
+	for len(sub.Events()) != 0 {
+		event := <-sub.Events()
+		assert.NotEmpty(t, event.LinkEvent.ID)
+		assert.False(t, event.Time.IsZero())
+		types = append(types, event.Type)
+	}
+	assert.Equal(t, []string{entity.EventLinkCreated, entity.EventLinkClicked, entity.EventLinkDeleted}, types)
+}
@@ -0,0 +1,36 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+)
+
+// ErrForeignLink Link of event is not owned by user of event
+var ErrForeignLink = errors.New("link of event is not owned by user")
+
+// Resolve Returns event with owner and original URL of link taken from storage if publisher doesn't know them
+//
+// Returns ErrForeignLink error if event of user is published for link of other user
+func Resolve(ctx context.Context, storage model.Storage, event entity.LinkEvent) (entity.LinkEvent, error) {
+	if event.UserID != "" && event.OriginalURL != "" {
+		return event, nil
+	}
+
+	link, err := storage.GetLink(ctx, "", event.ShortURL)
+	if err != nil {
+		return entity.LinkEvent{}, fmt.Errorf("error while getting link of event: %w", err)
+	}
+
+	if event.UserID != "" && event.UserID != link.UserID {
+		return entity.LinkEvent{}, ErrForeignLink
+	}
+
+	event.UserID = link.UserID
+	event.OriginalURL = link.OriginalURL
+
+	return event, nil
+}
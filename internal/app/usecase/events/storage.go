@@ -0,0 +1,141 @@
+// Package events implements publishing of user link events from storage and live event bus
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+)
+
+// Publisher Publishes link events without blocking caller
+type Publisher interface {
+	Publish(event entity.LinkEvent)
+}
+
+// Storage Decorator of storage publishing link events after successful changes
+//
+// Every existing code path creating, deleting, redirecting or purging links goes through storage,
+// so events are published without changes of handlers and use cases
+type Storage struct {
+	model.Storage
+	publishers []Publisher
+	now        func() time.Time
+}
+
+// NewStorage Creates decorator of storage publishing link events to every publisher
+func NewStorage(storage model.Storage, publishers ...Publisher) *Storage {
+	return &Storage{
+		Storage:    storage,
+		publishers: publishers,
+		now:        time.Now,
+	}
+}
+
+// SaveURL Saves URL to decorated storage and publishes link.created event
+func (s *Storage) SaveURL(ctx context.Context, userID entity.UserID, key, value entity.URL) error {
+	err := s.Storage.SaveURL(ctx, userID, key, value)
+	if err != nil {
+		return err
+	}
+
+	s.publish(entity.EventLinkCreated, userID, key.String(), value.String())
+
+	return nil
+}
+
+// SaveBatchURL Saves batch of URLs to decorated storage and publishes link.created event for every saved URL
+func (s *Storage) SaveBatchURL(ctx context.Context, userID entity.UserID, batch model.Batch) (model.Batch, error) {
+	saved, err := s.Storage.SaveBatchURL(ctx, userID, batch)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range saved {
+		s.publish(entity.EventLinkCreated, userID, obj.ShortURL, obj.InputURL)
+	}
+
+	return saved, nil
+}
+
+// SaveLink Saves link to decorated storage and publishes link.created event
+func (s *Storage) SaveLink(ctx context.Context, link entity.Link) error {
+	err := s.Storage.SaveLink(ctx, link)
+	if err != nil {
+		return err
+	}
+
+	s.publish(entity.EventLinkCreated, link.UserID, link.ShortURL, link.OriginalURL)
+
+	return nil
+}
+
+// RecordClick Records click to decorated storage and publishes link.clicked event
+func (s *Storage) RecordClick(ctx context.Context, shortURL string) error {
+	err := s.Storage.RecordClick(ctx, shortURL)
+	if err != nil {
+		return err
+	}
+
+	s.publish(entity.EventLinkClicked, "", shortURL, "")
+
+	return nil
+}
+
+// DeleteBatchURL Deletes user URLs from decorated storage and publishes link.deleted event for every URL
+//
+// Events of URLs not owned by user are skipped by Resolve
+func (s *Storage) DeleteBatchURL(ctx context.Context, urls entity.DeletedURLBatch) error {
+	err := s.Storage.DeleteBatchURL(ctx, urls)
+	if err != nil {
+		return err
+	}
+
+	for _, deleted := range urls {
+		s.publish(entity.EventLinkDeleted, entity.UserID(deleted.UserID), deleted.ShortURL, "")
+	}
+
+	return nil
+}
+
+// PurgeDeletedLinks Purges expired links from decorated storage and publishes link.expired event for every link
+func (s *Storage) PurgeDeletedLinks(ctx context.Context, deletedBefore time.Time, limit int) ([]entity.Link, error) {
+	links, err := s.Storage.PurgeDeletedLinks(ctx, deletedBefore, limit)
+	for _, link := range links {
+		s.publish(entity.EventLinkExpired, link.UserID, link.ShortURL, link.OriginalURL)
+	}
+
+	return links, err
+}
+
+// Compact Compacts decorated storage
+//
+// Returns ErrCompactionNotSupported error if decorated storage doesn't support compaction
+func (s *Storage) Compact(ctx context.Context) (models.CompactionResult, error) {
+	compactor, ok := s.Storage.(model.Compactor)
+	if !ok {
+		return models.CompactionResult{}, api.ErrCompactionNotSupported
+	}
+
+	return compactor.Compact(ctx)
+}
+
+func (s *Storage) publish(event string, userID entity.UserID, shortURL, originalURL string) {
+	linkEvent := entity.LinkEvent{
+		ID:          uuid.New().String(),
+		Type:        event,
+		UserID:      userID,
+		ShortURL:    shortURL,
+		OriginalURL: originalURL,
+		Time:        s.now().UTC(),
+	}
+
+	for _, publisher := range s.publishers {
+		publisher.Publish(linkEvent)
+	}
+}
@@ -6,30 +6,41 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"math/big"
 	"net"
+	"os"
+	"path/filepath"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/avGenie/url-shortener/internal/app/entity"
 )
 
 const (
-	uniqueSertificateNumber = 1658
-	keyLength               = 4096
+	serialNumberBits = 128
+	keyLength        = 4096
 )
 
 // GenerateHTTPSCredentials Generates HTTPS credentials using X509 certificate
 func GenerateHTTPSCredentials() (entity.HTTPSCredentials, error) {
+	// генерируем уникальный номер сертификата
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumberBits))
+	if err != nil {
+		return entity.HTTPSCredentials{}, fmt.Errorf("error while generating certificate serial number: %w", err)
+	}
+
 	cert := &x509.Certificate{
-		// указываем уникальный номер сертификата
-		SerialNumber: big.NewInt(uniqueSertificateNumber),
+		SerialNumber: serialNumber,
 		// заполняем базовую информацию о владельце сертификата
 		Subject: pkix.Name{
 			Organization: []string{"URL_Shortener"},
 			Country:      []string{"RU"},
 		},
-		// разрешаем использование сертификата для 127.0.0.1 и ::1
+		// разрешаем использование сертификата для localhost, 127.0.0.1 и ::1
+		DNSNames:    []string{"localhost"},
 		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
 		// сертификат верен, начиная со времени создания
 		NotBefore: time.Now(),
@@ -72,3 +83,63 @@ func GenerateHTTPSCredentials() (entity.HTTPSCredentials, error) {
 
 	return creds, nil
 }
+
+// EnsureSelfSignedCert Generates self-signed certificate and key files if they don't exist
+//
+// Existing files are kept, so the same certificate is used between restarts
+func EnsureSelfSignedCert(certFile, keyFile string) error {
+	certExists, err := fileExists(certFile)
+	if err != nil {
+		return err
+	}
+
+	keyExists, err := fileExists(keyFile)
+	if err != nil {
+		return err
+	}
+
+	if certExists && keyExists {
+		return nil
+	}
+
+	zap.L().Info("generating self-signed certificate", zap.String("cert_file", certFile), zap.String("key_file", keyFile))
+
+	creds, err := GenerateHTTPSCredentials()
+	if err != nil {
+		return err
+	}
+
+	err = writeFile(keyFile, creds.PrivateKey.Bytes(), 0600)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(certFile, creds.Cert.Bytes(), 0644)
+}
+
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if err == nil {
+		return true, nil
+	}
+
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("error while checking file %s: %w", path, err)
+}
+
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return fmt.Errorf("error while creating directory of %s: %w", path, err)
+	}
+
+	err = os.WriteFile(path, data, perm)
+	if err != nil {
+		return fmt.Errorf("error while writing %s: %w", path, err)
+	}
+
+	return nil
+}
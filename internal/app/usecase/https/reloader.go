@@ -0,0 +1,153 @@
+package https
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const certCheckInterval = 10 * time.Second
+
+// CertReloader Keeps certificate loaded from files and reloads it when files change
+type CertReloader struct {
+	certFile string
+	keyFile  string
+
+	mutex   sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+
+	done chan struct{}
+	once sync.Once
+}
+
+// NewCertReloader Loads certificate from files and starts watching them
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	reloader := &CertReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		done:     make(chan struct{}),
+	}
+
+	err := reloader.Reload()
+	if err != nil {
+		return nil, err
+	}
+
+	go reloader.watch()
+
+	return reloader, nil
+}
+
+// GetCertificate Returns current certificate
+//
+// Can be used as tls.Config GetCertificate callback
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.cert, nil
+}
+
+// Reload Loads certificate from files
+//
+// Current certificate is kept if files couldn't be loaded
+func (r *CertReloader) Reload() error {
+	modTime, err := r.filesModTime()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("error while loading TLS certificate: %w", err)
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return fmt.Errorf("error while parsing TLS certificate: %w", err)
+	}
+	cert.Leaf = leaf
+
+	r.mutex.Lock()
+	r.cert = &cert
+	r.modTime = modTime
+	r.mutex.Unlock()
+
+	zap.L().Info(
+		"TLS certificate is loaded",
+		zap.String("cert_file", r.certFile),
+		zap.String("serial_number", leaf.SerialNumber.String()),
+		zap.Time("not_after", leaf.NotAfter),
+	)
+
+	return nil
+}
+
+// Close Stops watching certificate files
+func (r *CertReloader) Close() {
+	if r == nil {
+		return
+	}
+
+	r.once.Do(func() {
+		close(r.done)
+	})
+}
+
+// watch Reloads certificate when modification time of files is changed
+func (r *CertReloader) watch() {
+	ticker := time.NewTicker(certCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-ticker.C:
+			if !r.isChanged() {
+				continue
+			}
+
+			err := r.Reload()
+			if err != nil {
+				zap.L().Error("error while reloading TLS certificate", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (r *CertReloader) isChanged() bool {
+	modTime, err := r.filesModTime()
+	if err != nil {
+		zap.L().Error("error while checking TLS certificate files", zap.Error(err))
+		return false
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return !modTime.Equal(r.modTime)
+}
+
+// filesModTime Returns the latest modification time of certificate and key files
+func (r *CertReloader) filesModTime() (time.Time, error) {
+	var latest time.Time
+	for _, path := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("error while checking TLS file: %w", err)
+		}
+
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+
+	return latest, nil
+}
@@ -2,20 +2,171 @@ package https
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+)
+
+// Minimal TLS versions
+//
+// TLSVersion12 - TLS 1.2
+// TLSVersion13 - TLS 1.3
+const (
+	TLSVersion12 = "1.2"
+	TLSVersion13 = "1.3"
 )
 
-// GenerateTLSCert Generates TLS sertificate using HTTPS credentials
-func GenerateTLSCert() (tls.Certificate, error) {
-	httpsCreds, err := GenerateHTTPSCredentials()
+// Paths of self-signed certificate and key used if paths are not configured
+const (
+	defaultSelfSignedCertFile = "/tmp/short-url-cert.pem"
+	defaultSelfSignedKeyFile  = "/tmp/short-url-key.pem"
+)
+
+var tlsVersions = map[string]uint16{
+	TLSVersion12: tls.VersionTLS12,
+	TLSVersion13: tls.VersionTLS13,
+}
+
+// IsEnabled Returns true if TLS is enabled for HTTP or GRPC listener
+func IsEnabled(config config.Config) bool {
+	return config.EnableHTTPS || config.GRPCEnableTLS
+}
+
+// NewTLSConfig Creates TLS config for HTTPS and GRPC listeners
+//
+// Certificate is loaded from configured files and reloaded when files change.
+// In self-signed mode certificate is generated once and kept in the files.
+// Returns nil config if TLS is disabled for all listeners
+func NewTLSConfig(config config.Config) (*tls.Config, *CertReloader, error) {
+	if !IsEnabled(config) {
+		return nil, nil, nil
+	}
+
+	minVersion, err := parseTLSVersion(config.TLSMinVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cipherSuites, err := parseCipherSuites(config.TLSCipherSuites)
 	if err != nil {
-		return tls.Certificate{}, fmt.Errorf("error while generating certificate: %w", err)
+		return nil, nil, err
 	}
 
-	cert, err := tls.X509KeyPair(httpsCreds.Cert.Bytes(), httpsCreds.PrivateKey.Bytes())
+	certFile, keyFile := certFiles(config)
+	if config.TLSSelfSigned {
+		err = EnsureSelfSignedCert(certFile, keyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	reloader, err := NewCertReloader(certFile, keyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &tls.Config{
+		GetCertificate: reloader.GetCertificate,
+		MinVersion:     minVersion,
+		CipherSuites:   cipherSuites,
+	}, reloader, nil
+}
+
+// WithClientCA Returns copy of TLS config which requires client certificates signed by CA bundle
+func WithClientCA(tlsConfig *tls.Config, caFile string) (*tls.Config, error) {
+	caPEM, err := os.ReadFile(caFile)
 	if err != nil {
-		return tls.Certificate{}, fmt.Errorf("error while generating TLS keys: %w", err)
+		return nil, fmt.Errorf("error while reading client CA bundle: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("client CA bundle %s doesn't contain certificates", caFile)
+	}
+
+	res := tlsConfig.Clone()
+	res.ClientCAs = pool
+	res.ClientAuth = tls.RequireAndVerifyClientCert
+
+	return res, nil
+}
+
+// ValidateConfig Validates TLS settings from config
+func ValidateConfig(config config.Config) error {
+	var errs []error
+
+	_, err := parseTLSVersion(config.TLSMinVersion)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	_, err = parseCipherSuites(config.TLSCipherSuites)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if IsEnabled(config) && !config.TLSSelfSigned && (config.TLSCertFile == "" || config.TLSKeyFile == "") {
+		errs = append(errs, fmt.Errorf("tls_cert_file and tls_key_file are required if tls_self_signed is disabled"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func certFiles(config config.Config) (string, string) {
+	certFile, keyFile := config.TLSCertFile, config.TLSKeyFile
+	if config.TLSSelfSigned {
+		if certFile == "" {
+			certFile = defaultSelfSignedCertFile
+		}
+		if keyFile == "" {
+			keyFile = defaultSelfSignedKeyFile
+		}
+	}
+
+	return certFile, keyFile
+}
+
+func parseTLSVersion(version string) (uint16, error) {
+	if version == "" {
+		return tls.VersionTLS12, nil
+	}
+
+	res, ok := tlsVersions[version]
+	if !ok {
+		return 0, fmt.Errorf("unsupported tls_min_version %q", version)
+	}
+
+	return res, nil
+}
+
+// parseCipherSuites Parses secure cipher suite names separated by comma
+//
+// Returns nil if names are empty, so default cipher suites are used
+func parseCipherSuites(names string) ([]uint16, error) {
+	if strings.TrimSpace(names) == "" {
+		return nil, nil
+	}
+
+	known := make(map[string]uint16)
+	for _, suite := range tls.CipherSuites() {
+		known[suite.Name] = suite.ID
+	}
+
+	var res []uint16
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+
+		id, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("unsupported tls_cipher_suites item %q", name)
+		}
+
+		res = append(res, id)
 	}
 
-	return cert, nil
+	return res, nil
 }
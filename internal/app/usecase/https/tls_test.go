@@ -0,0 +1,149 @@
+package https
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  config.Config
+		isError bool
+	}{
+		{
+			name:    "tls disabled",
+			config:  config.Config{},
+			isError: false,
+		},
+		{
+			name: "certificate files",
+			config: config.Config{
+				EnableHTTPS:   true,
+				TLSCertFile:   "cert.pem",
+				TLSKeyFile:    "key.pem",
+				TLSMinVersion: TLSVersion13,
+			},
+			isError: false,
+		},
+		{
+			name: "self-signed certificate",
+			config: config.Config{
+				GRPCEnableTLS:   true,
+				TLSSelfSigned:   true,
+				TLSCipherSuites: "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+			},
+			isError: false,
+		},
+		{
+			name: "missing key file",
+			config: config.Config{
+				EnableHTTPS: true,
+				TLSCertFile: "cert.pem",
+			},
+			isError: true,
+		},
+		{
+			name: "unsupported version",
+			config: config.Config{
+				TLSMinVersion: "1.0",
+			},
+			isError: true,
+		},
+		{
+			name: "insecure cipher suite",
+			config: config.Config{
+				TLSCipherSuites: "TLS_RSA_WITH_RC4_128_SHA",
+			},
+			isError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateConfig(test.config)
+			if test.isError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	writeTestCert(t, certFile, keyFile, 1)
+
+	reloader, err := NewCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+	defer reloader.Close()
+
+	cert, err := reloader.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cert.Leaf.SerialNumber.Int64())
+
+	writeTestCert(t, certFile, keyFile, 2)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	assert.True(t, reloader.isChanged())
+
+	require.NoError(t, reloader.Reload())
+
+	cert, err = reloader.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cert.Leaf.SerialNumber.Int64())
+	assert.False(t, reloader.isChanged())
+
+	require.NoError(t, os.WriteFile(certFile, []byte("broken"), 0600))
+	assert.Error(t, reloader.Reload())
+
+	cert, err = reloader.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cert.Leaf.SerialNumber.Int64())
+}
+
+func writeTestCert(t *testing.T, certFile, keyFile string, serial int64) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}), 0600)
+	require.NoError(t, err)
+
+	err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600)
+	require.NoError(t, err)
+}
@@ -0,0 +1,445 @@
+// Package link implements use cases of short links shared by API handlers
+package link
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/encoding"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+)
+
+// Page sizes of user links list
+//
+// DefaultPageSize - used if page size is not set
+// MaxPageSize - max page size, bigger page sizes are reduced to it
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 1000
+)
+
+const (
+	shortIDSize = 8
+
+	maxMetadataKeyLen   = 64
+	maxMetadataValueLen = 512
+)
+
+// Errors returning by link use cases
+//
+// ErrInvalidURL - original URL couldn't be parsed
+// ErrInvalidMetadata - link metadata contains too long keys or values
+// ErrInvalidPageToken - page token is malformed
+// ErrLinkExists - link for original URL already exists
+// ErrLinkNotFound - link is not found
+// ErrInvalidStatisticQuery - parameters of statistic request are invalid
+var (
+	ErrInvalidURL            = errors.New("invalid original url")
+	ErrInvalidMetadata       = errors.New("invalid link metadata")
+	ErrInvalidPageToken      = errors.New("invalid page token")
+	ErrLinkExists            = errors.New("link already exists")
+	ErrLinkNotFound          = errors.New("link is not found")
+	ErrInvalidStatisticQuery = errors.New("invalid statistic query")
+)
+
+// FieldError Error of request field
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// Error Implements error interface
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Err.Error())
+}
+
+// Unwrap Returns error of field
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Deleter Deletes user URLs in background
+type Deleter interface {
+	ProcessDeletedURLs(ctx context.Context, userID entity.UserID, batch models.ReqDeletedURLBatch) (string, error)
+}
+
+// BatchItem Original URL of batch with correlation ID
+type BatchItem struct {
+	CorrelationID string
+	OriginalURL   string
+}
+
+// BatchResult Created link of batch with correlation ID
+type BatchResult struct {
+	CorrelationID string
+	Link          entity.Link
+}
+
+// BatchKeys Returns short IDs of created links of batch
+func BatchKeys(results []BatchResult) []string {
+	keys := make([]string, 0, len(results))
+	for _, result := range results {
+		keys = append(keys, result.Link.ShortURL)
+	}
+
+	return keys
+}
+
+// Service Use cases of short links
+type Service struct {
+	storage model.Storage
+	deleter Deleter
+	domains *domain.Registry
+	now     func() time.Time
+}
+
+// NewService Creates service of short links on domains
+func NewService(storage model.Storage, deleter Deleter, domains *domain.Registry) *Service {
+	return &Service{
+		storage: storage,
+		deleter: deleter,
+		domains: domains,
+		now:     time.Now,
+	}
+}
+
+// ShortID Returns short ID of original URL
+func ShortID(originalURL string) string {
+	bs := encoding.NewSHA256([]byte(originalURL))
+
+	return hex.EncodeToString(bs)[:shortIDSize]
+}
+
+// ShortURL Returns full short URL of link on its domain
+func (s *Service) ShortURL(link entity.Link) string {
+	return s.domains.ShortURL(link.ShortURL)
+}
+
+// Key Returns short ID of link in storage by short ID or full short URL
+func (s *Service) Key(shortURL string) string {
+	return s.domains.Key(shortURL)
+}
+
+// Keys Returns short IDs of links in storage by short IDs or full short URLs
+func (s *Service) Keys(shortURLs []string) []string {
+	keys := make([]string, 0, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		keys = append(keys, s.Key(shortURL))
+	}
+
+	return keys
+}
+
+// Create Creates link of user for original URL on domain with given name, default domain is used if name is empty
+//
+// Returns existing link with ErrLinkExists error if link already exists.
+// Returns ErrDomainForbidden error if user is not allowed to create links on domain
+func (s *Service) Create(ctx context.Context, userID entity.UserID, domainName, originalURL string, metadata map[string]string) (entity.Link, error) {
+	return s.create(ctx, userID, "", domainName, originalURL, metadata)
+}
+
+// CreateInWorkspace Creates link of user in workspace for original URL on domain with given name
+//
+// Access of user to workspace isn't checked.
+// Returns existing link with ErrLinkExists error if link already exists.
+// Returns ErrDomainForbidden error if user is not allowed to create links on domain
+func (s *Service) CreateInWorkspace(
+	ctx context.Context,
+	userID entity.UserID,
+	workspaceID, domainName, originalURL string,
+	metadata map[string]string,
+) (entity.Link, error) {
+	return s.create(ctx, userID, workspaceID, domainName, originalURL, metadata)
+}
+
+func (s *Service) create(
+	ctx context.Context,
+	userID entity.UserID,
+	workspaceID, domainName, originalURL string,
+	metadata map[string]string,
+) (entity.Link, error) {
+	if !entity.IsValidURL(originalURL) {
+		return entity.Link{}, &FieldError{Field: "original_url", Err: ErrInvalidURL}
+	}
+
+	err := validateMetadata(metadata)
+	if err != nil {
+		return entity.Link{}, err
+	}
+
+	dom, err := s.selectDomain(userID, domainName)
+	if err != nil {
+		return entity.Link{}, err
+	}
+
+	link := entity.Link{
+		ShortURL:    dom.Key(ShortID(originalURL)),
+		OriginalURL: originalURL,
+		UserID:      userID,
+		WorkspaceID: workspaceID,
+		CreatedAt:   s.now().UTC(),
+		Metadata:    metadata,
+	}
+
+	err = s.storage.SaveLink(ctx, link)
+	if err != nil {
+		if !errors.Is(err, storage_err.ErrURLAlreadyExists) {
+			return entity.Link{}, fmt.Errorf("error while saving link: %w", err)
+		}
+
+		existing, getErr := s.storage.GetLink(ctx, userID, link.ShortURL)
+		if getErr == nil {
+			link = existing
+		}
+
+		return link, ErrLinkExists
+	}
+
+	return link, nil
+}
+
+// CreateBatch Creates links of user for batch of original URLs on domain with given name
+//
+// Default domain is used if name is empty.
+// Returns ErrDomainForbidden error if user is not allowed to create links on domain
+func (s *Service) CreateBatch(ctx context.Context, userID entity.UserID, domainName string, items []BatchItem) ([]BatchResult, error) {
+	dom, err := s.selectDomain(userID, domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := make(model.Batch, 0, len(items))
+	for i, item := range items {
+		if !entity.IsValidURL(item.OriginalURL) {
+			return nil, &FieldError{Field: fmt.Sprintf("entries[%d].original_url", i), Err: ErrInvalidURL}
+		}
+
+		batch = append(batch, model.BatchObject{
+			ID:       item.CorrelationID,
+			InputURL: item.OriginalURL,
+			ShortURL: dom.Key(ShortID(item.OriginalURL)),
+		})
+	}
+
+	createdAt := s.now().UTC()
+
+	saved, err := s.storage.SaveBatchURL(ctx, userID, batch)
+	if err != nil {
+		return nil, fmt.Errorf("error while saving batch of links: %w", err)
+	}
+
+	res := make([]BatchResult, 0, len(saved))
+	for _, obj := range saved {
+		res = append(res, BatchResult{
+			CorrelationID: obj.ID,
+			Link: entity.Link{
+				ShortURL:    obj.ShortURL,
+				OriginalURL: obj.InputURL,
+				UserID:      userID,
+				CreatedAt:   createdAt,
+			},
+		})
+	}
+
+	return res, nil
+}
+
+// Get Returns link by short ID or full short URL
+//
+// Link of any user is returned if user id is empty.
+// Deleted links are returned with deletion state
+func (s *Service) Get(ctx context.Context, userID entity.UserID, shortURL string) (entity.Link, error) {
+	link, err := s.storage.GetLink(ctx, userID, s.Key(shortURL))
+	if err != nil {
+		if errors.Is(err, storage_err.ErrShortURLNotFound) {
+			return entity.Link{}, ErrLinkNotFound
+		}
+
+		return entity.Link{}, fmt.Errorf("error while getting link: %w", err)
+	}
+
+	return link, nil
+}
+
+// GetInWorkspace Returns link of workspace by short ID or full short URL
+//
+// Deleted links are returned with deletion state
+func (s *Service) GetInWorkspace(ctx context.Context, workspaceID, shortURL string) (entity.Link, error) {
+	link, err := s.storage.GetWorkspaceLink(ctx, workspaceID, s.Key(shortURL))
+	if err != nil {
+		if errors.Is(err, storage_err.ErrShortURLNotFound) {
+			return entity.Link{}, ErrLinkNotFound
+		}
+
+		return entity.Link{}, fmt.Errorf("error while getting workspace link: %w", err)
+	}
+
+	return link, nil
+}
+
+// List Returns page of user links ordered by creation time and token of the next page
+//
+// Token of the next page is empty if there are no more links
+func (s *Service) List(ctx context.Context, userID entity.UserID, pageSize int, pageToken string) ([]entity.Link, string, error) {
+	return s.list(pageSize, pageToken, func(cursor entity.LinkCursor, limit int) ([]entity.Link, error) {
+		links, err := s.storage.ListUserLinks(ctx, userID, cursor, limit)
+		if err != nil {
+			return nil, fmt.Errorf("error while listing user links: %w", err)
+		}
+
+		return links, nil
+	})
+}
+
+// ListWorkspace Returns page of links of workspace ordered by creation time and token of the next page
+//
+// Access of user to workspace isn't checked.
+// Token of the next page is empty if there are no more links
+func (s *Service) ListWorkspace(ctx context.Context, workspaceID string, pageSize int, pageToken string) ([]entity.Link, string, error) {
+	return s.list(pageSize, pageToken, func(cursor entity.LinkCursor, limit int) ([]entity.Link, error) {
+		links, err := s.storage.ListWorkspaceLinks(ctx, workspaceID, cursor, limit)
+		if err != nil {
+			return nil, fmt.Errorf("error while listing workspace links: %w", err)
+		}
+
+		return links, nil
+	})
+}
+
+// UpdateMetadata Replaces metadata of link
+//
+// Only the row of link owner in workspace of link is changed, access of user to link isn't checked
+func (s *Service) UpdateMetadata(ctx context.Context, link entity.Link, metadata map[string]string) (entity.Link, error) {
+	err := validateMetadata(metadata)
+	if err != nil {
+		return entity.Link{}, err
+	}
+
+	err = s.storage.UpdateLinkMetadata(ctx, link.UserID, link.WorkspaceID, link.ShortURL, metadata)
+	if err != nil {
+		if errors.Is(err, storage_err.ErrShortURLNotFound) {
+			return entity.Link{}, ErrLinkNotFound
+		}
+
+		return entity.Link{}, fmt.Errorf("error while updating link metadata: %w", err)
+	}
+
+	link.Metadata = metadata
+
+	return link, nil
+}
+
+// list Returns page of links from lister and token of the next page
+func (s *Service) list(
+	pageSize int,
+	pageToken string,
+	lister func(cursor entity.LinkCursor, limit int) ([]entity.Link, error),
+) ([]entity.Link, string, error) {
+	cursor, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", &FieldError{Field: "page_token", Err: err}
+	}
+
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	links, err := lister(cursor, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(links) <= pageSize {
+		return links, "", nil
+	}
+
+	links = links[:pageSize]
+
+	return links, encodePageToken(links[pageSize-1].Cursor()), nil
+}
+
+// All Returns all not deleted user links
+func (s *Service) All(ctx context.Context, userID entity.UserID) ([]entity.Link, error) {
+	var res []entity.Link
+
+	var token string
+	for {
+		links, next, err := s.List(ctx, userID, MaxPageSize, token)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, link := range links {
+			if !link.Deleted {
+				res = append(res, link)
+			}
+		}
+
+		if next == "" {
+			return res, nil
+		}
+		token = next
+	}
+}
+
+// Delete Deletes user links by short IDs or full short URLs in background
+//
+// Returns ID of deletion job
+func (s *Service) Delete(ctx context.Context, userID entity.UserID, shortURLs []string) (string, error) {
+	jobID, err := s.deleter.ProcessDeletedURLs(ctx, userID, s.Keys(shortURLs))
+	if err != nil {
+		return "", fmt.Errorf("error while queueing links for deletion: %w", err)
+	}
+
+	return jobID, nil
+}
+
+// Statistic Returns count of links and users
+func (s *Service) Statistic(ctx context.Context) (models.CountStatistic, error) {
+	stat, err := s.storage.GetStatistic(ctx)
+	if err != nil {
+		return models.CountStatistic{}, fmt.Errorf("error while getting statistic: %w", err)
+	}
+
+	return stat, nil
+}
+
+// selectDomain Returns domain of new links of user by name
+func (s *Service) selectDomain(userID entity.UserID, name string) (domain.Domain, error) {
+	dom, err := s.domains.Select(userID, name, "")
+	if errors.Is(err, domain.ErrUnknownDomain) {
+		return domain.Domain{}, &FieldError{Field: "domain", Err: err}
+	}
+
+	return dom, err
+}
+
+func validateMetadata(metadata map[string]string) error {
+	for key, value := range metadata {
+		if key == "" || len(key) > maxMetadataKeyLen {
+			return &FieldError{
+				Field: "metadata",
+				Err:   fmt.Errorf("%w: key %q must contain from 1 to %d bytes", ErrInvalidMetadata, key, maxMetadataKeyLen),
+			}
+		}
+
+		if len(value) > maxMetadataValueLen {
+			return &FieldError{
+				Field: "metadata",
+				Err:   fmt.Errorf("%w: value of key %q must contain at most %d bytes", ErrInvalidMetadata, key, maxMetadataValueLen),
+			}
+		}
+	}
+
+	return nil
+}
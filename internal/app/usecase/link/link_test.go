@@ -0,0 +1,204 @@
+package link
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/storage/local"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+)
+
+const (
+	baseURIPrefix = "http://localhost:8080"
+	brandPrefix   = "https://go.brand.io"
+	userID        = entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	otherUserID   = entity.UserID("0c0a4811-4f10-487f-bde3-e39a14af7cd8")
+)
+
+type testDeleter struct {
+	batch models.ReqDeletedURLBatch
+}
+
+func (d *testDeleter) ProcessDeletedURLs(_ context.Context, _ entity.UserID, batch models.ReqDeletedURLBatch) (string, error) {
+	d.batch = batch
+
+	return "job", nil
+}
+
+func newTestService(t *testing.T, deleter Deleter) *Service {
+	domains, err := domain.NewRegistry(config.Config{
+		BaseURIPrefix: baseURIPrefix,
+		Domains:       brandPrefix + ";users=" + userID.String(),
+	})
+	require.NoError(t, err)
+
+	service := NewService(local.NewTSLocalStorage(0), deleter, domains)
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	return service
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name        string
+		originalURL string
+		metadata    map[string]string
+		wantErr     error
+		wantField   string
+	}{
+		{
+			name:        "created link",
+			originalURL: "https://practicum.yandex.ru/",
+			metadata:    map[string]string{"campaign": "autumn"},
+		},
+		{
+			name:        "invalid url",
+			originalURL: "practicum",
+			wantErr:     ErrInvalidURL,
+			wantField:   "original_url",
+		},
+		{
+			name:        "empty metadata key",
+			originalURL: "https://practicum.yandex.ru/",
+			metadata:    map[string]string{"": "value"},
+			wantErr:     ErrInvalidMetadata,
+			wantField:   "metadata",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := newTestService(t, &testDeleter{})
+
+			created, err := service.Create(context.Background(), userID, "", test.originalURL, test.metadata)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+
+				var fieldErr *FieldError
+				require.ErrorAs(t, err, &fieldErr)
+				assert.Equal(t, test.wantField, fieldErr.Field)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, ShortID(test.originalURL), created.ShortURL)
+			assert.Equal(t, baseURIPrefix+"/"+created.ShortURL, service.ShortURL(created))
+
+			found, err := service.Get(context.Background(), userID, service.ShortURL(created))
+			require.NoError(t, err)
+			assert.Equal(t, test.originalURL, found.OriginalURL)
+			assert.Equal(t, test.metadata, found.Metadata)
+		})
+	}
+}
+
+func TestCreateExisting(t *testing.T) {
+	service := newTestService(t, &testDeleter{})
+
+	created, err := service.Create(context.Background(), userID, "", "https://practicum.yandex.ru/", nil)
+	require.NoError(t, err)
+
+	existing, err := service.Create(context.Background(), userID, "", "https://practicum.yandex.ru/", nil)
+	require.ErrorIs(t, err, ErrLinkExists)
+	assert.Equal(t, created.ShortURL, existing.ShortURL)
+	assert.True(t, created.CreatedAt.Equal(existing.CreatedAt))
+}
+
+func TestCreateOnDomain(t *testing.T) {
+	ctx := context.Background()
+	service := newTestService(t, &testDeleter{})
+
+	created, err := service.Create(ctx, userID, "go.brand.io", "https://practicum.yandex.ru/", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "go.brand.io/"+ShortID("https://practicum.yandex.ru/"), created.ShortURL)
+	assert.Equal(t, brandPrefix+"/"+ShortID("https://practicum.yandex.ru/"), service.ShortURL(created))
+
+	// the same original URL gets separate link in namespace of default domain
+	_, err = service.Create(ctx, userID, "", "https://practicum.yandex.ru/", nil)
+	require.NoError(t, err)
+
+	found, err := service.Get(ctx, userID, service.ShortURL(created))
+	require.NoError(t, err)
+	assert.Equal(t, created.ShortURL, found.ShortURL)
+
+	results, err := service.CreateBatch(ctx, userID, brandPrefix, []BatchItem{{CorrelationID: "1", OriginalURL: "https://yandex.ru/"}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "go.brand.io/"+ShortID("https://yandex.ru/"), results[0].Link.ShortURL)
+
+	_, err = service.Create(ctx, otherUserID, "go.brand.io", "https://yandex.ru/", nil)
+	require.ErrorIs(t, err, domain.ErrDomainForbidden)
+
+	_, err = service.Create(ctx, userID, "unknown.io", "https://yandex.ru/", nil)
+	require.ErrorIs(t, err, domain.ErrUnknownDomain)
+
+	var fieldErr *FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "domain", fieldErr.Field)
+}
+
+func TestGetNotFound(t *testing.T) {
+	service := newTestService(t, &testDeleter{})
+
+	_, err := service.Get(context.Background(), userID, "abcdefgh")
+	require.ErrorIs(t, err, ErrLinkNotFound)
+}
+
+func TestList(t *testing.T) {
+	service := newTestService(t, &testDeleter{})
+
+	var created []string
+	for i := 0; i < 5; i++ {
+		link, err := service.Create(context.Background(), userID, "", fmt.Sprintf("https://practicum.yandex.ru/%d", i), nil)
+		require.NoError(t, err)
+
+		created = append(created, link.ShortURL)
+	}
+
+	var listed []string
+	var token string
+	pages := 0
+	for {
+		links, next, err := service.List(context.Background(), userID, 2, token)
+		require.NoError(t, err)
+
+		for _, link := range links {
+			listed = append(listed, link.ShortURL)
+		}
+		pages++
+
+		if next == "" {
+			break
+		}
+		token = next
+	}
+
+	assert.Equal(t, created, listed)
+	assert.Equal(t, 3, pages)
+
+	_, _, err := service.List(context.Background(), userID, 2, "invalid token")
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestDelete(t *testing.T) {
+	deleter := &testDeleter{}
+	service := newTestService(t, deleter)
+
+	jobID, err := service.Delete(context.Background(), userID, []string{baseURIPrefix + "/abcdefgh", "12345678"})
+	require.NoError(t, err)
+	assert.Equal(t, "job", jobID)
+	assert.Equal(t, models.ReqDeletedURLBatch{"abcdefgh", "12345678"}, deleter.batch)
+}
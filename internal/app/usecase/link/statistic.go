@@ -0,0 +1,136 @@
+package link
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	storage_err "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+)
+
+// Limits of service statistic request
+//
+// DefaultStatisticTop - count of top domains and links if it is not set
+// MaxStatisticTop - max count of top domains and links
+// MaxStatisticBuckets - max count of time buckets in requested range
+const (
+	DefaultStatisticTop = 10
+	MaxStatisticTop     = 100
+	MaxStatisticBuckets = 1000
+)
+
+// defaultStatisticBuckets Count of time buckets in range if start of range is not set
+const defaultStatisticBuckets = 30
+
+// DetailedStatistic Returns service statistic with time series of created links, top domains and top clicked links
+//
+// Missing query parameters are replaced by defaults: the last 30 days up to now with daily granularity.
+// Buckets without created links are included with zero count.
+// Returns FieldError with ErrInvalidStatisticQuery if query is invalid
+func (s *Service) DetailedStatistic(ctx context.Context, query models.StatisticQuery) (models.ServiceStatistic, error) {
+	query, err := s.normalizeQuery(query)
+	if err != nil {
+		return models.ServiceStatistic{}, err
+	}
+
+	stat, err := s.storage.GetServiceStatistic(ctx, query)
+	if err != nil {
+		return models.ServiceStatistic{}, fmt.Errorf("error while getting service statistic: %w", err)
+	}
+
+	stat.From = query.From
+	stat.To = query.To
+	stat.Granularity = query.Granularity
+	stat.Created = fillSeries(query, stat.Created)
+
+	if stat.TopDomains == nil {
+		stat.TopDomains = []models.DomainCount{}
+	}
+
+	topLinks := make([]models.LinkClicks, 0, len(stat.TopLinks))
+	for _, link := range stat.TopLinks {
+		link.ShortURL = s.ShortURL(entity.Link{ShortURL: link.ShortURL})
+		topLinks = append(topLinks, link)
+	}
+	stat.TopLinks = topLinks
+
+	return stat, nil
+}
+
+// RecordClick Records redirect by link with short ID or full short URL
+//
+// Returns ErrLinkNotFound if link is not found or deleted
+func (s *Service) RecordClick(ctx context.Context, shortURL string) error {
+	err := s.storage.RecordClick(ctx, s.Key(shortURL))
+	if err != nil {
+		if errors.Is(err, storage_err.ErrShortURLNotFound) {
+			return ErrLinkNotFound
+		}
+
+		return fmt.Errorf("error while recording click: %w", err)
+	}
+
+	return nil
+}
+
+// normalizeQuery Sets default values of statistic query and validates it
+func (s *Service) normalizeQuery(query models.StatisticQuery) (models.StatisticQuery, error) {
+	switch query.Granularity {
+	case "":
+		query.Granularity = models.GranularityDay
+	case models.GranularityHour, models.GranularityDay:
+	default:
+		return query, statisticQueryError("granularity", "must be %q or %q", models.GranularityHour, models.GranularityDay)
+	}
+
+	if query.Top == 0 {
+		query.Top = DefaultStatisticTop
+	}
+	if query.Top < 0 || query.Top > MaxStatisticTop {
+		return query, statisticQueryError("top", "must be from 1 to %d", MaxStatisticTop)
+	}
+
+	if query.To.IsZero() {
+		query.To = s.now()
+	}
+	if query.From.IsZero() {
+		query.From = query.Truncate(query.To.Add(-defaultStatisticBuckets * query.Step()))
+	}
+	query.From = query.From.UTC()
+	query.To = query.To.UTC()
+
+	if !query.From.Before(query.To) {
+		return query, statisticQueryError("from", "must be before end of range")
+	}
+
+	if query.To.Sub(query.Truncate(query.From)) > MaxStatisticBuckets*query.Step() {
+		return query, statisticQueryError("from", "range must contain at most %d buckets of %s granularity", MaxStatisticBuckets, query.Granularity)
+	}
+
+	return query, nil
+}
+
+// fillSeries Returns counts of all buckets of query range including buckets without created links
+func fillSeries(query models.StatisticQuery, series []models.TimeCount) []models.TimeCount {
+	counts := make(map[time.Time]int, len(series))
+	for _, bucket := range series {
+		counts[query.Truncate(bucket.Time)] += bucket.Count
+	}
+
+	var res []models.TimeCount
+	for bucket := query.Truncate(query.From); bucket.Before(query.To); bucket = bucket.Add(query.Step()) {
+		res = append(res, models.TimeCount{Time: bucket, Count: counts[bucket]})
+	}
+
+	return res
+}
+
+func statisticQueryError(field, format string, args ...any) error {
+	return &FieldError{
+		Field: field,
+		Err:   fmt.Errorf("%w: %s", ErrInvalidStatisticQuery, fmt.Sprintf(format, args...)),
+	}
+}
@@ -0,0 +1,128 @@
+package link
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/storage/local"
+)
+
+func TestDetailedStatistic(t *testing.T) {
+	storage := local.NewTSLocalStorage(0)
+	service := newTestService(t, &testDeleter{})
+	service.storage = storage
+
+	ctx := context.Background()
+
+	var links []entity.Link
+	for _, originalURL := range []string{
+		"https://practicum.yandex.ru/first",
+		"https://Practicum.Yandex.ru/second",
+		"https://example.com/",
+	} {
+		created, err := service.Create(ctx, userID, "", originalURL, nil)
+		require.NoError(t, err)
+
+		links = append(links, created)
+	}
+
+	require.NoError(t, service.RecordClick(ctx, links[0].ShortURL))
+	require.NoError(t, service.RecordClick(ctx, service.ShortURL(links[0])))
+	require.NoError(t, service.RecordClick(ctx, links[1].ShortURL))
+	require.NoError(t, service.RecordClick(ctx, links[2].ShortURL))
+
+	err := storage.DeleteBatchURL(ctx, entity.DeletedURLBatch{{UserID: userID.String(), ShortURL: links[2].ShortURL}})
+	require.NoError(t, err)
+
+	err = service.RecordClick(ctx, links[2].ShortURL)
+	require.ErrorIs(t, err, ErrLinkNotFound)
+
+	stat, err := service.DetailedStatistic(ctx, models.StatisticQuery{
+		From:        time.Date(2026, time.October, 19, 10, 30, 0, 0, time.UTC),
+		To:          time.Date(2026, time.October, 19, 13, 0, 0, 0, time.UTC),
+		Granularity: models.GranularityHour,
+		Top:         1,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, models.CountStatistic{URLCount: 2, UserCount: 1, DeletedCount: 1}, stat.CountStatistic)
+	assert.Positive(t, stat.StorageSize)
+	assert.Equal(t, []models.TimeCount{
+		{Time: time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC), Count: 0},
+		{Time: time.Date(2026, time.October, 19, 11, 0, 0, 0, time.UTC), Count: 0},
+		{Time: time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC), Count: 3},
+	}, stat.Created)
+	assert.Equal(t, []models.DomainCount{{Domain: "practicum.yandex.ru", Count: 2}}, stat.TopDomains)
+	assert.Equal(t, []models.LinkClicks{{
+		ShortURL:    service.ShortURL(links[0]),
+		OriginalURL: links[0].OriginalURL,
+		Clicks:      2,
+	}}, stat.TopLinks)
+}
+
+func TestDetailedStatisticDefaults(t *testing.T) {
+	service := newTestService(t, &testDeleter{})
+
+	stat, err := service.DetailedStatistic(context.Background(), models.StatisticQuery{})
+	require.NoError(t, err)
+
+	assert.Equal(t, models.GranularityDay, stat.Granularity)
+	assert.Equal(t, time.Date(2026, time.September, 19, 0, 0, 0, 0, time.UTC), stat.From)
+	assert.Len(t, stat.Created, defaultStatisticBuckets+1)
+	assert.Empty(t, stat.TopDomains)
+	assert.Empty(t, stat.TopLinks)
+}
+
+func TestDetailedStatisticInvalidQuery(t *testing.T) {
+	to := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		query     models.StatisticQuery
+		wantField string
+	}{
+		{
+			name:      "unknown granularity",
+			query:     models.StatisticQuery{Granularity: "week"},
+			wantField: "granularity",
+		},
+		{
+			name:      "too many top items",
+			query:     models.StatisticQuery{Top: MaxStatisticTop + 1},
+			wantField: "top",
+		},
+		{
+			name:      "empty range",
+			query:     models.StatisticQuery{From: to, To: to},
+			wantField: "from",
+		},
+		{
+			name: "too many buckets",
+			query: models.StatisticQuery{
+				From:        to.AddDate(0, 0, -60),
+				To:          to,
+				Granularity: models.GranularityHour,
+			},
+			wantField: "from",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := newTestService(t, &testDeleter{})
+
+			_, err := service.DetailedStatistic(context.Background(), test.query)
+			require.ErrorIs(t, err, ErrInvalidStatisticQuery)
+
+			var fieldErr *FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, test.wantField, fieldErr.Field)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package link
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+)
+
+// pageToken Position of the next page of user links
+type pageToken struct {
+	CreatedAt time.Time `json:"t"`
+	ShortURL  string    `json:"s"`
+}
+
+// encodePageToken Returns opaque token of the next page placed after cursor
+func encodePageToken(cursor entity.LinkCursor) string {
+	data, _ := json.Marshal(pageToken{
+		CreatedAt: cursor.CreatedAt,
+		ShortURL:  cursor.ShortURL,
+	})
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken Returns cursor of page token
+//
+// Returns empty cursor if token is empty
+func decodePageToken(token string) (entity.LinkCursor, error) {
+	if token == "" {
+		return entity.LinkCursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return entity.LinkCursor{}, ErrInvalidPageToken
+	}
+
+	var res pageToken
+	err = json.Unmarshal(data, &res)
+	if err != nil || res.ShortURL == "" {
+		return entity.LinkCursor{}, ErrInvalidPageToken
+	}
+
+	return entity.LinkCursor{
+		CreatedAt: res.CreatedAt,
+		ShortURL:  res.ShortURL,
+	}, nil
+}
@@ -2,9 +2,9 @@ package server
 
 import "net/http"
 
-// Start Starts HTTP or HTTPS server
-func Start(isHTTPS bool, server *http.Server) {
-	if isHTTPS {
+// Start Starts HTTP server or HTTPS server if server has TLS config
+func Start(server *http.Server) {
+	if server.TLSConfig != nil {
 		startHTTPS(server)
 		return
 	}
@@ -0,0 +1,83 @@
+package trash
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/metrics"
+)
+
+// Purger Purges expired links from trash by ticker
+type Purger struct {
+	service *Service
+	done    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// NewPurger Creates purger of expired links using interval of service
+func NewPurger(service *Service) *Purger {
+	return &Purger{
+		service: service,
+		done:    make(chan struct{}),
+	}
+}
+
+// Start Starts purging expired links in background
+func (p *Purger) Start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.run()
+	}()
+}
+
+// Stop Stops purging and waits for current purge to complete
+func (p *Purger) Stop() {
+	p.once.Do(func() {
+		close(p.done)
+	})
+
+	p.wg.Wait()
+}
+
+func (p *Purger) run() {
+	ticker := time.NewTicker(p.service.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.purge()
+		}
+	}
+}
+
+func (p *Purger) purge() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-p.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	count, err := p.service.Purge(ctx)
+	metrics.ObserveTrashPurge(count, err)
+	if err != nil {
+		zap.L().Error("error while purging expired links from trash", zap.Error(err))
+		return
+	}
+
+	if count != 0 {
+		zap.L().Info("expired links are purged from trash", zap.Int("count", count))
+	}
+}
@@ -0,0 +1,159 @@
+// Package trash implements use cases of deleted links kept in trash of user
+//
+// Deleted links can be restored during retention period, after that they are purged from storage
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+// MaxRestoreSize Max count of links restored by one request
+const MaxRestoreSize = 1000
+
+// ErrInvalidRestore Links to restore are not set or too many
+var ErrInvalidRestore = errors.New("invalid links to restore")
+
+// Service Use cases of trash of deleted links
+type Service struct {
+	storage   model.Storage
+	domains   *domain.Registry
+	retention time.Duration
+	interval  time.Duration
+	batchSize int
+	now       func() time.Time
+}
+
+// ValidateConfig Validates settings of trash from config
+func ValidateConfig(config config.Config) error {
+	_, err := NewService(nil, config, nil)
+
+	return err
+}
+
+// NewService Creates service of trash
+//
+// Retention of deleted links, purge interval and purge batch size are taken from config.
+// Short URLs of links are built on their domains
+func NewService(storage model.Storage, config config.Config, domains *domain.Registry) (*Service, error) {
+	var errs []error
+
+	retention, err := parseDuration("trash_retention", config.TrashRetention)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	interval, err := parseDuration("trash_purge_interval", config.TrashPurgeInterval)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if config.TrashPurgeBatchSize <= 0 {
+		errs = append(errs, fmt.Errorf("trash_purge_batch_size must be positive, got %d", config.TrashPurgeBatchSize))
+	}
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return &Service{
+		storage:   storage,
+		domains:   domains,
+		retention: retention,
+		interval:  interval,
+		batchSize: config.TrashPurgeBatchSize,
+		now:       time.Now,
+	}, nil
+}
+
+// ShortURL Returns full short URL of link on its domain
+func (s *Service) ShortURL(link entity.Link) string {
+	return s.domains.ShortURL(link.ShortURL)
+}
+
+// ExpiresAt Returns time after which deleted link couldn't be restored
+func (s *Service) ExpiresAt(link entity.Link) time.Time {
+	return link.DeletedAt.Add(s.retention)
+}
+
+// List Returns deleted links of user which can be restored, the most recently deleted first
+func (s *Service) List(ctx context.Context, userID entity.UserID) ([]entity.Link, error) {
+	links, err := s.storage.ListDeletedLinks(ctx, userID, s.deletedAfter())
+	if err != nil {
+		return nil, fmt.Errorf("error while listing deleted links: %w", err)
+	}
+
+	return links, nil
+}
+
+// Restore Restores deleted links of user by short IDs or full short URLs
+//
+// Links which are not in trash of user or whose retention is expired are skipped.
+// Returns short IDs of restored links
+func (s *Service) Restore(ctx context.Context, userID entity.UserID, shortURLs []string) ([]string, error) {
+	if len(shortURLs) == 0 || len(shortURLs) > MaxRestoreSize {
+		return nil, &link.FieldError{
+			Field: "short_urls",
+			Err:   fmt.Errorf("%w: count must be from 1 to %d, got %d", ErrInvalidRestore, MaxRestoreSize, len(shortURLs)),
+		}
+	}
+
+	shortIDs := make([]string, 0, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		shortIDs = append(shortIDs, s.domains.Key(shortURL))
+	}
+
+	restored, err := s.storage.RestoreLinks(ctx, userID, shortIDs, s.deletedAfter())
+	if err != nil {
+		return nil, fmt.Errorf("error while restoring links: %w", err)
+	}
+
+	return restored, nil
+}
+
+// Purge Removes links kept in trash longer than retention from storage
+//
+// Links are removed by batches until there are no expired links.
+// Returns count of removed links
+func (s *Service) Purge(ctx context.Context) (int, error) {
+	deletedBefore := s.deletedAfter()
+
+	var total int
+	for {
+		links, err := s.storage.PurgeDeletedLinks(ctx, deletedBefore, s.batchSize)
+		total += len(links)
+		if err != nil {
+			return total, fmt.Errorf("error while purging deleted links: %w", err)
+		}
+
+		if len(links) < s.batchSize {
+			return total, nil
+		}
+	}
+}
+
+// deletedAfter Returns deletion time of the oldest link which can be restored
+func (s *Service) deletedAfter() time.Time {
+	return s.now().UTC().Add(-s.retention)
+}
+
+func parseDuration(name, value string) (time.Duration, error) {
+	res, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, value, err)
+	}
+
+	if res <= 0 {
+		return 0, fmt.Errorf("%s must be positive, got %q", name, value)
+	}
+
+	return res, nil
+}
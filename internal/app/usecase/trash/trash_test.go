@@ -0,0 +1,131 @@
+package trash
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/storage/local"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+const (
+	baseURIPrefix = "http://localhost:8080"
+	userID        = entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+)
+
+func newTestConfig() config.Config {
+	return config.Config{
+		BaseURIPrefix:       baseURIPrefix,
+		TrashRetention:      "168h",
+		TrashPurgeInterval:  "1h",
+		TrashPurgeBatchSize: 2,
+	}
+}
+
+func newTestService(t *testing.T, shortURLs ...string) *Service {
+	storage := local.NewTSLocalStorage(0)
+	ctx := context.Background()
+
+	var deleted entity.DeletedURLBatch
+	for _, shortURL := range shortURLs {
+		err := storage.SaveLink(ctx, entity.Link{
+			ShortURL:    shortURL,
+			OriginalURL: "https://practicum.yandex.ru/" + shortURL,
+			UserID:      userID,
+			CreatedAt:   time.Now().UTC(),
+		})
+		require.NoError(t, err)
+
+		deleted = append(deleted, entity.DeletedURL{UserID: userID.String(), ShortURL: shortURL})
+	}
+	require.NoError(t, storage.DeleteBatchURL(ctx, deleted))
+
+	domains, err := domain.NewRegistry(newTestConfig())
+	require.NoError(t, err)
+
+	service, err := NewService(storage, newTestConfig(), domains)
+	require.NoError(t, err)
+
+	return service
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.NoError(t, ValidateConfig(newTestConfig()))
+
+	err := ValidateConfig(config.Config{
+		TrashRetention:      "week",
+		TrashPurgeInterval:  "-1h",
+		TrashPurgeBatchSize: 0,
+	})
+	assert.ErrorContains(t, err, `invalid trash_retention "week"`)
+	assert.ErrorContains(t, err, `trash_purge_interval must be positive, got "-1h"`)
+	assert.ErrorContains(t, err, "trash_purge_batch_size must be positive, got 0")
+}
+
+func TestListAndRestore(t *testing.T) {
+	service := newTestService(t, "42b3e75f", "77fca595")
+	ctx := context.Background()
+
+	links, err := service.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, links[0].DeletedAt.Add(168*time.Hour), service.ExpiresAt(links[0]))
+
+	restored, err := service.Restore(ctx, userID, []string{baseURIPrefix + "/42b3e75f", "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"42b3e75f"}, restored)
+
+	links, err = service.List(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, baseURIPrefix+"/77fca595", service.ShortURL(links[0]))
+
+	// retention of deleted link is expired
+	service.now = func() time.Time {
+		return time.Now().Add(200 * time.Hour)
+	}
+
+	links, err = service.List(ctx, userID)
+	require.NoError(t, err)
+	assert.Empty(t, links)
+
+	restored, err = service.Restore(ctx, userID, []string{"77fca595"})
+	require.NoError(t, err)
+	assert.Empty(t, restored)
+
+	_, err = service.Restore(ctx, userID, nil)
+	require.ErrorIs(t, err, ErrInvalidRestore)
+
+	var fieldErr *link.FieldError
+	require.ErrorAs(t, err, &fieldErr)
+	assert.Equal(t, "short_urls", fieldErr.Field)
+}
+
+func TestPurge(t *testing.T) {
+	service := newTestService(t, "42b3e75f", "77fca595", "ac6bb669")
+	ctx := context.Background()
+
+	count, err := service.Purge(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	service.now = func() time.Time {
+		return time.Now().Add(200 * time.Hour)
+	}
+
+	// links are purged by two batches
+	count, err = service.Purge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = service.Purge(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress Error that will be returned if webhook resolves to address of internal network
+var ErrForbiddenAddress = errors.New("webhook address is forbidden")
+
+// sharedAddressSpace Carrier-grade NAT range, which is also used by metadata services of some clouds
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// newClient Creates HTTP client delivering events to webhooks
+//
+// Redirects aren't followed and connections to loopback, private, link-local and other
+// internal addresses are refused after name resolution, unless private addresses are allowed.
+// Proxy from environment isn't used, so the address of receiver is always checked
+func newClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivate {
+		dialer.Control = checkAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkAddress Refuses connection to internal address, it is called with resolved address before connecting
+func checkAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrForbiddenAddress, address)
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil || isInternalAddr(addr) {
+		return fmt.Errorf("%w: %q", ErrForbiddenAddress, address)
+	}
+
+	return nil
+}
+
+// isInternalAddr Returns true if address doesn't belong to public internet
+func isInternalAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr)
+}
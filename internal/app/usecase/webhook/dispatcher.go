@@ -0,0 +1,320 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/metrics"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/usecase/events"
+)
+
+const (
+	tickerTime        = time.Second
+	retryDelayBase    = 5 * time.Second
+	maxRetryDelay     = time.Hour
+	storageTimeout    = 5 * time.Second
+	pendingBatchSize  = 100
+	maxSendingWorkers = 8
+)
+
+// Payload JSON body of request delivering event to webhook
+type Payload struct {
+	ID   string      `json:"id"`
+	Type string      `json:"type"`
+	Time time.Time   `json:"time"`
+	Data PayloadLink `json:"data"`
+}
+
+// PayloadLink Link of event delivered to webhook
+type PayloadLink struct {
+	ShortURL    string `json:"short_url"`
+	Alias       string `json:"alias"`
+	OriginalURL string `json:"original_url"`
+}
+
+// Publisher Publishes link events to webhooks
+type Publisher interface {
+	Publish(event entity.LinkEvent)
+}
+
+// Publish Queues link event for delivery to webhooks of its owner
+//
+// Doesn't block caller: event is dropped with warning if queue is full
+func (s *Service) Publish(event entity.LinkEvent) {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+
+	if event.Time.IsZero() {
+		event.Time = s.now().UTC()
+	}
+
+	select {
+	case s.events <- event:
+		metrics.ObserveWebhookEvent(false)
+	default:
+		metrics.ObserveWebhookEvent(true)
+		zap.L().Warn("webhook event is dropped because queue is full", zap.String("event", event.Type), zap.String("short_url", event.ShortURL))
+	}
+}
+
+// Start Starts processing of queued events and sending of deliveries in background
+func (s *Service) Start() {
+	s.wg.Add(2)
+	go func() {
+		defer s.wg.Done()
+		s.consume()
+	}()
+	go func() {
+		defer s.wg.Done()
+		s.send()
+	}()
+}
+
+// Stop Stops background processing and waits for it to complete
+//
+// Queued events are saved as pending deliveries, so they are sent after restart
+func (s *Service) Stop() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+
+	s.wg.Wait()
+}
+
+func (s *Service) consume() {
+	for {
+		select {
+		case <-s.done:
+			s.drain()
+			return
+		case event := <-s.events:
+			s.processEvent(event)
+		}
+	}
+}
+
+func (s *Service) drain() {
+	for {
+		select {
+		case event := <-s.events:
+			s.processEvent(event)
+		default:
+			return
+		}
+	}
+}
+
+// processEvent Saves pending delivery of event for every subscribed webhook of link owner
+//
+// Owner and original URL are resolved from storage if publisher doesn't know them,
+// events of links not owned by user of event are skipped
+func (s *Service) processEvent(event entity.LinkEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), storageTimeout)
+	defer cancel()
+
+	resolved, err := events.Resolve(ctx, s.storage, event)
+	if err != nil {
+		zap.L().Debug("webhook event is skipped", zap.String("short_url", event.ShortURL), zap.Error(err))
+		return
+	}
+	event = resolved
+
+	webhooks, err := s.storage.ListWebhooks(ctx, event.UserID)
+	if err != nil {
+		zap.L().Error("error while listing webhooks of event", zap.String("event", event.Type), zap.Error(err))
+		return
+	}
+
+	var payload []byte
+	for _, webhook := range webhooks {
+		if !webhook.IsSubscribed(event.Type) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = s.payload(event)
+			if err != nil {
+				zap.L().Error("error while converting webhook event to payload", zap.String("event", event.Type), zap.Error(err))
+				return
+			}
+		}
+
+		delivery := s.newDelivery(webhook.ID, event.UserID, event.Type, payload)
+		err = s.storage.SaveWebhookDelivery(ctx, delivery)
+		if errors.Is(err, api.ErrWebhookNotFound) {
+			// webhook is deleted or its user is erased after webhooks are listed
+			zap.L().Debug("webhook of event is removed", zap.String("webhook_id", webhook.ID))
+			continue
+		}
+		if err != nil {
+			zap.L().Error("error while saving webhook delivery", zap.String("webhook_id", webhook.ID), zap.Error(err))
+			continue
+		}
+
+		metrics.ObserveWebhookDelivery(string(delivery.Status))
+		s.notify()
+	}
+}
+
+func (s *Service) payload(event entity.LinkEvent) ([]byte, error) {
+	return json.Marshal(Payload{
+		ID:   event.ID,
+		Type: event.Type,
+		Time: event.Time,
+		Data: PayloadLink{
+			ShortURL:    s.ShortURL(event.ShortURL),
+			Alias:       event.ShortURL,
+			OriginalURL: event.OriginalURL,
+		},
+	})
+}
+
+func (s *Service) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Service) send() {
+	ticker := time.NewTicker(tickerTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+
+		s.deliverPending()
+	}
+}
+
+// deliverPending Sends due pending deliveries concurrently by limited count of workers
+func (s *Service) deliverPending() {
+	ctx, cancel := context.WithTimeout(context.Background(), storageTimeout)
+	deliveries, err := s.storage.ListPendingWebhookDeliveries(ctx, s.now().UTC(), pendingBatchSize)
+	cancel()
+	if err != nil {
+		zap.L().Error("error while listing pending webhook deliveries", zap.Error(err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, maxSendingWorkers)
+	for _, delivery := range deliveries {
+		workers <- struct{}{}
+		wg.Add(1)
+		go func(delivery entity.WebhookDelivery) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			s.deliver(delivery)
+		}(delivery)
+	}
+
+	wg.Wait()
+}
+
+// deliver Makes attempt of delivery and saves its result
+//
+// Delivery is failed if its webhook is deleted or all attempts are exhausted,
+// otherwise the next attempt is scheduled with exponential backoff.
+// Result isn't saved if delivery is removed during attempt, e.g. by erasure of its user
+func (s *Service) deliver(delivery entity.WebhookDelivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), storageTimeout+s.timeout)
+	defer cancel()
+
+	webhook, err := s.storage.GetWebhook(ctx, delivery.UserID, delivery.WebhookID)
+	if err != nil && !errors.Is(err, api.ErrWebhookNotFound) {
+		zap.L().Error("error while getting webhook of delivery", zap.String("delivery_id", delivery.ID), zap.Error(err))
+		return
+	}
+
+	if err != nil {
+		delivery.Status = entity.WebhookDeliveryFailed
+		delivery.LastError = "webhook is deleted"
+	} else {
+		delivery.Attempts++
+		delivery.ResponseCode, err = s.post(ctx, webhook, delivery)
+		switch {
+		case err == nil:
+			delivery.Status = entity.WebhookDeliveryDelivered
+			delivery.LastError = ""
+		case delivery.Attempts >= s.maxAttempts:
+			delivery.Status = entity.WebhookDeliveryFailed
+			delivery.LastError = err.Error()
+		default:
+			delivery.LastError = err.Error()
+			delivery.NextAttemptAt = s.now().UTC().Add(retryDelay(delivery.Attempts))
+		}
+	}
+
+	delivery.UpdatedAt = s.now().UTC()
+	metrics.ObserveWebhookDelivery(string(delivery.Status))
+
+	err = s.storage.UpdateWebhookDelivery(ctx, delivery)
+	if errors.Is(err, api.ErrWebhookDeliveryNotFound) {
+		zap.L().Debug("state of removed webhook delivery is not saved", zap.String("delivery_id", delivery.ID))
+		return
+	}
+	if err != nil {
+		zap.L().Error("error while saving state of webhook delivery", zap.String("delivery_id", delivery.ID), zap.Error(err))
+	}
+}
+
+// post Posts signed payload of delivery to webhook
+//
+// Returns status code of response and error if receiver didn't accept payload with 2xx status
+func (s *Service) post(ctx context.Context, webhook entity.Webhook, delivery entity.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("error while creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error while sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// retryDelay Returns delay before the next attempt of delivery doubled after every failed attempt
+func retryDelay(attempts int) time.Duration {
+	delay := retryDelayBase
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxRetryDelay)
+}
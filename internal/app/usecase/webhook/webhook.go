@@ -0,0 +1,324 @@
+// Package webhook implements user subscriptions to link events delivered to external URLs
+//
+// Events of link lifecycle and clicks are published by decorator of storage, queued in memory
+// and converted to deliveries kept in storage. Deliveries are sent in background as signed JSON
+// and retried with exponential backoff until receiver accepts them or attempts are exhausted
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+// Headers of request delivering event to webhook
+//
+// SignatureHeader contains HMAC-SHA256 of request body signed by secret of webhook in form "sha256=<hex>"
+const (
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// Limits of webhooks
+//
+// MaxWebhooksPerUser - max count of webhooks of one user
+// MaxDeliveriesList - max count of the latest deliveries returned for webhook
+const (
+	MaxWebhooksPerUser = 10
+	MaxDeliveriesList  = 100
+)
+
+const secretSize = 32
+
+// Errors of webhook use cases
+//
+// ErrInvalidWebhookURL - URL of webhook is not absolute HTTP or HTTPS URL or its host is internal IP address
+// ErrInvalidEvent - event type of webhook is unknown
+// ErrTooManyWebhooks - user has max count of webhooks
+// ErrWebhookNotFound - webhook is not found for user
+// ErrDeliveryNotFound - delivery is not found for user
+var (
+	ErrInvalidWebhookURL = errors.New("invalid webhook url")
+	ErrInvalidEvent      = errors.New("invalid event type")
+	ErrTooManyWebhooks   = errors.New("too many webhooks")
+	ErrWebhookNotFound   = errors.New("webhook is not found")
+	ErrDeliveryNotFound  = errors.New("webhook delivery is not found")
+)
+
+// Service Use cases of webhooks and dispatcher of their events
+type Service struct {
+	storage      model.Storage
+	client       *http.Client
+	allowPrivate bool
+	domains      *domain.Registry
+	timeout      time.Duration
+	maxAttempts  int
+	events       chan entity.LinkEvent
+	wake         chan struct{}
+	now          func() time.Time
+
+	done chan struct{}
+	wg   sync.WaitGroup
+	once sync.Once
+}
+
+// ValidateConfig Validates settings of webhooks from config
+func ValidateConfig(config config.Config) error {
+	_, err := NewService(nil, config, nil)
+
+	return err
+}
+
+// NewService Creates service of webhooks
+//
+// Delivery timeout, max count of attempts and size of event queue are taken from config.
+// Events are delivered to loopback, private and link-local addresses only if it is allowed by config.
+// Short URLs of links in payloads are built on their domains
+func NewService(storage model.Storage, config config.Config, domains *domain.Registry) (*Service, error) {
+	var errs []error
+
+	timeout, err := time.ParseDuration(config.WebhookTimeout)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid webhook_timeout %q: %w", config.WebhookTimeout, err))
+	} else if timeout <= 0 {
+		errs = append(errs, fmt.Errorf("webhook_timeout must be positive, got %q", config.WebhookTimeout))
+	}
+
+	if config.WebhookMaxAttempts <= 0 {
+		errs = append(errs, fmt.Errorf("webhook_max_attempts must be positive, got %d", config.WebhookMaxAttempts))
+	}
+
+	if config.WebhookQueueSize <= 0 {
+		errs = append(errs, fmt.Errorf("webhook_queue_size must be positive, got %d", config.WebhookQueueSize))
+	}
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return &Service{
+		storage:      storage,
+		client:       newClient(timeout, config.WebhookAllowPrivate),
+		allowPrivate: config.WebhookAllowPrivate,
+		domains:      domains,
+		timeout:      timeout,
+		maxAttempts:  config.WebhookMaxAttempts,
+		events:       make(chan entity.LinkEvent, config.WebhookQueueSize),
+		wake:         make(chan struct{}, 1),
+		now:          time.Now,
+		done:         make(chan struct{}),
+	}, nil
+}
+
+// Create Creates webhook of user for URL subscribed to given event types
+//
+// Webhook without event types is subscribed to all events.
+// Secret is generated if it is empty, it is returned only by this method
+func (s *Service) Create(ctx context.Context, userID entity.UserID, webhookURL string, events []string, secret string) (entity.Webhook, error) {
+	if !s.isValidWebhookURL(webhookURL) {
+		return entity.Webhook{}, &link.FieldError{Field: "url", Err: ErrInvalidWebhookURL}
+	}
+
+	for _, event := range events {
+		if !slices.Contains(entity.LinkEvents, event) {
+			return entity.Webhook{}, &link.FieldError{Field: "events", Err: fmt.Errorf("%w %q", ErrInvalidEvent, event)}
+		}
+	}
+
+	webhooks, err := s.storage.ListWebhooks(ctx, userID)
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("error while listing webhooks: %w", err)
+	}
+
+	if len(webhooks) >= MaxWebhooksPerUser {
+		return entity.Webhook{}, &link.FieldError{
+			Field: "url",
+			Err:   fmt.Errorf("%w: user can have at most %d webhooks", ErrTooManyWebhooks, MaxWebhooksPerUser),
+		}
+	}
+
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			return entity.Webhook{}, fmt.Errorf("error while generating webhook secret: %w", err)
+		}
+	}
+
+	var subscribed []string
+	if len(events) != 0 {
+		subscribed = slices.Clone(events)
+		slices.Sort(subscribed)
+		subscribed = slices.Compact(subscribed)
+	}
+
+	webhook := entity.Webhook{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		URL:       webhookURL,
+		Secret:    secret,
+		Events:    subscribed,
+		CreatedAt: s.now().UTC(),
+	}
+
+	err = s.storage.SaveWebhook(ctx, webhook)
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("error while saving webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+// List Returns webhooks of user ordered by creation time
+func (s *Service) List(ctx context.Context, userID entity.UserID) ([]entity.Webhook, error) {
+	webhooks, err := s.storage.ListWebhooks(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// Delete Deletes webhook of user with its deliveries
+func (s *Service) Delete(ctx context.Context, userID entity.UserID, webhookID string) error {
+	err := s.storage.DeleteWebhook(ctx, userID, webhookID)
+	if err != nil {
+		if errors.Is(err, api.ErrWebhookNotFound) {
+			return ErrWebhookNotFound
+		}
+
+		return fmt.Errorf("error while deleting webhook: %w", err)
+	}
+
+	return nil
+}
+
+// Deliveries Returns the latest deliveries of webhook of user, the newest first
+func (s *Service) Deliveries(ctx context.Context, userID entity.UserID, webhookID string) ([]entity.WebhookDelivery, error) {
+	_, err := s.storage.GetWebhook(ctx, userID, webhookID)
+	if err != nil {
+		if errors.Is(err, api.ErrWebhookNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+
+		return nil, fmt.Errorf("error while getting webhook: %w", err)
+	}
+
+	deliveries, err := s.storage.ListWebhookDeliveries(ctx, userID, webhookID, MaxDeliveriesList)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// Replay Sends payload of delivery of user to its webhook again
+//
+// New pending delivery is created, so replayed delivery is kept unchanged in log
+func (s *Service) Replay(ctx context.Context, userID entity.UserID, deliveryID string) (entity.WebhookDelivery, error) {
+	delivery, err := s.storage.GetWebhookDelivery(ctx, userID, deliveryID)
+	if err != nil {
+		if errors.Is(err, api.ErrWebhookDeliveryNotFound) {
+			return entity.WebhookDelivery{}, ErrDeliveryNotFound
+		}
+
+		return entity.WebhookDelivery{}, fmt.Errorf("error while getting webhook delivery: %w", err)
+	}
+
+	_, err = s.storage.GetWebhook(ctx, userID, delivery.WebhookID)
+	if err != nil {
+		if errors.Is(err, api.ErrWebhookNotFound) {
+			return entity.WebhookDelivery{}, ErrWebhookNotFound
+		}
+
+		return entity.WebhookDelivery{}, fmt.Errorf("error while getting webhook: %w", err)
+	}
+
+	replay := s.newDelivery(delivery.WebhookID, userID, delivery.Event, delivery.Payload)
+	err = s.storage.SaveWebhookDelivery(ctx, replay)
+	if err != nil {
+		if errors.Is(err, api.ErrWebhookNotFound) {
+			return entity.WebhookDelivery{}, ErrWebhookNotFound
+		}
+
+		return entity.WebhookDelivery{}, fmt.Errorf("error while saving webhook delivery: %w", err)
+	}
+
+	s.notify()
+
+	return replay, nil
+}
+
+// ShortURL Returns full short URL of link on its domain
+func (s *Service) ShortURL(shortURL string) string {
+	return s.domains.ShortURL(shortURL)
+}
+
+// Sign Returns value of signature header for payload signed by secret of webhook
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Service) newDelivery(webhookID string, userID entity.UserID, event string, payload []byte) entity.WebhookDelivery {
+	now := s.now().UTC()
+
+	return entity.WebhookDelivery{
+		ID:            uuid.New().String(),
+		WebhookID:     webhookID,
+		UserID:        userID,
+		Event:         event,
+		Payload:       payload,
+		Status:        entity.WebhookDeliveryPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// isValidWebhookURL Returns true if URL is absolute HTTP or HTTPS URL
+//
+// Host names are checked after resolution on delivery, internal IP addresses are rejected at once
+func (s *Service) isValidWebhookURL(webhookURL string) bool {
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+
+	if addr, err := netip.ParseAddr(u.Hostname()); err == nil && !s.allowPrivate && isInternalAddr(addr) {
+		return false
+	}
+
+	return true
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,412 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/local"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/events"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+const (
+	baseURIPrefix = "http://localhost:8080"
+	userID        = entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	otherUserID   = entity.UserID("0c0a4811-4f10-487f-bde3-e39a14af7cd8")
+)
+
+// receiver Local webhook receiver responding with queued status codes
+type receiver struct {
+	mutex    sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (r *receiver) ServeHTTP(writer http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+
+	status := http.StatusOK
+	if len(r.statuses) != 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	writer.WriteHeader(status)
+}
+
+func (r *receiver) count() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.requests)
+}
+
+func newTestConfig() config.Config {
+	return config.Config{
+		BaseURIPrefix:      baseURIPrefix,
+		WebhookTimeout:     "1s",
+		WebhookMaxAttempts: 3,
+		WebhookQueueSize:   10,
+		// receivers of tests are served by httptest on loopback
+		WebhookAllowPrivate: true,
+	}
+}
+
+func newTestService(t *testing.T) (*Service, *local.TSLocalStorage) {
+	storage := local.NewTSLocalStorage(0)
+
+	domains, err := domain.NewRegistry(newTestConfig())
+	require.NoError(t, err)
+
+	service, err := NewService(storage, newTestConfig(), domains)
+	require.NoError(t, err)
+
+	return service, storage
+}
+
+func TestValidateConfig(t *testing.T) {
+	assert.NoError(t, ValidateConfig(newTestConfig()))
+
+	err := ValidateConfig(config.Config{
+		WebhookTimeout:     "soon",
+		WebhookMaxAttempts: 0,
+		WebhookQueueSize:   -1,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid webhook_timeout "soon"`)
+	assert.Contains(t, err.Error(), "webhook_max_attempts must be positive, got 0")
+	assert.Contains(t, err.Error(), "webhook_queue_size must be positive, got -1")
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newTestService(t)
+
+	tests := []struct {
+		name   string
+		url    string
+		events []string
+		err    error
+	}{
+		{
+			name: "relative url",
+			url:  "/hook",
+			err:  ErrInvalidWebhookURL,
+		},
+		{
+			name: "not http url",
+			url:  "ftp://example.com/hook",
+			err:  ErrInvalidWebhookURL,
+		},
+		{
+			name:   "unknown event",
+			url:    "https://example.com/hook",
+			events: []string{entity.EventLinkCreated, "link.renamed"},
+			err:    ErrInvalidEvent,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := service.Create(ctx, userID, test.url, test.events, "")
+
+			var fieldErr *link.FieldError
+			require.ErrorAs(t, err, &fieldErr)
+			assert.ErrorIs(t, err, test.err)
+		})
+	}
+
+	webhook, err := service.Create(ctx, userID, "https://example.com/hook", []string{entity.EventLinkDeleted, entity.EventLinkCreated, entity.EventLinkDeleted}, "")
+	require.NoError(t, err)
+	assert.Len(t, webhook.Secret, 2*secretSize)
+	assert.Equal(t, []string{entity.EventLinkCreated, entity.EventLinkDeleted}, webhook.Events)
+
+	for i := 1; i < MaxWebhooksPerUser; i++ {
+		_, err = service.Create(ctx, userID, "https://example.com/hook", nil, "secret")
+		require.NoError(t, err)
+	}
+
+	_, err = service.Create(ctx, userID, "https://example.com/hook", nil, "secret")
+	assert.ErrorIs(t, err, ErrTooManyWebhooks)
+
+	webhooks, err := service.List(ctx, otherUserID)
+	require.NoError(t, err)
+	assert.Empty(t, webhooks)
+
+	require.NoError(t, service.Delete(ctx, userID, webhook.ID))
+	assert.ErrorIs(t, service.Delete(ctx, userID, webhook.ID), ErrWebhookNotFound)
+
+	_, err = service.Deliveries(ctx, userID, webhook.ID)
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+}
+
+func TestDelivery(t *testing.T) {
+	ctx := context.Background()
+	service, storage := newTestService(t)
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	hook := &receiver{statuses: []int{http.StatusInternalServerError}}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	webhook, err := service.Create(ctx, userID, server.URL, []string{entity.EventLinkCreated}, "secret")
+	require.NoError(t, err)
+
+	clicks, err := service.Create(ctx, userID, server.URL, []string{entity.EventLinkClicked}, "secret")
+	require.NoError(t, err)
+
+	decorated := events.NewStorage(storage, service)
+	require.NoError(t, decorated.SaveLink(ctx, entity.Link{
+		ShortURL:    "42b3e75f",
+		OriginalURL: "https://practicum.yandex.ru/",
+		UserID:      userID,
+		CreatedAt:   now,
+	}))
+	service.drain()
+
+	// the first attempt is failed by receiver and is retried after backoff
+	service.deliverPending()
+	require.Equal(t, 1, hook.count())
+
+	deliveries, err := service.Deliveries(ctx, userID, webhook.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entity.WebhookDeliveryPending, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, deliveries[0].ResponseCode)
+	assert.Equal(t, now.Add(retryDelayBase), deliveries[0].NextAttemptAt)
+
+	service.deliverPending()
+	require.Equal(t, 1, hook.count())
+
+	now = now.Add(retryDelayBase)
+	service.deliverPending()
+	require.Equal(t, 2, hook.count())
+
+	deliveries, err = service.Deliveries(ctx, userID, webhook.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entity.WebhookDeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 2, deliveries[0].Attempts)
+
+	// webhook subscribed to other events doesn't receive event
+	skipped, err := service.Deliveries(ctx, userID, clicks.ID)
+	require.NoError(t, err)
+	assert.Empty(t, skipped)
+
+	req, body := hook.requests[1], hook.bodies[1]
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, entity.EventLinkCreated, req.Header.Get(EventHeader))
+	assert.Equal(t, deliveries[0].ID, req.Header.Get(DeliveryHeader))
+	assert.Equal(t, Sign("secret", body), req.Header.Get(SignatureHeader))
+
+	var payload Payload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, entity.EventLinkCreated, payload.Type)
+	assert.Equal(t, PayloadLink{
+		ShortURL:    baseURIPrefix + "/42b3e75f",
+		Alias:       "42b3e75f",
+		OriginalURL: "https://practicum.yandex.ru/",
+	}, payload.Data)
+
+	replay, err := service.Replay(ctx, userID, deliveries[0].ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, deliveries[0].ID, replay.ID)
+
+	service.deliverPending()
+	require.Equal(t, 3, hook.count())
+	assert.Equal(t, body, hook.bodies[2])
+	assert.Equal(t, replay.ID, hook.requests[2].Header.Get(DeliveryHeader))
+
+	_, err = service.Replay(ctx, otherUserID, deliveries[0].ID)
+	assert.ErrorIs(t, err, ErrDeliveryNotFound)
+}
+
+func TestDeliveryFailed(t *testing.T) {
+	ctx := context.Background()
+	service, storage := newTestService(t)
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	hook := &receiver{statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	webhook, err := service.Create(ctx, userID, server.URL, nil, "secret")
+	require.NoError(t, err)
+
+	require.NoError(t, storage.SaveLink(ctx, entity.Link{
+		ShortURL:    "42b3e75f",
+		OriginalURL: "https://practicum.yandex.ru/",
+		UserID:      userID,
+		CreatedAt:   now,
+	}))
+
+	decorated := events.NewStorage(storage, service)
+	require.NoError(t, decorated.DeleteBatchURL(ctx, entity.DeletedURLBatch{
+		{UserID: otherUserID.String(), ShortURL: "42b3e75f"},
+		{UserID: userID.String(), ShortURL: "42b3e75f"},
+	}))
+	service.drain()
+
+	for i := 0; i < 3; i++ {
+		service.deliverPending()
+		now = now.Add(time.Hour)
+	}
+	assert.Equal(t, 3, hook.count())
+
+	// deletion by other user is not delivered to webhook of owner
+	deliveries, err := service.Deliveries(ctx, userID, webhook.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entity.EventLinkDeleted, deliveries[0].Event)
+	assert.Equal(t, entity.WebhookDeliveryFailed, deliveries[0].Status)
+	assert.Equal(t, 3, deliveries[0].Attempts)
+	assert.Equal(t, "unexpected response status 502", deliveries[0].LastError)
+}
+
+func TestInternalAddresses(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := newTestConfig()
+	cfg.WebhookAllowPrivate = false
+	service, err := NewService(local.NewTSLocalStorage(0), cfg, nil)
+	require.NoError(t, err)
+
+	for _, webhookURL := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://[::1]/hook",
+		"http://10.0.0.1/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://100.100.100.200/hook",
+		"http://[::ffff:192.168.1.1]/hook",
+	} {
+		_, err := service.Create(ctx, userID, webhookURL, nil, "secret")
+		assert.ErrorIs(t, err, ErrInvalidWebhookURL, webhookURL)
+	}
+
+	_, err = service.Create(ctx, userID, "https://example.com/hook", nil, "secret")
+	require.NoError(t, err)
+
+	hook := &receiver{}
+	server := httptest.NewServer(hook)
+	defer server.Close()
+
+	// host names resolved to internal addresses are refused on connection
+	_, err = service.client.Post(strings.Replace(server.URL, "127.0.0.1", "localhost", 1), "application/json", nil)
+	assert.ErrorIs(t, err, ErrForbiddenAddress)
+	assert.Zero(t, hook.count())
+}
+
+func TestRedirectNotFollowed(t *testing.T) {
+	target := &receiver{}
+	targetServer := httptest.NewServer(target)
+	defer targetServer.Close()
+
+	server := httptest.NewServer(http.RedirectHandler(targetServer.URL, http.StatusTemporaryRedirect))
+	defer server.Close()
+
+	resp, err := newClient(time.Second, true).Post(server.URL, "application/json", nil)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, http.StatusTemporaryRedirect, resp.StatusCode)
+	assert.Zero(t, target.count())
+}
+
+func TestStop(t *testing.T) {
+	ctx := context.Background()
+	service, storage := newTestService(t)
+
+	webhook, err := service.Create(ctx, userID, "http://127.0.0.1:1/hook", nil, "secret")
+	require.NoError(t, err)
+
+	service.Publish(entity.LinkEvent{
+		Type:        entity.EventLinkExpired,
+		UserID:      userID,
+		ShortURL:    "42b3e75f",
+		OriginalURL: "https://practicum.yandex.ru/",
+	})
+
+	// queued events are saved as pending deliveries on stop
+	service.Start()
+	service.Stop()
+
+	deliveries, err := storage.ListWebhookDeliveries(ctx, userID, webhook.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entity.EventLinkExpired, deliveries[0].Event)
+	assert.Equal(t, entity.WebhookDeliveryPending, deliveries[0].Status)
+}
+
+func TestErasedUser(t *testing.T) {
+	ctx := context.Background()
+	service, storage := newTestService(t)
+
+	// user is erased while delivery is in flight
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, err := storage.EraseUser(ctx, userID)
+		assert.NoError(t, err)
+
+		writer.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	webhook, err := service.Create(ctx, userID, server.URL, nil, "secret")
+	require.NoError(t, err)
+
+	event := entity.LinkEvent{
+		Type:        entity.EventLinkExpired,
+		UserID:      userID,
+		ShortURL:    "42b3e75f",
+		OriginalURL: "https://practicum.yandex.ru/",
+	}
+	service.Publish(event)
+	service.drain()
+
+	deliveries, err := storage.ListWebhookDeliveries(ctx, userID, webhook.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+
+	service.deliverPending()
+
+	_, err = storage.GetWebhookDelivery(ctx, userID, deliveries[0].ID)
+	assert.ErrorIs(t, err, api.ErrWebhookDeliveryNotFound)
+
+	pending, err := storage.ListPendingWebhookDeliveries(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// event queued before erasure doesn't create delivery for erased webhook
+	err = storage.SaveWebhookDelivery(ctx, service.newDelivery(webhook.ID, userID, event.Type, []byte(`{}`)))
+	assert.ErrorIs(t, err, api.ErrWebhookNotFound)
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, retryDelay(1))
+	assert.Equal(t, 10*time.Second, retryDelay(2))
+	assert.Equal(t, 40*time.Second, retryDelay(4))
+	assert.Equal(t, time.Hour, retryDelay(20))
+}
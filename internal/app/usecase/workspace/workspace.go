@@ -0,0 +1,526 @@
+// Package workspace implements workspaces sharing ownership of links across team members
+//
+// Members of workspace have roles: owners manage members and invites, editors create, edit
+// and delete links of workspace and viewers list them. Links created in workspace are kept
+// with their creator, so they stay manageable by other members when creator leaves workspace
+package workspace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	api "github.com/avGenie/url-shortener/internal/app/storage/api/errors"
+	"github.com/avGenie/url-shortener/internal/app/storage/api/model"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+// Limits of workspaces
+//
+// MaxNameLen - max length of workspace name in characters
+// MaxWorkspacesPerUser - max count of workspaces user is member of
+// MaxLinksPerRequest - max count of links deleted by one request
+// InviteTTL - time during which invite can be accepted
+const (
+	MaxNameLen           = 100
+	MaxWorkspacesPerUser = 50
+	MaxLinksPerRequest   = 1000
+	InviteTTL            = 7 * 24 * time.Hour
+)
+
+const inviteIDSize = 16
+
+// Errors of workspace use cases
+//
+// ErrInvalidName - workspace name is empty or too long
+// ErrInvalidRole - role of member is unknown
+// ErrTooManyWorkspaces - user is member of max count of workspaces
+// ErrWorkspaceNotFound - workspace is not found or user is not its member
+// ErrForbidden - role of user doesn't allow operation in workspace
+// ErrMemberNotFound - user is not member of workspace
+// ErrInviteNotFound - invite is not found, it is already accepted or expired
+// ErrAlreadyMember - user accepting invite is already member of workspace
+// ErrLastOwner - operation leaves workspace without owners
+var (
+	ErrInvalidName       = errors.New("invalid workspace name")
+	ErrInvalidRole       = errors.New("invalid workspace role")
+	ErrTooManyWorkspaces = errors.New("too many workspaces")
+	ErrWorkspaceNotFound = errors.New("workspace is not found")
+	ErrForbidden         = errors.New("operation is not allowed for role")
+	ErrMemberNotFound    = errors.New("workspace member is not found")
+	ErrInviteNotFound    = errors.New("workspace invite is not found")
+	ErrAlreadyMember     = errors.New("user is already member of workspace")
+	ErrLastOwner         = errors.New("workspace must have at least one owner")
+)
+
+// Membership Workspace with role of user in it
+type Membership struct {
+	Workspace entity.Workspace
+	Role      entity.WorkspaceRole
+}
+
+// Service Use cases of workspaces
+type Service struct {
+	storage model.Storage
+	links   *link.Service
+	now     func() time.Time
+}
+
+// NewService Creates service of workspaces managing their links by links service
+func NewService(storage model.Storage, links *link.Service) *Service {
+	return &Service{
+		storage: storage,
+		links:   links,
+		now:     time.Now,
+	}
+}
+
+// Create Creates workspace with user as its owner
+func (s *Service) Create(ctx context.Context, userID entity.UserID, name string) (Membership, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLen {
+		return Membership{}, &link.FieldError{
+			Field: "name",
+			Err:   fmt.Errorf("%w: name must contain from 1 to %d characters", ErrInvalidName, MaxNameLen),
+		}
+	}
+
+	err := s.checkMembershipsLimit(ctx, userID, "name")
+	if err != nil {
+		return Membership{}, err
+	}
+
+	now := s.now().UTC()
+	workspace := entity.Workspace{
+		ID:        uuid.New().String(),
+		Name:      name,
+		CreatedBy: userID,
+		CreatedAt: now,
+	}
+
+	err = s.storage.SaveWorkspace(ctx, workspace)
+	if err != nil {
+		return Membership{}, fmt.Errorf("error while saving workspace: %w", err)
+	}
+
+	err = s.storage.SaveWorkspaceMember(ctx, entity.WorkspaceMember{
+		WorkspaceID: workspace.ID,
+		UserID:      userID,
+		Role:        entity.WorkspaceOwner,
+		CreatedAt:   now,
+	})
+	if err != nil {
+		return Membership{}, fmt.Errorf("error while saving owner of workspace: %w", err)
+	}
+
+	return Membership{Workspace: workspace, Role: entity.WorkspaceOwner}, nil
+}
+
+// List Returns workspaces user is member of with roles of user ordered by time of joining
+func (s *Service) List(ctx context.Context, userID entity.UserID) ([]Membership, error) {
+	memberships, err := s.storage.ListUserMemberships(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing memberships of user: %w", err)
+	}
+
+	res := make([]Membership, 0, len(memberships))
+	for _, member := range memberships {
+		workspace, err := s.storage.GetWorkspace(ctx, member.WorkspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("error while getting workspace: %w", err)
+		}
+
+		res = append(res, Membership{Workspace: workspace, Role: member.Role})
+	}
+
+	return res, nil
+}
+
+// Members Returns members of workspace ordered by time of joining
+//
+// Any member of workspace can list its members
+func (s *Service) Members(ctx context.Context, userID entity.UserID, workspaceID string) ([]entity.WorkspaceMember, error) {
+	_, err := s.authorize(ctx, userID, workspaceID, entity.WorkspaceViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.storage.ListWorkspaceMembers(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing workspace members: %w", err)
+	}
+
+	return members, nil
+}
+
+// SetRole Changes role of member of workspace
+//
+// Only owners can change roles. Returns ErrLastOwner error if the last owner is demoted
+func (s *Service) SetRole(
+	ctx context.Context,
+	userID entity.UserID,
+	workspaceID string,
+	memberID entity.UserID,
+	role entity.WorkspaceRole,
+) (entity.WorkspaceMember, error) {
+	if !role.IsValid() {
+		return entity.WorkspaceMember{}, &link.FieldError{Field: "role", Err: fmt.Errorf("%w %q", ErrInvalidRole, role)}
+	}
+
+	_, err := s.authorize(ctx, userID, workspaceID, entity.WorkspaceOwner)
+	if err != nil {
+		return entity.WorkspaceMember{}, err
+	}
+
+	member, err := s.member(ctx, workspaceID, memberID)
+	if err != nil {
+		return entity.WorkspaceMember{}, err
+	}
+
+	if member.Role == entity.WorkspaceOwner && role != entity.WorkspaceOwner {
+		err = s.checkOtherOwners(ctx, workspaceID, memberID)
+		if err != nil {
+			return entity.WorkspaceMember{}, err
+		}
+	}
+
+	member.Role = role
+	err = s.storage.SaveWorkspaceMember(ctx, member)
+	if err != nil {
+		return entity.WorkspaceMember{}, fmt.Errorf("error while saving workspace member: %w", err)
+	}
+
+	return member, nil
+}
+
+// RemoveMember Removes member from workspace
+//
+// Owners can remove any member, other members can remove only themselves to leave workspace.
+// Links created by removed member stay in workspace.
+// Returns ErrLastOwner error if the last owner is removed
+func (s *Service) RemoveMember(ctx context.Context, userID entity.UserID, workspaceID string, memberID entity.UserID) error {
+	required := entity.WorkspaceOwner
+	if memberID == userID {
+		required = entity.WorkspaceViewer
+	}
+
+	_, err := s.authorize(ctx, userID, workspaceID, required)
+	if err != nil {
+		return err
+	}
+
+	member, err := s.member(ctx, workspaceID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if member.Role == entity.WorkspaceOwner {
+		err = s.checkOtherOwners(ctx, workspaceID, memberID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.storage.DeleteWorkspaceMember(ctx, workspaceID, memberID)
+	if err != nil {
+		if errors.Is(err, api.ErrWorkspaceMemberNotFound) {
+			return ErrMemberNotFound
+		}
+
+		return fmt.Errorf("error while deleting workspace member: %w", err)
+	}
+
+	return nil
+}
+
+// Invite Creates single use invite to join workspace with role
+//
+// Only owners can invite users. ID of invite is secret which should be passed to invited user
+func (s *Service) Invite(ctx context.Context, userID entity.UserID, workspaceID string, role entity.WorkspaceRole) (entity.WorkspaceInvite, error) {
+	if !role.IsValid() {
+		return entity.WorkspaceInvite{}, &link.FieldError{Field: "role", Err: fmt.Errorf("%w %q", ErrInvalidRole, role)}
+	}
+
+	_, err := s.authorize(ctx, userID, workspaceID, entity.WorkspaceOwner)
+	if err != nil {
+		return entity.WorkspaceInvite{}, err
+	}
+
+	inviteID, err := generateInviteID()
+	if err != nil {
+		return entity.WorkspaceInvite{}, fmt.Errorf("error while generating workspace invite id: %w", err)
+	}
+
+	now := s.now().UTC()
+	invite := entity.WorkspaceInvite{
+		ID:          inviteID,
+		WorkspaceID: workspaceID,
+		Role:        role,
+		CreatedBy:   userID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(InviteTTL),
+	}
+
+	err = s.storage.SaveWorkspaceInvite(ctx, invite)
+	if err != nil {
+		return entity.WorkspaceInvite{}, fmt.Errorf("error while saving workspace invite: %w", err)
+	}
+
+	return invite, nil
+}
+
+// Accept Adds user to workspace of invite with its role
+//
+// Invite is removed when it is accepted
+func (s *Service) Accept(ctx context.Context, userID entity.UserID, inviteID string) (entity.WorkspaceMember, error) {
+	invite, err := s.storage.GetWorkspaceInvite(ctx, inviteID)
+	if err != nil {
+		if errors.Is(err, api.ErrWorkspaceInviteNotFound) {
+			return entity.WorkspaceMember{}, ErrInviteNotFound
+		}
+
+		return entity.WorkspaceMember{}, fmt.Errorf("error while getting workspace invite: %w", err)
+	}
+
+	now := s.now().UTC()
+	if invite.IsExpired(now) {
+		return entity.WorkspaceMember{}, ErrInviteNotFound
+	}
+
+	_, err = s.storage.GetWorkspaceMember(ctx, invite.WorkspaceID, userID)
+	if err == nil {
+		return entity.WorkspaceMember{}, ErrAlreadyMember
+	}
+	if !errors.Is(err, api.ErrWorkspaceMemberNotFound) {
+		return entity.WorkspaceMember{}, fmt.Errorf("error while getting workspace member: %w", err)
+	}
+
+	err = s.checkMembershipsLimit(ctx, userID, "id")
+	if err != nil {
+		return entity.WorkspaceMember{}, err
+	}
+
+	err = s.storage.DeleteWorkspaceInvite(ctx, inviteID)
+	if err != nil {
+		if errors.Is(err, api.ErrWorkspaceInviteNotFound) {
+			return entity.WorkspaceMember{}, ErrInviteNotFound
+		}
+
+		return entity.WorkspaceMember{}, fmt.Errorf("error while deleting workspace invite: %w", err)
+	}
+
+	member := entity.WorkspaceMember{
+		WorkspaceID: invite.WorkspaceID,
+		UserID:      userID,
+		Role:        invite.Role,
+		CreatedAt:   now,
+	}
+
+	err = s.storage.SaveWorkspaceMember(ctx, member)
+	if err != nil {
+		return entity.WorkspaceMember{}, fmt.Errorf("error while saving workspace member: %w", err)
+	}
+
+	return member, nil
+}
+
+// CreateLink Creates link in workspace for original URL on domain with given name
+//
+// Only owners and editors can create links. Link is created by user, default domain is used if name is empty
+func (s *Service) CreateLink(
+	ctx context.Context,
+	userID entity.UserID,
+	workspaceID, domainName, originalURL string,
+	metadata map[string]string,
+) (entity.Link, error) {
+	_, err := s.authorize(ctx, userID, workspaceID, entity.WorkspaceEditor)
+	if err != nil {
+		return entity.Link{}, err
+	}
+
+	return s.links.CreateInWorkspace(ctx, userID, workspaceID, domainName, originalURL, metadata)
+}
+
+// Links Returns page of links of workspace ordered by creation time and token of the next page
+//
+// Any member of workspace can list its links
+func (s *Service) Links(ctx context.Context, userID entity.UserID, workspaceID string, pageSize int, pageToken string) ([]entity.Link, string, error) {
+	_, err := s.authorize(ctx, userID, workspaceID, entity.WorkspaceViewer)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return s.links.ListWorkspace(ctx, workspaceID, pageSize, pageToken)
+}
+
+// UpdateLink Replaces metadata of link of workspace by short ID or full short URL
+//
+// Only owners and editors can edit links
+func (s *Service) UpdateLink(
+	ctx context.Context,
+	userID entity.UserID,
+	workspaceID, shortURL string,
+	metadata map[string]string,
+) (entity.Link, error) {
+	_, err := s.authorize(ctx, userID, workspaceID, entity.WorkspaceEditor)
+	if err != nil {
+		return entity.Link{}, err
+	}
+
+	found, err := s.links.GetInWorkspace(ctx, workspaceID, shortURL)
+	if err != nil {
+		return entity.Link{}, err
+	}
+
+	return s.links.UpdateMetadata(ctx, found, metadata)
+}
+
+// DeleteLinks Deletes links of workspace by short IDs or full short URLs
+//
+// Only owners and editors can delete links. Links are queued as deletion jobs of their creators
+// and moved to their trash in background, links which are not found in workspace or are already deleted are skipped.
+// Returns short IDs of links queued for deletion.
+// Returns ErrQueueFull error of delete handler if deletion queue of creator is full
+func (s *Service) DeleteLinks(ctx context.Context, userID entity.UserID, workspaceID string, shortURLs []string) ([]string, error) {
+	if len(shortURLs) > MaxLinksPerRequest {
+		return nil, &link.FieldError{
+			Field: "short_urls",
+			Err:   fmt.Errorf("at most %d links can be deleted by one request", MaxLinksPerRequest),
+		}
+	}
+
+	_, err := s.authorize(ctx, userID, workspaceID, entity.WorkspaceEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	creators := make([]entity.UserID, 0)
+	links := make(map[entity.UserID][]string)
+	deleted := make([]string, 0, len(shortURLs))
+	for _, shortURL := range shortURLs {
+		found, err := s.links.GetInWorkspace(ctx, workspaceID, shortURL)
+		if errors.Is(err, link.ErrLinkNotFound) || found.Deleted {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := links[found.UserID]; !ok {
+			creators = append(creators, found.UserID)
+		}
+		links[found.UserID] = append(links[found.UserID], found.ShortURL)
+		deleted = append(deleted, found.ShortURL)
+	}
+
+	for _, creator := range creators {
+		_, err = s.links.Delete(ctx, creator, links[creator])
+		if err != nil {
+			return nil, fmt.Errorf("error while deleting workspace links: %w", err)
+		}
+	}
+
+	return deleted, nil
+}
+
+// ShortURL Returns full short URL of link on its domain
+func (s *Service) ShortURL(found entity.Link) string {
+	return s.links.ShortURL(found)
+}
+
+// authorize Returns membership of user in workspace if its role allows operations of required role
+//
+// Returns ErrWorkspaceNotFound error if user is not member of workspace,
+// so workspaces of other users couldn't be discovered
+func (s *Service) authorize(
+	ctx context.Context,
+	userID entity.UserID,
+	workspaceID string,
+	required entity.WorkspaceRole,
+) (entity.WorkspaceMember, error) {
+	member, err := s.storage.GetWorkspaceMember(ctx, workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, api.ErrWorkspaceMemberNotFound) {
+			return entity.WorkspaceMember{}, ErrWorkspaceNotFound
+		}
+
+		return entity.WorkspaceMember{}, fmt.Errorf("error while getting workspace member: %w", err)
+	}
+
+	allowed := true
+	switch required {
+	case entity.WorkspaceOwner:
+		allowed = member.Role.CanManage()
+	case entity.WorkspaceEditor:
+		allowed = member.Role.CanEdit()
+	}
+
+	if !allowed {
+		return entity.WorkspaceMember{}, fmt.Errorf("%w %q", ErrForbidden, member.Role)
+	}
+
+	return member, nil
+}
+
+func (s *Service) member(ctx context.Context, workspaceID string, userID entity.UserID) (entity.WorkspaceMember, error) {
+	member, err := s.storage.GetWorkspaceMember(ctx, workspaceID, userID)
+	if err != nil {
+		if errors.Is(err, api.ErrWorkspaceMemberNotFound) {
+			return entity.WorkspaceMember{}, ErrMemberNotFound
+		}
+
+		return entity.WorkspaceMember{}, fmt.Errorf("error while getting workspace member: %w", err)
+	}
+
+	return member, nil
+}
+
+// checkOtherOwners Returns ErrLastOwner error if workspace has no owners except member
+func (s *Service) checkOtherOwners(ctx context.Context, workspaceID string, memberID entity.UserID) error {
+	members, err := s.storage.ListWorkspaceMembers(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("error while listing workspace members: %w", err)
+	}
+
+	for _, member := range members {
+		if member.UserID != memberID && member.Role == entity.WorkspaceOwner {
+			return nil
+		}
+	}
+
+	return ErrLastOwner
+}
+
+func (s *Service) checkMembershipsLimit(ctx context.Context, userID entity.UserID, field string) error {
+	memberships, err := s.storage.ListUserMemberships(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("error while listing memberships of user: %w", err)
+	}
+
+	if len(memberships) >= MaxWorkspacesPerUser {
+		return &link.FieldError{
+			Field: field,
+			Err:   fmt.Errorf("%w: user can be member of at most %d workspaces", ErrTooManyWorkspaces, MaxWorkspacesPerUser),
+		}
+	}
+
+	return nil
+}
+
+func generateInviteID() (string, error) {
+	id := make([]byte, inviteIDSize)
+	_, err := rand.Read(id)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
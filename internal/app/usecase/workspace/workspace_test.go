@@ -0,0 +1,285 @@
+package workspace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/avGenie/url-shortener/internal/app/config"
+	"github.com/avGenie/url-shortener/internal/app/entity"
+	"github.com/avGenie/url-shortener/internal/app/models"
+	"github.com/avGenie/url-shortener/internal/app/storage/local"
+	"github.com/avGenie/url-shortener/internal/app/usecase/domain"
+	"github.com/avGenie/url-shortener/internal/app/usecase/link"
+)
+
+const (
+	baseURIPrefix = "http://localhost:8080"
+	ownerID       = entity.UserID("ac2a4811-4f10-487f-bde3-e39a14af7cd8")
+	editorID      = entity.UserID("0c0a4811-4f10-487f-bde3-e39a14af7cd8")
+	viewerID      = entity.UserID("1d1a4811-4f10-487f-bde3-e39a14af7cd8")
+	strangerID    = entity.UserID("2e2a4811-4f10-487f-bde3-e39a14af7cd8")
+)
+
+// testDeleter Keeps queued links of users instead of deleting them
+type testDeleter struct {
+	queued map[entity.UserID]models.ReqDeletedURLBatch
+}
+
+func (d *testDeleter) ProcessDeletedURLs(_ context.Context, userID entity.UserID, batch models.ReqDeletedURLBatch) (string, error) {
+	if d.queued == nil {
+		d.queued = make(map[entity.UserID]models.ReqDeletedURLBatch)
+	}
+	d.queued[userID] = append(d.queued[userID], batch...)
+
+	return "job", nil
+}
+
+func newTestService(t *testing.T) *Service {
+	return newTestServiceWithDeleter(t, &testDeleter{})
+}
+
+func newTestServiceWithDeleter(t *testing.T, deleter link.Deleter) *Service {
+	domains, err := domain.NewRegistry(config.Config{
+		BaseURIPrefix: baseURIPrefix,
+	})
+	require.NoError(t, err)
+
+	storage := local.NewTSLocalStorage(0)
+	service := NewService(storage, link.NewService(storage, deleter, domains))
+
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+
+	return service
+}
+
+// newTestWorkspace Creates workspace of owner with editor and viewer members
+func newTestWorkspace(t *testing.T, service *Service) string {
+	ctx := context.Background()
+
+	created, err := service.Create(ctx, ownerID, "  Team  ")
+	require.NoError(t, err)
+
+	for userID, role := range map[entity.UserID]entity.WorkspaceRole{
+		editorID: entity.WorkspaceEditor,
+		viewerID: entity.WorkspaceViewer,
+	} {
+		invite, err := service.Invite(ctx, ownerID, created.Workspace.ID, role)
+		require.NoError(t, err)
+
+		_, err = service.Accept(ctx, userID, invite.ID)
+		require.NoError(t, err)
+	}
+
+	return created.Workspace.ID
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name      string
+		wsName    string
+		wantErr   error
+		wantField string
+	}{
+		{
+			name:   "valid name",
+			wsName: "Marketing",
+		},
+		{
+			name:      "empty name",
+			wsName:    "   ",
+			wantErr:   ErrInvalidName,
+			wantField: "name",
+		},
+		{
+			name:      "too long name",
+			wsName:    string(make([]rune, MaxNameLen+1)),
+			wantErr:   ErrInvalidName,
+			wantField: "name",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := newTestService(t)
+
+			created, err := service.Create(context.Background(), ownerID, test.wsName)
+			if test.wantErr != nil {
+				require.ErrorIs(t, err, test.wantErr)
+
+				var fieldErr *link.FieldError
+				require.ErrorAs(t, err, &fieldErr)
+				assert.Equal(t, test.wantField, fieldErr.Field)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, test.wsName, created.Workspace.Name)
+			assert.Equal(t, entity.WorkspaceOwner, created.Role)
+
+			memberships, err := service.List(context.Background(), ownerID)
+			require.NoError(t, err)
+			assert.Equal(t, []Membership{created}, memberships)
+		})
+	}
+}
+
+func TestRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  entity.UserID
+		action  func(ctx context.Context, service *Service, userID entity.UserID, workspaceID string) error
+		wantErr map[entity.UserID]error
+	}{
+		{
+			name: "list members",
+			action: func(ctx context.Context, service *Service, userID entity.UserID, workspaceID string) error {
+				_, err := service.Members(ctx, userID, workspaceID)
+				return err
+			},
+			wantErr: map[entity.UserID]error{
+				strangerID: ErrWorkspaceNotFound,
+			},
+		},
+		{
+			name: "create link",
+			action: func(ctx context.Context, service *Service, userID entity.UserID, workspaceID string) error {
+				_, err := service.CreateLink(ctx, userID, workspaceID, "", "https://example.com/"+userID.String(), nil)
+				return err
+			},
+			wantErr: map[entity.UserID]error{
+				viewerID:   ErrForbidden,
+				strangerID: ErrWorkspaceNotFound,
+			},
+		},
+		{
+			name: "invite",
+			action: func(ctx context.Context, service *Service, userID entity.UserID, workspaceID string) error {
+				_, err := service.Invite(ctx, userID, workspaceID, entity.WorkspaceViewer)
+				return err
+			},
+			wantErr: map[entity.UserID]error{
+				editorID:   ErrForbidden,
+				viewerID:   ErrForbidden,
+				strangerID: ErrWorkspaceNotFound,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service := newTestService(t)
+			workspaceID := newTestWorkspace(t, service)
+
+			for _, userID := range []entity.UserID{ownerID, editorID, viewerID, strangerID} {
+				err := test.action(context.Background(), service, userID, workspaceID)
+				if test.wantErr[userID] != nil {
+					assert.ErrorIs(t, err, test.wantErr[userID], userID)
+					continue
+				}
+				assert.NoError(t, err, userID)
+			}
+		})
+	}
+}
+
+func TestAccept(t *testing.T) {
+	ctx := context.Background()
+	service := newTestService(t)
+	workspaceID := newTestWorkspace(t, service)
+
+	invite, err := service.Invite(ctx, ownerID, workspaceID, entity.WorkspaceEditor)
+	require.NoError(t, err)
+
+	_, err = service.Accept(ctx, viewerID, invite.ID)
+	assert.ErrorIs(t, err, ErrAlreadyMember)
+
+	member, err := service.Accept(ctx, strangerID, invite.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.WorkspaceEditor, member.Role)
+
+	_, err = service.Accept(ctx, strangerID, invite.ID)
+	assert.ErrorIs(t, err, ErrInviteNotFound, "invite is single use")
+
+	expired, err := service.Invite(ctx, ownerID, workspaceID, entity.WorkspaceViewer)
+	require.NoError(t, err)
+
+	service.now = func() time.Time {
+		return expired.ExpiresAt.Add(time.Second)
+	}
+	_, err = service.Accept(ctx, "3f3a4811-4f10-487f-bde3-e39a14af7cd8", expired.ID)
+	assert.ErrorIs(t, err, ErrInviteNotFound)
+}
+
+func TestLastOwner(t *testing.T) {
+	ctx := context.Background()
+	service := newTestService(t)
+	workspaceID := newTestWorkspace(t, service)
+
+	_, err := service.SetRole(ctx, ownerID, workspaceID, ownerID, entity.WorkspaceEditor)
+	assert.ErrorIs(t, err, ErrLastOwner)
+
+	err = service.RemoveMember(ctx, ownerID, workspaceID, ownerID)
+	assert.ErrorIs(t, err, ErrLastOwner)
+
+	_, err = service.SetRole(ctx, ownerID, workspaceID, editorID, entity.WorkspaceOwner)
+	require.NoError(t, err)
+
+	err = service.RemoveMember(ctx, ownerID, workspaceID, ownerID)
+	require.NoError(t, err)
+
+	_, err = service.Members(ctx, ownerID, workspaceID)
+	assert.ErrorIs(t, err, ErrWorkspaceNotFound)
+
+	err = service.RemoveMember(ctx, viewerID, workspaceID, editorID)
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	err = service.RemoveMember(ctx, viewerID, workspaceID, viewerID)
+	require.NoError(t, err, "member can leave workspace")
+}
+
+func TestLinksOfRemovedMember(t *testing.T) {
+	ctx := context.Background()
+	deleter := &testDeleter{}
+	service := newTestServiceWithDeleter(t, deleter)
+	workspaceID := newTestWorkspace(t, service)
+
+	created, err := service.CreateLink(ctx, editorID, workspaceID, "", "https://example.com", nil)
+	require.NoError(t, err)
+	assert.Equal(t, editorID, created.UserID)
+	assert.Equal(t, workspaceID, created.WorkspaceID)
+
+	err = service.RemoveMember(ctx, ownerID, workspaceID, editorID)
+	require.NoError(t, err)
+
+	links, _, err := service.Links(ctx, viewerID, workspaceID, 0, "")
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.Equal(t, created.ShortURL, links[0].ShortURL)
+
+	_, err = service.UpdateLink(ctx, viewerID, workspaceID, created.ShortURL, map[string]string{"team": "sales"})
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	updated, err := service.UpdateLink(ctx, ownerID, workspaceID, created.ShortURL, map[string]string{"team": "sales"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "sales"}, updated.Metadata)
+
+	other, err := service.Create(ctx, ownerID, "Other")
+	require.NoError(t, err)
+
+	_, err = service.UpdateLink(ctx, ownerID, other.Workspace.ID, created.ShortURL, nil)
+	assert.ErrorIs(t, err, link.ErrLinkNotFound)
+
+	deleted, err := service.DeleteLinks(ctx, ownerID, workspaceID, []string{created.ShortURL, "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{created.ShortURL}, deleted)
+
+	assert.Equal(t, map[entity.UserID]models.ReqDeletedURLBatch{
+		editorID: {created.ShortURL},
+	}, deleter.queued, "link is queued for deletion by job of its creator")
+}
@@ -1,10 +1,13 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v25.3.0
+// source: proto/shortener.proto
 
 package url_shortener
 
 import (
 	context "context"
-
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -16,6 +19,15 @@ import (
 // Requires gRPC-Go v1.32.0 or later.
 const _ = grpc.SupportPackageIsVersion7
 
+const (
+	Shortener_GetOriginalURL_FullMethodName   = "/shortener.Shortener/GetOriginalURL"
+	Shortener_GetShortURL_FullMethodName      = "/shortener.Shortener/GetShortURL"
+	Shortener_GetBatchShortURL_FullMethodName = "/shortener.Shortener/GetBatchShortURL"
+	Shortener_GetAllUserURL_FullMethodName    = "/shortener.Shortener/GetAllUserURL"
+	Shortener_DeleteURLs_FullMethodName       = "/shortener.Shortener/DeleteURLs"
+	Shortener_GetStatistic_FullMethodName     = "/shortener.Shortener/GetStatistic"
+)
+
 // ShortenerClient is the client API for Shortener service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
@@ -24,7 +36,7 @@ type ShortenerClient interface {
 	GetShortURL(ctx context.Context, in *OriginalURL, opts ...grpc.CallOption) (*ShortURL, error)
 	GetBatchShortURL(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error)
 	GetAllUserURL(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AllUrlsResponse, error)
-	DeleteURLs(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteURLs(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 	GetStatistic(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatisticResposne, error)
 }
 
@@ -38,7 +50,7 @@ func NewShortenerClient(cc grpc.ClientConnInterface) ShortenerClient {
 
 func (c *shortenerClient) GetOriginalURL(ctx context.Context, in *ShortURL, opts ...grpc.CallOption) (*OriginalURL, error) {
 	out := new(OriginalURL)
-	err := c.cc.Invoke(ctx, "/shortener.Shortener/GetOriginalURL", in, out, opts...)
+	err := c.cc.Invoke(ctx, Shortener_GetOriginalURL_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -47,7 +59,7 @@ func (c *shortenerClient) GetOriginalURL(ctx context.Context, in *ShortURL, opts
 
 func (c *shortenerClient) GetShortURL(ctx context.Context, in *OriginalURL, opts ...grpc.CallOption) (*ShortURL, error) {
 	out := new(ShortURL)
-	err := c.cc.Invoke(ctx, "/shortener.Shortener/GetShortURL", in, out, opts...)
+	err := c.cc.Invoke(ctx, Shortener_GetShortURL_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +68,7 @@ func (c *shortenerClient) GetShortURL(ctx context.Context, in *OriginalURL, opts
 
 func (c *shortenerClient) GetBatchShortURL(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*BatchResponse, error) {
 	out := new(BatchResponse)
-	err := c.cc.Invoke(ctx, "/shortener.Shortener/GetBatchShortURL", in, out, opts...)
+	err := c.cc.Invoke(ctx, Shortener_GetBatchShortURL_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -65,16 +77,16 @@ func (c *shortenerClient) GetBatchShortURL(ctx context.Context, in *BatchRequest
 
 func (c *shortenerClient) GetAllUserURL(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AllUrlsResponse, error) {
 	out := new(AllUrlsResponse)
-	err := c.cc.Invoke(ctx, "/shortener.Shortener/GetAllUserURL", in, out, opts...)
+	err := c.cc.Invoke(ctx, Shortener_GetAllUserURL_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *shortenerClient) DeleteURLs(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, "/shortener.Shortener/DeleteURLs", in, out, opts...)
+func (c *shortenerClient) DeleteURLs(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, Shortener_DeleteURLs_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +95,7 @@ func (c *shortenerClient) DeleteURLs(ctx context.Context, in *DeleteRequest, opt
 
 func (c *shortenerClient) GetStatistic(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatisticResposne, error) {
 	out := new(StatisticResposne)
-	err := c.cc.Invoke(ctx, "/shortener.Shortener/GetStatistic", in, out, opts...)
+	err := c.cc.Invoke(ctx, Shortener_GetStatistic_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +110,7 @@ type ShortenerServer interface {
 	GetShortURL(context.Context, *OriginalURL) (*ShortURL, error)
 	GetBatchShortURL(context.Context, *BatchRequest) (*BatchResponse, error)
 	GetAllUserURL(context.Context, *emptypb.Empty) (*AllUrlsResponse, error)
-	DeleteURLs(context.Context, *DeleteRequest) (*emptypb.Empty, error)
+	DeleteURLs(context.Context, *DeleteRequest) (*DeleteResponse, error)
 	GetStatistic(context.Context, *emptypb.Empty) (*StatisticResposne, error)
 	mustEmbedUnimplementedShortenerServer()
 }
@@ -119,7 +131,7 @@ func (UnimplementedShortenerServer) GetBatchShortURL(context.Context, *BatchRequ
 func (UnimplementedShortenerServer) GetAllUserURL(context.Context, *emptypb.Empty) (*AllUrlsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllUserURL not implemented")
 }
-func (UnimplementedShortenerServer) DeleteURLs(context.Context, *DeleteRequest) (*emptypb.Empty, error) {
+func (UnimplementedShortenerServer) DeleteURLs(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteURLs not implemented")
 }
 func (UnimplementedShortenerServer) GetStatistic(context.Context, *emptypb.Empty) (*StatisticResposne, error) {
@@ -148,7 +160,7 @@ func _Shortener_GetOriginalURL_Handler(srv interface{}, ctx context.Context, dec
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shortener.Shortener/GetOriginalURL",
+		FullMethod: Shortener_GetOriginalURL_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ShortenerServer).GetOriginalURL(ctx, req.(*ShortURL))
@@ -166,7 +178,7 @@ func _Shortener_GetShortURL_Handler(srv interface{}, ctx context.Context, dec fu
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shortener.Shortener/GetShortURL",
+		FullMethod: Shortener_GetShortURL_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ShortenerServer).GetShortURL(ctx, req.(*OriginalURL))
@@ -184,7 +196,7 @@ func _Shortener_GetBatchShortURL_Handler(srv interface{}, ctx context.Context, d
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shortener.Shortener/GetBatchShortURL",
+		FullMethod: Shortener_GetBatchShortURL_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ShortenerServer).GetBatchShortURL(ctx, req.(*BatchRequest))
@@ -202,7 +214,7 @@ func _Shortener_GetAllUserURL_Handler(srv interface{}, ctx context.Context, dec
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shortener.Shortener/GetAllUserURL",
+		FullMethod: Shortener_GetAllUserURL_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ShortenerServer).GetAllUserURL(ctx, req.(*emptypb.Empty))
@@ -220,7 +232,7 @@ func _Shortener_DeleteURLs_Handler(srv interface{}, ctx context.Context, dec fun
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shortener.Shortener/DeleteURLs",
+		FullMethod: Shortener_DeleteURLs_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ShortenerServer).DeleteURLs(ctx, req.(*DeleteRequest))
@@ -238,7 +250,7 @@ func _Shortener_GetStatistic_Handler(srv interface{}, ctx context.Context, dec f
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shortener.Shortener/GetStatistic",
+		FullMethod: Shortener_GetStatistic_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
 		return srv.(ShortenerServer).GetStatistic(ctx, req.(*emptypb.Empty))
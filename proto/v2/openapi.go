@@ -0,0 +1,8 @@
+package shortenerv2
+
+import _ "embed"
+
+// OpenAPI OpenAPI document of REST gateway generated from shortener.v2 service
+//
+//go:embed shortener.swagger.json
+var OpenAPI []byte